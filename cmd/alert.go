@@ -0,0 +1,210 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"mohua/internal/alert"
+	"mohua/internal/display"
+	"mohua/internal/metrics"
+	"mohua/internal/policy"
+	"mohua/internal/sagemaker"
+	"mohua/internal/tagging"
+)
+
+var (
+	alertFile   string
+	alertDryRun bool
+)
+
+// alertCmd sends webhook alerts about the collected resources
+var alertCmd = &cobra.Command{
+	Use:   "alert",
+	Short: "Send webhook alerts about running resources",
+	Long: `Evaluate the rules of a YAML alert file against the resources that the
+resource listing collects and post the alerts to generic, Slack or Microsoft
+Teams webhooks. Run it on a schedule, e.g. from cron.
+
+Example alert file:
+
+  repeatAfter: 24h # alert again while still firing, 0 alerts once
+  sinks:
+    - name: ml-alerts
+      format: slack # generic (default), slack or teams
+      url: https://hooks.slack.com/services/...
+  rules:
+    - name: new-gpu-endpoint
+      trigger: new
+      select: {types: [Endpoint], instanceTypes: ["ml.g*", "ml.p*"]}
+    - name: expensive
+      trigger: cost
+      hourlyCost: 20
+    - name: long-running-notebook
+      trigger: age
+      age: 7d
+      select: {types: [Notebook]}
+    - name: idle-endpoint
+      trigger: idle
+      idleFor: 24h
+      sinks: [ml-alerts]
+    - name: failed
+      trigger: failed
+
+Triggers: new (not seen by the previous run), age, cost (estimated hourly
+cost), idle (endpoints without invocations) and failed. Rules take the
+selectors of policy files.
+
+A state file remembers the resources seen and the alerts sent, so each alert
+is sent once while it keeps firing. It defaults to alert-state.json in the
+history directory and can be set with state: in the alert file. The first run
+records the resources without alerting on them as new.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := alert.Load(alertFile)
+		if err != nil {
+			return err
+		}
+
+		client, err := sagemaker.NewClient(region)
+		if err != nil {
+			return fmt.Errorf("failed to create SageMaker client: %w", err)
+		}
+
+		var tagClient tagging.Client
+		if cfg.UsesTags() {
+			mohuaCfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			tagClient, err = newTaggingClient(client, mohuaCfg)
+			if err != nil {
+				return err
+			}
+		}
+
+		var metricsClient metrics.Client
+		if cfg.UsesIdle() {
+			metricsClient, err = metrics.NewClient(region)
+			if err != nil {
+				return fmt.Errorf("failed to create CloudWatch client: %w", err)
+			}
+		}
+
+		return runAlert(client, tagClient, metricsClient, alert.NewSender(), cfg, time.Now())
+	},
+}
+
+func init() {
+	alertCmd.Flags().StringVarP(&alertFile, "file", "f", "mohua-alerts.yaml", "Alert file to evaluate")
+	alertCmd.Flags().BoolVar(&alertDryRun, "dry-run", false, "Print the alerts without sending them or updating the state file")
+	rootCmd.AddCommand(alertCmd)
+}
+
+// alertReport is the JSON representation of an alert run
+type alertReport struct {
+	Alerts  []alert.Alert `json:"alerts"`  // Alerts sent, or pending with --dry-run
+	Firing  int           `json:"firing"`  // Alerts firing, including those sent before
+	Skipped int           `json:"skipped"` // Firing alerts not sent again yet
+	DryRun  bool          `json:"dryRun"`
+}
+
+// alertRun is the outcome of evaluating and sending alerts
+type alertRun struct {
+	firing  []alert.Alert
+	pending []alert.Alert // Firing alerts that were not sent recently
+	sent    []alert.Alert // Pending alerts delivered, or all of them with dryRun
+	sendErr error         // Why some pending alerts were not delivered
+}
+
+// deliverAlerts evaluates the alert rules, sends the pending alerts and
+// updates the state file for the scanned regions. With dryRun nothing is sent
+// or saved.
+func deliverAlerts(ctx context.Context, cfg *alert.Config, sender *alert.Sender, regions []string, resources []sagemaker.ResourceInfo, incomplete []string, idle policy.IdleFunc, now time.Time, dryRun bool) (alertRun, error) {
+	var run alertRun
+	statePath := cfg.State
+	if statePath == "" {
+		var err error
+		if statePath, err = alert.DefaultStatePath(); err != nil {
+			return run, fmt.Errorf("failed to find alert state directory: %w", err)
+		}
+	}
+	state, err := alert.LoadState(statePath)
+	if err != nil {
+		return run, err
+	}
+
+	if run.firing, err = alert.Evaluate(cfg, resources, state.KnownResources(regions), now, idle); err != nil {
+		return run, err
+	}
+	run.pending = state.Pending(run.firing, now, time.Duration(cfg.RepeatAfter))
+	if dryRun {
+		run.sent = run.pending
+		return run, nil
+	}
+
+	run.sent, run.sendErr = sender.Deliver(ctx, cfg.Sinks, run.pending)
+	state.Record(regions, resources, incomplete, run.firing, run.sent, now)
+	if err := state.Save(statePath); err != nil {
+		return run, fmt.Errorf("failed to save alert state: %w", err)
+	}
+	return run, nil
+}
+
+// runAlert evaluates the alert rules, sends the pending alerts and updates the
+// state file. Resources of failed collectors are skipped and the collector
+// error is returned after the alerts of the others are sent.
+func runAlert(client sagemaker.Client, tagClient tagging.Client, metricsClient metrics.Client, sender *alert.Sender, cfg *alert.Config, now time.Time) error {
+	ctx := context.Background()
+
+	collectors := collectors(client)
+	results := collectResources(ctx, client, collectors)
+	resources, collectErr := mergeResults(collectors, results)
+	var incomplete []string
+	for i, c := range collectors {
+		if results[i].Error != nil {
+			incomplete = append(incomplete, c.resourceType)
+		}
+	}
+
+	if tagClient != nil {
+		if err := attachTags(ctx, tagClient, resources); err != nil {
+			return err
+		}
+	}
+
+	run, err := deliverAlerts(ctx, cfg, sender, []string{client.GetRegion()}, resources, incomplete, endpointIdleFunc(ctx, metricsClient), now, alertDryRun)
+	if err != nil {
+		return err
+	}
+	sent := run.sent
+
+	printer := display.NewPrinter(jsonOutput)
+	if jsonOutput {
+		if sent == nil {
+			sent = []alert.Alert{}
+		}
+		report := alertReport{Alerts: sent, Firing: len(run.firing), Skipped: len(run.firing) - len(run.pending), DryRun: alertDryRun}
+		if err := printer.PrintJSON(report); err != nil {
+			return err
+		}
+	} else {
+		if len(sent) > 0 {
+			rows := make([][]string, 0, len(sent))
+			for _, a := range sent {
+				rows = append(rows, []string{a.Rule, a.ResourceType, a.Resource, a.Message})
+			}
+			printer.PrintTable([]string{"Rule", "Type", "Resource", "Alert"}, rows)
+		}
+		verb := "sent"
+		if alertDryRun {
+			verb = "would be sent"
+		}
+		printer.PrintSummary("%d alerts %s, %d firing, %d already sent", len(sent), verb, len(run.firing), len(run.firing)-len(run.pending))
+	}
+
+	if run.sendErr != nil {
+		return run.sendErr
+	}
+	return collectErr
+}
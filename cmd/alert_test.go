@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"mohua/internal/alert"
+	"mohua/internal/sagemaker"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// alertReceiver is a webhook that records the bodies posted to it
+func alertReceiver(t *testing.T) (*httptest.Server, *[]string) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+	}))
+	t.Cleanup(server.Close)
+	return server, &bodies
+}
+
+func TestRunAlert(t *testing.T) {
+	resetCommand()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	server, bodies := alertReceiver(t)
+	cfg, err := alert.Parse([]byte(fmt.Sprintf(`
+state: %s
+sinks:
+  - {name: chat, format: slack, url: %q}
+rules:
+  - {name: new-endpoint, trigger: new, select: {types: [Endpoint]}}
+  - {name: idle-endpoint, trigger: idle, idleFor: 24h}
+`, filepath.Join(t.TempDir(), "state.json"), server.URL)))
+	assert.NoError(t, err)
+
+	run := func(endpoints ...string) error {
+		var resources []sagemaker.ResourceInfo
+		for _, name := range endpoints {
+			resources = append(resources, sagemaker.ResourceInfo{Name: name, InstanceType: "ml.m5.xlarge", CreationTime: now.Add(-48 * time.Hour)})
+		}
+		mockClient := new(MockSageMakerClient)
+		mockClient.On("ListEndpoints", mock.Anything).Return(resources, nil)
+		expectEmptyCollectors(mockClient)
+		metricsClient := new(MockMetricsClient)
+		metricsClient.On("EndpointInvocations", mock.Anything, "churn", 24*time.Hour).Return(0.0, nil).Maybe()
+		metricsClient.On("EndpointInvocations", mock.Anything, mock.Anything, 24*time.Hour).Return(50.0, nil).Maybe()
+		return runAlert(mockClient, nil, metricsClient, alert.NewSender(), cfg, now)
+	}
+
+	// The first run records the endpoints and only alerts on the idle one
+	assert.NoError(t, run("churn", "search"))
+	if assert.Len(t, *bodies, 1) {
+		assert.Contains(t, (*bodies)[0], "*idle-endpoint*: Endpoint churn")
+	}
+
+	// The idle alert was sent already, the new endpoint is alerted once
+	assert.NoError(t, run("churn", "search", "fraud"))
+	if assert.Len(t, *bodies, 2) {
+		assert.Contains(t, (*bodies)[1], "*new-endpoint*: Endpoint fraud in us-east-1: new endpoint on ml.m5.xlarge")
+		assert.NotContains(t, (*bodies)[1], "churn")
+	}
+	assert.NoError(t, run("churn", "search", "fraud"))
+	assert.Len(t, *bodies, 2)
+}
+
+func TestRunAlert_DryRun(t *testing.T) {
+	resetCommand()
+	alertDryRun = true
+	jsonOutput = true
+	server, bodies := alertReceiver(t)
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	cfg, err := alert.Parse([]byte(fmt.Sprintf("state: %s\nsinks:\n  - {name: ops, url: %q}\nrules:\n  - {name: failed, trigger: failed}", statePath, server.URL)))
+	assert.NoError(t, err)
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListNotebooks", mock.Anything).Return([]sagemaker.ResourceInfo{{Name: "notebook", Status: "Failed"}}, nil)
+	expectEmptyCollectors(mockClient)
+
+	err = runAlert(mockClient, nil, nil, alert.NewSender(), cfg, time.Now())
+
+	assert.NoError(t, err)
+	assert.Empty(t, *bodies)
+	mockClient.AssertExpectations(t)
+	assert.NoFileExists(t, statePath)
+}
+
+func TestRunAlert_CollectorError(t *testing.T) {
+	resetCommand()
+	server, bodies := alertReceiver(t)
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	cfg, err := alert.Parse([]byte(fmt.Sprintf("state: %s\nsinks:\n  - {name: ops, url: %q}\nrules:\n  - {name: failed, trigger: failed}", statePath, server.URL)))
+	assert.NoError(t, err)
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListEndpoints", mock.Anything).Return(nil, errors.New("access denied"))
+	mockClient.On("ListNotebooks", mock.Anything).Return([]sagemaker.ResourceInfo{{Name: "notebook", Status: "Failed"}}, nil)
+	expectEmptyCollectors(mockClient)
+
+	err = runAlert(mockClient, nil, nil, alert.NewSender(), cfg, time.Now())
+
+	// Alerts of the other collectors are still sent
+	assert.ErrorContains(t, err, "access denied")
+	assert.Len(t, *bodies, 1)
+	assert.FileExists(t, statePath)
+}
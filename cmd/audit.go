@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"mohua/internal/audit"
+	"mohua/internal/display"
+	"mohua/internal/sagemaker"
+)
+
+var (
+	auditFormat      string
+	failSeverity     string
+	maxFindings      int
+	broadRolePattern string
+)
+
+// auditCmd evaluates the built-in security rules against notebooks, endpoints and Studio
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Check notebooks, endpoints and Studio for insecure settings",
+	Long: `Evaluate built-in security rules against notebook instances, endpoints,
+Studio domains and Studio user profiles:
+
+  SM001  notebook instance has root access enabled           (medium)
+  SM002  notebook instance has direct internet access        (high)
+  SM003  notebook instance is not attached to a VPC          (medium)
+  SM004  resource has no customer managed KMS key            (low)
+  SM005  endpoint serves a model without network isolation   (medium)
+  SM006  Studio domain uses PublicInternetOnly               (high)
+  SM007  user profile execution role matches --broad-role-pattern (high)
+
+Findings are printed as a table, JSON (--json or --format json) or SARIF
+(--format sarif). The command fails when more than --max-findings findings
+have at least --fail-severity severity.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := sagemaker.NewClient(region)
+		if err != nil {
+			return fmt.Errorf("failed to create SageMaker client: %w", err)
+		}
+
+		return runAudit(client, cmd.OutOrStdout())
+	},
+}
+
+func init() {
+	auditCmd.Flags().StringVar(&auditFormat, "format", "table", "Output format: table, json or sarif")
+	auditCmd.Flags().StringVar(&failSeverity, "fail-severity", "low", "Minimum severity counted against --max-findings: low, medium or high")
+	auditCmd.Flags().IntVar(&maxFindings, "max-findings", 0, "Number of findings allowed before the command fails")
+	auditCmd.Flags().StringVar(&broadRolePattern, "broad-role-pattern", audit.DefaultBroadRolePattern, "Regular expression matched against user profile execution role names")
+	rootCmd.AddCommand(auditCmd)
+}
+
+// auditReport is the JSON representation of the audit
+type auditReport struct {
+	Findings []audit.Finding `json:"findings"`
+	Summary  map[string]int  `json:"summary"`
+}
+
+func runAudit(client sagemaker.Client, out io.Writer) error {
+	ctx := context.Background()
+
+	format := auditFormat
+	if jsonOutput {
+		format = "json"
+	}
+	if format != "table" && format != "json" && format != "sarif" {
+		return fmt.Errorf("invalid format %q: expected table, json or sarif", auditFormat)
+	}
+	threshold, err := audit.ParseSeverity(failSeverity)
+	if err != nil {
+		return err
+	}
+	pattern, err := regexp.Compile(broadRolePattern)
+	if err != nil {
+		return fmt.Errorf("invalid broad role pattern: %w", err)
+	}
+
+	listers := []struct {
+		label string
+		list  func(context.Context) ([]sagemaker.SecurityInfo, error)
+	}{
+		{"notebook instances", client.ListNotebookSecurity},
+		{"endpoints", client.ListEndpointSecurity},
+		{"domains", client.ListDomainSecurity},
+		{"user profiles", client.ListUserProfileSecurity},
+	}
+
+	// Launch goroutines for each API call
+	type securityResult struct {
+		resources []sagemaker.SecurityInfo
+		err       error
+	}
+	results := make([]securityResult, len(listers))
+	var wg sync.WaitGroup
+	wg.Add(len(listers))
+	for i, lister := range listers {
+		go func(i int, list func(context.Context) ([]sagemaker.SecurityInfo, error)) {
+			defer wg.Done()
+			resources, err := list(ctx)
+			results[i] = securityResult{resources: resources, err: err}
+		}(i, lister.list)
+	}
+	wg.Wait()
+
+	var firstError error
+	var failed []error
+	var all []sagemaker.SecurityInfo
+	for i, result := range results {
+		if result.err != nil {
+			if retryableErr, ok := result.err.(*sagemaker.RetryableError); ok {
+				fmt.Fprintf(os.Stderr, "Retryable error listing %s: %v\n", listers[i].label, retryableErr)
+				failed = append(failed, fmt.Errorf("failed to list %s: %w", listers[i].label, result.err))
+			} else if firstError == nil {
+				firstError = fmt.Errorf("failed to list %s: %w", listers[i].label, result.err)
+			}
+			continue
+		}
+		all = append(all, result.resources...)
+	}
+	if firstError != nil {
+		return firstError
+	}
+
+	findings := audit.Evaluate(all, audit.Options{BroadRolePattern: pattern})
+	summary := map[string]int{"high": 0, "medium": 0, "low": 0}
+	for _, finding := range findings {
+		summary[finding.Severity.String()]++
+	}
+
+	printer := display.NewPrinter(format == "json")
+	switch format {
+	case "sarif":
+		if err := audit.WriteSARIF(out, findings); err != nil {
+			return fmt.Errorf("failed to write SARIF: %w", err)
+		}
+	case "json":
+		if findings == nil {
+			findings = []audit.Finding{}
+		}
+		if err := printer.PrintJSON(auditReport{Findings: findings, Summary: summary}); err != nil {
+			return err
+		}
+	default:
+		if len(findings) == 0 {
+			printer.PrintSummary("No findings for %d resources in region %s", len(all), client.GetRegion())
+			break
+		}
+		rows := make([][]string, 0, len(findings))
+		highlight := make([]bool, 0, len(findings))
+		for _, finding := range findings {
+			rows = append(rows, []string{finding.Severity.String(), finding.RuleID, finding.ResourceType, finding.Resource, finding.Message})
+			highlight = append(highlight, finding.Severity == audit.SeverityHigh)
+		}
+		printer.PrintHighlightedTable([]string{"Severity", "Rule", "Type", "Resource", "Finding"}, rows, highlight)
+		printer.PrintSummary("%d findings: %d high, %d medium, %d low", len(findings), summary["high"], summary["medium"], summary["low"])
+	}
+
+	// Findings take precedence, but an audit of a partial inventory must
+	// never pass as a clean one
+	var partialErr error
+	if len(failed) > 0 {
+		partialErr = withExitCode(ExitPartial, errors.Join(failed...))
+	}
+	if count := audit.CountAtLeast(findings, threshold); count > maxFindings {
+		findingsErr := fmt.Errorf("%d findings with %s severity or higher exceed the threshold of %d", count, threshold, maxFindings)
+		if partialErr != nil {
+			findingsErr = errors.Join(findingsErr, partialErr)
+		}
+		return withExitCode(ExitViolation, findingsErr)
+	}
+	return partialErr
+}
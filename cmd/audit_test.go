@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"mohua/internal/audit"
+	"mohua/internal/sagemaker"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newAuditMock() *MockSageMakerClient {
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListNotebookSecurity", mock.Anything).Return([]sagemaker.SecurityInfo{
+		{ResourceType: "Notebook", Name: "open", KmsKeyID: "key", SubnetID: "subnet-1", DirectInternetAccess: true},
+	}, nil)
+	mockClient.On("ListEndpointSecurity", mock.Anything).Return([]sagemaker.SecurityInfo{
+		{ResourceType: "Endpoint", Name: "churn", KmsKeyID: "key"},
+	}, nil)
+	mockClient.On("ListDomainSecurity", mock.Anything).Return([]sagemaker.SecurityInfo{}, nil)
+	mockClient.On("ListUserProfileSecurity", mock.Anything).Return([]sagemaker.SecurityInfo{}, nil)
+	mockClient.On("GetRegion").Return("us-east-1").Maybe()
+	return mockClient
+}
+
+func TestRunAudit_Threshold(t *testing.T) {
+	resetCommand()
+	mockClient := newAuditMock()
+
+	err := runAudit(mockClient, &bytes.Buffer{})
+	assert.EqualError(t, err, "1 findings with low severity or higher exceed the threshold of 0")
+
+	maxFindings = 1
+	assert.NoError(t, runAudit(mockClient, &bytes.Buffer{}))
+
+	maxFindings = 0
+	failSeverity = "high"
+	assert.Error(t, runAudit(mockClient, &bytes.Buffer{}))
+	mockClient.AssertExpectations(t)
+}
+
+func TestRunAudit_SARIF(t *testing.T) {
+	resetCommand()
+	auditFormat = "sarif"
+	maxFindings = 10
+	mockClient := newAuditMock()
+
+	var buf bytes.Buffer
+	assert.NoError(t, runAudit(mockClient, &buf))
+
+	var log map[string]interface{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+	assert.Equal(t, "2.1.0", log["version"])
+}
+
+func TestRunAudit_Errors(t *testing.T) {
+	resetCommand()
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListNotebookSecurity", mock.Anything).Return([]sagemaker.SecurityInfo{}, nil)
+	mockClient.On("ListEndpointSecurity", mock.Anything).Return(nil, &sagemaker.RetryableError{Err: errors.New("throttled")})
+	mockClient.On("ListDomainSecurity", mock.Anything).Return(nil, errors.New("access denied"))
+	mockClient.On("ListUserProfileSecurity", mock.Anything).Return([]sagemaker.SecurityInfo{}, nil)
+
+	err := runAudit(mockClient, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "failed to list domains")
+
+	auditFormat = "xml"
+	assert.ErrorContains(t, runAudit(mockClient, &bytes.Buffer{}), "invalid format")
+}
+
+func TestRunAudit_Partial(t *testing.T) {
+	resetCommand()
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListNotebookSecurity", mock.Anything).Return([]sagemaker.SecurityInfo{}, nil)
+	mockClient.On("ListEndpointSecurity", mock.Anything).Return(nil, &sagemaker.RetryableError{Err: errors.New("throttled")})
+	mockClient.On("ListDomainSecurity", mock.Anything).Return([]sagemaker.SecurityInfo{}, nil)
+	mockClient.On("ListUserProfileSecurity", mock.Anything).Return([]sagemaker.SecurityInfo{}, nil)
+	mockClient.On("GetRegion").Return("us-east-1").Maybe()
+
+	// Nothing left to flag, but the endpoints were never checked
+	err := runAudit(mockClient, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "failed to list endpoints")
+	assert.Equal(t, ExitPartial, ExitCode(err))
+
+	// Findings in the other resources still take precedence
+	mockClient = new(MockSageMakerClient)
+	mockClient.On("ListNotebookSecurity", mock.Anything).Return([]sagemaker.SecurityInfo{
+		{ResourceType: "Notebook", Name: "open", KmsKeyID: "key", SubnetID: "subnet-1", DirectInternetAccess: true},
+	}, nil)
+	mockClient.On("ListEndpointSecurity", mock.Anything).Return(nil, &sagemaker.RetryableError{Err: errors.New("throttled")})
+	mockClient.On("ListDomainSecurity", mock.Anything).Return([]sagemaker.SecurityInfo{}, nil)
+	mockClient.On("ListUserProfileSecurity", mock.Anything).Return([]sagemaker.SecurityInfo{}, nil)
+
+	err = runAudit(mockClient, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "exceed the threshold")
+	assert.ErrorContains(t, err, "failed to list endpoints")
+	assert.Equal(t, ExitViolation, ExitCode(err))
+}
+
+func TestAuditReportSeverityJSON(t *testing.T) {
+	data, err := json.Marshal(audit.Finding{RuleID: "SM006", Severity: audit.SeverityHigh})
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"severity":"high"`)
+}
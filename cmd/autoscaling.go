@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"mohua/internal/autoscaling"
+	"mohua/internal/display"
+	"mohua/internal/sagemaker"
+)
+
+// autoscalingCmd reports the Application Auto Scaling configuration of endpoint variants
+var autoscalingCmd = &cobra.Command{
+	Use:   "autoscaling",
+	Short: "Show autoscaling settings of endpoint variants",
+	Long: `Show the Application Auto Scaling min/max capacity and policies of every
+endpoint variant, and flag variants that have no autoscaling or keep more
+than one instance at all times, with no scheduled action that lowers their
+min capacity to 1, e.g. outside business hours.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := sagemaker.NewClient(region)
+		if err != nil {
+			return fmt.Errorf("failed to create SageMaker client: %w", err)
+		}
+
+		scalingClient, err := autoscaling.NewClient(region)
+		if err != nil {
+			return fmt.Errorf("failed to create Application Auto Scaling client: %w", err)
+		}
+
+		return runAutoscaling(client, scalingClient)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(autoscalingCmd)
+}
+
+func runAutoscaling(client sagemaker.Client, scalingClient autoscaling.Client) error {
+	ctx := context.Background()
+
+	var (
+		wg         sync.WaitGroup
+		variants   []sagemaker.EndpointVariant
+		scaling    map[string]autoscaling.VariantScaling
+		variantErr error
+		scalingErr error
+	)
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		variants, variantErr = client.ListEndpointVariants(ctx)
+	}()
+
+	go func() {
+		defer wg.Done()
+		scaling, scalingErr = scalingClient.ListVariantScaling(ctx)
+	}()
+
+	wg.Wait()
+
+	if variantErr != nil {
+		return fmt.Errorf("failed to list endpoint variants: %w", variantErr)
+	}
+	if scalingErr != nil {
+		return fmt.Errorf("failed to list scalable targets: %w", scalingErr)
+	}
+
+	reports := autoscaling.BuildReport(variants, scaling)
+	printer := display.NewPrinter(jsonOutput)
+
+	if jsonOutput {
+		return printer.PrintJSON(reports)
+	}
+
+	if len(reports) == 0 {
+		printer.PrintNoResources(client.GetRegion())
+		return nil
+	}
+
+	rows := make([][]string, 0, len(reports))
+	for _, report := range reports {
+		minCapacity, maxCapacity, target := "-", "-", "-"
+		if report.MaxCapacity > 0 {
+			minCapacity = strconv.Itoa(report.MinCapacity)
+			maxCapacity = strconv.Itoa(report.MaxCapacity)
+		}
+		if report.TargetValue != 0 {
+			target = strconv.FormatFloat(report.TargetValue, 'f', -1, 64)
+		}
+		policy := report.PolicyType
+		if policy == "" {
+			policy = "-"
+		}
+		rows = append(rows, []string{
+			report.EndpointName,
+			report.VariantName,
+			strconv.Itoa(report.InstanceCount),
+			minCapacity,
+			maxCapacity,
+			policy,
+			target,
+			strings.Join(report.Findings, "; "),
+		})
+	}
+
+	printer.PrintTable([]string{"Endpoint", "Variant", "Instances", "Min", "Max", "Policy", "Target", "Findings"}, rows)
+	return nil
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"mohua/internal/autoscaling"
+	"mohua/internal/sagemaker"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRunAutoscaling(t *testing.T) {
+	mockClient := new(MockSageMakerClient)
+	mockScaling := new(MockAutoScalingClient)
+
+	mockClient.On("ListEndpointVariants", mock.Anything).Return([]sagemaker.EndpointVariant{
+		{EndpointName: "e1", VariantName: "AllTraffic", InstanceCount: 4},
+	}, nil)
+	mockScaling.On("ListVariantScaling", mock.Anything).Return(map[string]autoscaling.VariantScaling{
+		"endpoint/e1/variant/AllTraffic": {ResourceID: "endpoint/e1/variant/AllTraffic", MinCapacity: 2, MaxCapacity: 4},
+	}, nil)
+
+	for _, useJSON := range []bool{false, true} {
+		jsonOutput = useJSON
+		err := runAutoscaling(mockClient, mockScaling)
+		assert.NoError(t, err)
+	}
+	jsonOutput = false
+
+	mockClient.AssertExpectations(t)
+	mockScaling.AssertExpectations(t)
+}
+
+func TestRunAutoscaling_Errors(t *testing.T) {
+	mockClient := new(MockSageMakerClient)
+	mockScaling := new(MockAutoScalingClient)
+
+	mockClient.On("ListEndpointVariants", mock.Anything).Return([]sagemaker.EndpointVariant{}, nil)
+	mockScaling.On("ListVariantScaling", mock.Anything).Return(nil, errors.New("access denied"))
+
+	err := runAutoscaling(mockClient, mockScaling)
+	assert.ErrorContains(t, err, "failed to list scalable targets")
+}
@@ -0,0 +1,615 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"mohua/internal/alert"
+	"mohua/internal/config"
+	"mohua/internal/display"
+	"mohua/internal/history"
+	"mohua/internal/metrics"
+	"mohua/internal/policy"
+	"mohua/internal/sagemaker"
+	"mohua/internal/schedule"
+	"mohua/internal/tagging"
+	"mohua/internal/telemetry"
+)
+
+var (
+	daemonSchedule   string
+	daemonListen     string
+	daemonAlertFile  string
+	daemonPolicyFile string
+)
+
+// shutdownTimeout is how long in-flight API requests may take after SIGTERM
+const shutdownTimeout = 10 * time.Second
+
+// daemonCmd scans on a schedule and serves the latest inventory over HTTP
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Scan on a schedule and serve the latest inventory over HTTP",
+	Long: `Run scans on a cron-like schedule, keep the latest inventory in memory,
+record every scan in the snapshot history, evaluate alert and policy rules
+after each scan, and serve the results over HTTP:
+
+  GET /api/resources  resources of the latest scan, filtered with ?type= and
+                      ?region=
+  GET /api/summary    counts, estimated cost, totals per type and region,
+                      violations and alerts
+  GET /api/errors     collector and rule errors of the latest scan
+  GET /api/changes    changes recorded in the history since ?since= (24h)
+
+Responses use the JSON envelope of the CLI: the payload next to metadata with
+the region and the time of the scan. Until the first scan completes the API
+responds with 503.
+
+--schedule takes five cron fields (minute hour day-of-month month
+day-of-week) in local time, a descriptor such as @hourly, or @every <duration>.
+The first scan runs at startup.
+
+SIGINT and SIGTERM cancel the running scan, including its retry waits, and
+shut the server down gracefully.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sched, err := schedule.Parse(daemonSchedule)
+		if err != nil {
+			return err
+		}
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+
+		d := &daemon{now: time.Now, sender: alert.NewSender()}
+		if daemonAlertFile != "" {
+			if d.alerts, err = alert.Load(daemonAlertFile); err != nil {
+				return err
+			}
+		}
+		if daemonPolicyFile != "" {
+			if d.policy, err = policy.Load(daemonPolicyFile); err != nil {
+				return err
+			}
+		}
+
+		usesTags := (d.alerts != nil && d.alerts.UsesTags()) || (d.policy != nil && d.policy.UsesTags())
+		usesIdle := (d.alerts != nil && d.alerts.UsesIdle()) || (d.policy != nil && d.policy.UsesIdle())
+		target, err := newScanTarget(region, cfg, usesTags, usesIdle)
+		if err != nil {
+			return err
+		}
+		d.targets = []scanTarget{target}
+		if cfg.History.Enabled {
+			if d.store, err = newHistoryStore(cfg); err != nil {
+				return err
+			}
+		}
+
+		if d.exporter, err = newExporter(cfg); err != nil {
+			return err
+		}
+		defer shutdownExporter(d.exporter)
+
+		listener, err := net.Listen("tcp", daemonListen)
+		if err != nil {
+			return fmt.Errorf("failed to listen on %s: %w", daemonListen, err)
+		}
+		fmt.Fprintf(os.Stderr, "Serving the API on %s\n", listener.Addr())
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		return runDaemon(ctx, d, sched, listener)
+	},
+}
+
+func init() {
+	daemonCmd.Flags().StringVar(&daemonSchedule, "schedule", "*/15 * * * *", "When to scan: cron fields, @hourly, @daily or @every <duration>")
+	daemonCmd.Flags().StringVar(&daemonListen, "listen", "127.0.0.1:9090", "Address the HTTP API listens on")
+	daemonCmd.Flags().StringVar(&daemonAlertFile, "alerts", "", "Alert file evaluated after every scan")
+	daemonCmd.Flags().StringVar(&daemonPolicyFile, "policy", "", "Policy file evaluated after every scan")
+	addTelemetryFlags(daemonCmd)
+	addPublishFlags(daemonCmd)
+	rootCmd.AddCommand(daemonCmd)
+}
+
+// daemon scans on a schedule and holds the latest inventory
+type daemon struct {
+	targets  []scanTarget   // One per scanned region
+	store    *history.Store // Optional
+	alerts   *alert.Config  // Optional
+	sender   *alert.Sender
+	policy   *policy.Policy      // Optional
+	ui       http.Handler        // Optional, served outside /api/
+	exporter *telemetry.Exporter // Optional
+	now      func() time.Time
+
+	mu        sync.RWMutex
+	inventory *inventory // Nil until the first scan completes
+	nextScan  time.Time
+	scans     int
+}
+
+// newScanTarget creates the clients of a region, with the tagging and metrics
+// clients only when the rules need them and the publisher when
+// --publish-cloudwatch is set
+func newScanTarget(region string, cfg *config.Config, tags, idle bool) (scanTarget, error) {
+	client, err := sagemaker.NewClient(region)
+	if err != nil {
+		return scanTarget{}, fmt.Errorf("failed to create SageMaker client: %w", err)
+	}
+	target := scanTarget{client: client}
+	if tags {
+		if target.tagClient, err = newTaggingClient(client, cfg); err != nil {
+			return scanTarget{}, err
+		}
+	}
+	if idle {
+		if target.metricsClient, err = metrics.NewClient(region); err != nil {
+			return scanTarget{}, fmt.Errorf("failed to create CloudWatch client: %w", err)
+		}
+	}
+	if target.publisher, err = newPublisher(context.Background(), region); err != nil {
+		return scanTarget{}, err
+	}
+	return target, nil
+}
+
+// scanTarget holds the clients of a scanned region
+type scanTarget struct {
+	client        sagemaker.Client
+	tagClient     tagging.Client // Optional, looks up tags for the rules
+	metricsClient metrics.Client // Optional, checks endpoint activity for the rules
+	publisher     *publisher     // Optional, puts the metrics of each scan in CloudWatch
+}
+
+// inventory is the outcome of a scan
+type inventory struct {
+	scannedAt  time.Time
+	duration   time.Duration
+	regions    []regionScan
+	errors     []apiError
+	violations []policy.Violation
+	alertsSent []alert.Alert
+}
+
+// regionScan holds the collector results of one region
+type regionScan struct {
+	region     string
+	collectors []collector
+	results    []ResourceResult
+}
+
+// apiError is an error of the latest scan
+type apiError struct {
+	Region    string    `json:"region,omitempty"` // Empty for rule errors
+	Source    string    `json:"source"`           // The collector label, or tags, history, policy or alerts
+	Message   string    `json:"message"`
+	Retryable bool      `json:"retryable"`
+	Time      time.Time `json:"time"`
+}
+
+// apiResource is a resource of the CLI JSON output with its region and
+// estimated cost, so API clients can total them
+type apiResource struct {
+	display.ResourceInfo
+	Region              string  `json:"region"`
+	EstimatedHourlyCost float64 `json:"estimatedHourlyCost"`
+}
+
+// total sums the resources of a group
+type total struct {
+	Resources  int     `json:"resources"`
+	Instances  int     `json:"instances"`
+	HourlyCost float64 `json:"hourlyCost"`
+}
+
+// daemonSummary is the payload of /api/summary
+type daemonSummary struct {
+	Resources       int                `json:"resources"`
+	HourlyCost      float64            `json:"hourlyCost"`
+	ByType          map[string]total   `json:"byType"`
+	ByRegion        map[string]total   `json:"byRegion"`
+	Instances       map[string]int     `json:"instances"` // Running instances per instance type
+	Violations      []policy.Violation `json:"violations"`
+	AlertsSent      []alert.Alert      `json:"alertsSent"`
+	Errors          int                `json:"errors"`
+	Scans           int                `json:"scans"`
+	ScanDuration    string             `json:"scanDuration"`
+	NextScan        *time.Time         `json:"nextScan,omitempty"`
+	PolicyEvaluated bool               `json:"policyEvaluated"`
+	AlertsEvaluated bool               `json:"alertsEvaluated"`
+}
+
+// runDaemon scans on the schedule and serves the API until ctx is cancelled,
+// then waits for the running scan to stop and shuts the server down
+func runDaemon(ctx context.Context, d *daemon, sched schedule.Schedule, listener net.Listener) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	server := &http.Server{Handler: d.handler(), ReadHeaderTimeout: 10 * time.Second}
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.Serve(listener)
+	}()
+
+	scanning := make(chan struct{})
+	go func() {
+		defer close(scanning)
+		d.run(ctx, sched)
+	}()
+
+	var err error
+	select {
+	case <-ctx.Done():
+	case err = <-serveErr:
+	}
+	cancel()
+
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancelShutdown()
+	if shutdownErr := server.Shutdown(shutdownCtx); shutdownErr != nil && err == nil {
+		err = shutdownErr
+	}
+	<-scanning
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// run scans now and at every time of the schedule until ctx is cancelled
+func (d *daemon) run(ctx context.Context, sched schedule.Schedule) {
+	for ctx.Err() == nil {
+		d.scan(ctx)
+
+		next := sched.Next(d.now())
+		d.mu.Lock()
+		d.nextScan = next
+		d.mu.Unlock()
+		if next.IsZero() {
+			fmt.Fprintln(os.Stderr, "The schedule has no further scans")
+			<-ctx.Done()
+			return
+		}
+
+		timer := time.NewTimer(next.Sub(d.now()))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// scan collects the resources of every region, records them and evaluates
+// the rules. A scan cancelled by ctx leaves the previous inventory in place.
+func (d *daemon) scan(ctx context.Context) {
+	start := d.now()
+	ctx, span := startScan(ctx, d.regions())
+	defer span.End()
+	inv := &inventory{scannedAt: start, regions: make([]regionScan, len(d.targets))}
+	tagErrors := make([]error, len(d.targets))
+	var wg sync.WaitGroup
+	for i, target := range d.targets {
+		wg.Add(1)
+		go func(i int, target scanTarget) {
+			defer wg.Done()
+			scan := regionScan{region: target.client.GetRegion(), collectors: collectors(target.client)}
+			scan.results = collectResources(ctx, target.client, scan.collectors)
+			if target.tagClient != nil {
+				lists := make([][]sagemaker.ResourceInfo, len(scan.results))
+				for j, result := range scan.results {
+					lists[j] = result.Resources
+				}
+				tagErrors[i] = attachTags(ctx, target.tagClient, lists...)
+			}
+			inv.regions[i] = scan
+		}(i, target)
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		return
+	}
+
+	addError := func(region, source string, err error) {
+		_, retryable := err.(*sagemaker.RetryableError)
+		inv.errors = append(inv.errors, apiError{Region: region, Source: source, Message: err.Error(), Retryable: retryable, Time: d.now()})
+	}
+	var resources []sagemaker.ResourceInfo
+	var incomplete []string
+	scanned := make([]string, 0, len(inv.regions))
+	for i, scan := range inv.regions {
+		scanned = append(scanned, scan.region)
+		if tagErrors[i] != nil {
+			addError(scan.region, "tags", tagErrors[i])
+		}
+		for j, c := range scan.collectors {
+			if err := scan.results[j].Error; err != nil {
+				addError(scan.region, c.label, err)
+				// Alert state keys of incomplete types are kept in every region
+				incomplete = append(incomplete, c.resourceType)
+				continue
+			}
+			resources = append(resources, scan.results[j].Resources...)
+		}
+		if d.store != nil {
+			if err := recordSnapshot(d.store, scan.region, scan.collectors, scan.results); err != nil {
+				addError(scan.region, "history", err)
+			}
+		}
+	}
+
+	for i, target := range d.targets {
+		if target.publisher != nil {
+			if err := target.publisher.publish(ctx, inv.regions[i], start); err != nil {
+				addError(inv.regions[i].region, "cloudwatch", err)
+			}
+		}
+	}
+
+	idle := d.idleFunc(ctx)
+	if d.policy != nil {
+		violations, err := policy.Evaluate(d.policy, resources, start, idle)
+		if err != nil {
+			addError("", "policy", err)
+		}
+		inv.violations = violations
+	}
+	if d.alerts != nil {
+		run, err := deliverAlerts(ctx, d.alerts, d.sender, scanned, resources, incomplete, idle, start, false)
+		if err == nil {
+			err = run.sendErr
+		}
+		if err != nil {
+			addError("", "alerts", err)
+		}
+		inv.alertsSent = run.sent
+	}
+	if ctx.Err() != nil {
+		return
+	}
+
+	inv.duration = d.now().Sub(start)
+	exportScan(ctx, d.exporter, span, inv.regions, inv.duration)
+	d.mu.Lock()
+	d.inventory = inv
+	d.scans++
+	d.mu.Unlock()
+	fmt.Fprintf(os.Stderr, "%s scan: %d resources, %d errors, %d alerts sent in %s\n",
+		start.Format(time.RFC3339), len(resources), len(inv.errors), len(inv.alertsSent), inv.duration.Round(time.Millisecond))
+}
+
+// idleFunc checks endpoint activity with the metrics client of the region of
+// each resource, or returns nil when no region has one
+func (d *daemon) idleFunc(ctx context.Context) policy.IdleFunc {
+	funcs := make(map[string]policy.IdleFunc)
+	for _, target := range d.targets {
+		if idle := endpointIdleFunc(ctx, target.metricsClient); idle != nil {
+			funcs[target.client.GetRegion()] = idle
+		}
+	}
+	if len(funcs) == 0 {
+		return nil
+	}
+	return func(resource sagemaker.ResourceInfo, window time.Duration) (bool, error) {
+		idle, ok := funcs[resource.Region]
+		if !ok {
+			return false, fmt.Errorf("no activity metrics for %s", resource.Region)
+		}
+		return idle(resource, window)
+	}
+}
+
+// regions returns the scanned regions, comma separated
+func (d *daemon) regions() string {
+	regions := make([]string, len(d.targets))
+	for i, target := range d.targets {
+		regions[i] = target.client.GetRegion()
+	}
+	return strings.Join(regions, ",")
+}
+
+// handler serves the API
+func (d *daemon) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/resources", d.serveResources)
+	mux.HandleFunc("GET /api/summary", d.serveSummary)
+	mux.HandleFunc("GET /api/errors", d.serveErrors)
+	mux.HandleFunc("GET /api/changes", d.serveChanges)
+	if d.ui != nil {
+		mux.Handle("GET /", d.ui)
+	}
+	return mux
+}
+
+// latest returns the inventory of the latest scan with the metadata of its
+// envelope, or writes 503 when no scan has completed yet
+func (d *daemon) latest(w http.ResponseWriter) (*inventory, display.Metadata, bool) {
+	d.mu.RLock()
+	inv := d.inventory
+	d.mu.RUnlock()
+
+	metadata := display.Metadata{Region: d.regions()}
+	if inv == nil {
+		metadata.Message = "The first scan has not completed yet"
+		writeJSON(w, http.StatusServiceUnavailable, display.Envelope{Resources: []display.ResourceInfo{}, Metadata: metadata})
+		return nil, metadata, false
+	}
+	metadata.ScannedAt = &inv.scannedAt
+	return inv, metadata, true
+}
+
+func (d *daemon) serveResources(w http.ResponseWriter, r *http.Request) {
+	inv, metadata, ok := d.latest(w)
+	if !ok {
+		return
+	}
+
+	resourceType, region := r.URL.Query().Get("type"), r.URL.Query().Get("region")
+	now := d.now()
+	resources := []apiResource{}
+	for _, scan := range inv.regions {
+		if region != "" && region != scan.region {
+			continue
+		}
+		for i, c := range scan.collectors {
+			if resourceType != "" && !strings.EqualFold(resourceType, c.resourceType) {
+				continue
+			}
+			for _, resource := range scan.results[i].Resources {
+				resources = append(resources, apiResource{
+					ResourceInfo:        displayResource(c, resource, now),
+					Region:              scan.region,
+					EstimatedHourlyCost: resource.EstimatedHourlyCost(),
+				})
+			}
+		}
+	}
+	if len(resources) == 0 {
+		metadata.Message = "No resources found"
+	}
+	writeJSON(w, http.StatusOK, display.Envelope{Resources: resources, Metadata: metadata})
+}
+
+func (d *daemon) serveSummary(w http.ResponseWriter, r *http.Request) {
+	inv, metadata, ok := d.latest(w)
+	if !ok {
+		return
+	}
+
+	d.mu.RLock()
+	summary := daemonSummary{
+		ByType:          make(map[string]total),
+		ByRegion:        make(map[string]total),
+		Instances:       make(map[string]int),
+		Violations:      inv.violations,
+		AlertsSent:      inv.alertsSent,
+		Errors:          len(inv.errors),
+		Scans:           d.scans,
+		ScanDuration:    inv.duration.Round(time.Millisecond).String(),
+		PolicyEvaluated: d.policy != nil,
+		AlertsEvaluated: d.alerts != nil,
+	}
+	if !d.nextScan.IsZero() {
+		next := d.nextScan
+		summary.NextScan = &next
+	}
+	d.mu.RUnlock()
+
+	for _, scan := range inv.regions {
+		for i, c := range scan.collectors {
+			for _, resource := range scan.results[i].Resources {
+				var instances int
+				for instanceType, count := range resource.Instances() {
+					summary.Instances[instanceType] += count
+					instances += count
+				}
+				cost := resource.EstimatedHourlyCost()
+				summary.Resources++
+				summary.HourlyCost += cost
+				summary.ByType[c.resourceType] = summary.ByType[c.resourceType].add(instances, cost)
+				summary.ByRegion[scan.region] = summary.ByRegion[scan.region].add(instances, cost)
+			}
+		}
+	}
+	if summary.Violations == nil {
+		summary.Violations = []policy.Violation{}
+	}
+	if summary.AlertsSent == nil {
+		summary.AlertsSent = []alert.Alert{}
+	}
+	writeJSON(w, http.StatusOK, display.Envelope{Summary: summary, Metadata: metadata})
+}
+
+// add counts a resource with its instances and cost
+func (t total) add(instances int, hourlyCost float64) total {
+	return total{Resources: t.Resources + 1, Instances: t.Instances + instances, HourlyCost: t.HourlyCost + hourlyCost}
+}
+
+func (d *daemon) serveErrors(w http.ResponseWriter, r *http.Request) {
+	inv, metadata, ok := d.latest(w)
+	if !ok {
+		return
+	}
+
+	errs := inv.errors
+	if errs == nil {
+		errs = []apiError{}
+	}
+	writeJSON(w, http.StatusOK, display.Envelope{Errors: errs, Metadata: metadata})
+}
+
+// serveChanges compares the latest snapshot of every scanned region with the
+// one taken ?since= ago (default 24h)
+func (d *daemon) serveChanges(w http.ResponseWriter, r *http.Request) {
+	metadata := display.Metadata{Region: d.regions()}
+	since := 24 * time.Hour
+	if value := r.URL.Query().Get("since"); value != "" {
+		var err error
+		if since, err = time.ParseDuration(value); err != nil || since <= 0 {
+			metadata.Message = fmt.Sprintf("invalid duration %q", value)
+			writeJSON(w, http.StatusBadRequest, display.Envelope{Changes: []diffReport{}, Metadata: metadata})
+			return
+		}
+	}
+	if d.store == nil {
+		metadata.Message = "History is disabled in the config file"
+		writeJSON(w, http.StatusOK, display.Envelope{Changes: []diffReport{}, Metadata: metadata})
+		return
+	}
+
+	snapshots, err := d.store.Snapshots()
+	if err != nil {
+		metadata.Message = err.Error()
+		writeJSON(w, http.StatusInternalServerError, display.Envelope{Changes: []diffReport{}, Metadata: metadata})
+		return
+	}
+	reports := []diffReport{}
+	for _, target := range d.targets {
+		if len(snapshots) == 0 {
+			break
+		}
+		to, ok := history.Latest(snapshots, target.client.GetRegion(), snapshots[len(snapshots)-1].Time)
+		if !ok {
+			continue
+		}
+		from, ok := previousSnapshot(snapshots, to, since)
+		if !ok {
+			continue
+		}
+		changes := history.Diff(from, to)
+		if changes == nil {
+			changes = []history.Change{}
+		}
+		reports = append(reports, diffReport{
+			From:    snapshotRef{ID: from.ID, Time: from.Time, Region: from.Region},
+			To:      snapshotRef{ID: to.ID, Time: to.Time, Region: to.Region},
+			Changes: changes,
+		})
+	}
+	writeJSON(w, http.StatusOK, display.Envelope{Changes: reports, Metadata: metadata})
+}
+
+// writeJSON writes an indented JSON response
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(append(data, '\n'))
+}
@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"mohua/internal/alert"
+	"mohua/internal/history"
+	"mohua/internal/policy"
+	"mohua/internal/retry"
+	"mohua/internal/sagemaker"
+	"mohua/internal/schedule"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// getEnvelope requests an API path and decodes the envelope
+func getEnvelope(t *testing.T, handler http.Handler, path string) (int, map[string]json.RawMessage) {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+	var envelope map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &envelope))
+	return recorder.Code, envelope
+}
+
+func TestDaemonScan(t *testing.T) {
+	resetCommand()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	p, err := policy.Parse([]byte("rules:\n  - name: endpoint-age\n    select: {types: [Endpoint]}\n    condition: {maxAge: 1d}"))
+	require.NoError(t, err)
+	webhook, bodies := alertReceiver(t)
+	alerts, err := alert.Parse([]byte(fmt.Sprintf("state: %s\nsinks:\n  - {name: ops, url: %q}\nrules:\n  - {name: expensive, trigger: cost, hourlyCost: 2}",
+		filepath.Join(t.TempDir(), "state.json"), webhook.URL)))
+	require.NoError(t, err)
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListEndpoints", mock.Anything).Return([]sagemaker.ResourceInfo{
+		{Name: "search", InstanceType: "ml.g5.xlarge", InstanceCount: 2, Status: "InService", CreationTime: now.Add(-48 * time.Hour)},
+	}, nil)
+	mockClient.On("ListNotebooks", mock.Anything).Return([]sagemaker.ResourceInfo{
+		{Name: "nb", InstanceType: "ml.t3.medium", Status: "InService", CreationTime: now.Add(-time.Hour)},
+	}, nil)
+	mockClient.On("ListTuningJobs", mock.Anything).Return(nil, &sagemaker.RetryableError{Err: errors.New("throttled")})
+	expectEmptyCollectors(mockClient)
+	store := writeHistory(t, history.Snapshot{ID: "old", Time: now.Add(-48 * time.Hour), Region: "us-east-1", Resources: []history.Resource{
+		{ResourceType: "Endpoint", Name: "search", Status: "Creating", InstanceType: "ml.g5.xlarge", InstanceCounts: map[string]int{"ml.g5.xlarge": 2}, CreationTime: now.Add(-48 * time.Hour)},
+		{ResourceType: "Endpoint", Name: "legacy", Status: "InService", CreationTime: now.Add(-72 * time.Hour)},
+	}})
+
+	d := &daemon{targets: []scanTarget{{client: mockClient}}, store: store, policy: p, alerts: alerts, sender: alert.NewSender(), now: func() time.Time { return now }}
+	handler := d.handler()
+
+	status, envelope := getEnvelope(t, handler, "/api/resources")
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.JSONEq(t, `{"region": "us-east-1", "message": "The first scan has not completed yet"}`, string(envelope["metadata"]))
+
+	d.scan(context.Background())
+
+	status, envelope = getEnvelope(t, handler, "/api/resources?type=endpoint")
+	assert.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, `[{"resourceType": "Endpoint", "name": "search", "status": "InService", "instanceType": "ml.g5.xlarge", "runningTime": "48h0m0s", "region": "us-east-1", "estimatedHourlyCost": 2.816}]`, string(envelope["resources"]))
+	assert.JSONEq(t, `{"region": "us-east-1", "scannedAt": "2026-10-18T12:00:00Z"}`, string(envelope["metadata"]))
+
+	status, envelope = getEnvelope(t, handler, "/api/summary")
+	assert.Equal(t, http.StatusOK, status)
+	var summary daemonSummary
+	require.NoError(t, json.Unmarshal(envelope["summary"], &summary))
+	assert.Equal(t, 2, summary.Resources)
+	assert.InDelta(t, 2.816, summary.ByType["Endpoint"].HourlyCost, 0.001)
+	assert.Equal(t, 2, summary.ByType["Endpoint"].Instances)
+	assert.Equal(t, 1, summary.ByType["Notebook"].Resources)
+	assert.Equal(t, 2, summary.ByRegion["us-east-1"].Resources)
+	assert.Equal(t, 3, summary.ByRegion["us-east-1"].Instances)
+	assert.Equal(t, map[string]int{"ml.g5.xlarge": 2, "ml.t3.medium": 1}, summary.Instances)
+	assert.InDelta(t, 2.866, summary.HourlyCost, 0.001)
+	assert.Equal(t, []policy.Violation{{Rule: "endpoint-age", ResourceType: "Endpoint", Resource: "search", Message: "age 2d exceeds 1d"}}, summary.Violations)
+	if assert.Len(t, summary.AlertsSent, 1) {
+		assert.Equal(t, "search", summary.AlertsSent[0].Resource)
+	}
+	assert.Len(t, *bodies, 1)
+	assert.Equal(t, 1, summary.Errors)
+	assert.Equal(t, 1, summary.Scans)
+
+	status, envelope = getEnvelope(t, handler, "/api/errors")
+	assert.Equal(t, http.StatusOK, status)
+	var errs []apiError
+	require.NoError(t, json.Unmarshal(envelope["errors"], &errs))
+	if assert.Len(t, errs, 1) {
+		assert.Equal(t, "tuning jobs", errs[0].Source)
+		assert.Equal(t, "us-east-1", errs[0].Region)
+		assert.True(t, errs[0].Retryable)
+	}
+
+	snapshots, err := store.Snapshots()
+	require.NoError(t, err)
+	if assert.Len(t, snapshots, 2) {
+		assert.Len(t, snapshots[1].Resources, 2)
+		assert.Equal(t, []string{"Tuning"}, snapshots[1].Incomplete)
+	}
+
+	status, envelope = getEnvelope(t, handler, "/api/changes?since=24h")
+	assert.Equal(t, http.StatusOK, status)
+	var reports []diffReport
+	require.NoError(t, json.Unmarshal(envelope["changes"], &reports))
+	if assert.Len(t, reports, 1) {
+		assert.Equal(t, "old", reports[0].From.ID)
+		assert.Equal(t, []history.Change{
+			{Kind: history.Added, ResourceType: "Notebook", Name: "nb"},
+			{Kind: history.Removed, ResourceType: "Endpoint", Name: "legacy"},
+			{Kind: history.StatusChanged, ResourceType: "Endpoint", Name: "search", From: "Creating", To: "InService"},
+		}, reports[0].Changes)
+	}
+	status, _ = getEnvelope(t, handler, "/api/changes?since=soon")
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/resources", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
+
+func TestRunDaemon_Shutdown(t *testing.T) {
+	resetCommand()
+	sched, err := schedule.Parse("@every 1h")
+	require.NoError(t, err)
+
+	// The endpoint collector is waiting to retry when the daemon is stopped
+	waiting := make(chan struct{})
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListEndpoints", mock.Anything).Run(func(args mock.Arguments) {
+		ctx := args.Get(0).(context.Context)
+		retrier := retry.NewRetrier(retry.Config{MaxAttempts: 3, InitialInterval: time.Hour, MaxInterval: time.Hour, Multiplier: 1})
+		attempts := 0
+		err := retrier.Do(ctx, func() error {
+			if attempts++; attempts == 1 {
+				close(waiting)
+			}
+			return errors.New("throttled")
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+	}).Return(nil, context.Canceled)
+	expectEmptyCollectors(mockClient)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	d := &daemon{targets: []scanTarget{{client: mockClient}}, now: time.Now}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- runDaemon(ctx, d, sched, listener)
+	}()
+
+	<-waiting
+	response, err := http.Get("http://" + listener.Addr().String() + "/api/summary")
+	require.NoError(t, err)
+	body, _ := io.ReadAll(response.Body)
+	response.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, response.StatusCode, string(body))
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("daemon did not shut down")
+	}
+	// The cancelled scan does not replace the inventory
+	assert.Nil(t, d.inventory)
+	_, err = net.Dial("tcp", listener.Addr().String())
+	assert.Error(t, err)
+}
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"mohua/internal/display"
+	"mohua/internal/history"
+)
+
+var (
+	diffSince time.Duration
+	diffFrom  string
+	diffTo    string
+)
+
+// diffCmd compares two snapshots from the history
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Show what changed between two recorded scans",
+	Long: `Compare two snapshots recorded by previous scans and show the resources
+added, removed, with a changed status and with changed instance types or
+counts.
+
+By default the latest snapshot is compared with the latest one taken at least
+--since earlier, or the oldest one when the history does not reach back that
+far. --from and --to compare snapshots by ID, as listed by mohua history.
+Snapshots of the region given by --region are compared, or of the region of
+the latest snapshot or the --to snapshot. Snapshots of different regions
+cannot be compared.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := openHistory()
+		if err != nil {
+			return err
+		}
+		return runDiff(store)
+	},
+}
+
+func init() {
+	diffCmd.Flags().DurationVar(&diffSince, "since", 24*time.Hour, "Compare the latest snapshot with the one taken this long before")
+	diffCmd.Flags().StringVar(&diffFrom, "from", "", "ID of the snapshot to compare from")
+	diffCmd.Flags().StringVar(&diffTo, "to", "", "ID of the snapshot to compare to (default latest)")
+	diffCmd.MarkFlagsMutuallyExclusive("since", "from")
+	rootCmd.AddCommand(diffCmd)
+}
+
+// snapshotRef identifies a compared snapshot in the JSON output
+type snapshotRef struct {
+	ID     string    `json:"id"`
+	Time   time.Time `json:"time"`
+	Region string    `json:"region"`
+}
+
+// diffReport is the JSON representation of the changes between two snapshots
+type diffReport struct {
+	From    snapshotRef      `json:"from"`
+	To      snapshotRef      `json:"to"`
+	Changes []history.Change `json:"changes"`
+}
+
+// selectSnapshots returns the snapshots to compare according to the flags
+func selectSnapshots(snapshots []history.Snapshot) (history.Snapshot, history.Snapshot, error) {
+	var from, to history.Snapshot
+	var ok bool
+
+	if diffTo != "" {
+		var err error
+		if to, err = findSnapshot(snapshots, region, diffTo); err != nil {
+			return from, to, err
+		}
+		ok = true
+	} else if len(snapshots) > 0 {
+		to, ok = history.Latest(snapshots, region, snapshots[len(snapshots)-1].Time)
+	}
+	if !ok {
+		return from, to, fmt.Errorf("no snapshots recorded yet; every run of mohua records one")
+	}
+
+	if diffFrom != "" {
+		// Only snapshots of the same region can be compared
+		from, err := findSnapshot(snapshots, to.Region, diffFrom)
+		return from, to, err
+	}
+
+	if from, ok = previousSnapshot(snapshots, to, diffSince); ok {
+		return from, to, nil
+	}
+	return from, to, fmt.Errorf("only one snapshot of %s recorded", to.Region)
+}
+
+// findSnapshot returns the snapshot with an ID, which must have been recorded
+// in region unless region is empty
+func findSnapshot(snapshots []history.Snapshot, region string, id string) (history.Snapshot, error) {
+	snapshot, ok := history.Find(snapshots, id)
+	if !ok {
+		return history.Snapshot{}, fmt.Errorf("snapshot %s not found in history", id)
+	}
+	if region != "" && snapshot.Region != region {
+		return history.Snapshot{}, fmt.Errorf("snapshot %s was not recorded in %s; only snapshots of the same region can be compared", id, region)
+	}
+	return snapshot, nil
+}
+
+// previousSnapshot returns the snapshot of the region of to taken since before
+// it, or the oldest one when the history does not reach back far enough
+func previousSnapshot(snapshots []history.Snapshot, to history.Snapshot, since time.Duration) (history.Snapshot, bool) {
+	if from, ok := history.Latest(snapshots, to.Region, to.Time.Add(-since)); ok {
+		return from, true
+	}
+	for _, snapshot := range snapshots {
+		if snapshot.Region == to.Region && snapshot.Time.Before(to.Time) {
+			return snapshot, true
+		}
+	}
+	return history.Snapshot{}, false
+}
+
+func runDiff(store *history.Store) error {
+	snapshots, err := store.Snapshots()
+	if err != nil {
+		return err
+	}
+	from, to, err := selectSnapshots(snapshots)
+	if err != nil {
+		return err
+	}
+	changes := history.Diff(from, to)
+
+	printer := display.NewPrinter(jsonOutput)
+	if jsonOutput {
+		if changes == nil {
+			changes = []history.Change{}
+		}
+		return printer.PrintJSON(diffReport{
+			From:    snapshotRef{ID: from.ID, Time: from.Time, Region: from.Region},
+			To:      snapshotRef{ID: to.ID, Time: to.Time, Region: to.Region},
+			Changes: changes,
+		})
+	}
+
+	period := fmt.Sprintf("between %s (%s) and %s (%s) in %s",
+		from.ID, from.Time.Local().Format(snapshotTimeFormat),
+		to.ID, to.Time.Local().Format(snapshotTimeFormat), to.Region)
+	if len(changes) == 0 {
+		printer.PrintSummary("No changes %s", period)
+		return nil
+	}
+
+	rows := make([][]string, 0, len(changes))
+	for _, change := range changes {
+		rows = append(rows, []string{change.Kind, change.ResourceType, change.Name, formatOptional(change.From), formatOptional(change.To)})
+	}
+	printer.PrintTable([]string{"Change", "Type", "Name", "From", "To"}, rows)
+	printer.PrintSummary("%d changes %s", len(changes), period)
+	return nil
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"mohua/internal/history"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var diffBase = time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+func testSnapshot(id string, region string, age time.Duration, resources ...history.Resource) history.Snapshot {
+	return history.Snapshot{ID: id, Region: region, Time: diffBase.Add(-age), Resources: resources}
+}
+
+func TestSelectSnapshots(t *testing.T) {
+	snapshots := []history.Snapshot{
+		testSnapshot("old", "us-east-1", 48*time.Hour),
+		testSnapshot("day", "us-east-1", 25*time.Hour),
+		testSnapshot("hour", "us-east-1", time.Hour),
+		testSnapshot("eu", "eu-west-1", 30*time.Minute),
+		testSnapshot("now-eu", "eu-west-1", 0),
+		testSnapshot("now", "us-east-1", 0),
+	}
+
+	tests := []struct {
+		name     string
+		setup    func()
+		from, to string
+		wantErr  string
+	}{
+		{name: "since", setup: func() { region = "us-east-1" }, from: "day", to: "now"},
+		{name: "since beyond history", setup: func() { region, diffSince = "us-east-1", 72*time.Hour }, from: "old", to: "now"},
+		{name: "region", setup: func() { region = "eu-west-1" }, from: "eu", to: "now-eu"},
+		{name: "from and to", setup: func() { diffFrom, diffTo = "old", "hour" }, from: "old", to: "hour"},
+		{name: "from in another region", setup: func() { diffFrom, diffTo = "eu", "hour" },
+			wantErr: "snapshot eu was not recorded in us-east-1; only snapshots of the same region can be compared"},
+		{name: "to in another region", setup: func() { region, diffFrom, diffTo = "us-east-1", "old", "now-eu" },
+			wantErr: "snapshot now-eu was not recorded in us-east-1; only snapshots of the same region can be compared"},
+		{name: "to in region", setup: func() { region, diffFrom, diffTo = "us-east-1", "old", "now" }, from: "old", to: "now"},
+		{name: "unknown from", setup: func() { diffFrom = "missing" }, wantErr: "snapshot missing not found in history"},
+		{name: "unknown to", setup: func() { diffTo = "missing" }, wantErr: "snapshot missing not found in history"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetCommand()
+			tt.setup()
+
+			from, to, err := selectSnapshots(snapshots)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.from, from.ID)
+			assert.Equal(t, tt.to, to.ID)
+		})
+	}
+
+	resetCommand()
+	_, _, err := selectSnapshots(nil)
+	assert.EqualError(t, err, "no snapshots recorded yet; every run of mohua records one")
+}
+
+func TestRunDiff(t *testing.T) {
+	store := writeHistory(t,
+		testSnapshot("before", "us-east-1", 24*time.Hour,
+			history.Resource{ResourceType: "Endpoint", Name: "churn", Status: "InService", InstanceType: "ml.m5.xlarge"},
+		),
+		testSnapshot("after", "us-east-1", 0,
+			history.Resource{ResourceType: "Endpoint", Name: "churn", Status: "Failed", InstanceType: "ml.m5.xlarge"},
+			history.Resource{ResourceType: "Notebook", Name: "dev", Status: "InService", InstanceType: "ml.t3.medium"},
+		),
+	)
+
+	for _, jsonFlag := range []bool{false, true} {
+		resetCommand()
+		jsonOutput = jsonFlag
+		assert.NoError(t, runDiff(store))
+	}
+}
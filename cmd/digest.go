@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"mohua/internal/config"
+	"mohua/internal/digest"
+	"mohua/internal/display"
+	"mohua/internal/metrics"
+	"mohua/internal/policy"
+	"mohua/internal/sagemaker"
+	"mohua/internal/tagging"
+)
+
+var digestDryRun bool
+
+// digestCmd emails a digest of the collected resources
+var digestCmd = &cobra.Command{
+	Use:   "digest",
+	Short: "Email a digest of running resources, top spenders, idle resources and policy violations",
+	Long: `Email an HTML and plain text digest of the running resources, the top
+spenders, idle endpoints and the violations of a policy file through an SMTP
+server. Run it daily, e.g. from cron.
+
+The digest is configured in the config file:
+
+  digest:
+    smtp:
+      host: smtp.example.com
+      port: 587                       # default
+      username: mohua
+      passwordEnv: MOHUA_SMTP_PASSWORD # default
+      startTLS: true                  # default
+    from: mohua <mohua@example.com>
+    to: [ml-leads@example.com]        # receive every resource
+    recipientTag: team
+    recipients:                       # receive the resources tagged with a value
+      search: [search-owner@example.com]
+    topSpenders: 10                   # default
+    idleFor: 24h                      # default, 0 skips the idle check
+    policy: mohua-policy.yaml         # optional
+
+Recipients of a tag value receive only the resources with that tag value and
+their violations; violations of limits across resources go to the to list.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		var p *policy.Policy
+		if cfg.Digest.Policy != "" {
+			if p, err = policy.Load(cfg.Digest.Policy); err != nil {
+				return err
+			}
+		}
+
+		client, err := sagemaker.NewClient(region)
+		if err != nil {
+			return fmt.Errorf("failed to create SageMaker client: %w", err)
+		}
+
+		var tagClient tagging.Client
+		if cfg.Digest.RecipientTag != "" || (p != nil && p.UsesTags()) {
+			if tagClient, err = newTaggingClient(client, cfg); err != nil {
+				return err
+			}
+		}
+
+		var metricsClient metrics.Client
+		if cfg.Digest.IdleFor > 0 || (p != nil && p.UsesIdle()) {
+			if metricsClient, err = metrics.NewClient(region); err != nil {
+				return fmt.Errorf("failed to create CloudWatch client: %w", err)
+			}
+		}
+
+		smtpCfg := cfg.Digest.SMTP
+		mailer := digest.NewSMTPMailer(digest.SMTPConfig{
+			Host:     smtpCfg.Host,
+			Port:     smtpCfg.Port,
+			Username: smtpCfg.Username,
+			Password: os.Getenv(smtpCfg.PasswordEnv),
+			StartTLS: smtpCfg.StartTLS,
+		})
+		return runDigest(client, tagClient, metricsClient, mailer, cfg.Digest, p, time.Now(), cmd.OutOrStdout())
+	},
+}
+
+func init() {
+	digestCmd.Flags().BoolVar(&digestDryRun, "dry-run", false, "Print the plain text digest of every recipient instead of sending it")
+	rootCmd.AddCommand(digestCmd)
+}
+
+// digestReport is the JSON representation of a digest sent to recipients
+type digestReport struct {
+	To      []string      `json:"to"`
+	Subject string        `json:"subject"`
+	Digest  digest.Digest `json:"digest"`
+}
+
+// runDigest collects the resources and sends a digest to each group of
+// recipients. p may be nil when no policy is configured.
+func runDigest(client sagemaker.Client, tagClient tagging.Client, metricsClient metrics.Client, mailer digest.Mailer, cfg config.DigestConfig, p *policy.Policy, now time.Time, out io.Writer) error {
+	ctx := context.Background()
+
+	if !digestDryRun {
+		if cfg.SMTP.Host == "" {
+			return errors.New("no SMTP server configured: set digest.smtp.host in the config file")
+		}
+		if cfg.From == "" {
+			return errors.New("no sender configured: set digest.from in the config file")
+		}
+	}
+	if len(cfg.To) == 0 && (cfg.RecipientTag == "" || len(cfg.Recipients) == 0) {
+		return errors.New("no recipients configured: set digest.to or digest.recipientTag and digest.recipients in the config file")
+	}
+
+	collectors := collectors(client)
+	results := collectResources(ctx, client, collectors)
+	resources, err := mergeResults(collectors, results)
+	if err != nil {
+		return err
+	}
+	if tagClient != nil {
+		if err := attachTags(ctx, tagClient, resources); err != nil {
+			return err
+		}
+	}
+
+	in := digest.Input{Resources: resources}
+	idle := endpointIdleFunc(ctx, metricsClient)
+	if idle != nil && cfg.IdleFor > 0 {
+		for _, resource := range resources {
+			isIdle, err := idle(resource, cfg.IdleFor)
+			if err != nil {
+				return err
+			}
+			if isIdle {
+				in.Idle = append(in.Idle, resource)
+			}
+		}
+	}
+	if p != nil {
+		if in.Violations, err = policy.Evaluate(p, resources, now, idle); err != nil {
+			return err
+		}
+	}
+
+	var reports []digestReport
+	for _, recipients := range digest.Route(resources, cfg.To, cfg.RecipientTag, cfg.Recipients) {
+		recipientInput := in
+		if !recipients.All {
+			recipientInput = in.Only(recipients.Resources)
+		}
+		d := digest.Build(recipientInput, client.GetRegion(), now, cfg.TopSpenders)
+		reports = append(reports, digestReport{To: recipients.To, Subject: d.Subject(), Digest: d})
+	}
+
+	// The digests are still sent, but a run without some resources is reported as partial
+	partialErr := partialError(collectors, results)
+	if jsonOutput && digestDryRun {
+		if err := display.NewPrinter(true).PrintJSON(reports); err != nil {
+			return err
+		}
+		return partialErr
+	}
+
+	for _, report := range reports {
+		text, err := report.Digest.Text()
+		if err != nil {
+			return err
+		}
+		if digestDryRun {
+			fmt.Fprintf(out, "To: %s\nSubject: %s\n\n%s\n", strings.Join(report.To, ", "), report.Subject, text)
+			continue
+		}
+
+		html, err := report.Digest.HTML()
+		if err != nil {
+			return err
+		}
+		message := digest.Message{From: cfg.From, To: report.To, Subject: report.Subject, Text: text, HTML: html}
+		if err := mailer.Send(ctx, message); err != nil {
+			return err
+		}
+	}
+
+	if !digestDryRun {
+		printer := display.NewPrinter(jsonOutput)
+		if jsonOutput {
+			if err := printer.PrintJSON(reports); err != nil {
+				return err
+			}
+			return partialErr
+		}
+		printer.PrintSummary("Sent %d digests to %d recipients", len(reports), countRecipients(reports))
+	}
+	return partialErr
+}
+
+func countRecipients(reports []digestReport) int {
+	var n int
+	for _, report := range reports {
+		n += len(report.To)
+	}
+	return n
+}
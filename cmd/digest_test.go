@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"mohua/internal/config"
+	"mohua/internal/digest"
+	"mohua/internal/policy"
+	"mohua/internal/sagemaker"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func digestConfig() config.DigestConfig {
+	cfg := config.Default().Digest
+	cfg.SMTP.Host = "smtp.example.com"
+	cfg.From = "mohua@example.com"
+	cfg.To = []string{"leads@example.com"}
+	cfg.RecipientTag = "team"
+	cfg.Recipients = map[string][]string{"search": {"search@example.com"}}
+	return cfg
+}
+
+func TestRunDigest(t *testing.T) {
+	resetCommand()
+	now := time.Date(2026, 10, 18, 7, 0, 0, 0, time.UTC)
+	p, err := policy.Parse([]byte("rules:\n  - name: endpoint-age\n    select: {types: [Endpoint]}\n    condition: {maxAge: 1d}"))
+	assert.NoError(t, err)
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListEndpoints", mock.Anything).Return([]sagemaker.ResourceInfo{
+		{Name: "search", Arn: "arn:search", InstanceType: "ml.g5.xlarge", InstanceCount: 2, CreationTime: now.Add(-48 * time.Hour)},
+		{Name: "churn", Arn: "arn:churn", InstanceType: "ml.m5.large", CreationTime: now.Add(-48 * time.Hour)},
+	}, nil)
+	expectEmptyCollectors(mockClient)
+	tagClient := new(MockTaggingClient)
+	tagClient.On("ResourceTags", mock.Anything, []string{"arn:search", "arn:churn"}).Return(map[string]map[string]string{
+		"arn:search": {"team": "search"},
+		"arn:churn":  {"team": "growth"},
+	}, nil)
+	metricsClient := new(MockMetricsClient)
+	metricsClient.On("EndpointInvocations", mock.Anything, "search", 24*time.Hour).Return(0.0, nil)
+	metricsClient.On("EndpointInvocations", mock.Anything, "churn", 24*time.Hour).Return(80.0, nil)
+
+	var messages []digest.Message
+	mailer := new(MockMailer)
+	mailer.On("Send", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		messages = append(messages, args.Get(1).(digest.Message))
+	}).Return(nil)
+
+	err = runDigest(mockClient, tagClient, metricsClient, mailer, digestConfig(), p, now, new(bytes.Buffer))
+
+	assert.NoError(t, err)
+	if assert.Len(t, messages, 2) {
+		assert.Equal(t, []string{"leads@example.com"}, messages[0].To)
+		assert.Equal(t, "mohua@example.com", messages[0].From)
+		assert.Equal(t, "SageMaker digest us-east-1: 2 running, $2.93/h, 1 idle, 2 violations", messages[0].Subject)
+		assert.Contains(t, messages[0].Text, "Endpoint churn")
+		assert.Contains(t, messages[0].HTML, "<h3>Idle resources</h3>")
+
+		assert.Equal(t, []string{"search@example.com"}, messages[1].To)
+		assert.Equal(t, "SageMaker digest us-east-1: 1 running, $2.82/h, 1 idle, 1 violations", messages[1].Subject)
+		assert.NotContains(t, messages[1].Text, "churn")
+		assert.Contains(t, messages[1].Text, "endpoint-age: Endpoint search: age 2d exceeds 1d")
+	}
+	mailer.AssertExpectations(t)
+	tagClient.AssertExpectations(t)
+	metricsClient.AssertExpectations(t)
+}
+
+func TestRunDigest_DryRun(t *testing.T) {
+	resetCommand()
+	digestDryRun = true
+	cfg := config.DigestConfig{To: []string{"leads@example.com"}, TopSpenders: 10}
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListNotebooks", mock.Anything).Return([]sagemaker.ResourceInfo{{Name: "nb", InstanceType: "ml.t3.medium"}}, nil)
+	expectEmptyCollectors(mockClient)
+	mailer := new(MockMailer)
+
+	var out bytes.Buffer
+	err := runDigest(mockClient, nil, nil, mailer, cfg, nil, time.Now(), &out)
+
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), "To: leads@example.com\nSubject: SageMaker digest us-east-1: 1 running, $0.05/h, 0 idle, 0 violations\n")
+	assert.Contains(t, out.String(), "Top spenders\n  $0.05/h  Notebook nb (ml.t3.medium)\n")
+	mailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+}
+
+func TestRunDigest_ThrottledCollector(t *testing.T) {
+	resetCommand()
+	cfg := digestConfig()
+	cfg.RecipientTag = ""
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListEndpoints", mock.Anything).Return(nil, &sagemaker.RetryableError{Err: errors.New("throttled")})
+	mockClient.On("ListNotebooks", mock.Anything).Return([]sagemaker.ResourceInfo{{Name: "nb", InstanceType: "ml.t3.medium"}}, nil)
+	expectEmptyCollectors(mockClient)
+	mailer := new(MockMailer)
+	mailer.On("Send", mock.Anything, mock.Anything).Return(nil).Once()
+
+	// The digest of the other resources is sent, but the run is reported as partial
+	err := runDigest(mockClient, nil, nil, mailer, cfg, nil, time.Now(), new(bytes.Buffer))
+
+	assert.ErrorContains(t, err, "failed to list endpoints: throttled")
+	assert.Equal(t, ExitPartial, ExitCode(err))
+	mailer.AssertExpectations(t)
+}
+
+func TestRunDigest_Errors(t *testing.T) {
+	resetCommand()
+	mockClient := new(MockSageMakerClient)
+
+	cfg := digestConfig()
+	cfg.SMTP.Host = ""
+	err := runDigest(mockClient, nil, nil, new(MockMailer), cfg, nil, time.Now(), new(bytes.Buffer))
+	assert.ErrorContains(t, err, "no SMTP server configured")
+
+	cfg = digestConfig()
+	cfg.To, cfg.Recipients = nil, nil
+	err = runDigest(mockClient, nil, nil, new(MockMailer), cfg, nil, time.Now(), new(bytes.Buffer))
+	assert.ErrorContains(t, err, "no recipients configured")
+
+	mockClient.On("ListEndpoints", mock.Anything).Return([]sagemaker.ResourceInfo{{Name: "churn"}}, nil)
+	expectEmptyCollectors(mockClient)
+	mailer := new(MockMailer)
+	mailer.On("Send", mock.Anything, mock.Anything).Return(errors.New("failed to connect to SMTP server"))
+	cfg = digestConfig()
+	cfg.IdleFor = 0
+	err = runDigest(mockClient, new(MockTaggingClient), nil, mailer, cfg, nil, time.Now(), new(bytes.Buffer))
+	assert.EqualError(t, err, "failed to connect to SMTP server")
+}
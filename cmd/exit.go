@@ -0,0 +1,42 @@
+package cmd
+
+import "errors"
+
+// Exit codes of the mohua binary
+const (
+	ExitOK        = 0
+	ExitError     = 1 // The command could not complete
+	ExitViolation = 2 // The command completed and found violations
+	ExitPartial   = 3 // The command completed without the resources of some collectors
+)
+
+// exitCodeError is an error that ends the process with a specific exit code
+type exitCodeError struct {
+	code int
+	err  error
+}
+
+func (e *exitCodeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitCodeError) Unwrap() error {
+	return e.err
+}
+
+// withExitCode attaches an exit code to an error
+func withExitCode(code int, err error) error {
+	return &exitCodeError{code: code, err: err}
+}
+
+// ExitCode returns the process exit code for an error returned by Execute
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	var codeErr *exitCodeError
+	if errors.As(err, &codeErr) {
+		return codeErr.code
+	}
+	return ExitError
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+)
+
+// formatAge renders a duration as hours and minutes, e.g. "72h 15m"
+func formatAge(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+	return fmt.Sprintf("%dh %dm", hours, minutes)
+}
+
+// formatHourlyCost shows a cost or "-" when it is unknown
+func formatHourlyCost(cost float64) string {
+	if cost == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("$%.2f", cost)
+}
+
+// formatOptional shows a value or "-" when it is empty
+func formatOptional(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
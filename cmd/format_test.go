@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatAge(t *testing.T) {
+	assert.Equal(t, "0h 0m", formatAge(0))
+	assert.Equal(t, "0h 0m", formatAge(-time.Hour))
+	assert.Equal(t, "72h 15m", formatAge(72*time.Hour+15*time.Minute+30*time.Second))
+}
+
+func TestFormatHourlyCost(t *testing.T) {
+	assert.Equal(t, "-", formatHourlyCost(0))
+	assert.Equal(t, "$1.41", formatHourlyCost(1.408))
+}
+
+func TestFormatOptional(t *testing.T) {
+	assert.Equal(t, "-", formatOptional(""))
+	assert.Equal(t, "InService", formatOptional("InService"))
+}
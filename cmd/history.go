@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"mohua/internal/config"
+	"mohua/internal/display"
+	"mohua/internal/history"
+)
+
+// historyCmd lists the snapshots recorded by previous scans
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List the snapshots recorded by previous scans",
+	Long: `List the snapshots that mohua records after every scan of running resources.
+Snapshot IDs can be compared with mohua diff --from and --to.
+
+History is configured in the config file:
+
+  history:
+    enabled: true
+    dir: ~/.local/share/mohua # default
+    retention: 720h           # snapshots older than this are removed, 0 keeps all`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := openHistory()
+		if err != nil {
+			return err
+		}
+		return runHistory(store)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+}
+
+// snapshotTimeFormat formats snapshot times in local time
+const snapshotTimeFormat = "2006-01-02 15:04"
+
+// snapshotSummary is the JSON representation of a stored snapshot
+type snapshotSummary struct {
+	ID         string   `json:"id"`
+	Time       string   `json:"time"`
+	Region     string   `json:"region"`
+	Resources  int      `json:"resources"`
+	HourlyCost float64  `json:"hourlyCost"`
+	Incomplete []string `json:"incomplete,omitempty"`
+}
+
+// openHistory opens the history store configured in the config file, even
+// when recording is disabled
+func openHistory() (*history.Store, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	return newHistoryStore(cfg)
+}
+
+// newHistoryStore creates the history store in the configured directory
+func newHistoryStore(cfg *config.Config) (*history.Store, error) {
+	dir := cfg.History.Dir
+	if dir == "" {
+		var err error
+		if dir, err = history.DefaultDir(); err != nil {
+			return nil, fmt.Errorf("failed to find history directory: %w", err)
+		}
+	}
+	return history.NewStore(dir, cfg.History.Retention), nil
+}
+
+// recordSnapshot stores the resources found by a scan. Collectors that
+// failed are recorded as incomplete.
+func recordSnapshot(store *history.Store, region string, collectors []collector, results []ResourceResult) error {
+	var resources []history.Resource
+	var incomplete []string
+	for i, c := range collectors {
+		if results[i].Error != nil {
+			incomplete = append(incomplete, c.resourceType)
+			continue
+		}
+		for _, resource := range results[i].Resources {
+			resources = append(resources, history.NewResource(resource))
+		}
+	}
+	_, err := store.Record(region, resources, incomplete)
+	return err
+}
+
+func runHistory(store *history.Store) error {
+	snapshots, err := store.Snapshots()
+	if err != nil {
+		return err
+	}
+
+	summaries := make([]snapshotSummary, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		if region != "" && snapshot.Region != region {
+			continue
+		}
+		var cost float64
+		for _, resource := range snapshot.Resources {
+			cost += resource.HourlyCost
+		}
+		summaries = append(summaries, snapshotSummary{
+			ID:         snapshot.ID,
+			Time:       snapshot.Time.Local().Format(snapshotTimeFormat),
+			Region:     snapshot.Region,
+			Resources:  len(snapshot.Resources),
+			HourlyCost: cost,
+			Incomplete: snapshot.Incomplete,
+		})
+	}
+
+	printer := display.NewPrinter(jsonOutput)
+	if jsonOutput {
+		return printer.PrintJSON(summaries)
+	}
+	if len(summaries) == 0 {
+		printer.PrintSummary("No snapshots recorded yet; every run of mohua records one")
+		return nil
+	}
+
+	rows := make([][]string, 0, len(summaries))
+	for _, summary := range summaries {
+		incomplete := "-"
+		if len(summary.Incomplete) > 0 {
+			incomplete = strings.Join(summary.Incomplete, ", ")
+		}
+		rows = append(rows, []string{
+			summary.ID,
+			summary.Time,
+			summary.Region,
+			fmt.Sprintf("%d", summary.Resources),
+			formatHourlyCost(summary.HourlyCost),
+			incomplete,
+		})
+	}
+	printer.PrintTable([]string{"ID", "Time", "Region", "Resources", "Hourly Cost", "Failed Collectors"}, rows)
+	return nil
+}
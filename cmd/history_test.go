@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"mohua/internal/history"
+	"mohua/internal/sagemaker"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// writeHistory creates a history store holding the snapshots
+func writeHistory(t *testing.T, snapshots ...history.Snapshot) *history.Store {
+	t.Helper()
+	dir := t.TempDir()
+	var data []byte
+	for _, snapshot := range snapshots {
+		line, err := json.Marshal(snapshot)
+		assert.NoError(t, err)
+		data = append(append(data, line...), '\n')
+	}
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "snapshots.jsonl"), data, 0o600))
+	return history.NewStore(dir, 0)
+}
+
+func TestRunMonitor_RecordsSnapshot(t *testing.T) {
+	resetCommand()
+	store := history.NewStore(t.TempDir(), 0)
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ValidateConfiguration", mock.Anything).Return(true, nil)
+	mockClient.On("ListEndpoints", mock.Anything).Return([]sagemaker.ResourceInfo{
+		{Name: "churn", Status: "InService", InstanceType: "ml.m5.xlarge", InstanceCount: 2, CreationTime: time.Now()},
+	}, nil)
+	mockClient.On("ListNotebooks", mock.Anything).Return(nil, &sagemaker.RetryableError{Err: assert.AnError})
+	expectEmptyCollectors(mockClient)
+
+	err := runMonitor(mockClient, nil, nil, store, nil, nil, nil)
+	assert.Equal(t, ExitPartial, ExitCode(err))
+
+	snapshots, err := store.Snapshots()
+	assert.NoError(t, err)
+	if assert.Len(t, snapshots, 1) {
+		snapshot := snapshots[0]
+		assert.Equal(t, "us-east-1", snapshot.Region)
+		assert.Equal(t, []string{"Notebook"}, snapshot.Incomplete)
+		if assert.Len(t, snapshot.Resources, 1) {
+			assert.Equal(t, "Endpoint", snapshot.Resources[0].ResourceType)
+			assert.Equal(t, map[string]int{"ml.m5.xlarge": 2}, snapshot.Resources[0].InstanceCounts)
+		}
+	}
+}
+
+func TestRunHistory(t *testing.T) {
+	for _, jsonFlag := range []bool{false, true} {
+		resetCommand()
+		jsonOutput = jsonFlag
+
+		store := writeHistory(t, history.Snapshot{
+			ID:        "20261018T120000Z",
+			Time:      time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC),
+			Region:    "us-east-1",
+			Resources: []history.Resource{{ResourceType: "Endpoint", Name: "churn", HourlyCost: 0.46}},
+		})
+		assert.NoError(t, runHistory(store))
+	}
+
+	resetCommand()
+	assert.NoError(t, runHistory(history.NewStore(t.TempDir(), 0)))
+}
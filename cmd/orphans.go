@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"mohua/internal/display"
+	"mohua/internal/sagemaker"
+)
+
+var (
+	includeModelPackages bool
+	deleteOrphans        bool
+	deleteModelPackages  bool
+	assumeYes            bool
+)
+
+// orphansCmd finds endpoint configs, models and model package groups that nothing references
+var orphansCmd = &cobra.Command{
+	Use:   "orphans",
+	Short: "Find endpoint configs and models not referenced by any endpoint",
+	Long: `Find endpoint configs, models and optionally model package groups that are not
+referenced by any endpoint or inference component.
+
+By default the command is a dry run that only lists the unreferenced resources.
+Use --delete to remove them. Unused model package groups are only deleted with
+--delete-model-packages, which also deletes every model package version
+registered in them. The versions are listed before the confirmation prompt.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := sagemaker.NewClient(region)
+		if err != nil {
+			return fmt.Errorf("failed to create SageMaker client: %w", err)
+		}
+
+		return runOrphans(client, cmd.InOrStdin(), time.Now())
+	},
+}
+
+func init() {
+	orphansCmd.Flags().BoolVar(&includeModelPackages, "include-model-packages", false, "Also report model package groups not used by any referenced model")
+	orphansCmd.Flags().BoolVar(&deleteOrphans, "delete", false, "Delete the unreferenced resources instead of only listing them")
+	orphansCmd.Flags().BoolVar(&deleteModelPackages, "delete-model-packages", false, "With --delete and --include-model-packages, also delete the unused model package groups and all their model package versions")
+	orphansCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Do not ask for confirmation before deleting")
+	rootCmd.AddCommand(orphansCmd)
+}
+
+// orphanEntry is a single unreferenced resource in the orphans report
+type orphanEntry struct {
+	ResourceType string    `json:"resourceType"`
+	Name         string    `json:"name"`
+	CreationTime time.Time `json:"creationTime"`
+	Age          string    `json:"age"`
+	Action       string    `json:"action"`
+	// ModelPackages are the versions deleted with a model package group
+	ModelPackages []string `json:"modelPackages,omitempty"`
+	Error         string   `json:"error,omitempty"`
+}
+
+func runOrphans(client sagemaker.Client, in io.Reader, now time.Time) error {
+	ctx := context.Background()
+
+	if deleteModelPackages && (!deleteOrphans || !includeModelPackages) {
+		return fmt.Errorf("--delete-model-packages requires --delete and --include-model-packages")
+	}
+
+	orphans, err := client.FindOrphans(ctx, includeModelPackages)
+	if err != nil {
+		return fmt.Errorf("failed to find unreferenced resources: %w", err)
+	}
+
+	printer := display.NewPrinter(jsonOutput)
+	if len(orphans) == 0 {
+		printer.PrintNoResources(client.GetRegion())
+		return nil
+	}
+
+	entries := make([]orphanEntry, 0, len(orphans))
+	for _, orphan := range orphans {
+		entries = append(entries, orphanEntry{
+			ResourceType: orphan.ResourceType,
+			Name:         orphan.Name,
+			CreationTime: orphan.CreationTime,
+			Age:          formatAge(now.Sub(orphan.CreationTime)),
+			Action:       "dry-run",
+		})
+	}
+
+	var failed, kept, versions int
+	if deleteOrphans {
+		for i, orphan := range orphans {
+			if orphan.ResourceType != sagemaker.OrphanModelPackageGroup {
+				continue
+			}
+			if !deleteModelPackages {
+				entries[i].Action = "kept"
+				kept++
+				continue
+			}
+			arns, err := client.ListModelPackageVersions(ctx, orphan.Name)
+			if err != nil {
+				return err
+			}
+			orphans[i].ModelPackages = arns
+			entries[i].ModelPackages = arns
+			versions += len(arns)
+			fmt.Fprintf(os.Stderr, "Model package group %s: %d versions will be deleted\n", orphan.Name, len(arns))
+			for _, arn := range arns {
+				fmt.Fprintf(os.Stderr, "  %s\n", arn)
+			}
+		}
+
+		question := fmt.Sprintf("Delete %d unreferenced resources in %s?", len(orphans)-kept, client.GetRegion())
+		if versions > 0 {
+			question = fmt.Sprintf("Delete %d unreferenced resources and %d model package versions in %s?", len(orphans)-kept, versions, client.GetRegion())
+		}
+		if !assumeYes && !confirm(in, question) {
+			return fmt.Errorf("deletion cancelled")
+		}
+
+		for i, orphan := range orphans {
+			if entries[i].Action == "kept" {
+				continue
+			}
+			if err := client.DeleteOrphan(ctx, orphan); err != nil {
+				entries[i].Action = "failed"
+				entries[i].Error = err.Error()
+				failed++
+				continue
+			}
+			entries[i].Action = "deleted"
+		}
+	}
+
+	if jsonOutput {
+		if err := printer.PrintJSON(entries); err != nil {
+			return err
+		}
+	} else {
+		rows := make([][]string, 0, len(entries))
+		highlight := make([]bool, 0, len(entries))
+		for _, entry := range entries {
+			rows = append(rows, []string{entry.ResourceType, entry.Name, entry.Age, entry.Action, entry.Error})
+			highlight = append(highlight, entry.Error != "")
+		}
+		printer.PrintHighlightedTable([]string{"Type", "Name", "Age", "Action", "Error"}, rows, highlight)
+		if !deleteOrphans {
+			printer.PrintSummary("Dry run: %d unreferenced resources found. Use --delete to remove them.", len(entries))
+		} else if kept > 0 {
+			printer.PrintSummary("Kept %d model package groups. Use --delete-model-packages to delete them with their versions.", kept)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d of %d unreferenced resources", failed, len(orphans)-kept)
+	}
+	return nil
+}
+
+// confirm asks a yes/no question on stderr and reads the answer from in
+func confirm(in io.Reader, question string) bool {
+	fmt.Fprintf(os.Stderr, "%s [y/N]: ", question)
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && answer == "" {
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"mohua/internal/sagemaker"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRunOrphans_DryRun(t *testing.T) {
+	resetCommand()
+	created := time.Now().Add(-48 * time.Hour)
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("GetRegion").Return("us-west-2").Maybe()
+	mockClient.On("FindOrphans", mock.Anything, false).Return([]sagemaker.OrphanInfo{
+		{ResourceType: sagemaker.OrphanEndpointConfig, Name: "old-config", CreationTime: created},
+	}, nil)
+
+	err := runOrphans(mockClient, strings.NewReader(""), time.Now())
+	assert.NoError(t, err)
+	mockClient.AssertNotCalled(t, "DeleteOrphan", mock.Anything, mock.Anything)
+}
+
+func TestRunOrphans_Delete(t *testing.T) {
+	resetCommand()
+	deleteOrphans = true
+	defer resetCommand()
+
+	orphans := []sagemaker.OrphanInfo{
+		{ResourceType: sagemaker.OrphanEndpointConfig, Name: "old-config"},
+		{ResourceType: sagemaker.OrphanModel, Name: "old-model"},
+	}
+
+	t.Run("cancelled", func(t *testing.T) {
+		mockClient := new(MockSageMakerClient)
+		mockClient.On("GetRegion").Return("us-west-2")
+		mockClient.On("FindOrphans", mock.Anything, false).Return(orphans, nil)
+
+		err := runOrphans(mockClient, strings.NewReader("n\n"), time.Now())
+		assert.EqualError(t, err, "deletion cancelled")
+		mockClient.AssertNotCalled(t, "DeleteOrphan", mock.Anything, mock.Anything)
+	})
+
+	t.Run("confirmed with partial failure", func(t *testing.T) {
+		mockClient := new(MockSageMakerClient)
+		mockClient.On("GetRegion").Return("us-west-2")
+		mockClient.On("FindOrphans", mock.Anything, false).Return(orphans, nil)
+		mockClient.On("DeleteOrphan", mock.Anything, orphans[0]).Return(nil)
+		mockClient.On("DeleteOrphan", mock.Anything, orphans[1]).Return(errors.New("in use"))
+
+		err := runOrphans(mockClient, strings.NewReader("yes\n"), time.Now())
+		assert.EqualError(t, err, "failed to delete 1 of 2 unreferenced resources")
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("assume yes", func(t *testing.T) {
+		assumeYes = true
+		mockClient := new(MockSageMakerClient)
+		mockClient.On("GetRegion").Return("us-west-2").Maybe()
+		mockClient.On("FindOrphans", mock.Anything, false).Return(orphans, nil)
+		mockClient.On("DeleteOrphan", mock.Anything, mock.Anything).Return(nil)
+
+		err := runOrphans(mockClient, strings.NewReader(""), time.Now())
+		assert.NoError(t, err)
+		mockClient.AssertNumberOfCalls(t, "DeleteOrphan", 2)
+	})
+}
+
+func TestRunOrphans_ModelPackages(t *testing.T) {
+	versions := []string{
+		"arn:aws:sagemaker:us-west-2:123456789012:model-package/old-group/1",
+		"arn:aws:sagemaker:us-west-2:123456789012:model-package/old-group/2",
+	}
+	orphans := []sagemaker.OrphanInfo{
+		{ResourceType: sagemaker.OrphanModel, Name: "old-model"},
+		{ResourceType: sagemaker.OrphanModelPackageGroup, Name: "old-group"},
+	}
+
+	t.Run("kept without explicit flag", func(t *testing.T) {
+		resetCommand()
+		includeModelPackages = true
+		deleteOrphans = true
+		defer resetCommand()
+
+		mockClient := new(MockSageMakerClient)
+		mockClient.On("GetRegion").Return("us-west-2")
+		mockClient.On("FindOrphans", mock.Anything, true).Return(orphans, nil)
+		mockClient.On("DeleteOrphan", mock.Anything, orphans[0]).Return(nil)
+
+		err := runOrphans(mockClient, strings.NewReader("y\n"), time.Now())
+		assert.NoError(t, err)
+		mockClient.AssertExpectations(t)
+		mockClient.AssertNotCalled(t, "ListModelPackageVersions", mock.Anything, mock.Anything)
+		mockClient.AssertNumberOfCalls(t, "DeleteOrphan", 1)
+	})
+
+	t.Run("deleted with listed versions", func(t *testing.T) {
+		resetCommand()
+		includeModelPackages = true
+		deleteOrphans = true
+		deleteModelPackages = true
+		defer resetCommand()
+
+		group := orphans[1]
+		group.ModelPackages = versions
+
+		mockClient := new(MockSageMakerClient)
+		mockClient.On("GetRegion").Return("us-west-2")
+		mockClient.On("FindOrphans", mock.Anything, true).Return(orphans, nil)
+		mockClient.On("ListModelPackageVersions", mock.Anything, "old-group").Return(versions, nil)
+		mockClient.On("DeleteOrphan", mock.Anything, orphans[0]).Return(nil)
+		mockClient.On("DeleteOrphan", mock.Anything, group).Return(nil)
+
+		err := runOrphans(mockClient, strings.NewReader("y\n"), time.Now())
+		assert.NoError(t, err)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("cancelled after listing versions", func(t *testing.T) {
+		resetCommand()
+		includeModelPackages = true
+		deleteOrphans = true
+		deleteModelPackages = true
+		defer resetCommand()
+
+		mockClient := new(MockSageMakerClient)
+		mockClient.On("GetRegion").Return("us-west-2")
+		mockClient.On("FindOrphans", mock.Anything, true).Return(orphans, nil)
+		mockClient.On("ListModelPackageVersions", mock.Anything, "old-group").Return(versions, nil)
+
+		err := runOrphans(mockClient, strings.NewReader("n\n"), time.Now())
+		assert.EqualError(t, err, "deletion cancelled")
+		mockClient.AssertNotCalled(t, "DeleteOrphan", mock.Anything, mock.Anything)
+	})
+
+	t.Run("requires delete and include", func(t *testing.T) {
+		resetCommand()
+		deleteModelPackages = true
+		defer resetCommand()
+
+		err := runOrphans(new(MockSageMakerClient), strings.NewReader(""), time.Now())
+		assert.EqualError(t, err, "--delete-model-packages requires --delete and --include-model-packages")
+	})
+}
+
+func TestConfirm(t *testing.T) {
+	assert.True(t, confirm(strings.NewReader("y\n"), "Delete?"))
+	assert.True(t, confirm(strings.NewReader("YES"), "Delete?"))
+	assert.False(t, confirm(strings.NewReader("\n"), "Delete?"))
+	assert.False(t, confirm(strings.NewReader(""), "Delete?"))
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"mohua/internal/display"
+	"mohua/internal/sagemaker"
+)
+
+// pipelinesCmd shows the per-step status of executing pipeline runs
+var pipelinesCmd = &cobra.Command{
+	Use:   "pipelines",
+	Short: "Show the steps of executing SageMaker Pipelines runs",
+	Long: `Show every executing SageMaker Pipelines run with the status of each step,
+the training or processing job it launched and that job's instance type.
+Runs with no step change for longer than --stuck-hours are highlighted.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := sagemaker.NewClient(region)
+		if err != nil {
+			return fmt.Errorf("failed to create SageMaker client: %w", err)
+		}
+
+		return runPipelines(client, time.Now())
+	},
+}
+
+func init() {
+	pipelinesCmd.Flags().IntVar(&stuckHours, "stuck-hours", 6, "Flag executions with no step change for this many hours")
+	rootCmd.AddCommand(pipelinesCmd)
+}
+
+// pipelineStepEntry is a single step row of the pipelines report
+type pipelineStepEntry struct {
+	Pipeline     string     `json:"pipeline"`
+	Execution    string     `json:"execution"`
+	Step         string     `json:"step"`
+	Status       string     `json:"status"`
+	JobType      string     `json:"jobType,omitempty"`
+	JobName      string     `json:"jobName,omitempty"`
+	InstanceType string     `json:"instanceType,omitempty"`
+	StartTime    *time.Time `json:"startTime,omitempty"`
+	Stuck        bool       `json:"stuck"`
+}
+
+func runPipelines(client sagemaker.Client, now time.Time) error {
+	ctx := context.Background()
+
+	runs, err := client.ListPipelineExecutions(ctx, time.Duration(stuckHours)*time.Hour)
+	if err != nil {
+		return fmt.Errorf("failed to list pipeline executions: %w", err)
+	}
+
+	var entries []pipelineStepEntry
+	for _, run := range runs {
+		if run.Pipeline == nil {
+			continue
+		}
+		for _, step := range run.Pipeline.Steps {
+			var started *time.Time
+			if !step.StartTime.IsZero() {
+				started = &step.StartTime
+			}
+			entries = append(entries, pipelineStepEntry{
+				Pipeline:     run.Pipeline.PipelineName,
+				Execution:    run.Name,
+				Step:         step.Name,
+				Status:       step.Status,
+				JobType:      step.JobType,
+				JobName:      step.JobName,
+				InstanceType: step.InstanceType,
+				StartTime:    started,
+				Stuck:        run.Pipeline.Stuck,
+			})
+		}
+	}
+
+	printer := display.NewPrinter(jsonOutput)
+	if jsonOutput {
+		if entries == nil {
+			entries = []pipelineStepEntry{}
+		}
+		return printer.PrintJSON(entries)
+	}
+
+	if len(entries) == 0 {
+		printer.PrintNoResources(client.GetRegion())
+		return nil
+	}
+
+	rows := make([][]string, 0, len(entries))
+	highlight := make([]bool, 0, len(entries))
+	for _, entry := range entries {
+		job, instance, started := "-", "-", "-"
+		if entry.JobName != "" {
+			job = entry.JobName
+		}
+		if entry.InstanceType != "" {
+			instance = entry.InstanceType
+		}
+		if entry.StartTime != nil {
+			started = formatAge(now.Sub(*entry.StartTime)) + " ago"
+		}
+		rows = append(rows, []string{entry.Execution, entry.Step, entry.Status, job, instance, started})
+		highlight = append(highlight, entry.Stuck)
+	}
+
+	printer.PrintHighlightedTable([]string{"Execution", "Step", "Status", "Job", "Instance", "Started"}, rows, highlight)
+	for _, run := range runs {
+		if run.Pipeline != nil && run.Pipeline.Stuck {
+			printer.PrintSummary("%s: no step change for %s", run.Name, formatAge(now.Sub(run.Pipeline.LastStepChange)))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"mohua/internal/sagemaker"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRunPipelines(t *testing.T) {
+	resetCommand()
+	now := time.Now()
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("GetRegion").Return("us-west-2").Maybe()
+	mockClient.On("ListPipelineExecutions", mock.Anything, 6*time.Hour).Return([]sagemaker.ResourceInfo{
+		{
+			Name:   "nightly/abc123",
+			Status: "Executing",
+			Pipeline: &sagemaker.PipelineRun{
+				PipelineName:   "nightly",
+				LastStepChange: now.Add(-8 * time.Hour),
+				Stuck:          true,
+				Steps: []sagemaker.PipelineStep{
+					{Name: "Preprocess", Status: "Succeeded", JobType: "ProcessingJob", JobName: "pre", InstanceType: "ml.m5.xlarge", StartTime: now.Add(-9 * time.Hour)},
+					{Name: "Train", Status: "Executing", JobType: "TrainingJob", JobName: "train", InstanceType: "ml.g5.xlarge", StartTime: now.Add(-8 * time.Hour)},
+				},
+			},
+		},
+	}, nil)
+
+	for _, useJSON := range []bool{false, true} {
+		jsonOutput = useJSON
+		assert.NoError(t, runPipelines(mockClient, now))
+	}
+	jsonOutput = false
+	mockClient.AssertExpectations(t)
+}
+
+func TestRunPipelines_Error(t *testing.T) {
+	resetCommand()
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListPipelineExecutions", mock.Anything, mock.Anything).Return(nil, errors.New("access denied"))
+
+	err := runPipelines(mockClient, time.Now())
+	assert.EqualError(t, err, "failed to list pipeline executions: access denied")
+}
+
+func TestPipelineStepEntry_JSON(t *testing.T) {
+	started := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+
+	data, err := json.Marshal(pipelineStepEntry{Step: "Pending", Status: "Starting"})
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "startTime")
+
+	data, err = json.Marshal(pipelineStepEntry{Step: "Train", Status: "Executing", StartTime: &started})
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"startTime":"2026-10-18T09:00:00Z"`)
+}
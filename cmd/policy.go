@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"mohua/internal/display"
+	"mohua/internal/metrics"
+	"mohua/internal/policy"
+	"mohua/internal/sagemaker"
+	"mohua/internal/tagging"
+)
+
+var policyFile string
+
+// policyCmd evaluates policy rules against the collected resources
+var policyCmd = &cobra.Command{
+	Use:   "policy",
+	Short: "Evaluate policy rules against running resources",
+	Long: `Evaluate the rules of a YAML policy file against the resources that the
+resource listing collects and report every violation.
+
+Example policy:
+
+  rules:
+    - name: gpu-endpoints-need-owner
+      select:
+        types: [Endpoint]
+        instanceTypes: ["ml.g*", "ml.p*"]
+        missingTags: [owner]
+      condition:
+        maxAge: 14d
+    - name: p4d-limit
+      select:
+        instanceTypes: ["ml.p4d.*"]
+      condition:
+        maxInstances: 2
+
+Selectors: types, instanceTypes (glob), tags ("*" matches any value),
+missingTags, olderThan, regions. Conditions: maxCount, maxInstances, maxAge,
+maxHourlyCost and idleFor (endpoints without invocations).
+
+Exit codes: 0 no violations, 1 the evaluation failed, 2 violations found,
+3 no violations but some resource types could not be collected.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p, err := policy.Load(policyFile)
+		if err != nil {
+			return err
+		}
+
+		client, err := sagemaker.NewClient(region)
+		if err != nil {
+			return fmt.Errorf("failed to create SageMaker client: %w", err)
+		}
+
+		var tagClient tagging.Client
+		if p.UsesTags() {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			tagClient, err = newTaggingClient(client, cfg)
+			if err != nil {
+				return err
+			}
+		}
+
+		var metricsClient metrics.Client
+		if p.UsesIdle() {
+			metricsClient, err = metrics.NewClient(region)
+			if err != nil {
+				return fmt.Errorf("failed to create CloudWatch client: %w", err)
+			}
+		}
+
+		return runPolicy(client, tagClient, metricsClient, p, time.Now())
+	},
+}
+
+func init() {
+	policyCmd.Flags().StringVarP(&policyFile, "file", "f", "mohua-policy.yaml", "Policy file to evaluate")
+	rootCmd.AddCommand(policyCmd)
+}
+
+// policyReport is the JSON representation of a policy evaluation
+type policyReport struct {
+	Violations []policy.Violation `json:"violations"`
+	Rules      int                `json:"rules"`
+	Resources  int                `json:"resources"`
+}
+
+func runPolicy(client sagemaker.Client, tagClient tagging.Client, metricsClient metrics.Client, p *policy.Policy, now time.Time) error {
+	ctx := context.Background()
+
+	collectors := collectors(client)
+	results := collectResources(ctx, client, collectors)
+	resources, err := mergeResults(collectors, results)
+	if err != nil {
+		return err
+	}
+
+	if tagClient != nil {
+		if err := attachTags(ctx, tagClient, resources); err != nil {
+			return err
+		}
+	}
+
+	violations, err := policy.Evaluate(p, resources, now, endpointIdleFunc(ctx, metricsClient))
+	if err != nil {
+		return err
+	}
+
+	printer := display.NewPrinter(jsonOutput)
+	if jsonOutput {
+		if violations == nil {
+			violations = []policy.Violation{}
+		}
+		err := printer.PrintJSON(policyReport{Violations: violations, Rules: len(p.Rules), Resources: len(resources)})
+		if err != nil {
+			return err
+		}
+	} else if len(violations) == 0 {
+		printer.PrintSummary("No violations of %d rules across %d resources", len(p.Rules), len(resources))
+	} else {
+		rows := make([][]string, 0, len(violations))
+		for _, violation := range violations {
+			resourceType, resource := violation.ResourceType, violation.Resource
+			if resource == "" {
+				resourceType, resource = "-", "(all selected)"
+			}
+			rows = append(rows, []string{violation.Rule, resourceType, resource, violation.Message})
+		}
+		printer.PrintTable([]string{"Rule", "Type", "Resource", "Violation"}, rows)
+		printer.PrintSummary("%d violations of %d rules across %d resources", len(violations), len(p.Rules), len(resources))
+	}
+
+	// Violations take precedence, but the result of a partial evaluation
+	// must never pass as a clean one
+	partialErr := partialError(collectors, results)
+	if len(violations) > 0 {
+		violationErr := fmt.Errorf("%d policy violations", len(violations))
+		if partialErr != nil {
+			violationErr = errors.Join(violationErr, partialErr)
+		}
+		return withExitCode(ExitViolation, violationErr)
+	}
+	return partialErr
+}
+
+// endpointIdleFunc reports endpoints without invocations as idle, or returns
+// nil without a metrics client
+func endpointIdleFunc(ctx context.Context, metricsClient metrics.Client) policy.IdleFunc {
+	if metricsClient == nil {
+		return nil
+	}
+	return func(resource sagemaker.ResourceInfo, window time.Duration) (bool, error) {
+		// Only endpoints report activity that tells whether they are in use
+		if resource.ResourceType != "Endpoint" {
+			return false, nil
+		}
+		invocations, err := metricsClient.EndpointInvocations(ctx, resource.Name, window)
+		if err != nil {
+			return false, err
+		}
+		return invocations == 0, nil
+	}
+}
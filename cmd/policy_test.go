@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"mohua/internal/policy"
+	"mohua/internal/sagemaker"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRunPolicy_Violations(t *testing.T) {
+	resetCommand()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	p, err := policy.Parse([]byte(`
+rules:
+  - name: endpoints-need-owner
+    select: {types: [Endpoint], missingTags: [owner]}
+  - name: idle-endpoints
+    select: {types: [Endpoint]}
+    condition: {idleFor: 24h}
+`))
+	assert.NoError(t, err)
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListEndpoints", mock.Anything).Return([]sagemaker.ResourceInfo{
+		{Name: "churn", Arn: "arn:churn", InstanceType: "ml.m5.xlarge", CreationTime: now.Add(-48 * time.Hour)},
+		{Name: "search", Arn: "arn:search", InstanceType: "ml.m5.xlarge", CreationTime: now.Add(-48 * time.Hour)},
+	}, nil)
+	expectEmptyCollectors(mockClient)
+	tagClient := new(MockTaggingClient)
+	tagClient.On("ResourceTags", mock.Anything, []string{"arn:churn", "arn:search"}).Return(map[string]map[string]string{
+		"arn:churn":  {"owner": "ml-platform"},
+		"arn:search": {},
+	}, nil)
+
+	metricsClient := new(MockMetricsClient)
+	metricsClient.On("EndpointInvocations", mock.Anything, "churn", 24*time.Hour).Return(0.0, nil)
+	metricsClient.On("EndpointInvocations", mock.Anything, "search", 24*time.Hour).Return(1200.0, nil)
+
+	err = runPolicy(mockClient, tagClient, metricsClient, p, now)
+
+	assert.EqualError(t, err, "2 policy violations")
+	assert.Equal(t, ExitViolation, ExitCode(err))
+	mockClient.AssertExpectations(t)
+	tagClient.AssertExpectations(t)
+	metricsClient.AssertExpectations(t)
+}
+
+func TestRunPolicy_NoViolations(t *testing.T) {
+	resetCommand()
+	jsonOutput = true
+	p, err := policy.Parse([]byte("rules:\n  - name: few-endpoints\n    select: {types: [Endpoint]}\n    condition: {maxCount: 5}"))
+	assert.NoError(t, err)
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListEndpoints", mock.Anything).Return([]sagemaker.ResourceInfo{{Name: "churn"}}, nil)
+	expectEmptyCollectors(mockClient)
+
+	err = runPolicy(mockClient, nil, nil, p, time.Now())
+
+	assert.NoError(t, err)
+	assert.Equal(t, ExitOK, ExitCode(err))
+}
+
+func TestRunPolicy_CollectorError(t *testing.T) {
+	resetCommand()
+	p, err := policy.Parse([]byte("rules:\n  - name: any\n"))
+	assert.NoError(t, err)
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListEndpoints", mock.Anything).Return(nil, errors.New("access denied"))
+	expectEmptyCollectors(mockClient)
+
+	err = runPolicy(mockClient, nil, nil, p, time.Now())
+
+	assert.ErrorContains(t, err, "access denied")
+	assert.Equal(t, ExitError, ExitCode(err))
+}
+
+func TestRunPolicy_ThrottledCollector(t *testing.T) {
+	resetCommand()
+	p, err := policy.Parse([]byte("rules:\n  - name: endpoints\n    select: {types: [Endpoint]}\n    condition: {maxCount: 1}"))
+	assert.NoError(t, err)
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListEndpoints", mock.Anything).Return(nil, &sagemaker.RetryableError{Err: errors.New("throttled")})
+	expectEmptyCollectors(mockClient)
+
+	// Without the endpoints no rule is violated, but the run is not clean
+	err = runPolicy(mockClient, nil, nil, p, time.Now())
+
+	assert.ErrorContains(t, err, "failed to list endpoints")
+	assert.Equal(t, ExitPartial, ExitCode(err))
+
+	// Violations found in the other resources still take precedence
+	p, err = policy.Parse([]byte("rules:\n  - name: any\n"))
+	assert.NoError(t, err)
+	mockClient = new(MockSageMakerClient)
+	mockClient.On("ListEndpoints", mock.Anything).Return(nil, &sagemaker.RetryableError{Err: errors.New("throttled")})
+	mockClient.On("ListNotebooks", mock.Anything).Return([]sagemaker.ResourceInfo{{Name: "nb"}}, nil)
+	expectEmptyCollectors(mockClient)
+
+	err = runPolicy(mockClient, nil, nil, p, time.Now())
+
+	assert.ErrorContains(t, err, "1 policy violations")
+	assert.ErrorContains(t, err, "failed to list endpoints")
+	assert.Equal(t, ExitViolation, ExitCode(err))
+}
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/spf13/cobra"
+	"mohua/internal/identity"
+	"mohua/internal/metrics"
+)
+
+var publishNamespace string
+
+// publishIdleWindow is how long an endpoint must go without invocations to
+// count in the published idle resources
+const publishIdleWindow = 24 * time.Hour
+
+// addPublishFlags adds the flags of the commands that publish scans to CloudWatch
+func addPublishFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&publishNamespace, "publish-cloudwatch", "", "CloudWatch namespace to put the running counts, estimated hourly cost and idle resources of each scan in")
+}
+
+// publisher puts the metrics of the scans of a region in CloudWatch
+type publisher struct {
+	client    metrics.Client
+	namespace string
+	account   string
+}
+
+// newPublisher creates the publisher of --publish-cloudwatch for a region, or
+// returns nil when the flag is not set
+func newPublisher(ctx context.Context, region string) (*publisher, error) {
+	if publishNamespace == "" {
+		return nil, nil
+	}
+	identityClient, err := identity.NewClient(region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create STS client: %w", err)
+	}
+	account, err := identityClient.AccountID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	metricsClient, err := metrics.NewClient(region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CloudWatch client: %w", err)
+	}
+	return &publisher{client: metricsClient, namespace: publishNamespace, account: account}, nil
+}
+
+// publish puts the metrics of the scan of a region with its Region and
+// Account dimensions: the running resources per resource type and the running
+// instances per instance type of the collectors that succeeded, with 0 for the
+// instance types published in the last 3 hours that no longer run, their
+// estimated hourly cost, the endpoints idle for publishIdleWindow and the
+// number of failed collectors. The cost is left out when a collector failed,
+// so a throttled collector does not look like a drop in cost, and the idle
+// count is left out when the invocations cannot be checked.
+func (p *publisher) publish(ctx context.Context, scan regionScan, timestamp time.Time) error {
+	dimensions := map[string]string{"Region": scan.region, "Account": p.account}
+	with := func(name, value string) map[string]string {
+		d := map[string]string{name: value}
+		for k, v := range dimensions {
+			d[k] = v
+		}
+		return d
+	}
+
+	var data []metrics.Datum
+	instances := make(map[string]int)
+	var cost float64
+	var idleCount int
+	var idleErr error
+	var failed int
+	idle := endpointIdleFunc(ctx, p.client)
+	for i, c := range scan.collectors {
+		result := scan.results[i]
+		if result.Error != nil {
+			failed++
+			continue
+		}
+		data = append(data, metrics.Datum{
+			Name:       "RunningResources",
+			Dimensions: with("ResourceType", c.resourceType),
+			Value:      float64(len(result.Resources)),
+			Unit:       types.StandardUnitCount,
+		})
+		for _, resource := range result.Resources {
+			for instanceType, count := range resource.Instances() {
+				instances[instanceType] += count
+			}
+			cost += resource.EstimatedHourlyCost()
+			if idleErr != nil {
+				continue
+			}
+			isIdle, err := idle(resource, publishIdleWindow)
+			if err != nil {
+				idleErr = fmt.Errorf("failed to check idle resources: %w", err)
+			} else if isIdle {
+				idleCount++
+			}
+		}
+	}
+
+	// Instance types published before report 0 once their last instance stops,
+	// so alarms see the drop rather than missing data. Without every collector
+	// a missing type may still be running.
+	var recentErr error
+	if failed == 0 {
+		var recent []string
+		recent, recentErr = p.client.RecentDimensionValues(ctx, p.namespace, "RunningInstances", "InstanceType", dimensions)
+		for _, instanceType := range recent {
+			if _, ok := instances[instanceType]; !ok {
+				instances[instanceType] = 0
+			}
+		}
+	}
+	instanceTypes := make([]string, 0, len(instances))
+	for instanceType := range instances {
+		instanceTypes = append(instanceTypes, instanceType)
+	}
+	sort.Strings(instanceTypes)
+	for _, instanceType := range instanceTypes {
+		data = append(data, metrics.Datum{
+			Name:       "RunningInstances",
+			Dimensions: with("InstanceType", instanceType),
+			Value:      float64(instances[instanceType]),
+			Unit:       types.StandardUnitCount,
+		})
+	}
+	if failed == 0 {
+		data = append(data, metrics.Datum{Name: "EstimatedHourlyCost", Dimensions: dimensions, Value: cost, Unit: types.StandardUnitNone})
+	}
+	if idleErr == nil {
+		data = append(data, metrics.Datum{Name: "IdleResources", Dimensions: dimensions, Value: float64(idleCount), Unit: types.StandardUnitCount})
+	}
+	data = append(data, metrics.Datum{Name: "FailedCollectors", Dimensions: dimensions, Value: float64(failed), Unit: types.StandardUnitCount})
+
+	return errors.Join(p.client.PutMetrics(ctx, p.namespace, timestamp, data), idleErr, recentErr)
+}
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"mohua/internal/identity"
+	"mohua/internal/metrics"
+	"mohua/internal/sagemaker"
+)
+
+func TestPublisher_Publish(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	scan := regionScan{
+		region: "us-east-1",
+		collectors: []collector{
+			{resourceType: "Endpoint", label: "endpoints"},
+			{resourceType: "Notebook", label: "notebooks"},
+			{resourceType: "Tuning", label: "tuning jobs"},
+			{resourceType: "AutoML", label: "AutoML jobs"},
+		},
+		results: []ResourceResult{
+			{Resources: []sagemaker.ResourceInfo{
+				{ResourceType: "Endpoint", Name: "search", InstanceType: "ml.g5.xlarge", InstanceCount: 2},
+				{ResourceType: "Endpoint", Name: "legacy", InstanceType: "ml.m5.large"},
+			}},
+			{Resources: []sagemaker.ResourceInfo{{ResourceType: "Notebook", Name: "nb", InstanceType: "ml.m5.large"}}},
+			{Error: errors.New("throttled")},
+			{Resources: []sagemaker.ResourceInfo{}},
+		},
+	}
+
+	metricsClient := new(MockMetricsClient)
+	metricsClient.On("EndpointInvocations", mock.Anything, "search", publishIdleWindow).Return(12.0, nil)
+	metricsClient.On("EndpointInvocations", mock.Anything, "legacy", publishIdleWindow).Return(0.0, nil)
+	metricsClient.On("PutMetrics", mock.Anything, "Mohua", now, mock.Anything).Return(nil)
+
+	p := &publisher{client: metricsClient, namespace: "Mohua", account: "123456789012"}
+	require.NoError(t, p.publish(context.Background(), scan, now))
+
+	dims := func(extra ...string) map[string]string {
+		d := map[string]string{"Region": "us-east-1", "Account": "123456789012"}
+		if len(extra) == 2 {
+			d[extra[0]] = extra[1]
+		}
+		return d
+	}
+	// The tuning job collector failed, so the cost and the zero instance counts are left out
+	metricsClient.AssertNotCalled(t, "RecentDimensionValues", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	data := putData(t, metricsClient)
+	assert.Equal(t, []metrics.Datum{
+		{Name: "RunningResources", Dimensions: dims("ResourceType", "Endpoint"), Value: 2, Unit: types.StandardUnitCount},
+		{Name: "RunningResources", Dimensions: dims("ResourceType", "Notebook"), Value: 1, Unit: types.StandardUnitCount},
+		{Name: "RunningResources", Dimensions: dims("ResourceType", "AutoML"), Value: 0, Unit: types.StandardUnitCount},
+		{Name: "RunningInstances", Dimensions: dims("InstanceType", "ml.g5.xlarge"), Value: 2, Unit: types.StandardUnitCount},
+		{Name: "RunningInstances", Dimensions: dims("InstanceType", "ml.m5.large"), Value: 2, Unit: types.StandardUnitCount},
+		{Name: "IdleResources", Dimensions: dims(), Value: 1, Unit: types.StandardUnitCount},
+		{Name: "FailedCollectors", Dimensions: dims(), Value: 1, Unit: types.StandardUnitCount},
+	}, data)
+}
+
+func TestPublisher_StoppedInstanceTypes(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	scan := regionScan{
+		region:     "us-east-1",
+		collectors: []collector{{resourceType: "Notebook", label: "notebooks"}},
+		results:    []ResourceResult{{Resources: []sagemaker.ResourceInfo{{ResourceType: "Notebook", Name: "nb", InstanceType: "ml.t3.medium"}}}},
+	}
+	dimensions := map[string]string{"Region": "us-east-1", "Account": "123456789012"}
+	metricsClient := new(MockMetricsClient)
+	metricsClient.On("RecentDimensionValues", mock.Anything, "Mohua", "RunningInstances", "InstanceType", dimensions).
+		Return([]string{"ml.p4d.24xlarge", "ml.t3.medium"}, nil)
+	metricsClient.On("PutMetrics", mock.Anything, "Mohua", now, mock.Anything).Return(nil)
+
+	p := &publisher{client: metricsClient, namespace: "Mohua", account: "123456789012"}
+	require.NoError(t, p.publish(context.Background(), scan, now))
+
+	// The last p4d instance stopped since the previous scan
+	data := putData(t, metricsClient)
+	assert.Equal(t, []metrics.Datum{
+		{Name: "RunningInstances", Dimensions: map[string]string{"Region": "us-east-1", "Account": "123456789012", "InstanceType": "ml.p4d.24xlarge"}, Value: 0, Unit: types.StandardUnitCount},
+		{Name: "RunningInstances", Dimensions: map[string]string{"Region": "us-east-1", "Account": "123456789012", "InstanceType": "ml.t3.medium"}, Value: 1, Unit: types.StandardUnitCount},
+	}, data[1:3])
+
+	// The scan is still published when the earlier types cannot be listed
+	metricsClient = new(MockMetricsClient)
+	metricsClient.On("RecentDimensionValues", mock.Anything, "Mohua", "RunningInstances", "InstanceType", dimensions).
+		Return(nil, errors.New("failed to list RunningInstances metrics in Mohua: access denied"))
+	metricsClient.On("PutMetrics", mock.Anything, "Mohua", now, mock.Anything).Return(nil)
+
+	p.client = metricsClient
+	assert.EqualError(t, p.publish(context.Background(), scan, now), "failed to list RunningInstances metrics in Mohua: access denied")
+	metricsClient.AssertNumberOfCalls(t, "PutMetrics", 1)
+}
+
+// putData returns the data of the single PutMetrics call
+func putData(t *testing.T, metricsClient *MockMetricsClient) []metrics.Datum {
+	for _, call := range metricsClient.Calls {
+		if call.Method == "PutMetrics" {
+			return call.Arguments.Get(3).([]metrics.Datum)
+		}
+	}
+	t.Fatal("PutMetrics was not called")
+	return nil
+}
+
+func TestPublisher_IdleCheckFails(t *testing.T) {
+	scan := regionScan{
+		region:     "us-east-1",
+		collectors: []collector{{resourceType: "Endpoint", label: "endpoints"}},
+		results:    []ResourceResult{{Resources: []sagemaker.ResourceInfo{{ResourceType: "Endpoint", Name: "search"}}}},
+	}
+	metricsClient := new(MockMetricsClient)
+	metricsClient.On("EndpointInvocations", mock.Anything, "search", publishIdleWindow).Return(0.0, errors.New("access denied"))
+	metricsClient.On("RecentDimensionValues", mock.Anything, "Mohua", "RunningInstances", "InstanceType", mock.Anything).Return([]string{}, nil)
+	metricsClient.On("PutMetrics", mock.Anything, "Mohua", mock.Anything, mock.Anything).Return(nil)
+
+	p := &publisher{client: metricsClient, namespace: "Mohua", account: "123456789012"}
+	err := p.publish(context.Background(), scan, time.Now())
+
+	// The other metrics are still published
+	assert.EqualError(t, err, "failed to check idle resources: access denied")
+	data := putData(t, metricsClient)
+	require.Len(t, data, 3)
+	assert.Equal(t, "EstimatedHourlyCost", data[1].Name)
+	assert.Equal(t, metrics.Datum{Name: "FailedCollectors", Dimensions: data[1].Dimensions, Value: 0, Unit: types.StandardUnitCount}, data[2])
+}
+
+func TestExecute_PublishCloudWatch(t *testing.T) {
+	origIdentity, origMetrics := identity.NewClient, metrics.NewClient
+	defer func() { identity.NewClient, metrics.NewClient = origIdentity, origMetrics }()
+	identityClient := new(MockIdentityClient)
+	identityClient.On("AccountID", mock.Anything).Return("123456789012", nil)
+	identity.NewClient = func(region string) (identity.Client, error) { return identityClient, nil }
+	metricsClient := new(MockMetricsClient)
+	metricsClient.On("RecentDimensionValues", mock.Anything, "Mohua", "RunningInstances", "InstanceType", mock.Anything).Return([]string{}, nil)
+	metricsClient.On("PutMetrics", mock.Anything, "Mohua", mock.Anything, mock.Anything).Return(nil)
+	metrics.NewClient = func(region string) (metrics.Client, error) { return metricsClient, nil }
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ValidateConfiguration", mock.Anything).Return(true, nil)
+	mockClient.On("ListNotebooks", mock.Anything).Return([]sagemaker.ResourceInfo{
+		{Name: "nb", InstanceType: "ml.t3.medium", Status: "InService", CreationTime: time.Now()},
+	}, nil)
+	expectEmptyCollectors(mockClient)
+
+	require.NoError(t, mockExecute(t, []string{"--publish-cloudwatch", "Mohua"}, mockClient))
+	metricsClient.AssertNumberOfCalls(t, "PutMetrics", 1)
+	data := putData(t, metricsClient)
+	assert.Contains(t, data, metrics.Datum{
+		Name:       "RunningInstances",
+		Dimensions: map[string]string{"Region": "us-east-1", "Account": "123456789012", "InstanceType": "ml.t3.medium"},
+		Value:      1,
+		Unit:       types.StandardUnitCount,
+	})
+	// Every collector succeeded
+	assert.Len(t, data, len(collectors(mockClient))+4)
+}
+
+func TestDaemonScan_PublishError(t *testing.T) {
+	resetCommand()
+	mockClient := new(MockSageMakerClient)
+	expectEmptyCollectors(mockClient)
+	metricsClient := new(MockMetricsClient)
+	metricsClient.On("RecentDimensionValues", mock.Anything, "Mohua", "RunningInstances", "InstanceType", mock.Anything).Return([]string{}, nil)
+	metricsClient.On("PutMetrics", mock.Anything, "Mohua", mock.Anything, mock.Anything).Return(errors.New("failed to put metrics in Mohua: access denied"))
+
+	p := &publisher{client: metricsClient, namespace: "Mohua", account: "123456789012"}
+	d := &daemon{targets: []scanTarget{{client: mockClient, publisher: p}}, now: time.Now}
+	d.scan(context.Background())
+
+	require.NotNil(t, d.inventory)
+	require.Len(t, d.inventory.errors, 1)
+	assert.Equal(t, apiError{Region: "us-east-1", Source: "cloudwatch", Message: "failed to put metrics in Mohua: access denied", Time: d.inventory.errors[0].Time}, d.inventory.errors[0])
+}
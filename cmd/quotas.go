@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"mohua/internal/display"
+	"mohua/internal/quotas"
+	"mohua/internal/sagemaker"
+)
+
+var quotaThreshold float64
+
+// quotasCmd reports the utilization of SageMaker instance quotas
+var quotasCmd = &cobra.Command{
+	Use:   "quotas",
+	Short: "Show utilization of SageMaker instance quotas",
+	Long: `Map the instances of running endpoints, training jobs (including those of
+tuning jobs, AutoML jobs and pipelines), notebook instances and Studio apps to
+the SageMaker service quotas that limit them, and show used, limit and
+utilization per quota. Quotas at or above --threshold percent are highlighted.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := sagemaker.NewClient(region)
+		if err != nil {
+			return fmt.Errorf("failed to create SageMaker client: %w", err)
+		}
+
+		quotaClient, err := quotas.NewClient(region)
+		if err != nil {
+			return fmt.Errorf("failed to create Service Quotas client: %w", err)
+		}
+
+		return runQuotas(client, quotaClient)
+	},
+}
+
+func init() {
+	quotasCmd.Flags().Float64Var(&quotaThreshold, "threshold", 80, "Highlight quotas with a utilization of at least this percentage")
+	rootCmd.AddCommand(quotasCmd)
+}
+
+// quotaCollectors returns the collectors of the resources whose instances count against quotas
+func quotaCollectors(client sagemaker.Client) []collector {
+	var selected []collector
+	for _, c := range collectors(client) {
+		switch c.resourceType {
+		case "Endpoint", "Notebook", "Studio":
+			selected = append(selected, c)
+		}
+	}
+	return append(selected, collector{resourceType: "Training", label: "training jobs", list: client.ListTrainingJobs})
+}
+
+// quotasReport is the JSON representation of the quota report
+type quotasReport struct {
+	Quotas    []quotas.Utilization `json:"quotas"`
+	Threshold float64              `json:"threshold"`
+}
+
+func runQuotas(client sagemaker.Client, quotaClient quotas.Client) error {
+	ctx := context.Background()
+
+	collectors := quotaCollectors(client)
+	results := collectResources(ctx, client, collectors)
+	resources, err := mergeResults(collectors, results)
+	if err != nil {
+		return err
+	}
+
+	sageMakerQuotas, err := quotaClient.SageMakerQuotas(ctx)
+	if err != nil {
+		return err
+	}
+	utilizations := quotas.Utilizations(sageMakerQuotas, resources)
+
+	printer := display.NewPrinter(jsonOutput)
+	if jsonOutput {
+		if err := printer.PrintJSON(quotasReport{Quotas: utilizations, Threshold: quotaThreshold}); err != nil {
+			return err
+		}
+		return partialError(collectors, results)
+	}
+
+	if len(utilizations) == 0 {
+		printer.PrintSummary("No running instances count against SageMaker instance quotas")
+		return partialError(collectors, results)
+	}
+
+	rows := make([][]string, 0, len(utilizations))
+	highlight := make([]bool, 0, len(utilizations))
+	above := 0
+	for _, utilization := range utilizations {
+		limit, percent := "-", "-"
+		if utilization.Limit != nil {
+			limit = fmt.Sprintf("%g", *utilization.Limit)
+			percent = fmt.Sprintf("%.0f%%", utilization.Percent)
+		}
+		rows = append(rows, []string{utilization.QuotaName, fmt.Sprintf("%d", utilization.Used), limit, percent})
+
+		isAbove := utilization.Limit != nil && utilization.Percent >= quotaThreshold
+		highlight = append(highlight, isAbove)
+		if isAbove {
+			above++
+		}
+	}
+	printer.PrintHighlightedTable([]string{"Quota", "Used", "Limit", "Utilization"}, rows, highlight)
+	printer.PrintSummary("%d of %d quotas at or above %g%% utilization", above, len(utilizations), quotaThreshold)
+	return partialError(collectors, results)
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"mohua/internal/quotas"
+	"mohua/internal/sagemaker"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRunQuotas(t *testing.T) {
+	resetCommand()
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListEndpoints", mock.Anything).Return([]sagemaker.ResourceInfo{
+		{Name: "churn", InstanceType: "ml.g5.xlarge", InstanceCount: 4},
+	}, nil)
+	mockClient.On("ListTrainingJobs", mock.Anything).Return([]sagemaker.ResourceInfo{
+		{Name: "xgb", InstanceType: "ml.m5.xlarge", InstanceCount: 2},
+	}, nil)
+	expectEmptyCollectors(mockClient)
+
+	quotaClient := new(MockQuotasClient)
+	quotaClient.On("SageMakerQuotas", mock.Anything).Return([]quotas.Quota{
+		{Code: "L-1", Name: "ml.g5.xlarge for endpoint usage", Value: 4},
+		{Code: "L-2", Name: "ml.m5.xlarge for training job usage", Value: 10},
+	}, nil)
+
+	assert.NoError(t, runQuotas(mockClient, quotaClient))
+
+	// Only the collectors of resources limited by instance quotas run
+	mockClient.AssertNotCalled(t, "ListTuningJobs", mock.Anything)
+	mockClient.AssertExpectations(t)
+	quotaClient.AssertExpectations(t)
+}
+
+func TestRunQuotas_Error(t *testing.T) {
+	resetCommand()
+	jsonOutput = true
+
+	mockClient := new(MockSageMakerClient)
+	expectEmptyCollectors(mockClient)
+
+	quotaClient := new(MockQuotasClient)
+	quotaClient.On("SageMakerQuotas", mock.Anything).Return(nil, errors.New("access denied"))
+
+	assert.EqualError(t, runQuotas(mockClient, quotaClient), "access denied")
+}
+
+func TestRunQuotas_ThrottledCollector(t *testing.T) {
+	resetCommand()
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListEndpoints", mock.Anything).Return(nil, &sagemaker.RetryableError{Err: errors.New("throttled")})
+	mockClient.On("ListTrainingJobs", mock.Anything).Return([]sagemaker.ResourceInfo{
+		{Name: "xgb", InstanceType: "ml.m5.xlarge", InstanceCount: 2},
+	}, nil)
+	expectEmptyCollectors(mockClient)
+
+	quotaClient := new(MockQuotasClient)
+	quotaClient.On("SageMakerQuotas", mock.Anything).Return([]quotas.Quota{
+		{Code: "L-2", Name: "ml.m5.xlarge for training job usage", Value: 10},
+	}, nil)
+
+	// The utilization of the other resources is reported, but as partial
+	err := runQuotas(mockClient, quotaClient)
+
+	assert.ErrorContains(t, err, "failed to list endpoints: throttled")
+	assert.Equal(t, ExitPartial, ExitCode(err))
+}
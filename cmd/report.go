@@ -0,0 +1,318 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"mohua/internal/display"
+	"mohua/internal/history"
+	"mohua/internal/pricing"
+	"mohua/internal/sagemaker"
+	"mohua/internal/tagging"
+)
+
+var (
+	reportPeriod  string
+	reportFormat  string
+	reportGroupBy []string
+	reportMaxGap  time.Duration
+)
+
+// reportPeriodDays maps --period to the number of days reported
+var reportPeriodDays = map[string]int{"week": 7, "month": 30}
+
+// reportCmd reports instance-hours and cost from the snapshot history
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Report instance-hours and cost trends from the snapshot history",
+	Long: `Integrate the resources recorded by previous scans into instance-hours and
+estimated cost per day, grouped by resource type, instance family, Studio user
+profile or tag value, with a sparkline of the daily cost of each group.
+
+The resources of a snapshot count until the next snapshot of the same region,
+but for at most --max-gap, so the report is as accurate as scans are frequent.
+Resource types that a scan failed to collect count with the resources of the
+previous scan of the region. Cost is estimated from on-demand list prices.
+
+--group-by takes type, family, user and tag:<key>, e.g. tag:team. Tags
+recorded with a snapshot are used, others are looked up for the resources of
+the current region.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		store, err := newHistoryStore(cfg)
+		if err != nil {
+			return err
+		}
+
+		var tagClient tagging.Client
+		var tagRegion string
+		for _, groupBy := range reportGroupBy {
+			if strings.HasPrefix(groupBy, "tag:") && tagClient == nil {
+				client, err := sagemaker.NewClient(region)
+				if err != nil {
+					return fmt.Errorf("failed to create SageMaker client: %w", err)
+				}
+				if tagClient, err = newTaggingClient(client, cfg); err != nil {
+					return err
+				}
+				tagRegion = client.GetRegion()
+			}
+		}
+
+		return runReport(store, tagClient, tagRegion, time.Now(), cmd.OutOrStdout())
+	},
+}
+
+func init() {
+	reportCmd.Flags().StringVar(&reportPeriod, "period", "week", "Reported period: week or month (30 days)")
+	reportCmd.Flags().StringVar(&reportFormat, "format", "table", "Output format: table, csv, markdown or json")
+	reportCmd.Flags().StringSliceVar(&reportGroupBy, "group-by", []string{"type", "family", "user"}, "Groupings: type, family, user or tag:<key> (comma separated)")
+	reportCmd.Flags().DurationVar(&reportMaxGap, "max-gap", 24*time.Hour, "Longest time a snapshot is assumed to hold without a newer scan")
+	rootCmd.AddCommand(reportCmd)
+}
+
+// reportSection is the usage of the groups of one grouping
+type reportSection struct {
+	GroupBy string               `json:"groupBy"`
+	Groups  []history.UsageGroup `json:"groups"`
+}
+
+// usageReport is the JSON representation of the usage report
+type usageReport struct {
+	Period        string          `json:"period"`
+	Start         time.Time       `json:"start"`
+	End           time.Time       `json:"end"`
+	InstanceHours float64         `json:"instanceHours"`
+	Cost          float64         `json:"estimatedCost"`
+	Sections      []reportSection `json:"sections"`
+}
+
+// reportGroupFunc returns the grouping named by a --group-by value
+func reportGroupFunc(groupBy string) (history.GroupFunc, error) {
+	switch {
+	case groupBy == "type":
+		return func(resource history.Resource, instanceType string) string {
+			return resource.ResourceType
+		}, nil
+	case groupBy == "family":
+		return func(resource history.Resource, instanceType string) string {
+			if instanceType == "" {
+				return "(no instances)"
+			}
+			return pricing.Family(instanceType)
+		}, nil
+	case groupBy == "user":
+		return func(resource history.Resource, instanceType string) string {
+			if resource.UserProfile == "" {
+				return "(no user profile)"
+			}
+			return resource.UserProfile
+		}, nil
+	case strings.HasPrefix(groupBy, "tag:") && len(groupBy) > len("tag:"):
+		key := strings.TrimPrefix(groupBy, "tag:")
+		return func(resource history.Resource, instanceType string) string {
+			if resource.Arn == "" {
+				return untaggableValue
+			}
+			if value := resource.Tags[key]; value != "" {
+				return value
+			}
+			return untaggedValue
+		}, nil
+	}
+	return nil, fmt.Errorf("invalid grouping %q: expected type, family, user or tag:<key>", groupBy)
+}
+
+// attachSnapshotTags looks up the tags of the resources of a region that were
+// recorded without tags
+func attachSnapshotTags(ctx context.Context, tagClient tagging.Client, tagRegion string, snapshots []history.Snapshot) error {
+	var arns []string
+	for _, snapshot := range snapshots {
+		if snapshot.Region != tagRegion {
+			continue
+		}
+		for _, resource := range snapshot.Resources {
+			if resource.Arn != "" && resource.Tags == nil {
+				arns = append(arns, resource.Arn)
+			}
+		}
+	}
+	if len(arns) == 0 {
+		return nil
+	}
+
+	tags, err := tagClient.ResourceTags(ctx, arns)
+	if err != nil {
+		return fmt.Errorf("failed to list tags: %w", err)
+	}
+	for _, snapshot := range snapshots {
+		if snapshot.Region != tagRegion {
+			continue
+		}
+		for i, resource := range snapshot.Resources {
+			if resource.Arn != "" && resource.Tags == nil {
+				snapshot.Resources[i].Tags = tags[resource.Arn]
+			}
+		}
+	}
+	return nil
+}
+
+// runReport reports the usage recorded during the period ending today. Tags
+// are looked up with tagClient, when it is not nil, for the resources of tagRegion.
+func runReport(store *history.Store, tagClient tagging.Client, tagRegion string, now time.Time, out io.Writer) error {
+	ctx := context.Background()
+
+	format := reportFormat
+	if jsonOutput {
+		format = "json"
+	}
+	if format != "table" && format != "csv" && format != "markdown" && format != "json" {
+		return fmt.Errorf("invalid format %q: expected table, csv, markdown or json", reportFormat)
+	}
+	days, ok := reportPeriodDays[reportPeriod]
+	if !ok {
+		return fmt.Errorf("invalid period %q: expected week or month", reportPeriod)
+	}
+	groupFuncs := make([]history.GroupFunc, len(reportGroupBy))
+	for i, groupBy := range reportGroupBy {
+		var err error
+		if groupFuncs[i], err = reportGroupFunc(groupBy); err != nil {
+			return err
+		}
+	}
+
+	all, err := store.Snapshots()
+	if err != nil {
+		return err
+	}
+	var snapshots []history.Snapshot
+	for _, snapshot := range all {
+		if region == "" || snapshot.Region == region {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	if tagClient != nil {
+		if err := attachSnapshotTags(ctx, tagClient, tagRegion, snapshots); err != nil {
+			return err
+		}
+	}
+
+	period := history.LastDays(now, days)
+	report := usageReport{Period: reportPeriod, Start: period.Start, End: period.End()}
+	for i, groupBy := range reportGroupBy {
+		groups := history.Usage(snapshots, period, now, reportMaxGap, groupFuncs[i])
+		report.Sections = append(report.Sections, reportSection{GroupBy: groupBy, Groups: groups})
+	}
+	// Every grouping covers all usage, so the totals come from any of them
+	if len(report.Sections) > 0 {
+		for _, group := range report.Sections[0].Groups {
+			report.InstanceHours += group.InstanceHours
+			report.Cost += group.Cost
+		}
+	}
+
+	switch format {
+	case "json":
+		return display.NewPrinter(true).PrintJSON(report)
+	case "csv":
+		return writeReportCSV(out, report, period)
+	case "markdown":
+		writeReportMarkdown(out, report)
+		return nil
+	}
+
+	printer := display.NewPrinter(false)
+	if report.Cost == 0 && report.InstanceHours == 0 {
+		printer.PrintSummary("No usage recorded between %s and %s; every run of mohua records a snapshot",
+			report.Start.Format("2006-01-02"), report.End.AddDate(0, 0, -1).Format("2006-01-02"))
+		return nil
+	}
+	for _, section := range report.Sections {
+		printer.PrintTable(reportHeaders(section.GroupBy), reportRows(section))
+	}
+	printer.PrintSummary("%.1f instance-hours, estimated $%.2f from %s to %s",
+		report.InstanceHours, report.Cost, report.Start.Format("2006-01-02"), report.End.AddDate(0, 0, -1).Format("2006-01-02"))
+	return nil
+}
+
+// reportHeaders returns the table headers of a grouping
+func reportHeaders(groupBy string) []string {
+	title := map[string]string{"type": "Type", "family": "Instance Family", "user": "User Profile"}[groupBy]
+	if title == "" {
+		title = "Tag " + strings.TrimPrefix(groupBy, "tag:")
+	}
+	return []string{title, "Instance Hours", "Est. Cost", "Daily Cost Trend"}
+}
+
+// reportRows returns the table rows of a grouping
+func reportRows(section reportSection) [][]string {
+	rows := make([][]string, 0, len(section.Groups))
+	for _, group := range section.Groups {
+		rows = append(rows, []string{
+			group.Name,
+			fmt.Sprintf("%.1f", group.InstanceHours),
+			fmt.Sprintf("$%.2f", group.Cost),
+			display.Sparkline(group.DailyCost),
+		})
+	}
+	return rows
+}
+
+// writeReportCSV writes one line per group with its daily cost in a column per day
+func writeReportCSV(out io.Writer, report usageReport, period history.Period) error {
+	writer := csv.NewWriter(out)
+	header := []string{"group_by", "group", "instance_hours", "estimated_cost", "trend"}
+	for day := 0; day < period.Days; day++ {
+		header = append(header, period.Start.AddDate(0, 0, day).Format("2006-01-02"))
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, section := range report.Sections {
+		for _, group := range section.Groups {
+			record := []string{
+				section.GroupBy,
+				group.Name,
+				fmt.Sprintf("%.2f", group.InstanceHours),
+				fmt.Sprintf("%.2f", group.Cost),
+				display.Sparkline(group.DailyCost),
+			}
+			for _, cost := range group.DailyCost {
+				record = append(record, fmt.Sprintf("%.2f", cost))
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// writeReportMarkdown writes a Markdown table per grouping
+func writeReportMarkdown(out io.Writer, report usageReport) {
+	fmt.Fprintf(out, "# SageMaker usage from %s to %s\n\n", report.Start.Format("2006-01-02"), report.End.AddDate(0, 0, -1).Format("2006-01-02"))
+	fmt.Fprintf(out, "%.1f instance-hours, estimated cost $%.2f\n", report.InstanceHours, report.Cost)
+
+	for _, section := range report.Sections {
+		headers := reportHeaders(section.GroupBy)
+		fmt.Fprintf(out, "\n## By %s\n\n", strings.ToLower(headers[0]))
+		fmt.Fprintf(out, "| %s |\n", strings.Join(headers, " | "))
+		fmt.Fprintf(out, "|---|---:|---:|---|\n")
+		for _, row := range reportRows(section) {
+			// Pipes in tag values would end the cell
+			row[0] = strings.ReplaceAll(row[0], "|", `\|`)
+			fmt.Fprintf(out, "| %s |\n", strings.Join(row, " | "))
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"mohua/internal/history"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var reportNow = time.Date(2026, 10, 18, 12, 0, 0, 0, time.Local)
+
+// reportStore holds an endpoint and a Studio app seen by two scans 12 hours apart
+func reportStore(t *testing.T) *history.Store {
+	endpoint := history.Resource{
+		ResourceType:   "Endpoint",
+		Name:           "churn",
+		Arn:            "arn:aws:sagemaker:us-east-1:123456789012:endpoint/churn",
+		InstanceCounts: map[string]int{"ml.g5.xlarge": 1},
+	}
+	app := history.Resource{
+		ResourceType:   "Studio",
+		Name:           "default",
+		UserProfile:    "alice",
+		InstanceCounts: map[string]int{"ml.t3.medium": 1},
+	}
+	return writeHistory(t,
+		history.Snapshot{ID: "1", Region: "us-east-1", Time: reportNow.Add(-24 * time.Hour), Resources: []history.Resource{endpoint, app}},
+		history.Snapshot{ID: "2", Region: "us-east-1", Time: reportNow.Add(-12 * time.Hour), Resources: []history.Resource{endpoint}},
+	)
+}
+
+func TestReportGroupFunc(t *testing.T) {
+	resource := history.Resource{ResourceType: "Endpoint", Arn: "arn", Tags: map[string]string{"team": "ml"}}
+
+	for groupBy, want := range map[string]string{
+		"type":     "Endpoint",
+		"family":   "ml.g5",
+		"user":     "(no user profile)",
+		"tag:team": "ml",
+		"tag:cost": untaggedValue,
+	} {
+		group, err := reportGroupFunc(groupBy)
+		assert.NoError(t, err)
+		assert.Equal(t, want, group(resource, "ml.g5.xlarge"), groupBy)
+	}
+
+	group, _ := reportGroupFunc("tag:team")
+	assert.Equal(t, untaggableValue, group(history.Resource{ResourceType: "Studio"}, "ml.t3.medium"))
+	group, _ = reportGroupFunc("family")
+	assert.Equal(t, "(no instances)", group(history.Resource{ResourceType: "MLflow"}, ""))
+
+	_, err := reportGroupFunc("tag:")
+	assert.EqualError(t, err, `invalid grouping "tag:": expected type, family, user or tag:<key>`)
+}
+
+func TestRunReport_CSV(t *testing.T) {
+	resetCommand()
+	reportFormat = "csv"
+	reportGroupBy = []string{"type", "tag:team"}
+
+	tagClient := new(MockTaggingClient)
+	tagClient.On("ResourceTags", mock.Anything, []string{
+		"arn:aws:sagemaker:us-east-1:123456789012:endpoint/churn",
+		"arn:aws:sagemaker:us-east-1:123456789012:endpoint/churn",
+	}).Return(map[string]map[string]string{
+		"arn:aws:sagemaker:us-east-1:123456789012:endpoint/churn": {"team": "ml"},
+	}, nil)
+
+	var out bytes.Buffer
+	assert.NoError(t, runReport(reportStore(t), tagClient, "us-east-1", reportNow, &out))
+
+	records, err := csv.NewReader(&out).ReadAll()
+	assert.NoError(t, err)
+	if assert.Len(t, records, 5) {
+		assert.Equal(t, []string{"group_by", "group", "instance_hours", "estimated_cost", "trend"}, records[0][:5])
+		assert.Equal(t, "2026-10-12", records[0][5])
+		assert.Len(t, records[0], 5+7)
+		// The endpoint is held for 12 hours by each snapshot, the app by the first only
+		assert.Equal(t, []string{"type", "Endpoint", "24.00", "33.79"}, records[1][:4])
+		assert.Equal(t, []string{"type", "Studio", "12.00", "0.60"}, records[2][:4])
+		assert.Equal(t, []string{"tag:team", "ml", "24.00", "33.79"}, records[3][:4])
+		assert.Equal(t, []string{"tag:team", untaggableValue, "12.00", "0.60"}, records[4][:4])
+	}
+	tagClient.AssertExpectations(t)
+}
+
+func TestRunReport_Formats(t *testing.T) {
+	for _, format := range []string{"table", "markdown", "json"} {
+		resetCommand()
+		reportFormat = format
+
+		var out bytes.Buffer
+		assert.NoError(t, runReport(reportStore(t), nil, "", reportNow, &out))
+		if format == "markdown" {
+			assert.Contains(t, out.String(), "## By instance family\n\n| Instance Family | Instance Hours | Est. Cost | Daily Cost Trend |\n|---|---:|---:|---|\n| ml.g5 | 24.0 | $33.79 |")
+		}
+	}
+
+	// An empty history is reported as no usage
+	resetCommand()
+	assert.NoError(t, runReport(history.NewStore(t.TempDir(), 0), nil, "", reportNow, &bytes.Buffer{}))
+}
+
+func TestRunReport_InvalidFlags(t *testing.T) {
+	store := history.NewStore(t.TempDir(), 0)
+	tests := []struct {
+		setup   func()
+		wantErr string
+	}{
+		{func() { reportFormat = "xml" }, `invalid format "xml": expected table, csv, markdown or json`},
+		{func() { reportPeriod = "year" }, `invalid period "year": expected week or month`},
+		{func() { reportGroupBy = []string{"owner"} }, `invalid grouping "owner"`},
+	}
+	for _, tt := range tests {
+		resetCommand()
+		tt.setup()
+		err := runReport(store, nil, "", reportNow, &bytes.Buffer{})
+		if assert.Error(t, err) {
+			assert.True(t, strings.HasPrefix(err.Error(), tt.wantErr), err.Error())
+		}
+	}
+}
@@ -0,0 +1,262 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"mohua/internal/display"
+	"mohua/internal/metrics"
+	"mohua/internal/rightsize"
+	"mohua/internal/sagemaker"
+)
+
+var (
+	rightsizeWindow   time.Duration
+	targetUtilization float64
+	gpuIdle           float64
+)
+
+// Namespaces of the utilization metrics. SageMaker publishes endpoint metrics
+// itself; notebook instances and Studio apps only have them when a lifecycle
+// configuration publishes them.
+const (
+	endpointNamespace = "/aws/sagemaker/Endpoints"
+	notebookNamespace = "/aws/sagemaker/NotebookInstances"
+	studioNamespace   = "/aws/sagemaker/StudioApps"
+)
+
+// rightsizeCmd suggests smaller instance types from utilization metrics
+var rightsizeCmd = &cobra.Command{
+	Use:   "rightsize",
+	Short: "Suggest smaller instance types from utilization metrics",
+	Long: `Read the p50 and p95 CPU, memory and GPU utilization of every endpoint
+variant, notebook instance and Studio app over --window and suggest the
+cheapest instance type that keeps the p95 at or below --target percent: a
+smaller size within the same family, or a CPU-only instance type when the
+GPU p95 stays below --gpu-idle percent. Savings are projected from the
+on-demand price of the instance types.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := sagemaker.NewClient(region)
+		if err != nil {
+			return fmt.Errorf("failed to create SageMaker client: %w", err)
+		}
+
+		metricsClient, err := metrics.NewClient(region)
+		if err != nil {
+			return fmt.Errorf("failed to create CloudWatch client: %w", err)
+		}
+
+		return runRightsize(client, metricsClient)
+	},
+}
+
+func init() {
+	rightsizeCmd.Flags().DurationVar(&rightsizeWindow, "window", 7*24*time.Hour, "Time window of the utilization metrics")
+	rightsizeCmd.Flags().Float64Var(&targetUtilization, "target", 60, "Highest p95 utilization percentage allowed on a suggested instance type")
+	rightsizeCmd.Flags().Float64Var(&gpuIdle, "gpu-idle", 10, "GPU p95 utilization percentage below which a CPU-only instance type is suggested")
+	rootCmd.AddCommand(rightsizeCmd)
+}
+
+// rightsizeTarget is a resource whose instance type is checked
+type rightsizeTarget struct {
+	resourceType  string
+	name          string
+	instanceType  string
+	instanceCount int
+	source        metrics.Source
+	cpuFamilies   []string // Families of CPU-only suggestions for idle GPUs
+}
+
+// Endpoints cannot use burstable instances, so only notebooks and Studio apps
+// are moved to ml.t3
+var (
+	endpointCPUFamilies = []string{"ml.m5", "ml.m6i", "ml.c5", "ml.r5"}
+	notebookCPUFamilies = []string{"ml.t3", "ml.m5", "ml.m6i", "ml.c5", "ml.r5"}
+)
+
+// rightsizeEntry is the JSON representation of a checked resource
+type rightsizeEntry struct {
+	ResourceType   string                   `json:"resourceType"`
+	Name           string                   `json:"name"`
+	InstanceType   string                   `json:"instanceType"`
+	InstanceCount  int                      `json:"instanceCount"`
+	Utilization    metrics.Utilization      `json:"utilization"` // Percentages of the whole instance
+	Recommendation rightsize.Recommendation `json:"recommendation"`
+}
+
+// rightsizeReport is the JSON representation of the right-sizing report
+type rightsizeReport struct {
+	Window            string           `json:"window"`
+	TargetUtilization float64          `json:"targetUtilization"`
+	GPUIdle           float64          `json:"gpuIdle"`
+	Resources         []rightsizeEntry `json:"resources"`
+	MonthlySaving     float64          `json:"monthlySaving"`
+}
+
+// rightsizeTargets lists the endpoint variants, notebooks and Studio apps that run on instances.
+// When the notebook or Studio collector failed, the other targets are returned
+// with an error that carries ExitPartial.
+func rightsizeTargets(ctx context.Context, client sagemaker.Client) ([]rightsizeTarget, error) {
+	variants, err := client.ListEndpointVariants(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list endpoint variants: %w", err)
+	}
+
+	var selected []collector
+	for _, c := range collectors(client) {
+		switch c.resourceType {
+		case "Notebook", "Studio":
+			selected = append(selected, c)
+		}
+	}
+	results := collectResources(ctx, client, selected)
+	resources, err := mergeResults(selected, results)
+	if err != nil {
+		return nil, err
+	}
+
+	var targets []rightsizeTarget
+	for _, variant := range variants {
+		// Serverless variants have no instances
+		if variant.InstanceType == "" {
+			continue
+		}
+		targets = append(targets, rightsizeTarget{
+			resourceType:  "Endpoint",
+			name:          fmt.Sprintf("%s/%s", variant.EndpointName, variant.VariantName),
+			instanceType:  variant.InstanceType,
+			instanceCount: variant.InstanceCount,
+			source: metrics.Source{
+				Namespace:  endpointNamespace,
+				Dimensions: map[string]string{"EndpointName": variant.EndpointName, "VariantName": variant.VariantName},
+			},
+			cpuFamilies: endpointCPUFamilies,
+		})
+	}
+	for _, resource := range resources {
+		target := rightsizeTarget{
+			resourceType:  resource.ResourceType,
+			name:          resource.Name,
+			instanceType:  resource.InstanceType,
+			instanceCount: 1,
+			cpuFamilies:   notebookCPUFamilies,
+		}
+		target.source = utilizationSource(resource)
+		if resource.ResourceType == "Studio" {
+			target.name = fmt.Sprintf("%s/%s", resource.UserProfile, resource.AppType)
+		}
+		targets = append(targets, target)
+	}
+	return targets, partialError(selected, results)
+}
+
+// utilizationSource returns the utilization metrics of a notebook or Studio app
+func utilizationSource(resource sagemaker.ResourceInfo) metrics.Source {
+	if resource.ResourceType == "Notebook" {
+		return metrics.Source{
+			Namespace:  notebookNamespace,
+			Dimensions: map[string]string{"NotebookInstanceName": resource.Name},
+		}
+	}
+	owner := map[string]string{"AppName": resource.Name, "UserProfileName": resource.UserProfile}
+	if resource.SpaceName != "" {
+		owner = map[string]string{"AppName": resource.Name, "SpaceName": resource.SpaceName}
+	}
+	return metrics.Source{Namespace: studioNamespace, Dimensions: owner}
+}
+
+func runRightsize(client sagemaker.Client, metricsClient metrics.Client) error {
+	ctx := context.Background()
+
+	targets, partialErr := rightsizeTargets(ctx, client)
+	if partialErr != nil && ExitCode(partialErr) != ExitPartial {
+		return partialErr
+	}
+
+	report := rightsizeReport{
+		Window:            rightsizeWindow.String(),
+		TargetUtilization: targetUtilization,
+		GPUIdle:           gpuIdle,
+		Resources:         []rightsizeEntry{},
+	}
+	for _, target := range targets {
+		utilization, err := metricsClient.Utilization(ctx, target.source, rightsizeWindow)
+		if err != nil {
+			return err
+		}
+		utilization = rightsize.Normalize(target.instanceType, utilization)
+		recommendation := rightsize.Recommend(target.instanceType, target.instanceCount, utilization, rightsize.Options{
+			TargetUtilization: targetUtilization,
+			GPUIdle:           gpuIdle,
+			CPUFamilies:       target.cpuFamilies,
+		})
+		report.MonthlySaving += recommendation.MonthlySaving
+		report.Resources = append(report.Resources, rightsizeEntry{
+			ResourceType:   target.resourceType,
+			Name:           target.name,
+			InstanceType:   target.instanceType,
+			InstanceCount:  target.instanceCount,
+			Utilization:    utilization,
+			Recommendation: recommendation,
+		})
+	}
+	// Largest savings first
+	sort.SliceStable(report.Resources, func(i, j int) bool {
+		return report.Resources[i].Recommendation.MonthlySaving > report.Resources[j].Recommendation.MonthlySaving
+	})
+
+	printer := display.NewPrinter(jsonOutput)
+	if jsonOutput {
+		if err := printer.PrintJSON(report); err != nil {
+			return err
+		}
+		return partialErr
+	}
+
+	if len(report.Resources) == 0 {
+		printer.PrintSummary("No endpoint variants, notebook instances or Studio apps run on instances")
+		return partialErr
+	}
+
+	rows := make([][]string, 0, len(report.Resources))
+	highlight := make([]bool, 0, len(report.Resources))
+	suggested := 0
+	for _, entry := range report.Resources {
+		suggestion, saving := "-", "-"
+		if entry.Recommendation.InstanceType != "" {
+			suggestion = entry.Recommendation.InstanceType
+			saving = fmt.Sprintf("$%.2f", entry.Recommendation.MonthlySaving)
+			suggested++
+		}
+		rows = append(rows, []string{
+			entry.ResourceType,
+			entry.Name,
+			entry.InstanceType,
+			fmt.Sprintf("%d", entry.InstanceCount),
+			formatPercentiles(entry.Utilization.CPU),
+			formatPercentiles(entry.Utilization.Memory),
+			formatPercentiles(entry.Utilization.GPU),
+			suggestion,
+			saving,
+			entry.Recommendation.Reason,
+		})
+		highlight = append(highlight, entry.Recommendation.InstanceType != "")
+	}
+	printer.PrintHighlightedTable([]string{
+		"Type", "Name", "Instance", "Count", "CPU p50/p95", "Memory p50/p95", "GPU p50/p95", "Suggestion", "Saving/Month", "Reason",
+	}, rows, highlight)
+	printer.PrintSummary("%d of %d resources can use a cheaper instance type, saving an estimated $%.2f per month (window %s)",
+		suggested, len(report.Resources), report.MonthlySaving, rightsizeWindow)
+	return partialErr
+}
+
+// formatPercentiles formats p50 and p95 as "p50%/p95%", or "-" without data points
+func formatPercentiles(p *metrics.Percentiles) string {
+	if p == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%%/%.0f%%", p.P50, p.P95)
+}
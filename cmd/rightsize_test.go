@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"mohua/internal/metrics"
+	"mohua/internal/sagemaker"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRightsizeTargets(t *testing.T) {
+	resetCommand()
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListEndpointVariants", mock.Anything).Return([]sagemaker.EndpointVariant{
+		{EndpointName: "churn", VariantName: "AllTraffic", InstanceType: "ml.g5.2xlarge", InstanceCount: 2},
+		{EndpointName: "churn", VariantName: "Serverless"},
+	}, nil)
+	mockClient.On("ListNotebooks", mock.Anything).Return([]sagemaker.ResourceInfo{
+		{Name: "dev", InstanceType: "ml.t3.xlarge"},
+	}, nil)
+	mockClient.On("ListStudioApps", mock.Anything).Return([]sagemaker.ResourceInfo{
+		{Name: "default", InstanceType: "ml.g5.xlarge", UserProfile: "alice", AppType: "JupyterLab", SpaceName: "alice-space"},
+	}, nil)
+	expectEmptyCollectors(mockClient)
+
+	targets, err := rightsizeTargets(context.Background(), mockClient)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []rightsizeTarget{
+		{
+			resourceType:  "Endpoint",
+			name:          "churn/AllTraffic",
+			instanceType:  "ml.g5.2xlarge",
+			instanceCount: 2,
+			source: metrics.Source{
+				Namespace:  endpointNamespace,
+				Dimensions: map[string]string{"EndpointName": "churn", "VariantName": "AllTraffic"},
+			},
+			cpuFamilies: endpointCPUFamilies,
+		},
+		{
+			resourceType:  "Notebook",
+			name:          "dev",
+			instanceType:  "ml.t3.xlarge",
+			instanceCount: 1,
+			source: metrics.Source{
+				Namespace:  notebookNamespace,
+				Dimensions: map[string]string{"NotebookInstanceName": "dev"},
+			},
+			cpuFamilies: notebookCPUFamilies,
+		},
+		{
+			resourceType:  "Studio",
+			name:          "alice/JupyterLab",
+			instanceType:  "ml.g5.xlarge",
+			instanceCount: 1,
+			source: metrics.Source{
+				Namespace:  studioNamespace,
+				Dimensions: map[string]string{"AppName": "default", "SpaceName": "alice-space"},
+			},
+			cpuFamilies: notebookCPUFamilies,
+		},
+	}, targets)
+	// Only the collectors of resources that run on instances are used
+	mockClient.AssertNotCalled(t, "ListTuningJobs", mock.Anything)
+}
+
+func TestRunRightsize(t *testing.T) {
+	for _, jsonFlag := range []bool{false, true} {
+		resetCommand()
+		jsonOutput = jsonFlag
+
+		mockClient := new(MockSageMakerClient)
+		mockClient.On("ListEndpointVariants", mock.Anything).Return([]sagemaker.EndpointVariant{
+			{EndpointName: "churn", VariantName: "AllTraffic", InstanceType: "ml.g5.2xlarge", InstanceCount: 2},
+		}, nil)
+		mockClient.On("ListNotebooks", mock.Anything).Return([]sagemaker.ResourceInfo{
+			{Name: "dev", InstanceType: "ml.t3.xlarge"},
+		}, nil)
+		expectEmptyCollectors(mockClient)
+
+		metricsClient := new(MockMetricsClient)
+		metricsClient.On("Utilization", mock.Anything, mock.MatchedBy(func(source metrics.Source) bool {
+			return source.Namespace == endpointNamespace
+		}), rightsizeWindow).Return(metrics.Utilization{
+			CPU:    &metrics.Percentiles{P50: 40, P95: 80},
+			Memory: &metrics.Percentiles{P50: 20, P95: 30},
+			GPU:    &metrics.Percentiles{P50: 2, P95: 5},
+		}, nil)
+		metricsClient.On("Utilization", mock.Anything, mock.MatchedBy(func(source metrics.Source) bool {
+			return source.Namespace == notebookNamespace
+		}), rightsizeWindow).Return(metrics.Utilization{}, nil)
+
+		assert.NoError(t, runRightsize(mockClient, metricsClient))
+		metricsClient.AssertExpectations(t)
+	}
+}
+
+func TestRunRightsize_Error(t *testing.T) {
+	resetCommand()
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListEndpointVariants", mock.Anything).Return([]sagemaker.EndpointVariant{
+		{EndpointName: "churn", VariantName: "AllTraffic", InstanceType: "ml.g5.2xlarge", InstanceCount: 2},
+	}, nil)
+	expectEmptyCollectors(mockClient)
+
+	metricsClient := new(MockMetricsClient)
+	metricsClient.On("Utilization", mock.Anything, mock.Anything, mock.Anything).
+		Return(metrics.Utilization{}, errors.New("access denied"))
+
+	assert.EqualError(t, runRightsize(mockClient, metricsClient), "access denied")
+}
+
+func TestRunRightsize_Partial(t *testing.T) {
+	resetCommand()
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListEndpointVariants", mock.Anything).Return([]sagemaker.EndpointVariant{
+		{EndpointName: "churn", VariantName: "AllTraffic", InstanceType: "ml.g5.2xlarge", InstanceCount: 2},
+	}, nil)
+	mockClient.On("ListStudioApps", mock.Anything).Return(nil, &sagemaker.RetryableError{Err: errors.New("throttled")})
+	expectEmptyCollectors(mockClient)
+
+	metricsClient := new(MockMetricsClient)
+	metricsClient.On("Utilization", mock.Anything, mock.Anything, rightsizeWindow).Return(metrics.Utilization{}, nil)
+
+	// The endpoint is still reported, but the Studio apps are missing
+	err := runRightsize(mockClient, metricsClient)
+	assert.ErrorContains(t, err, "failed to list studio apps")
+	assert.Equal(t, ExitPartial, ExitCode(err))
+	metricsClient.AssertNumberOfCalls(t, "Utilization", 1)
+}
+
+func TestFormatPercentiles(t *testing.T) {
+	assert.Equal(t, "-", formatPercentiles(nil))
+	assert.Equal(t, "5%/13%", formatPercentiles(&metrics.Percentiles{P50: 5.2, P95: 12.6}))
+}
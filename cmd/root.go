@@ -1,15 +1,20 @@
-
 package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"os"
-	"sync"
-	"time"
 	"github.com/spf13/cobra"
 	"mohua/internal/display"
+	"mohua/internal/history"
+	"mohua/internal/policy"
 	"mohua/internal/sagemaker"
+	"mohua/internal/tagging"
+	"mohua/internal/telemetry"
+	"mohua/internal/trail"
+	"os"
+	"sync"
+	"time"
 )
 
 // ResourceResult holds the results and errors from API calls
@@ -19,18 +24,22 @@ type ResourceResult struct {
 }
 
 var (
-	region    string
+	region     string
 	jsonOutput bool
+	stuckHours int
+	configFile string
+	showTags   []string
+	failIf     []string
 )
 
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
-	Use:                        "mohua",
-	Short:                      "Monitor AWS SageMaker compute resources and their costs",
+	Use:   "mohua",
+	Short: "Monitor AWS SageMaker compute resources and their costs",
 	Long: `A monitoring tool for AWS SageMaker that helps track running compute resources
 and their associated costs.`,
-	SilenceUsage:                    true,
-	SilenceErrors:                   true,
+	SilenceUsage:  true,
+	SilenceErrors: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
 
 		// Create SageMaker client
@@ -39,7 +48,52 @@ and their associated costs.`,
 			return fmt.Errorf("failed to create SageMaker client: %w", err)
 		}
 
-		return runMonitor(client)
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+
+		thresholds, err := parseFailIf(failIf)
+		if err != nil {
+			return err
+		}
+
+		var tagClient tagging.Client
+		if len(showTags) > 0 || thresholdsUseTags(thresholds) {
+			tagClient, err = newTaggingClient(client, cfg)
+			if err != nil {
+				return err
+			}
+		}
+
+		var trailClient trail.Client
+		if lookupCreators {
+			trailClient, err = newTrailClient(client)
+			if err != nil {
+				return err
+			}
+		}
+
+		var store *history.Store
+		if cfg.History.Enabled {
+			store, err = newHistoryStore(cfg)
+			if err != nil {
+				return err
+			}
+		}
+
+		exporter, err := newExporter(cfg)
+		if err != nil {
+			return err
+		}
+		defer shutdownExporter(exporter)
+
+		publisher, err := newPublisher(context.Background(), region)
+		if err != nil {
+			return err
+		}
+
+		return runMonitor(client, tagClient, trailClient, store, exporter, publisher, thresholds)
 	},
 }
 
@@ -47,11 +101,88 @@ and their associated costs.`,
 func Execute() error {
 	rootCmd.PersistentFlags().StringVarP(&region, "region", "r", "", "AWS region (optional, defaults to AWS CLI configuration)")
 	rootCmd.PersistentFlags().BoolVarP(&jsonOutput, "json", "j", false, "Output in JSON format")
-	
+	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "Config file (defaults to mohua/config.yaml in the user config directory)")
+	rootCmd.Flags().StringSliceVar(&showTags, "show-tags", nil, "Show the values of these tag keys as columns (comma separated)")
+	rootCmd.Flags().BoolVar(&lookupCreators, "who", false, "Look up who created each resource in CloudTrail (last 90 days)")
+	rootCmd.Flags().IntVar(&stuckHours, "stuck-hours", 6, "Flag pipeline executions with no step change for this many hours")
+	rootCmd.Flags().StringArrayVar(&failIf, "fail-if", nil, "Exit with code 2 when this expression is true, e.g. 'count(type=Endpoint)>5', 'cost.hourly>20' or 'any(age>168h)' (repeatable)")
+	addTelemetryFlags(rootCmd)
+	addPublishFlags(rootCmd)
+
 	return rootCmd.Execute()
 }
 
-func runMonitor(client sagemaker.Client) error {
+// collector retrieves one resource type for the resource listing
+type collector struct {
+	resourceType string
+	label        string // Used in error messages, e.g. "endpoints"
+	list         func(ctx context.Context) ([]sagemaker.ResourceInfo, error)
+	displayName  func(resource sagemaker.ResourceInfo) string // Optional, defaults to the resource name
+}
+
+// collectors returns the resource collectors in display order
+func collectors(client sagemaker.Client) []collector {
+	return []collector{
+		{resourceType: "Endpoint", label: "endpoints", list: client.ListEndpoints},
+		{resourceType: "Notebook", label: "notebooks", list: client.ListNotebooks},
+		{
+			resourceType: "Studio",
+			label:        "studio apps",
+			list:         client.ListStudioApps,
+			displayName: func(app sagemaker.ResourceInfo) string {
+				return fmt.Sprintf("%s/%s", app.UserProfile, app.AppType)
+			},
+		},
+		{resourceType: "Tuning", label: "tuning jobs", list: client.ListTuningJobs},
+		{
+			resourceType: "Pipeline",
+			label:        "pipeline executions",
+			list: func(ctx context.Context) ([]sagemaker.ResourceInfo, error) {
+				return client.ListPipelineExecutions(ctx, time.Duration(stuckHours)*time.Hour)
+			},
+		},
+		{resourceType: "AutoML", label: "AutoML jobs", list: client.ListAutoMLJobs},
+		{resourceType: "Compilation", label: "compilation jobs", list: client.ListCompilationJobs},
+		{resourceType: "Recommender", label: "inference recommendations jobs", list: client.ListInferenceRecommendationsJobs},
+		{resourceType: "Labeling", label: "labeling jobs", list: client.ListLabelingJobs},
+		{resourceType: "MLflow", label: "MLflow tracking servers", list: client.ListMlflowTrackingServers},
+		{resourceType: "FeatureGroup", label: "online feature groups", list: client.ListOnlineFeatureGroups},
+		{resourceType: "Monitor", label: "monitoring schedules", list: client.ListMonitoringSchedules},
+		{resourceType: "Experiment", label: "inference experiments", list: client.ListInferenceExperiments},
+	}
+}
+
+// parseFailIf parses the --fail-if expressions
+func parseFailIf(expressions []string) ([]*policy.Expr, error) {
+	thresholds := make([]*policy.Expr, 0, len(expressions))
+	for _, s := range expressions {
+		expr, err := policy.ParseExpr(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --fail-if: %w", err)
+		}
+		thresholds = append(thresholds, expr)
+	}
+	return thresholds, nil
+}
+
+// thresholdsUseTags reports whether any threshold needs the resource tags
+func thresholdsUseTags(thresholds []*policy.Expr) bool {
+	for _, expr := range thresholds {
+		if expr.UsesTags() {
+			return true
+		}
+	}
+	return false
+}
+
+// runMonitor lists the running resources. Tags and creators are looked up
+// with tagClient and trailClient, a snapshot is recorded in store, the scan is
+// exported with exporter and its metrics are put in CloudWatch by publisher,
+// when they are not nil. The returned error carries ExitViolation when a
+// threshold is true, otherwise ExitPartial when some collectors failed; it is
+// fatal when every collector failed. Thresholds that cover the type of a failed
+// collector are not evaluated.
+func runMonitor(client sagemaker.Client, tagClient tagging.Client, trailClient trail.Client, store *history.Store, exporter *telemetry.Exporter, publisher *publisher, thresholds []*policy.Expr) error {
 	ctx := context.Background()
 
 	// Validate AWS configuration
@@ -62,6 +193,8 @@ func runMonitor(client sagemaker.Client) error {
 
 	// Create printer for output
 	printer := display.NewPrinter(jsonOutput)
+	printer.SetTagColumns(showTags)
+	printer.SetCreatorColumn(trailClient != nil)
 
 	// If no resources are configured, print message and return
 	if !hasConfiguredResources {
@@ -69,138 +202,203 @@ func runMonitor(client sagemaker.Client) error {
 		return nil
 	}
 
-	// Create channels for each resource type
-	endpointsChan := make(chan ResourceResult, 1)
-	notebooksChan := make(chan ResourceResult, 1)
-	appsChan := make(chan ResourceResult, 1)
-
-	// Launch goroutines for each API call
-	var wg sync.WaitGroup
-	wg.Add(3)
-
-	go func() {
-		defer wg.Done()
-		endpoints, err := client.ListEndpoints(ctx)
-		endpointsChan <- ResourceResult{Resources: endpoints, Error: err}
-	}()
-
-	go func() {
-		defer wg.Done()
-		notebooks, err := client.ListNotebooks(ctx)
-		notebooksChan <- ResourceResult{Resources: notebooks, Error: err}
+	start := time.Now()
+	scanCtx, span := startScan(ctx, client.GetRegion())
+	collectors := collectors(client)
+	results := collectResources(scanCtx, client, collectors)
+	defer func() {
+		scans := []regionScan{{region: client.GetRegion(), collectors: collectors, results: results}}
+		exportScan(ctx, exporter, span, scans, time.Since(start))
 	}()
 
-	go func() {
-		defer wg.Done()
-		apps, err := client.ListStudioApps(ctx)
-		appsChan <- ResourceResult{Resources: apps, Error: err}
-	}()
+	resourceLists := make([][]sagemaker.ResourceInfo, len(results))
+	for i, result := range results {
+		resourceLists[i] = result.Resources
+	}
+	if tagClient != nil {
+		if err := attachTags(scanCtx, tagClient, resourceLists...); err != nil {
+			return err
+		}
+	}
+	if trailClient != nil {
+		if err := attachCreators(scanCtx, trailClient, resourceLists...); err != nil {
+			return err
+		}
+	}
 
-	// Close channels after all goroutines complete
-	go func() {
-		wg.Wait()
-		close(endpointsChan)
-		close(notebooksChan)
-		close(appsChan)
-	}()
+	if store != nil {
+		if err := recordSnapshot(store, client.GetRegion(), collectors, results); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to record history: %v\n", err)
+		}
+	}
+	if publisher != nil {
+		scan := regionScan{region: client.GetRegion(), collectors: collectors, results: results}
+		if err := publisher.publish(scanCtx, scan, start); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to publish metrics: %v\n", err)
+		}
+	}
 
-	// Track if any resources were found and collect errors
-	resourceFound := false
-	var firstError error
+	// Track if any resources were found and collect every error
+	var resources []sagemaker.ResourceInfo
+	var collectErrors []error
 
-	// Process endpoints
-	if result := <-endpointsChan; result.Error != nil {
-		// Check if the error is retryable
-		if retryableErr, ok := result.Error.(*sagemaker.RetryableError); ok {
-			// Log the retryable error, but don't stop execution
-			fmt.Fprintf(os.Stderr, "Retryable error listing endpoints: %v\n", retryableErr)
-		} else {
-			if firstError == nil {
-				firstError = fmt.Errorf("failed to list endpoints: %w", result.Error)
-			}
+	// Process results in collector order
+	for i, c := range collectors {
+		result := results[i]
+		if result.Error != nil {
+			collectErrors = append(collectErrors, fmt.Errorf("failed to list %s: %w", c.label, result.Error))
+			continue
 		}
-	} else if len(result.Resources) > 0 {
-		if !resourceFound {
-			printer.PrintHeader()
-			resourceFound = true
-		}
-		for _, endpoint := range result.Resources {
-			printer.PrintResource(display.ResourceInfo{
-				ResourceType:  "Endpoint",
-				Name:         endpoint.Name,
-				Status:       endpoint.Status,
-				InstanceType: endpoint.InstanceType,
-				RunningTime:  time.Since(endpoint.CreationTime).String(),
-			})
-		}
-	}
-
-	// Process notebooks
-	if result := <-notebooksChan; result.Error != nil {
-		// Check if the error is retryable
-		if retryableErr, ok := result.Error.(*sagemaker.RetryableError); ok {
-			// Log the retryable error, but don't stop execution
-			fmt.Fprintf(os.Stderr, "Retryable error listing notebooks: %v\n", retryableErr)
-		} else {
-			if firstError == nil {
-				firstError = fmt.Errorf("failed to list notebooks: %w", result.Error)
+
+		for _, resource := range result.Resources {
+			if len(resources) == 0 {
+				printer.PrintHeader()
 			}
+			resources = append(resources, resource)
+			printer.PrintResource(displayResource(c, resource, time.Now()))
 		}
-	} else if len(result.Resources) > 0 {
-		if !resourceFound {
-			printer.PrintHeader()
-			resourceFound = true
-		}
-		for _, notebook := range result.Resources {
-			printer.PrintResource(display.ResourceInfo{
-				ResourceType:  "Notebook",
-				Name:         notebook.Name,
-				Status:       notebook.Status,
-				InstanceType: notebook.InstanceType,
-				RunningTime:  time.Since(notebook.CreationTime).String(),
-			})
-		}
-	}
-
-	// Process Studio apps
-	if result := <-appsChan; result.Error != nil {
-		// Check if the error is retryable
-		if retryableErr, ok := result.Error.(*sagemaker.RetryableError); ok {
-			// Log the retryable error, but don't stop execution
-			fmt.Fprintf(os.Stderr, "Retryable error listing studio apps: %v\n", retryableErr)
-		} else {
-			if firstError == nil {
-				firstError = fmt.Errorf("failed to list studio apps: %w", result.Error)
-			}
+	}
+
+	// Nothing was collected, so neither the listing nor the thresholds mean anything
+	if len(collectErrors) == len(collectors) {
+		return errors.Join(collectErrors...)
+	}
+
+	if len(resources) == 0 {
+		printer.PrintNoResources(client.GetRegion())
+	} else {
+		printer.PrintFooter()
+	}
+
+	// An expression over the types of a failed collector cannot be decided,
+	// e.g. count(type=Endpoint)<1 when the endpoints were not listed
+	var violations, undecided []error
+	for _, expr := range thresholds {
+		if resourceType := uncoveredType(expr, collectors, results); resourceType != "" {
+			undecided = append(undecided, fmt.Errorf("--fail-if %s cannot be evaluated: %s resources were not collected", expr, resourceType))
+			continue
 		}
-	} else if len(result.Resources) > 0 {
-		if !resourceFound {
-			printer.PrintHeader()
-			resourceFound = true
+		if violated, value := expr.Evaluate(resources, time.Now()); violated {
+			violations = append(violations, fmt.Errorf("--fail-if %s is true: %s", expr, value))
 		}
-		for _, app := range result.Resources {
-			printer.PrintResource(display.ResourceInfo{
-				ResourceType:  "Studio",
-				Name:         fmt.Sprintf("%s/%s", app.UserProfile, app.AppType),
-				Status:       app.Status,
-				InstanceType: app.InstanceType,
-				RunningTime:  time.Since(app.CreationTime).String(),
-			})
+	}
+	if len(violations) > 0 {
+		return withExitCode(ExitViolation, errors.Join(append(append(violations, undecided...), collectErrors...)...))
+	}
+	if len(collectErrors) > 0 {
+		return withExitCode(ExitPartial, errors.Join(append(undecided, collectErrors...)...))
+	}
+	return nil
+}
+
+// uncoveredType returns the type of the first failed collector that the
+// expression covers, or an empty string when its result is complete
+func uncoveredType(expr *policy.Expr, collectors []collector, results []ResourceResult) string {
+	for i, c := range collectors {
+		if results[i].Error != nil && expr.Covers(c.resourceType) {
+			return c.resourceType
 		}
 	}
+	return ""
+}
 
-	// Return first error encountered if any
-	if firstError != nil {
-		return firstError
+// displayResource converts a collected resource for the printer
+func displayResource(c collector, resource sagemaker.ResourceInfo, now time.Time) display.ResourceInfo {
+	name := resource.Name
+	if c.displayName != nil {
+		name = c.displayName(resource)
 	}
+	return display.ResourceInfo{
+		ResourceType: c.resourceType,
+		Name:         name,
+		Status:       resource.Status,
+		InstanceType: resource.InstanceType,
+		RunningTime:  now.Sub(resource.CreationTime).String(),
+		Details:      resource.Details,
+		HourlyCost:   resource.HourlyCost,
+		Tags:         resource.Tags,
+		CreatedBy:    resource.CreatedBy,
+	}
+}
 
-	// If no resources found, print no resources message
-	if !resourceFound {
-		printer.PrintNoResources(client.GetRegion())
-		return nil
+// collectResources runs every collector concurrently and returns their results
+// in collector order, with the resource type and region set on every resource
+func collectResources(ctx context.Context, client sagemaker.Client, collectors []collector) []ResourceResult {
+	results := make([]ResourceResult, len(collectors))
+	region := client.GetRegion()
+
+	// Launch goroutines for each API call
+	var wg sync.WaitGroup
+	wg.Add(len(collectors))
+	for i, c := range collectors {
+		go func(i int, c collector) {
+			defer wg.Done()
+			resources, err := traceCollector(ctx, c, region)
+			for j := range resources {
+				resources[j].ResourceType = c.resourceType
+				resources[j].Region = region
+			}
+			results[i] = ResourceResult{Resources: resources, Error: err}
+		}(i, c)
 	}
+	wg.Wait()
+
+	// Cross-reference resources of different types
+	markExperimentEndpoints(collectors, results)
+	return results
+}
 
-	// Print footer if resources were found
-	printer.PrintFooter()
+// mergeResults returns the resources of every collector and the first
+// non-retryable error. Retryable errors are logged and the collector skipped.
+func mergeResults(collectors []collector, results []ResourceResult) ([]sagemaker.ResourceInfo, error) {
+	var resources []sagemaker.ResourceInfo
+	var firstError error
+	for i, c := range collectors {
+		result := results[i]
+		if result.Error != nil {
+			if retryableErr, ok := result.Error.(*sagemaker.RetryableError); ok {
+				fmt.Fprintf(os.Stderr, "Retryable error listing %s: %v\n", c.label, retryableErr)
+			} else if firstError == nil {
+				firstError = fmt.Errorf("failed to list %s: %w", c.label, result.Error)
+			}
+			continue
+		}
+		resources = append(resources, result.Resources...)
+	}
+	return resources, firstError
+}
+
+// collectorErrors returns an error for every collector that failed, including
+// the retryable failures that mergeResults only logs
+func collectorErrors(collectors []collector, results []ResourceResult) []error {
+	var errs []error
+	for i, c := range collectors {
+		if results[i].Error != nil {
+			errs = append(errs, fmt.Errorf("failed to list %s: %w", c.label, results[i].Error))
+		}
+	}
+	return errs
+}
+
+// partialError returns an ExitPartial error when some collectors failed, so
+// that a report built from the others is not mistaken for a complete one
+func partialError(collectors []collector, results []ResourceResult) error {
+	if errs := collectorErrors(collectors, results); len(errs) > 0 {
+		return withExitCode(ExitPartial, errors.Join(errs...))
+	}
 	return nil
 }
+
+// markExperimentEndpoints flags the endpoints that an inference experiment is attached to
+func markExperimentEndpoints(collectors []collector, results []ResourceResult) {
+	var endpoints, experiments []sagemaker.ResourceInfo
+	for i, c := range collectors {
+		switch c.resourceType {
+		case "Endpoint":
+			endpoints = results[i].Resources
+		case "Experiment":
+			experiments = results[i].Resources
+		}
+	}
+	sagemaker.MarkExperimentEndpoints(endpoints, experiments)
+}
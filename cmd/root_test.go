@@ -1,9 +1,13 @@
 package cmd
 
 import (
+	"errors"
 	"os"
 	"testing"
+	"time"
 
+	"mohua/internal/audit"
+	"mohua/internal/policy"
 	"mohua/internal/sagemaker"
 
 	"github.com/stretchr/testify/assert"
@@ -15,6 +19,71 @@ func resetCommand() {
 	rootCmd.ResetFlags()
 	region = ""
 	jsonOutput = false
+	stuckHours = 6
+	stoppedDays = 7
+	includeModelPackages = false
+	deleteOrphans = false
+	deleteModelPackages = false
+	assumeYes = false
+	auditFormat = "table"
+	failSeverity = "low"
+	maxFindings = 0
+	broadRolePattern = audit.DefaultBroadRolePattern
+	policyFile = "mohua-policy.yaml"
+	configFile = ""
+	showTags = nil
+	failIf = nil
+	requiredTags = nil
+	groupByTag = "team"
+	lookupCreators = false
+	quotaThreshold = 80
+	rightsizeWindow = 7 * 24 * time.Hour
+	targetUtilization = 60
+	gpuIdle = 10
+	diffSince = 24 * time.Hour
+	diffFrom = ""
+	diffTo = ""
+	reportPeriod = "week"
+	reportFormat = "table"
+	reportGroupBy = []string{"type", "family", "user"}
+	reportMaxGap = 24 * time.Hour
+	alertFile = "mohua-alerts.yaml"
+	alertDryRun = false
+	digestDryRun = false
+	daemonSchedule = "*/15 * * * *"
+	daemonListen = "127.0.0.1:9090"
+	daemonAlertFile = ""
+	daemonPolicyFile = ""
+	uiListen = "127.0.0.1:8080"
+	uiRefresh = 5 * time.Minute
+	uiRegions = nil
+	otlpEndpoint = ""
+	publishNamespace = ""
+	tuiRefresh = 30 * time.Second
+}
+
+// expectEmptyCollectors lets every resource collector that has no expectation
+// yet return an empty result. Expectations set before calling it take precedence.
+func expectEmptyCollectors(m *MockSageMakerClient) {
+	for _, method := range []string{
+		"ListEndpoints",
+		"ListNotebooks",
+		"ListStudioApps",
+		"ListTuningJobs",
+		"ListAutoMLJobs",
+		"ListCompilationJobs",
+		"ListInferenceRecommendationsJobs",
+		"ListLabelingJobs",
+		"ListMlflowTrackingServers",
+		"ListOnlineFeatureGroups",
+		"ListMonitoringSchedules",
+		"ListInferenceExperiments",
+		"ListTrainingJobs",
+	} {
+		m.On(method, mock.Anything).Return([]sagemaker.ResourceInfo{}, nil).Maybe()
+	}
+	m.On("ListPipelineExecutions", mock.Anything, mock.Anything).Return([]sagemaker.ResourceInfo{}, nil).Maybe()
+	m.On("GetRegion").Return("us-east-1").Maybe()
 }
 
 // mockExecute is a helper function that executes the command with a mock client
@@ -22,6 +91,11 @@ func mockExecute(t *testing.T, args []string, client sagemaker.Client) error {
 	// Reset command before test
 	resetCommand()
 
+	// Keep the config and history of the user out of the test
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+
 	// Save original args
 	oldArgs := os.Args
 	// Set up new args for test
@@ -55,6 +129,7 @@ func TestExecute_Unit(t *testing.T) {
 	mockClient.On("ListEndpoints", mock.Anything).Return([]sagemaker.ResourceInfo{}, nil)
 	mockClient.On("ListNotebooks", mock.Anything).Return([]sagemaker.ResourceInfo{}, nil)
 	mockClient.On("ListStudioApps", mock.Anything).Return([]sagemaker.ResourceInfo{}, nil)
+	expectEmptyCollectors(mockClient)
 
 	err := mockExecute(t, []string{}, mockClient)
 	assert.NoError(t, err)
@@ -72,6 +147,7 @@ func TestExecuteWithFlags_Unit(t *testing.T) {
 	mockClient.On("ListEndpoints", mock.Anything).Return([]sagemaker.ResourceInfo{}, nil)
 	mockClient.On("ListNotebooks", mock.Anything).Return([]sagemaker.ResourceInfo{}, nil)
 	mockClient.On("ListStudioApps", mock.Anything).Return([]sagemaker.ResourceInfo{}, nil)
+	expectEmptyCollectors(mockClient)
 
 	tests := []struct {
 		name    string
@@ -110,6 +186,160 @@ func TestExecuteWithFlags_Unit(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+func TestRunMonitor_Collectors(t *testing.T) {
+	resetCommand()
+	now := time.Now()
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ValidateConfiguration", mock.Anything).Return(true, nil)
+	mockClient.On("ListEndpoints", mock.Anything).Return([]sagemaker.ResourceInfo{
+		{Name: "endpoint", Status: "InService", InstanceType: "ml.m5.xlarge", CreationTime: now},
+	}, nil)
+	mockClient.On("ListNotebooks", mock.Anything).Return(nil, &sagemaker.RetryableError{Err: errors.New("throttled")})
+	mockClient.On("ListStudioApps", mock.Anything).Return([]sagemaker.ResourceInfo{}, nil)
+	mockClient.On("ListTuningJobs", mock.Anything).Return([]sagemaker.ResourceInfo{
+		{Name: "xgb-tuning", Status: "InProgress", InstanceType: "ml.m5.xlarge", CreationTime: now, Details: "jobs=1/1/0"},
+	}, nil)
+	expectEmptyCollectors(mockClient)
+
+	err := runMonitor(mockClient, nil, nil, nil, nil, nil, nil)
+	assert.EqualError(t, err, "failed to list notebooks: throttled")
+	assert.Equal(t, ExitPartial, ExitCode(err))
+	mockClient.AssertExpectations(t)
+}
+
+func TestRunMonitor_CollectorErrors(t *testing.T) {
+	resetCommand()
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ValidateConfiguration", mock.Anything).Return(true, nil)
+	mockClient.On("ListEndpoints", mock.Anything).Return([]sagemaker.ResourceInfo{}, nil)
+	mockClient.On("ListNotebooks", mock.Anything).Return([]sagemaker.ResourceInfo{}, nil)
+	mockClient.On("ListStudioApps", mock.Anything).Return(nil, errors.New("access denied"))
+	mockClient.On("ListTuningJobs", mock.Anything).Return(nil, errors.New("also denied"))
+	expectEmptyCollectors(mockClient)
+
+	err := runMonitor(mockClient, nil, nil, nil, nil, nil, nil)
+	assert.EqualError(t, err, "failed to list studio apps: access denied\nfailed to list tuning jobs: also denied")
+	assert.Equal(t, ExitPartial, ExitCode(err))
+}
+
+func TestRunMonitor_EveryCollectorFails(t *testing.T) {
+	resetCommand()
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ValidateConfiguration", mock.Anything).Return(true, nil)
+	for _, method := range []string{
+		"ListEndpoints",
+		"ListNotebooks",
+		"ListStudioApps",
+		"ListTuningJobs",
+		"ListAutoMLJobs",
+		"ListCompilationJobs",
+		"ListInferenceRecommendationsJobs",
+		"ListLabelingJobs",
+		"ListMlflowTrackingServers",
+		"ListOnlineFeatureGroups",
+		"ListMonitoringSchedules",
+		"ListInferenceExperiments",
+	} {
+		mockClient.On(method, mock.Anything).Return(nil, errors.New("expired token"))
+	}
+	mockClient.On("ListPipelineExecutions", mock.Anything, mock.Anything).Return(nil, errors.New("expired token"))
+	mockClient.On("GetRegion").Return("us-east-1")
+
+	err := runMonitor(mockClient, nil, nil, nil, nil, nil, []*policy.Expr{})
+	assert.ErrorContains(t, err, "failed to list endpoints: expired token")
+	assert.Equal(t, ExitError, ExitCode(err))
+}
+
+func TestRunMonitor_FailIf(t *testing.T) {
+	now := time.Now()
+	endpoints := []sagemaker.ResourceInfo{
+		{Name: "churn", Status: "InService", InstanceType: "ml.g5.xlarge", InstanceCount: 2, CreationTime: now.Add(-200 * time.Hour)},
+		{Name: "fraud", Status: "InService", InstanceType: "ml.m5.large", InstanceCount: 1, CreationTime: now.Add(-time.Hour)},
+	}
+	tests := []struct {
+		name        string
+		expressions []string
+		endpointErr error
+		notebookErr error
+		wantCode    int
+		wantErr     string
+	}{
+		{name: "no threshold true", expressions: []string{"count(type=Endpoint)>5", "cost.hourly>20"}, wantCode: ExitOK},
+		{
+			name:        "threshold true",
+			expressions: []string{"count(type=Endpoint)>5", "any(age>168h)"},
+			wantCode:    ExitViolation,
+			wantErr:     "--fail-if any(age>168h) is true: 1 resources match: Endpoint churn",
+		},
+		{
+			name:        "threshold true with a partial collection",
+			expressions: []string{"cost.hourly(type=Endpoint)>2"},
+			notebookErr: errors.New("access denied"),
+			wantCode:    ExitViolation,
+			wantErr:     "--fail-if cost.hourly(type=Endpoint)>2 is true: cost.hourly is $2.93\nfailed to list notebooks: access denied",
+		},
+		{
+			name:        "threshold over a failed type",
+			expressions: []string{"count(type=Endpoint)<1"},
+			endpointErr: &sagemaker.RetryableError{Err: errors.New("throttled")},
+			wantCode:    ExitPartial,
+			wantErr:     "--fail-if count(type=Endpoint)<1 cannot be evaluated: Endpoint resources were not collected\nfailed to list endpoints: throttled",
+		},
+		{
+			name:        "threshold without a type filter",
+			expressions: []string{"cost.hourly>2", "count(type=Notebook)>5"},
+			endpointErr: &sagemaker.RetryableError{Err: errors.New("throttled")},
+			wantCode:    ExitPartial,
+			wantErr:     "--fail-if cost.hourly>2 cannot be evaluated: Endpoint resources were not collected\nfailed to list endpoints: throttled",
+		},
+		{
+			name:        "partial collection",
+			expressions: []string{"cost.hourly(type=Endpoint)>20"},
+			notebookErr: errors.New("access denied"),
+			wantCode:    ExitPartial,
+			wantErr:     "failed to list notebooks: access denied",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetCommand()
+			thresholds, err := parseFailIf(tt.expressions)
+			assert.NoError(t, err)
+
+			mockClient := new(MockSageMakerClient)
+			mockClient.On("ValidateConfiguration", mock.Anything).Return(true, nil)
+			if tt.endpointErr != nil {
+				mockClient.On("ListEndpoints", mock.Anything).Return(nil, tt.endpointErr)
+			} else {
+				mockClient.On("ListEndpoints", mock.Anything).Return(endpoints, nil)
+			}
+			if tt.notebookErr != nil {
+				mockClient.On("ListNotebooks", mock.Anything).Return(nil, tt.notebookErr)
+			}
+			expectEmptyCollectors(mockClient)
+
+			err = runMonitor(mockClient, nil, nil, nil, nil, nil, thresholds)
+			assert.Equal(t, tt.wantCode, ExitCode(err))
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseFailIf(t *testing.T) {
+	thresholds, err := parseFailIf([]string{"count(tag.team=ml)>1", "any(age>7d)"})
+	assert.NoError(t, err)
+	assert.True(t, thresholdsUseTags(thresholds))
+
+	_, err = parseFailIf([]string{"cost.hourly>20", "total>1"})
+	assert.ErrorContains(t, err, "invalid --fail-if")
+}
+
 // func TestExecuteWithInvalidFlags_Unit(t *testing.T) {
 // 	mockClient := new(MockSageMakerClient)
 
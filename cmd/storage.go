@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"mohua/internal/display"
+	"mohua/internal/pricing"
+	"mohua/internal/sagemaker"
+)
+
+var stoppedDays int
+
+// storageCmd reports the storage volumes attached to notebooks, spaces and domains
+var storageCmd = &cobra.Command{
+	Use:   "storage",
+	Short: "Report storage attached to notebooks, Studio spaces and domains",
+	Long: `Report the ML storage volumes of notebook instances and Studio spaces and the
+EFS home volumes of Studio domains, with an estimated monthly storage cost.
+Storage attached to resources that have been stopped for longer than
+--stopped-days is highlighted.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := sagemaker.NewClient(region)
+		if err != nil {
+			return fmt.Errorf("failed to create SageMaker client: %w", err)
+		}
+
+		return runStorage(client, time.Now())
+	},
+}
+
+func init() {
+	storageCmd.Flags().IntVar(&stoppedDays, "stopped-days", 7, "Highlight storage of resources stopped for more than this many days")
+	rootCmd.AddCommand(storageCmd)
+}
+
+// storageEntry is a single row of the storage report
+type storageEntry struct {
+	ResourceType string  `json:"resourceType"`
+	Name         string  `json:"name"`
+	Status       string  `json:"status"`
+	VolumeSizeGB int     `json:"volumeSizeGB,omitempty"`
+	VolumeID     string  `json:"volumeId,omitempty"`
+	MonthlyCost  float64 `json:"monthlyCost"`
+	StoppedDays  int     `json:"stoppedDays,omitempty"`
+	Stale        bool    `json:"stale"`
+}
+
+// storageReport is the JSON representation of the storage report
+type storageReport struct {
+	Volumes          []storageEntry `json:"volumes"`
+	TotalMonthlyCost float64        `json:"totalMonthlyCost"`
+}
+
+// buildStorageReport estimates costs and marks volumes of resources stopped for more than stoppedDays
+func buildStorageReport(volumes []sagemaker.StorageInfo, now time.Time, stoppedDays int) storageReport {
+	report := storageReport{Volumes: make([]storageEntry, 0, len(volumes))}
+	for _, volume := range volumes {
+		entry := storageEntry{
+			ResourceType: volume.ResourceType,
+			Name:         volume.Name,
+			Status:       volume.Status,
+			VolumeSizeGB: volume.VolumeSizeGB,
+			VolumeID:     volume.VolumeID,
+			MonthlyCost:  pricing.MonthlyStorageCost(volume.VolumeSizeGB),
+		}
+		if !volume.StoppedSince.IsZero() {
+			entry.StoppedDays = int(now.Sub(volume.StoppedSince).Hours() / 24)
+			entry.Stale = entry.StoppedDays > stoppedDays
+		}
+		report.TotalMonthlyCost += entry.MonthlyCost
+		report.Volumes = append(report.Volumes, entry)
+	}
+	return report
+}
+
+func runStorage(client sagemaker.Client, now time.Time) error {
+	ctx := context.Background()
+
+	listers := []struct {
+		label string
+		list  func(context.Context) ([]sagemaker.StorageInfo, error)
+	}{
+		{"notebook volumes", client.ListNotebookVolumes},
+		{"space volumes", client.ListSpaceVolumes},
+		{"domain volumes", client.ListDomainVolumes},
+	}
+
+	// Launch goroutines for each API call
+	type storageResult struct {
+		volumes []sagemaker.StorageInfo
+		err     error
+	}
+	results := make([]storageResult, len(listers))
+	var wg sync.WaitGroup
+	wg.Add(len(listers))
+	for i, lister := range listers {
+		go func(i int, list func(context.Context) ([]sagemaker.StorageInfo, error)) {
+			defer wg.Done()
+			volumes, err := list(ctx)
+			results[i] = storageResult{volumes: volumes, err: err}
+		}(i, lister.list)
+	}
+	wg.Wait()
+
+	var firstError error
+	var failed []error
+	var all []sagemaker.StorageInfo
+	for i, result := range results {
+		if result.err != nil {
+			if retryableErr, ok := result.err.(*sagemaker.RetryableError); ok {
+				fmt.Fprintf(os.Stderr, "Retryable error listing %s: %v\n", listers[i].label, retryableErr)
+				failed = append(failed, fmt.Errorf("failed to list %s: %w", listers[i].label, result.err))
+			} else if firstError == nil {
+				firstError = fmt.Errorf("failed to list %s: %w", listers[i].label, result.err)
+			}
+			continue
+		}
+		all = append(all, result.volumes...)
+	}
+	if firstError != nil {
+		return firstError
+	}
+
+	// Volumes of the failed listings are missing from the report and its total
+	var partialErr error
+	if len(failed) > 0 {
+		partialErr = withExitCode(ExitPartial, errors.Join(failed...))
+	}
+
+	report := buildStorageReport(all, now, stoppedDays)
+	printer := display.NewPrinter(jsonOutput)
+
+	if jsonOutput {
+		if err := printer.PrintJSON(report); err != nil {
+			return err
+		}
+		return partialErr
+	}
+
+	if len(report.Volumes) == 0 {
+		printer.PrintNoResources(client.GetRegion())
+		return partialErr
+	}
+
+	rows := make([][]string, 0, len(report.Volumes))
+	highlight := make([]bool, 0, len(report.Volumes))
+	for _, entry := range report.Volumes {
+		size, cost, stopped := "-", "-", "-"
+		if entry.VolumeSizeGB > 0 {
+			size = strconv.Itoa(entry.VolumeSizeGB)
+			cost = fmt.Sprintf("$%.2f", entry.MonthlyCost)
+		}
+		if entry.StoppedDays > 0 {
+			stopped = strconv.Itoa(entry.StoppedDays)
+		}
+		volumeID := entry.VolumeID
+		if volumeID == "" {
+			volumeID = "-"
+		}
+		rows = append(rows, []string{entry.ResourceType, entry.Name, entry.Status, size, volumeID, cost, stopped})
+		highlight = append(highlight, entry.Stale)
+	}
+
+	printer.PrintHighlightedTable([]string{"Type", "Name", "State", "Size (GB)", "Volume", "Monthly Cost", "Stopped (days)"}, rows, highlight)
+	printer.PrintSummary("Estimated monthly storage cost: $%.2f (EFS home volumes not included)", report.TotalMonthlyCost)
+	return partialErr
+}
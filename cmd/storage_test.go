@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"mohua/internal/sagemaker"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBuildStorageReport(t *testing.T) {
+	now := time.Date(2025, 1, 20, 12, 0, 0, 0, time.UTC)
+	volumes := []sagemaker.StorageInfo{
+		{ResourceType: "Notebook", Name: "running", Status: "InService", VolumeSizeGB: 5},
+		{ResourceType: "Notebook", Name: "stale", Status: "Stopped", VolumeSizeGB: 100, StoppedSince: now.Add(-10 * 24 * time.Hour)},
+		{ResourceType: "Space", Name: "recent", Status: "Stopped", VolumeSizeGB: 50, StoppedSince: now.Add(-2 * 24 * time.Hour)},
+		{ResourceType: "Domain", Name: "research", Status: "InService", VolumeID: "fs-0123"},
+	}
+
+	report := buildStorageReport(volumes, now, 7)
+
+	assert.Len(t, report.Volumes, 4)
+	assert.False(t, report.Volumes[0].Stale)
+	assert.True(t, report.Volumes[1].Stale)
+	assert.Equal(t, 10, report.Volumes[1].StoppedDays)
+	assert.False(t, report.Volumes[2].Stale)
+	assert.Equal(t, 2, report.Volumes[2].StoppedDays)
+	assert.Equal(t, "fs-0123", report.Volumes[3].VolumeID)
+	assert.InDelta(t, 0.0, report.Volumes[3].MonthlyCost, 1e-9)
+	assert.InDelta(t, 155*0.14, report.TotalMonthlyCost, 1e-9)
+}
+
+func TestRunStorage(t *testing.T) {
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListNotebookVolumes", mock.Anything).Return([]sagemaker.StorageInfo{
+		{ResourceType: "Notebook", Name: "nb", Status: "Stopped", VolumeSizeGB: 5, StoppedSince: time.Now().Add(-30 * 24 * time.Hour)},
+	}, nil)
+	mockClient.On("ListSpaceVolumes", mock.Anything).Return([]sagemaker.StorageInfo{}, nil)
+	mockClient.On("ListDomainVolumes", mock.Anything).Return([]sagemaker.StorageInfo{}, nil)
+
+	for _, useJSON := range []bool{false, true} {
+		jsonOutput = useJSON
+		assert.NoError(t, runStorage(mockClient, time.Now()))
+	}
+	jsonOutput = false
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestRunStorage_Errors(t *testing.T) {
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListNotebookVolumes", mock.Anything).Return([]sagemaker.StorageInfo{}, nil)
+	mockClient.On("ListSpaceVolumes", mock.Anything).Return(nil, &sagemaker.RetryableError{Err: errors.New("throttled")})
+	mockClient.On("ListDomainVolumes", mock.Anything).Return(nil, errors.New("access denied"))
+
+	err := runStorage(mockClient, time.Now())
+	assert.ErrorContains(t, err, "failed to list domain volumes")
+}
+
+func TestRunStorage_Partial(t *testing.T) {
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListNotebookVolumes", mock.Anything).Return([]sagemaker.StorageInfo{
+		{ResourceType: "Notebook", Name: "dev", Status: "Stopped", VolumeSizeGB: 5},
+	}, nil)
+	mockClient.On("ListSpaceVolumes", mock.Anything).Return(nil, &sagemaker.RetryableError{Err: errors.New("throttled")})
+	mockClient.On("ListDomainVolumes", mock.Anything).Return([]sagemaker.StorageInfo{}, nil)
+
+	// The notebook volume is still reported, but the total is not complete
+	err := runStorage(mockClient, time.Now())
+	assert.ErrorContains(t, err, "failed to list space volumes")
+	assert.Equal(t, ExitPartial, ExitCode(err))
+}
@@ -0,0 +1,309 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"mohua/internal/config"
+	"mohua/internal/display"
+	"mohua/internal/pricing"
+	"mohua/internal/sagemaker"
+	"mohua/internal/tagging"
+	"mohua/internal/trail"
+)
+
+var (
+	requiredTags []string
+	groupByTag   string
+)
+
+// tagsCmd groups the tag related commands
+var tagsCmd = &cobra.Command{
+	Use:   "tags",
+	Short: "Inspect the tags of running resources",
+}
+
+// tagsReportCmd reports missing tags and spend per tag value
+var tagsReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Report resources missing required tags and spend by tag",
+	Long: `List the running resources that are missing any of the required tags and
+group the estimated hourly cost of all resources by the value of one tag key.
+
+Required tags are read from the tags.required setting of the config file
+unless --required is given:
+
+  tags:
+    required: [owner, team, cost-center]
+    cacheTTL: 1h
+
+Resources that cannot be tagged, such as Studio apps and pipeline executions,
+are grouped separately.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		if cmd.Flags().Changed("required") {
+			cfg.Tags.Required = requiredTags
+		}
+
+		client, err := sagemaker.NewClient(region)
+		if err != nil {
+			return fmt.Errorf("failed to create SageMaker client: %w", err)
+		}
+		tagClient, err := newTaggingClient(client, cfg)
+		if err != nil {
+			return err
+		}
+
+		var trailClient trail.Client
+		if lookupCreators {
+			trailClient, err = newTrailClient(client)
+			if err != nil {
+				return err
+			}
+		}
+
+		return runTagsReport(client, tagClient, trailClient, cfg.Tags.Required, groupByTag)
+	},
+}
+
+func init() {
+	tagsReportCmd.Flags().StringSliceVar(&requiredTags, "required", nil, "Required tag keys, overriding the config file (comma separated)")
+	tagsReportCmd.Flags().StringVar(&groupByTag, "group-by", "team", "Tag key to group spend by, e.g. team, project or cost-center")
+	tagsReportCmd.Flags().BoolVar(&lookupCreators, "who", false, "Look up who created the resources missing required tags in CloudTrail")
+	tagsCmd.AddCommand(tagsReportCmd)
+	rootCmd.AddCommand(tagsCmd)
+}
+
+// loadConfig reads the file given by --config, or the default config file if it exists
+func loadConfig() (*config.Config, error) {
+	return config.Load(configFile)
+}
+
+// newTaggingClient creates a tagging client for the region of client that
+// caches tags on disk for the configured TTL
+func newTaggingClient(client sagemaker.Client, cfg *config.Config) (tagging.Client, error) {
+	tagClient, err := tagging.NewClient(region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tagging client: %w", err)
+	}
+	if cfg.Tags.CacheTTL <= 0 {
+		return tagClient, nil
+	}
+	path, err := tagging.DefaultCachePath(client.GetRegion())
+	if err != nil {
+		// Without a cache directory every run looks tags up again
+		return tagClient, nil
+	}
+	return tagging.NewCachedClient(tagClient, path, cfg.Tags.CacheTTL), nil
+}
+
+// attachTags looks up the tags of every resource that has an ARN in a single
+// batched lookup. Resources without an ARN keep nil tags.
+func attachTags(ctx context.Context, tagClient tagging.Client, resourceLists ...[]sagemaker.ResourceInfo) error {
+	var arns []string
+	for _, resources := range resourceLists {
+		for _, resource := range resources {
+			if resource.Arn != "" {
+				arns = append(arns, resource.Arn)
+			}
+		}
+	}
+	if len(arns) == 0 {
+		return nil
+	}
+
+	tags, err := tagClient.ResourceTags(ctx, arns)
+	if err != nil {
+		return fmt.Errorf("failed to list tags: %w", err)
+	}
+	for _, resources := range resourceLists {
+		for i := range resources {
+			if resources[i].Arn != "" {
+				resources[i].Tags = tags[resources[i].Arn]
+			}
+		}
+	}
+	return nil
+}
+
+// missingTagsEntry is a resource that lacks some of the required tags
+type missingTagsEntry struct {
+	ResourceType string   `json:"resourceType"`
+	Name         string   `json:"name"`
+	Arn          string   `json:"arn"`
+	Missing      []string `json:"missing"`
+	HourlyCost   float64  `json:"hourlyCost"`
+	CreatedBy    string   `json:"createdBy,omitempty"`
+}
+
+// tagSpend is the estimated cost of the resources sharing one tag value
+type tagSpend struct {
+	Value       string  `json:"value"`
+	Resources   int     `json:"resources"`
+	HourlyCost  float64 `json:"hourlyCost"`
+	MonthlyCost float64 `json:"monthlyCost"`
+}
+
+// tagsReport is the JSON representation of the tag report
+type tagsReport struct {
+	RequiredTags []string           `json:"requiredTags"`
+	MissingTags  []missingTagsEntry `json:"missingTags"`
+	GroupBy      string             `json:"groupBy"`
+	Spend        []tagSpend         `json:"spend"`
+}
+
+const (
+	untaggedValue   = "(untagged)"
+	untaggableValue = "(not taggable)"
+)
+
+// buildTagsReport finds missing required tags and groups the estimated cost by the groupBy tag
+func buildTagsReport(resources []sagemaker.ResourceInfo, required []string, groupBy string) tagsReport {
+	report := tagsReport{
+		RequiredTags: required,
+		MissingTags:  []missingTagsEntry{},
+		GroupBy:      groupBy,
+		Spend:        []tagSpend{},
+	}
+
+	spendByValue := make(map[string]*tagSpend)
+	for _, resource := range resources {
+		cost := resource.EstimatedHourlyCost()
+
+		value := untaggableValue
+		if resource.Tags != nil {
+			value = resource.Tags[groupBy]
+			if value == "" {
+				value = untaggedValue
+			}
+
+			if missing := missingTags(resource, required); len(missing) > 0 {
+				report.MissingTags = append(report.MissingTags, missingTagsEntry{
+					ResourceType: resource.ResourceType,
+					Name:         resource.Name,
+					Arn:          resource.Arn,
+					Missing:      missing,
+					HourlyCost:   cost,
+					CreatedBy:    resource.CreatedBy,
+				})
+			}
+		}
+
+		spend, ok := spendByValue[value]
+		if !ok {
+			spend = &tagSpend{Value: value}
+			spendByValue[value] = spend
+		}
+		spend.Resources++
+		spend.HourlyCost += cost
+	}
+
+	for _, spend := range spendByValue {
+		spend.MonthlyCost = spend.HourlyCost * pricing.HoursPerMonth
+		report.Spend = append(report.Spend, *spend)
+	}
+	sort.Slice(report.Spend, func(i, j int) bool {
+		if report.Spend[i].HourlyCost != report.Spend[j].HourlyCost {
+			return report.Spend[i].HourlyCost > report.Spend[j].HourlyCost
+		}
+		return report.Spend[i].Value < report.Spend[j].Value
+	})
+	return report
+}
+
+// missingTags returns the required tag keys a taggable resource has no value for
+func missingTags(resource sagemaker.ResourceInfo, required []string) []string {
+	if resource.Tags == nil {
+		return nil
+	}
+	var missing []string
+	for _, key := range required {
+		if resource.Tags[key] == "" {
+			missing = append(missing, key)
+		}
+	}
+	return missing
+}
+
+func runTagsReport(client sagemaker.Client, tagClient tagging.Client, trailClient trail.Client, required []string, groupBy string) error {
+	ctx := context.Background()
+
+	collectors := collectors(client)
+	results := collectResources(ctx, client, collectors)
+	resources, err := mergeResults(collectors, results)
+	if err != nil {
+		return err
+	}
+	if err := attachTags(ctx, tagClient, resources); err != nil {
+		return err
+	}
+
+	if trailClient != nil {
+		// Only the resources without an owner need their creator looked up
+		var unowned []sagemaker.ResourceInfo
+		var indexes []int
+		for i, resource := range resources {
+			if len(missingTags(resource, required)) > 0 {
+				unowned = append(unowned, resource)
+				indexes = append(indexes, i)
+			}
+		}
+		if err := attachCreators(ctx, trailClient, unowned); err != nil {
+			return err
+		}
+		for j, i := range indexes {
+			resources[i].CreatedBy = unowned[j].CreatedBy
+		}
+	}
+
+	report := buildTagsReport(resources, required, groupBy)
+
+	printer := display.NewPrinter(jsonOutput)
+	if jsonOutput {
+		if err := printer.PrintJSON(report); err != nil {
+			return err
+		}
+		return partialError(collectors, results)
+	}
+
+	if len(report.MissingTags) == 0 {
+		printer.PrintSummary("All taggable resources have the required tags (%s)", strings.Join(required, ", "))
+	} else {
+		headers := []string{"Type", "Name", "Missing Tags", "Hourly Cost"}
+		if trailClient != nil {
+			headers = append(headers, "Created By")
+		}
+		rows := make([][]string, 0, len(report.MissingTags))
+		for _, entry := range report.MissingTags {
+			row := []string{entry.ResourceType, entry.Name, strings.Join(entry.Missing, ","), formatHourlyCost(entry.HourlyCost)}
+			if trailClient != nil {
+				row = append(row, entry.CreatedBy)
+			}
+			rows = append(rows, row)
+		}
+		printer.PrintTable(headers, rows)
+		printer.PrintSummary("%d resources are missing required tags (%s)", len(report.MissingTags), strings.Join(required, ", "))
+	}
+	printer.PrintSummary("")
+
+	rows := make([][]string, 0, len(report.Spend))
+	var total float64
+	for _, spend := range report.Spend {
+		rows = append(rows, []string{
+			spend.Value,
+			fmt.Sprintf("%d", spend.Resources),
+			fmt.Sprintf("$%.2f", spend.HourlyCost),
+			fmt.Sprintf("$%.2f", spend.MonthlyCost),
+		})
+		total += spend.HourlyCost
+	}
+	printer.PrintTable([]string{groupBy, "Resources", "Hourly Cost", "Monthly Cost"}, rows)
+	printer.PrintSummary("Total estimated cost: $%.2f/h ($%.2f/month)", total, total*pricing.HoursPerMonth)
+	return partialError(collectors, results)
+}
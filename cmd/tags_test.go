@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"mohua/internal/sagemaker"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBuildTagsReport(t *testing.T) {
+	resources := []sagemaker.ResourceInfo{
+		{ResourceType: "Endpoint", Name: "churn", Arn: "arn:churn", InstanceType: "ml.m5.xlarge", InstanceCount: 2,
+			Tags: map[string]string{"owner": "alice", "team": "search"}},
+		{ResourceType: "Endpoint", Name: "ranker", Arn: "arn:ranker", InstanceType: "ml.m5.xlarge",
+			Tags: map[string]string{"team": "search"}},
+		{ResourceType: "Notebook", Name: "scratch", Arn: "arn:scratch", InstanceType: "ml.t3.medium",
+			Tags: map[string]string{}},
+		{ResourceType: "Studio", Name: "default", InstanceType: "ml.t3.medium"},
+	}
+
+	report := buildTagsReport(resources, []string{"owner", "team"}, "team")
+
+	assert.Equal(t, []missingTagsEntry{
+		{ResourceType: "Endpoint", Name: "ranker", Arn: "arn:ranker", Missing: []string{"owner"}, HourlyCost: 0.23},
+		{ResourceType: "Notebook", Name: "scratch", Arn: "arn:scratch", Missing: []string{"owner", "team"}, HourlyCost: 0.05},
+	}, report.MissingTags)
+
+	if assert.Len(t, report.Spend, 3) {
+		assert.Equal(t, "search", report.Spend[0].Value)
+		assert.Equal(t, 2, report.Spend[0].Resources)
+		assert.InDelta(t, 0.69, report.Spend[0].HourlyCost, 1e-9)
+		assert.InDelta(t, 0.69*730, report.Spend[0].MonthlyCost, 1e-6)
+		assert.Equal(t, untaggableValue, report.Spend[1].Value)
+		assert.Equal(t, untaggedValue, report.Spend[2].Value)
+	}
+}
+
+func TestRunTagsReport(t *testing.T) {
+	resetCommand()
+	jsonOutput = true
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListEndpoints", mock.Anything).Return([]sagemaker.ResourceInfo{
+		{Name: "churn", Arn: "arn:churn", InstanceType: "ml.m5.xlarge"},
+	}, nil)
+	mockClient.On("ListNotebooks", mock.Anything).Return([]sagemaker.ResourceInfo{
+		{Name: "scratch", Arn: "arn:scratch", InstanceType: "ml.t3.medium"},
+	}, nil)
+	expectEmptyCollectors(mockClient)
+
+	tagClient := new(MockTaggingClient)
+	tagClient.On("ResourceTags", mock.Anything, []string{"arn:churn", "arn:scratch"}).
+		Return(map[string]map[string]string{"arn:churn": {"owner": "alice"}, "arn:scratch": {}}, nil)
+
+	assert.NoError(t, runTagsReport(mockClient, tagClient, nil, []string{"owner"}, "team"))
+	tagClient.AssertExpectations(t)
+
+	tagClient = new(MockTaggingClient)
+	tagClient.On("ResourceTags", mock.Anything, mock.Anything).Return(nil, errors.New("access denied"))
+	err := runTagsReport(mockClient, tagClient, nil, []string{"owner"}, "team")
+	assert.EqualError(t, err, "failed to list tags: access denied")
+}
+
+func TestRunTagsReport_ThrottledCollector(t *testing.T) {
+	resetCommand()
+	jsonOutput = true
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListEndpoints", mock.Anything).Return(nil, &sagemaker.RetryableError{Err: errors.New("throttled")})
+	mockClient.On("ListNotebooks", mock.Anything).Return([]sagemaker.ResourceInfo{
+		{Name: "scratch", Arn: "arn:scratch", InstanceType: "ml.t3.medium"},
+	}, nil)
+	expectEmptyCollectors(mockClient)
+
+	tagClient := new(MockTaggingClient)
+	tagClient.On("ResourceTags", mock.Anything, []string{"arn:scratch"}).
+		Return(map[string]map[string]string{"arn:scratch": {"owner": "alice"}}, nil)
+
+	err := runTagsReport(mockClient, tagClient, nil, []string{"owner"}, "team")
+
+	assert.ErrorContains(t, err, "failed to list endpoints: throttled")
+	assert.Equal(t, ExitPartial, ExitCode(err))
+}
+
+func TestRunMonitor_ShowTags(t *testing.T) {
+	resetCommand()
+	showTags = []string{"owner"}
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ValidateConfiguration", mock.Anything).Return(true, nil)
+	mockClient.On("ListEndpoints", mock.Anything).Return([]sagemaker.ResourceInfo{
+		{Name: "churn", Arn: "arn:churn", Status: "InService", InstanceType: "ml.m5.xlarge"},
+	}, nil)
+	mockClient.On("ListStudioApps", mock.Anything).Return([]sagemaker.ResourceInfo{
+		{Name: "default", Status: "InService", UserProfile: "alice", AppType: "JupyterLab"},
+	}, nil)
+	expectEmptyCollectors(mockClient)
+
+	// Only resources with an ARN are looked up
+	tagClient := new(MockTaggingClient)
+	tagClient.On("ResourceTags", mock.Anything, []string{"arn:churn"}).
+		Return(map[string]map[string]string{"arn:churn": {"owner": "alice"}}, nil)
+
+	assert.NoError(t, runMonitor(mockClient, tagClient, nil, nil, nil, nil, nil))
+	tagClient.AssertExpectations(t)
+}
+
+func TestRunTagsReport_Who(t *testing.T) {
+	resetCommand()
+	created := time.Now().Add(-24 * time.Hour)
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListEndpoints", mock.Anything).Return([]sagemaker.ResourceInfo{
+		{Name: "churn", Arn: "arn:churn", CreationTime: created},
+		{Name: "ranker", Arn: "arn:ranker", CreationTime: created},
+	}, nil)
+	expectEmptyCollectors(mockClient)
+
+	tagClient := new(MockTaggingClient)
+	tagClient.On("ResourceTags", mock.Anything, mock.Anything).
+		Return(map[string]map[string]string{"arn:churn": {"owner": "alice"}, "arn:ranker": {}}, nil)
+
+	// Only the resource without an owner tag is looked up
+	trailClient := new(MockTrailClient)
+	trailClient.On("CreatedBy", mock.Anything, mock.MatchedBy(func(resources []sagemaker.ResourceInfo) bool {
+		return len(resources) == 1 && resources[0].Name == "ranker"
+	})).Return([]string{"bob (SSO)"}, nil)
+
+	assert.NoError(t, runTagsReport(mockClient, tagClient, trailClient, []string{"owner"}, "team"))
+	trailClient.AssertExpectations(t)
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"mohua/internal/config"
+	"mohua/internal/sagemaker"
+	"mohua/internal/telemetry"
+)
+
+var otlpEndpoint string
+
+const (
+	tracerName = "mohua/cmd"
+	// exportTimeout bounds the export after a scan, including the retries of
+	// an unreachable receiver
+	exportTimeout = 10 * time.Second
+)
+
+// addTelemetryFlags adds the flags of the commands that export scans
+func addTelemetryFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&otlpEndpoint, "otlp-endpoint", "", "OTLP/HTTP receiver to push scan traces and inventory metrics to, e.g. http://localhost:4318")
+}
+
+// newExporter creates the OTLP exporter of --otlp-endpoint, the config file
+// or OTEL_EXPORTER_OTLP_ENDPOINT, or returns nil when none is set
+func newExporter(cfg *config.Config) (*telemetry.Exporter, error) {
+	endpoint := otlpEndpoint
+	if endpoint == "" {
+		endpoint = cfg.Telemetry.Endpoint
+	}
+	if endpoint == "" {
+		endpoint = os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	}
+	if endpoint == "" {
+		return nil, nil
+	}
+	return telemetry.New(context.Background(), endpoint, cfg.Telemetry.Headers)
+}
+
+// shutdownExporter pushes what remains to be exported and stops the exporter
+func shutdownExporter(exporter *telemetry.Exporter) {
+	if exporter == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+	defer cancel()
+	if err := exporter.Shutdown(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to export telemetry: %v\n", err)
+	}
+}
+
+// startScan starts the span that the collector and AWS API call spans of a
+// scan of the comma separated regions are recorded under
+func startScan(ctx context.Context, regions string) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, "scan", trace.WithAttributes(attribute.String("cloud.region", regions)))
+}
+
+// exportScan ends the span of a scan and pushes it with the gauges of the
+// resources it collected. Export errors are logged and do not fail the scan.
+func exportScan(ctx context.Context, exporter *telemetry.Exporter, span trace.Span, scans []regionScan, duration time.Duration) {
+	span.End()
+	if exporter == nil {
+		return
+	}
+	inv := telemetry.Inventory{FailedCollectors: make(map[string]int), Duration: duration}
+	for _, scan := range scans {
+		inv.Regions = append(inv.Regions, scan.region)
+		for i := range scan.collectors {
+			if scan.results[i].Error != nil {
+				inv.FailedCollectors[scan.region]++
+				continue
+			}
+			inv.Resources = append(inv.Resources, scan.results[i].Resources...)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), exportTimeout)
+	defer cancel()
+	if err := exporter.Record(ctx, inv); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to export telemetry: %v\n", err)
+	}
+}
+
+// traceCollector runs a collector in a span of the scan
+func traceCollector(ctx context.Context, c collector, region string) ([]sagemaker.ResourceInfo, error) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "collect "+c.label, trace.WithAttributes(
+		attribute.String("resource.type", c.resourceType),
+		attribute.String("cloud.region", region),
+	))
+	defer span.End()
+	resources, err := c.list(ctx)
+	span.SetAttributes(attribute.Int("mohua.resources", len(resources)))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return resources, err
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"mohua/internal/sagemaker"
+	"mohua/internal/telemetry"
+	"mohua/internal/telemetry/otlptest"
+)
+
+func TestExecute_OTLPExport(t *testing.T) {
+	receiver := otlptest.NewReceiver(t)
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ValidateConfiguration", mock.Anything).Return(true, nil)
+	mockClient.On("ListEndpoints", mock.Anything).Return([]sagemaker.ResourceInfo{
+		{Name: "search", InstanceType: "ml.g5.xlarge", InstanceCount: 2, Status: "InService", CreationTime: time.Now()},
+	}, nil)
+	mockClient.On("ListNotebooks", mock.Anything).Return(nil, errors.New("access denied"))
+	expectEmptyCollectors(mockClient)
+
+	err := mockExecute(t, []string{"--otlp-endpoint", receiver.URL}, mockClient)
+	assert.EqualError(t, err, "failed to list notebooks: access denied")
+
+	scans := receiver.SpansNamed("scan")
+	require.Len(t, scans, 1)
+	assert.Equal(t, "us-east-1", otlptest.Attribute(scans[0].Attributes, "cloud.region"))
+	endpoints := receiver.SpansNamed("collect endpoints")
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, scans[0].SpanId, endpoints[0].ParentSpanId)
+	assert.Equal(t, "1", otlptest.Attribute(endpoints[0].Attributes, "mohua.resources"))
+	notebooks := receiver.SpansNamed("collect notebooks")
+	require.Len(t, notebooks, 1)
+	assert.Equal(t, tracepb.Status_STATUS_CODE_ERROR, notebooks[0].Status.Code)
+	assert.Len(t, receiver.SpansNamed("collect pipeline executions"), 1)
+
+	resources, ok := receiver.Gauge("mohua.resources", map[string]string{"cloud.region": "us-east-1", "resource.type": "Endpoint"})
+	assert.True(t, ok)
+	assert.Equal(t, 1.0, resources)
+	instances, _ := receiver.Gauge("mohua.instances", map[string]string{"cloud.region": "us-east-1", "resource.type": "Endpoint", "instance.type": "ml.g5.xlarge"})
+	assert.Equal(t, 2.0, instances)
+	failed, _ := receiver.Gauge("mohua.collectors.failed", map[string]string{"cloud.region": "us-east-1"})
+	assert.Equal(t, 1.0, failed)
+}
+
+func TestDaemonScan_OTLPExport(t *testing.T) {
+	resetCommand()
+	receiver := otlptest.NewReceiver(t)
+	exporter, err := telemetry.New(context.Background(), receiver.URL, nil)
+	require.NoError(t, err)
+	defer exporter.Shutdown(context.Background())
+
+	east, west := new(MockSageMakerClient), new(MockSageMakerClient)
+	east.On("GetRegion").Return("us-east-1")
+	east.On("ListNotebooks", mock.Anything).Return([]sagemaker.ResourceInfo{{Name: "nb", InstanceType: "ml.t3.medium", Status: "InService"}}, nil)
+	expectEmptyCollectors(east)
+	west.On("GetRegion").Return("us-west-2")
+	west.On("ListNotebooks", mock.Anything).Return([]sagemaker.ResourceInfo{
+		{Name: "a", InstanceType: "ml.m5.large", Status: "InService"},
+		{Name: "b", InstanceType: "ml.m5.large", Status: "InService"},
+	}, nil)
+	expectEmptyCollectors(west)
+
+	d := &daemon{targets: []scanTarget{{client: east}, {client: west}}, exporter: exporter, now: time.Now}
+	d.scan(context.Background())
+
+	// The gauges and the scan span are pushed after the scan
+	cost, ok := receiver.Gauge("mohua.cost.hourly", map[string]string{"cloud.region": "us-west-2", "resource.type": "Notebook"})
+	assert.True(t, ok)
+	assert.InDelta(t, 0.23, cost, 1e-9)
+	notebooks, _ := receiver.Gauge("mohua.resources", map[string]string{"cloud.region": "us-east-1", "resource.type": "Notebook"})
+	assert.Equal(t, 1.0, notebooks)
+	scans := receiver.SpansNamed("scan")
+	require.Len(t, scans, 1)
+	assert.Equal(t, "us-east-1,us-west-2", otlptest.Attribute(scans[0].Attributes, "cloud.region"))
+	assert.Len(t, receiver.SpansNamed("collect notebooks"), 2)
+}
@@ -2,8 +2,13 @@ package cmd
 
 import (
 	"context"
-	"mohua/internal/sagemaker"
 	"github.com/stretchr/testify/mock"
+	"mohua/internal/autoscaling"
+	"mohua/internal/digest"
+	"mohua/internal/metrics"
+	"mohua/internal/quotas"
+	"mohua/internal/sagemaker"
+	"time"
 )
 
 // MockSageMakerClient is a mock implementation of the sagemaker.Client interface
@@ -40,7 +45,295 @@ func (m *MockSageMakerClient) ListStudioApps(ctx context.Context) ([]sagemaker.R
 	return args.Get(0).([]sagemaker.ResourceInfo), args.Error(1)
 }
 
+func (m *MockSageMakerClient) ListTuningJobs(ctx context.Context) ([]sagemaker.ResourceInfo, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]sagemaker.ResourceInfo), args.Error(1)
+}
+
+func (m *MockSageMakerClient) ListPipelineExecutions(ctx context.Context, stuckAfter time.Duration) ([]sagemaker.ResourceInfo, error) {
+	args := m.Called(ctx, stuckAfter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]sagemaker.ResourceInfo), args.Error(1)
+}
+
+func (m *MockSageMakerClient) ListAutoMLJobs(ctx context.Context) ([]sagemaker.ResourceInfo, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]sagemaker.ResourceInfo), args.Error(1)
+}
+
+func (m *MockSageMakerClient) ListCompilationJobs(ctx context.Context) ([]sagemaker.ResourceInfo, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]sagemaker.ResourceInfo), args.Error(1)
+}
+
+func (m *MockSageMakerClient) ListInferenceRecommendationsJobs(ctx context.Context) ([]sagemaker.ResourceInfo, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]sagemaker.ResourceInfo), args.Error(1)
+}
+
+func (m *MockSageMakerClient) ListLabelingJobs(ctx context.Context) ([]sagemaker.ResourceInfo, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]sagemaker.ResourceInfo), args.Error(1)
+}
+
+func (m *MockSageMakerClient) ListMlflowTrackingServers(ctx context.Context) ([]sagemaker.ResourceInfo, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]sagemaker.ResourceInfo), args.Error(1)
+}
+
+func (m *MockSageMakerClient) ListOnlineFeatureGroups(ctx context.Context) ([]sagemaker.ResourceInfo, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]sagemaker.ResourceInfo), args.Error(1)
+}
+
+func (m *MockSageMakerClient) ListMonitoringSchedules(ctx context.Context) ([]sagemaker.ResourceInfo, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]sagemaker.ResourceInfo), args.Error(1)
+}
+
+func (m *MockSageMakerClient) ListInferenceExperiments(ctx context.Context) ([]sagemaker.ResourceInfo, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]sagemaker.ResourceInfo), args.Error(1)
+}
+
+func (m *MockSageMakerClient) ListTrainingJobs(ctx context.Context) ([]sagemaker.ResourceInfo, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]sagemaker.ResourceInfo), args.Error(1)
+}
+
+func (m *MockSageMakerClient) ListEndpointVariants(ctx context.Context) ([]sagemaker.EndpointVariant, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]sagemaker.EndpointVariant), args.Error(1)
+}
+
+func (m *MockSageMakerClient) ListNotebookVolumes(ctx context.Context) ([]sagemaker.StorageInfo, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]sagemaker.StorageInfo), args.Error(1)
+}
+
+func (m *MockSageMakerClient) ListSpaceVolumes(ctx context.Context) ([]sagemaker.StorageInfo, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]sagemaker.StorageInfo), args.Error(1)
+}
+
+func (m *MockSageMakerClient) ListDomainVolumes(ctx context.Context) ([]sagemaker.StorageInfo, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]sagemaker.StorageInfo), args.Error(1)
+}
+
+func (m *MockSageMakerClient) ListNotebookSecurity(ctx context.Context) ([]sagemaker.SecurityInfo, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]sagemaker.SecurityInfo), args.Error(1)
+}
+
+func (m *MockSageMakerClient) ListEndpointSecurity(ctx context.Context) ([]sagemaker.SecurityInfo, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]sagemaker.SecurityInfo), args.Error(1)
+}
+
+func (m *MockSageMakerClient) ListDomainSecurity(ctx context.Context) ([]sagemaker.SecurityInfo, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]sagemaker.SecurityInfo), args.Error(1)
+}
+
+func (m *MockSageMakerClient) ListUserProfileSecurity(ctx context.Context) ([]sagemaker.SecurityInfo, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]sagemaker.SecurityInfo), args.Error(1)
+}
+
+func (m *MockSageMakerClient) FindOrphans(ctx context.Context, includeModelPackages bool) ([]sagemaker.OrphanInfo, error) {
+	args := m.Called(ctx, includeModelPackages)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]sagemaker.OrphanInfo), args.Error(1)
+}
+
+func (m *MockSageMakerClient) DeleteOrphan(ctx context.Context, orphan sagemaker.OrphanInfo) error {
+	args := m.Called(ctx, orphan)
+	return args.Error(0)
+}
+
+func (m *MockSageMakerClient) ListModelPackageVersions(ctx context.Context, group string) ([]string, error) {
+	args := m.Called(ctx, group)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockSageMakerClient) DescribeResource(ctx context.Context, resource sagemaker.ResourceInfo) (string, error) {
+	args := m.Called(ctx, resource)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockSageMakerClient) StopResource(ctx context.Context, resource sagemaker.ResourceInfo) error {
+	args := m.Called(ctx, resource)
+	return args.Error(0)
+}
+
+func (m *MockSageMakerClient) DeleteResource(ctx context.Context, resource sagemaker.ResourceInfo) error {
+	args := m.Called(ctx, resource)
+	return args.Error(0)
+}
+
 func (m *MockSageMakerClient) GetRegion() string {
 	args := m.Called()
 	return args.String(0)
 }
+
+// MockAutoScalingClient is a mock implementation of the autoscaling.Client interface
+type MockAutoScalingClient struct {
+	mock.Mock
+}
+
+func (m *MockAutoScalingClient) ListVariantScaling(ctx context.Context) (map[string]autoscaling.VariantScaling, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]autoscaling.VariantScaling), args.Error(1)
+}
+
+// MockMetricsClient is a mock implementation of the metrics.Client interface
+type MockMetricsClient struct {
+	mock.Mock
+}
+
+func (m *MockMetricsClient) EndpointInvocations(ctx context.Context, endpointName string, window time.Duration) (float64, error) {
+	args := m.Called(ctx, endpointName, window)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+func (m *MockMetricsClient) Utilization(ctx context.Context, source metrics.Source, window time.Duration) (metrics.Utilization, error) {
+	args := m.Called(ctx, source, window)
+	return args.Get(0).(metrics.Utilization), args.Error(1)
+}
+
+func (m *MockMetricsClient) PutMetrics(ctx context.Context, namespace string, timestamp time.Time, data []metrics.Datum) error {
+	args := m.Called(ctx, namespace, timestamp, data)
+	return args.Error(0)
+}
+
+func (m *MockMetricsClient) RecentDimensionValues(ctx context.Context, namespace string, metricName string, dimension string, filter map[string]string) ([]string, error) {
+	args := m.Called(ctx, namespace, metricName, dimension, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// MockMailer is a mock implementation of the digest.Mailer interface
+type MockMailer struct {
+	mock.Mock
+}
+
+func (m *MockMailer) Send(ctx context.Context, message digest.Message) error {
+	args := m.Called(ctx, message)
+	return args.Error(0)
+}
+
+// MockTaggingClient is a mock implementation of the tagging.Client interface
+type MockTaggingClient struct {
+	mock.Mock
+}
+
+func (m *MockTaggingClient) ResourceTags(ctx context.Context, arns []string) (map[string]map[string]string, error) {
+	args := m.Called(ctx, arns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]map[string]string), args.Error(1)
+}
+
+// MockTrailClient is a mock implementation of the trail.Client interface
+type MockTrailClient struct {
+	mock.Mock
+}
+
+func (m *MockTrailClient) CreatedBy(ctx context.Context, resources []sagemaker.ResourceInfo) ([]string, error) {
+	args := m.Called(ctx, resources)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// MockQuotasClient is a mock implementation of the quotas.Client interface
+type MockQuotasClient struct {
+	mock.Mock
+}
+
+func (m *MockQuotasClient) SageMakerQuotas(ctx context.Context) ([]quotas.Quota, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]quotas.Quota), args.Error(1)
+}
+
+// MockIdentityClient is a mock implementation of the identity.Client interface
+type MockIdentityClient struct {
+	mock.Mock
+}
+
+func (m *MockIdentityClient) AccountID(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/spf13/cobra"
+	"mohua/internal/metrics"
+	"mohua/internal/sagemaker"
+	"mohua/internal/tagging"
+	"mohua/internal/tui"
+)
+
+var tuiRefresh time.Duration
+
+// tuiMetricsWindow is the window of the metrics pane
+const tuiMetricsWindow = 24 * time.Hour
+
+// tuiCmd browses the running resources in the terminal
+var tuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Browse and act on running resources in an interactive terminal UI",
+	Long: `Browse the resources that the resource listing collects in an interactive
+terminal UI. The list refreshes in the background and the status bar shows
+the collectors that failed.
+
+Keys:
+  j/k, arrows    select a resource, or scroll the detail pane
+  /              filter as you type by type, name, status, instance type or tag
+  enter          show the details: describe output, tags, metrics and cost
+  tab, 1-4       switch the detail pane
+  esc            back to the list, or clear the filter
+  s              stop the selected resource
+  ctrl-d         delete the selected resource
+  r              refresh now
+  q, ctrl-c      quit
+
+Stop and delete ask for confirmation. Stopping a Studio app deletes the app
+and keeps its user profile or space. Notebook instances must be stopped
+before they can be deleted.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if tuiRefresh < time.Second {
+			return fmt.Errorf("--refresh must be at least 1s")
+		}
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		client, err := sagemaker.NewClient(region)
+		if err != nil {
+			return fmt.Errorf("failed to create SageMaker client: %w", err)
+		}
+		tagClient, err := newTaggingClient(client, cfg)
+		if err != nil {
+			return err
+		}
+		metricsClient, err := metrics.NewClient(region)
+		if err != nil {
+			return fmt.Errorf("failed to create CloudWatch client: %w", err)
+		}
+		screen, err := tcell.NewScreen()
+		if err != nil {
+			return fmt.Errorf("failed to open terminal: %w", err)
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		return tui.New(screen, tuiConfig(client, tagClient, metricsClient, tuiRefresh)).Run(ctx)
+	},
+}
+
+func init() {
+	tuiCmd.Flags().DurationVar(&tuiRefresh, "refresh", 30*time.Second, "How often to refresh the list in the background")
+	rootCmd.AddCommand(tuiCmd)
+}
+
+// tuiConfig connects the terminal UI to the collectors and clients
+func tuiConfig(client sagemaker.Client, tagClient tagging.Client, metricsClient metrics.Client, interval time.Duration) tui.Config {
+	return tui.Config{
+		Region:   client.GetRegion(),
+		Interval: interval,
+		Load: func(ctx context.Context) tui.Inventory {
+			return loadInventory(ctx, client, tagClient)
+		},
+		Describe: func(ctx context.Context, item tui.Item) (string, error) {
+			return client.DescribeResource(ctx, item.Resource)
+		},
+		Tags: func(ctx context.Context, item tui.Item) (map[string]string, error) {
+			if item.Resource.Arn == "" {
+				return nil, fmt.Errorf("%s resources cannot be tagged", item.Resource.ResourceType)
+			}
+			tags, err := tagClient.ResourceTags(ctx, []string{item.Resource.Arn})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list tags: %w", err)
+			}
+			return tags[item.Resource.Arn], nil
+		},
+		Metrics: func(ctx context.Context, item tui.Item) (string, error) {
+			return resourceMetrics(ctx, metricsClient, item.Resource)
+		},
+		Stop: func(ctx context.Context, item tui.Item) error {
+			return client.StopResource(ctx, item.Resource)
+		},
+		Delete: func(ctx context.Context, item tui.Item) error {
+			return client.DeleteResource(ctx, item.Resource)
+		},
+	}
+}
+
+// loadInventory runs the collectors and looks up the tags the filter matches
+func loadInventory(ctx context.Context, client sagemaker.Client, tagClient tagging.Client) tui.Inventory {
+	var inventory tui.Inventory
+	collectors := collectors(client)
+	results := collectResources(ctx, client, collectors)
+	lists := make([][]sagemaker.ResourceInfo, len(results))
+	for i, result := range results {
+		lists[i] = result.Resources
+	}
+	if err := attachTags(ctx, tagClient, lists...); err != nil {
+		inventory.Errors = append(inventory.Errors, tui.CollectorError{Collector: "tags", Err: err})
+	}
+
+	for i, c := range collectors {
+		if err := results[i].Error; err != nil {
+			inventory.Errors = append(inventory.Errors, tui.CollectorError{Collector: c.label, Err: err})
+			continue
+		}
+		for _, resource := range results[i].Resources {
+			name := resource.Name
+			if c.displayName != nil {
+				name = c.displayName(resource)
+			}
+			inventory.Items = append(inventory.Items, tui.Item{Resource: resource, Name: name})
+		}
+	}
+	return inventory
+}
+
+// resourceMetrics is the content of the metrics pane: the invocations of
+// endpoints and the utilization of notebooks and Studio apps
+func resourceMetrics(ctx context.Context, metricsClient metrics.Client, resource sagemaker.ResourceInfo) (string, error) {
+	lines := []string{fmt.Sprintf("Window         last %.0fh", tuiMetricsWindow.Hours())}
+	switch resource.ResourceType {
+	case "Endpoint":
+		invocations, err := metricsClient.EndpointInvocations(ctx, resource.Name, tuiMetricsWindow)
+		if err != nil {
+			return "", err
+		}
+		lines = append(lines, fmt.Sprintf("Invocations    %.0f", invocations))
+	case "Notebook", "Studio":
+		utilization, err := metricsClient.Utilization(ctx, utilizationSource(resource), tuiMetricsWindow)
+		if err != nil {
+			return "", err
+		}
+		lines = append(lines,
+			"Utilization    p50/p95",
+			"CPU            "+formatPercentiles(utilization.CPU),
+			"Memory         "+formatPercentiles(utilization.Memory),
+			"GPU            "+formatPercentiles(utilization.GPU),
+		)
+	default:
+		return "", errors.New("no metrics are collected for " + resource.ResourceType + " resources")
+	}
+	return strings.Join(lines, "\n"), nil
+}
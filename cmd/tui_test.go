@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"mohua/internal/metrics"
+	"mohua/internal/sagemaker"
+	"mohua/internal/tui"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTUIConfig(t *testing.T) {
+	resetCommand()
+	ctx := context.Background()
+	now := time.Now()
+	endpointArn := "arn:aws:sagemaker:us-east-1:123456789012:endpoint/search"
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListEndpoints", mock.Anything).Return([]sagemaker.ResourceInfo{
+		{Name: "search", Arn: endpointArn, InstanceType: "ml.g5.xlarge", Status: "InService", CreationTime: now},
+	}, nil)
+	mockClient.On("ListStudioApps", mock.Anything).Return([]sagemaker.ResourceInfo{
+		{Name: "default", UserProfile: "alice", AppType: "JupyterLab", Status: "InService", CreationTime: now},
+	}, nil)
+	mockClient.On("ListTuningJobs", mock.Anything).Return(nil, errors.New("throttled"))
+	expectEmptyCollectors(mockClient)
+	mockTagClient := new(MockTaggingClient)
+	mockTagClient.On("ResourceTags", mock.Anything, []string{endpointArn}).
+		Return(map[string]map[string]string{endpointArn: {"team": "search"}}, nil)
+	mockMetricsClient := new(MockMetricsClient)
+	mockMetricsClient.On("EndpointInvocations", mock.Anything, "search", 24*time.Hour).Return(1234.0, nil)
+	mockMetricsClient.On("Utilization", mock.Anything, metrics.Source{
+		Namespace:  studioNamespace,
+		Dimensions: map[string]string{"AppName": "default", "UserProfileName": "alice"},
+	}, 24*time.Hour).Return(metrics.Utilization{CPU: &metrics.Percentiles{P50: 12, P95: 40.5, Samples: 288}}, nil)
+
+	cfg := tuiConfig(mockClient, mockTagClient, mockMetricsClient, time.Minute)
+	assert.Equal(t, "us-east-1", cfg.Region)
+
+	inventory := cfg.Load(ctx)
+	require.Len(t, inventory.Items, 2)
+	endpoint, app := inventory.Items[0], inventory.Items[1]
+	assert.Equal(t, "Endpoint", endpoint.Resource.ResourceType)
+	assert.Equal(t, "us-east-1", endpoint.Resource.Region)
+	assert.Equal(t, map[string]string{"team": "search"}, endpoint.Resource.Tags)
+	assert.Equal(t, "alice/JupyterLab", app.Name)
+	if assert.Len(t, inventory.Errors, 1) {
+		assert.Equal(t, "tuning jobs", inventory.Errors[0].Collector)
+	}
+
+	tags, err := cfg.Tags(ctx, endpoint)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "search"}, tags)
+	_, err = cfg.Tags(ctx, app)
+	assert.EqualError(t, err, "Studio resources cannot be tagged")
+
+	text, err := cfg.Metrics(ctx, endpoint)
+	assert.NoError(t, err)
+	assert.Equal(t, "Window         last 24h\nInvocations    1234", text)
+	text, err = cfg.Metrics(ctx, app)
+	assert.NoError(t, err)
+	assert.Equal(t, "Window         last 24h\nUtilization    p50/p95\nCPU            12%/40%\nMemory         -\nGPU            -", text)
+	_, err = cfg.Metrics(ctx, tui.Item{Resource: sagemaker.ResourceInfo{ResourceType: "Tuning"}})
+	assert.EqualError(t, err, "no metrics are collected for Tuning resources")
+
+	mockClient.On("StopResource", mock.Anything, app.Resource).Return(nil)
+	assert.NoError(t, cfg.Stop(ctx, app))
+	mockClient.On("DeleteResource", mock.Anything, endpoint.Resource).Return(errors.New("access denied"))
+	assert.EqualError(t, cfg.Delete(ctx, endpoint), "access denied")
+	mockClient.AssertExpectations(t)
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"mohua/internal/dashboard"
+	"mohua/internal/schedule"
+)
+
+var (
+	uiListen  string
+	uiRefresh time.Duration
+	uiRegions []string
+)
+
+// uiCmd serves the web dashboard
+var uiCmd = &cobra.Command{
+	Use:   "ui",
+	Short: "Serve a web dashboard of the running resources",
+	Long: `Serve a web dashboard of the resources that the resource listing collects:
+the inventory with sorting and filtering, totals per resource type and region,
+estimated costs, collector errors and the recent changes recorded in the
+snapshot history.
+
+The dashboard scans at startup and every --refresh, and the page reloads after
+each scan. It is embedded in the binary and served together with the JSON API
+of mohua daemon under /api/. Scans are recorded in the history when it is
+enabled in the config file.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if uiRefresh < time.Minute {
+			return fmt.Errorf("--refresh must be at least 1m")
+		}
+		sched, err := schedule.Parse("@every " + uiRefresh.String())
+		if err != nil {
+			return err
+		}
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+
+		d := &daemon{now: time.Now, ui: dashboard.Handler()}
+		regions := uiRegions
+		if len(regions) == 0 {
+			regions = []string{region}
+		}
+		for _, r := range regions {
+			target, err := newScanTarget(r, cfg, false, false)
+			if err != nil {
+				return err
+			}
+			d.targets = append(d.targets, target)
+		}
+		if cfg.History.Enabled {
+			if d.store, err = newHistoryStore(cfg); err != nil {
+				return err
+			}
+		}
+
+		if d.exporter, err = newExporter(cfg); err != nil {
+			return err
+		}
+		defer shutdownExporter(d.exporter)
+
+		listener, err := net.Listen("tcp", uiListen)
+		if err != nil {
+			return fmt.Errorf("failed to listen on %s: %w", uiListen, err)
+		}
+		fmt.Fprintf(os.Stderr, "Serving the dashboard on http://%s/\n", listener.Addr())
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		return runDaemon(ctx, d, sched, listener)
+	},
+}
+
+func init() {
+	uiCmd.Flags().StringVar(&uiListen, "listen", "127.0.0.1:8080", "Address the dashboard listens on")
+	uiCmd.Flags().DurationVar(&uiRefresh, "refresh", 5*time.Minute, "How often to scan and reload the dashboard")
+	uiCmd.Flags().StringSliceVar(&uiRegions, "regions", nil, "Regions to scan, comma separated (default the --region)")
+	addTelemetryFlags(uiCmd)
+	addPublishFlags(uiCmd)
+	rootCmd.AddCommand(uiCmd)
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"mohua/internal/dashboard"
+	"mohua/internal/sagemaker"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUI_Regions(t *testing.T) {
+	resetCommand()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	east := new(MockSageMakerClient)
+	east.On("ListEndpoints", mock.Anything).Return([]sagemaker.ResourceInfo{
+		{Name: "search", InstanceType: "ml.g5.xlarge", InstanceCount: 2, Status: "InService", CreationTime: now.Add(-48 * time.Hour)},
+	}, nil)
+	expectEmptyCollectors(east)
+	west := new(MockSageMakerClient)
+	west.On("GetRegion").Return("eu-west-1")
+	west.On("ListEndpoints", mock.Anything).Return([]sagemaker.ResourceInfo{
+		{Name: "ranker", InstanceType: "ml.m5.large", InstanceCount: 1, Status: "InService", CreationTime: now.Add(-time.Hour)},
+	}, nil)
+	west.On("ListNotebooks", mock.Anything).Return(nil, errors.New("access denied"))
+	expectEmptyCollectors(west)
+
+	d := &daemon{targets: []scanTarget{{client: east}, {client: west}}, ui: dashboard.Handler(), now: func() time.Time { return now }}
+	handler := d.handler()
+	d.scan(context.Background())
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	body, _ := io.ReadAll(recorder.Body)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, string(body), "<title>mohua</title>")
+
+	status, envelope := getEnvelope(t, handler, "/api/summary")
+	assert.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, `{"region": "us-east-1,eu-west-1", "scannedAt": "2026-10-18T12:00:00Z"}`, string(envelope["metadata"]))
+	var summary daemonSummary
+	require.NoError(t, json.Unmarshal(envelope["summary"], &summary))
+	assert.Equal(t, map[string]total{
+		"us-east-1": {Resources: 1, Instances: 2, HourlyCost: 2.816},
+		"eu-west-1": {Resources: 1, Instances: 1, HourlyCost: 0.115},
+	}, summary.ByRegion)
+	assert.Equal(t, map[string]total{"Endpoint": {Resources: 2, Instances: 3, HourlyCost: 2.816 + 0.115}}, summary.ByType)
+
+	status, envelope = getEnvelope(t, handler, "/api/resources?region=eu-west-1")
+	assert.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, `[{"resourceType": "Endpoint", "name": "ranker", "status": "InService", "instanceType": "ml.m5.large", "runningTime": "1h0m0s", "region": "eu-west-1", "estimatedHourlyCost": 0.115}]`, string(envelope["resources"]))
+
+	status, envelope = getEnvelope(t, handler, "/api/errors")
+	assert.Equal(t, http.StatusOK, status)
+	var errs []apiError
+	require.NoError(t, json.Unmarshal(envelope["errors"], &errs))
+	if assert.Len(t, errs, 1) {
+		assert.Equal(t, "eu-west-1", errs[0].Region)
+		assert.Equal(t, "notebooks", errs[0].Source)
+	}
+
+	// Without history the dashboard shows why there are no changes
+	status, envelope = getEnvelope(t, handler, "/api/changes")
+	assert.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, `{"region": "us-east-1,eu-west-1", "message": "History is disabled in the config file"}`, string(envelope["metadata"]))
+}
+
+func TestUI_InvalidRefresh(t *testing.T) {
+	resetCommand()
+	err := mockExecute(t, []string{"ui", "--refresh", "30s"}, new(MockSageMakerClient))
+	assert.EqualError(t, err, "--refresh must be at least 1m")
+}
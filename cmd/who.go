@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"mohua/internal/sagemaker"
+	"mohua/internal/trail"
+)
+
+// lookupCreators is set by --who on the commands that can show who created a resource
+var lookupCreators bool
+
+// newTrailClient creates a CloudTrail client for the region of client that
+// caches the creators it finds on disk
+func newTrailClient(client sagemaker.Client) (trail.Client, error) {
+	trailClient, err := trail.NewClient(region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CloudTrail client: %w", err)
+	}
+	path, err := trail.DefaultCachePath(client.GetRegion())
+	if err != nil {
+		return trailClient, nil
+	}
+	return trail.NewCachedClient(trailClient, path), nil
+}
+
+// attachCreators looks up who created each resource in CloudTrail
+func attachCreators(ctx context.Context, trailClient trail.Client, resourceLists ...[]sagemaker.ResourceInfo) error {
+	var resources []sagemaker.ResourceInfo
+	for _, list := range resourceLists {
+		resources = append(resources, list...)
+	}
+	if len(resources) == 0 {
+		return nil
+	}
+
+	creators, err := trailClient.CreatedBy(ctx, resources)
+	if err != nil {
+		return fmt.Errorf("failed to look up creators: %w", err)
+	}
+	i := 0
+	for _, list := range resourceLists {
+		for j := range list {
+			list[j].CreatedBy = creators[i]
+			i++
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"mohua/internal/sagemaker"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRunMonitor_Who(t *testing.T) {
+	resetCommand()
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ValidateConfiguration", mock.Anything).Return(true, nil)
+	mockClient.On("ListEndpoints", mock.Anything).Return([]sagemaker.ResourceInfo{{Name: "churn"}}, nil)
+	mockClient.On("ListNotebooks", mock.Anything).Return([]sagemaker.ResourceInfo{{Name: "scratch"}}, nil)
+	expectEmptyCollectors(mockClient)
+
+	trailClient := new(MockTrailClient)
+	trailClient.On("CreatedBy", mock.Anything, []sagemaker.ResourceInfo{
+		{ResourceType: "Endpoint", Region: "us-east-1", Name: "churn"},
+		{ResourceType: "Notebook", Region: "us-east-1", Name: "scratch"},
+	}).Return([]string{"alice (SSO)", ""}, nil)
+
+	assert.NoError(t, runMonitor(mockClient, nil, trailClient, nil, nil, nil, nil))
+	trailClient.AssertExpectations(t)
+}
+
+func TestAttachCreators(t *testing.T) {
+	endpoints := []sagemaker.ResourceInfo{{Name: "churn"}, {Name: "ranker"}}
+	notebooks := []sagemaker.ResourceInfo{{Name: "scratch"}}
+
+	trailClient := new(MockTrailClient)
+	trailClient.On("CreatedBy", mock.Anything, mock.Anything).Return([]string{"alice", "", "bob"}, nil)
+
+	assert.NoError(t, attachCreators(context.Background(), trailClient, endpoints, nil, notebooks))
+	assert.Equal(t, "alice", endpoints[0].CreatedBy)
+	assert.Equal(t, "", endpoints[1].CreatedBy)
+	assert.Equal(t, "bob", notebooks[0].CreatedBy)
+}
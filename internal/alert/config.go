@@ -0,0 +1,174 @@
+package alert
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+
+	"gopkg.in/yaml.v3"
+	"mohua/internal/policy"
+)
+
+// Triggers of alert rules
+const (
+	TriggerNew    = "new"    // The resource was not running during the previous run
+	TriggerAge    = "age"    // The resource is older than Age
+	TriggerCost   = "cost"   // The estimated hourly cost exceeds HourlyCost
+	TriggerIdle   = "idle"   // The endpoint had no invocations for IdleFor
+	TriggerFailed = "failed" // The resource has the Failed status
+)
+
+// Sink formats
+const (
+	FormatGeneric = "generic"
+	FormatSlack   = "slack"
+	FormatTeams   = "teams"
+)
+
+// Config is a set of alert rules and the webhooks they notify
+type Config struct {
+	State       string          `yaml:"state"`       // State file, defaults to the user data directory
+	RepeatAfter policy.Duration `yaml:"repeatAfter"` // Alert again while still firing, 0 alerts once
+	Sinks       []SinkConfig    `yaml:"sinks"`
+	Rules       []Rule          `yaml:"rules"`
+}
+
+// SinkConfig is a webhook that receives alerts
+type SinkConfig struct {
+	Name   string `yaml:"name"`
+	Format string `yaml:"format"` // generic (default), slack or teams
+	URL    string `yaml:"url"`
+}
+
+// Rule raises an alert for every selected resource that meets its trigger
+type Rule struct {
+	Name       string          `yaml:"name"`
+	Trigger    string          `yaml:"trigger"`
+	Select     policy.Selector `yaml:"select"`
+	Age        policy.Duration `yaml:"age"`        // For the age trigger
+	HourlyCost float64         `yaml:"hourlyCost"` // For the cost trigger, in USD
+	IdleFor    policy.Duration `yaml:"idleFor"`    // For the idle trigger
+	Sinks      []string        `yaml:"sinks"`      // Defaults to every sink
+}
+
+// Load reads and validates an alert config file
+func Load(filename string) (*Config, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read alert config: %w", err)
+	}
+	cfg, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid alert config %s: %w", filename, err)
+	}
+	return cfg, nil
+}
+
+// Parse decodes and validates an alert config, rejecting unknown fields
+func Parse(data []byte) (*Config, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var cfg Config
+	if err := decoder.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// validate checks the sinks and the trigger settings of every rule
+func (c *Config) validate() error {
+	if len(c.Rules) == 0 {
+		return errors.New("no rules defined")
+	}
+	if len(c.Sinks) == 0 {
+		return errors.New("no sinks defined")
+	}
+
+	sinks := make(map[string]bool, len(c.Sinks))
+	for i, sink := range c.Sinks {
+		if sink.Name == "" {
+			return fmt.Errorf("sink %d has no name", i+1)
+		}
+		if sinks[sink.Name] {
+			return fmt.Errorf("duplicate sink name %q", sink.Name)
+		}
+		sinks[sink.Name] = true
+		switch sink.Format {
+		case "", FormatGeneric, FormatSlack, FormatTeams:
+		default:
+			return fmt.Errorf("sink %q: invalid format %q, expected generic, slack or teams", sink.Name, sink.Format)
+		}
+		if u, err := url.Parse(sink.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("sink %q: invalid URL %q", sink.Name, sink.URL)
+		}
+	}
+
+	rules := make(map[string]bool, len(c.Rules))
+	for i, rule := range c.Rules {
+		if rule.Name == "" {
+			return fmt.Errorf("rule %d has no name", i+1)
+		}
+		if rules[rule.Name] {
+			return fmt.Errorf("duplicate rule name %q", rule.Name)
+		}
+		rules[rule.Name] = true
+		if err := rule.validate(sinks); err != nil {
+			return fmt.Errorf("rule %q: %w", rule.Name, err)
+		}
+	}
+	return nil
+}
+
+// validate checks that the trigger has its setting and the sinks exist
+func (r Rule) validate(sinks map[string]bool) error {
+	switch r.Trigger {
+	case TriggerNew, TriggerFailed:
+	case TriggerAge:
+		if r.Age <= 0 {
+			return errors.New("age trigger needs age")
+		}
+	case TriggerCost:
+		if r.HourlyCost <= 0 {
+			return errors.New("cost trigger needs hourlyCost")
+		}
+	case TriggerIdle:
+		if r.IdleFor <= 0 {
+			return errors.New("idle trigger needs idleFor")
+		}
+	default:
+		return fmt.Errorf("invalid trigger %q, expected new, age, cost, idle or failed", r.Trigger)
+	}
+	for _, sink := range r.Sinks {
+		if !sinks[sink] {
+			return fmt.Errorf("unknown sink %q", sink)
+		}
+	}
+	return r.Select.Validate()
+}
+
+// UsesTags reports whether any rule selects resources by tag
+func (c *Config) UsesTags() bool {
+	for _, rule := range c.Rules {
+		if len(rule.Select.Tags) > 0 || len(rule.Select.MissingTags) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// UsesIdle reports whether any rule has the idle trigger
+func (c *Config) UsesIdle() bool {
+	for _, rule := range c.Rules {
+		if rule.Trigger == TriggerIdle {
+			return true
+		}
+	}
+	return false
+}
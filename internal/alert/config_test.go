@@ -0,0 +1,95 @@
+package alert
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"mohua/internal/policy"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const exampleConfig = `
+repeatAfter: 24h
+sinks:
+  - name: chat
+    format: slack
+    url: https://hooks.slack.com/services/T0/B0/x
+  - name: ops
+    url: https://ops.example.com/hooks/mohua
+rules:
+  - name: new-gpu-endpoint
+    trigger: new
+    select: {types: [Endpoint], instanceTypes: ["ml.g*"]}
+  - name: expensive
+    trigger: cost
+    hourlyCost: 20
+    sinks: [ops]
+  - name: old-notebook
+    trigger: age
+    age: 7d
+    select: {types: [Notebook], tags: {team: "*"}}
+  - name: idle-endpoint
+    trigger: idle
+    idleFor: 24h
+  - name: failed
+    trigger: failed
+`
+
+func TestParse(t *testing.T) {
+	cfg, err := Parse([]byte(exampleConfig))
+
+	assert.NoError(t, err)
+	assert.Equal(t, policy.Duration(24*time.Hour), cfg.RepeatAfter)
+	assert.Len(t, cfg.Sinks, 2)
+	if assert.Len(t, cfg.Rules, 5) {
+		assert.Equal(t, TriggerNew, cfg.Rules[0].Trigger)
+		assert.Equal(t, 20.0, cfg.Rules[1].HourlyCost)
+		assert.Equal(t, []string{"ops"}, cfg.Rules[1].Sinks)
+		assert.Equal(t, policy.Duration(7*24*time.Hour), cfg.Rules[2].Age)
+	}
+	assert.True(t, cfg.UsesTags())
+	assert.True(t, cfg.UsesIdle())
+}
+
+func TestParse_Invalid(t *testing.T) {
+	sink := "sinks:\n  - {name: a, url: \"https://example.com\"}\n"
+	tests := []struct {
+		name   string
+		config string
+		err    string
+	}{
+		{"no rules", sink + "rules: []", "no rules defined"},
+		{"no sinks", "rules:\n  - {name: a, trigger: new}", "no sinks defined"},
+		{"duplicate sink", sink + "  - {name: a, url: \"https://example.com\"}\nrules:\n  - {name: a, trigger: new}", `duplicate sink name "a"`},
+		{"bad format", "sinks:\n  - {name: a, format: discord, url: \"https://example.com\"}\nrules:\n  - {name: a, trigger: new}", `invalid format "discord"`},
+		{"bad url", "sinks:\n  - {name: a, url: example.com}\nrules:\n  - {name: a, trigger: new}", `invalid URL "example.com"`},
+		{"bad trigger", sink + "rules:\n  - {name: a, trigger: slow}", `invalid trigger "slow"`},
+		{"age without age", sink + "rules:\n  - {name: a, trigger: age}", "age trigger needs age"},
+		{"cost without cost", sink + "rules:\n  - {name: a, trigger: cost}", "cost trigger needs hourlyCost"},
+		{"idle without window", sink + "rules:\n  - {name: a, trigger: idle}", "idle trigger needs idleFor"},
+		{"unknown sink", sink + "rules:\n  - {name: a, trigger: new, sinks: [b]}", `unknown sink "b"`},
+		{"unknown field", sink + "rules:\n  - {name: a, trigger: new, maxAge: 1d}", "field maxAge not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.config))
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "alerts.yaml")
+	assert.NoError(t, os.WriteFile(filename, []byte(exampleConfig), 0o600))
+
+	cfg, err := Load(filename)
+	assert.NoError(t, err)
+	assert.Len(t, cfg.Rules, 5)
+
+	_, err = Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorContains(t, err, "failed to read alert config")
+}
@@ -0,0 +1,108 @@
+package alert
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"mohua/internal/policy"
+	"mohua/internal/sagemaker"
+)
+
+// Alert is a rule triggered by a resource
+type Alert struct {
+	Rule         string  `json:"rule"`
+	Trigger      string  `json:"trigger"`
+	ResourceType string  `json:"resourceType"`
+	Resource     string  `json:"resource"`
+	Region       string  `json:"region"`
+	InstanceType string  `json:"instanceType,omitempty"`
+	HourlyCost   float64 `json:"hourlyCost,omitempty"`
+	Message      string  `json:"message"`
+	key          string  // Identifies the rule and resource across runs
+	resourceKey  string
+	sinks        []string
+}
+
+// Key identifies a resource across runs. Studio app names are only unique
+// within a user profile or space.
+func Key(resource sagemaker.ResourceInfo) string {
+	return strings.Join([]string{resource.Region, resource.ResourceType, resource.UserProfile, resource.SpaceName, resource.Name}, "/")
+}
+
+// Evaluate returns the alerts of every rule. known holds the keys of the
+// resources seen by the previous run; when it is nil no resource counts as
+// new, so the first run does not alert on everything. idle may be nil when
+// no rule has the idle trigger.
+func Evaluate(cfg *Config, resources []sagemaker.ResourceInfo, known map[string]bool, now time.Time, idle policy.IdleFunc) ([]Alert, error) {
+	var alerts []Alert
+	for _, rule := range cfg.Rules {
+		for _, resource := range resources {
+			if !rule.Select.Matches(resource, now) {
+				continue
+			}
+			message, fired, err := rule.check(resource, known, now, idle)
+			if err != nil {
+				return nil, fmt.Errorf("rule %q: %w", rule.Name, err)
+			}
+			if !fired {
+				continue
+			}
+
+			sinks := rule.Sinks
+			if len(sinks) == 0 {
+				for _, sink := range cfg.Sinks {
+					sinks = append(sinks, sink.Name)
+				}
+			}
+			alerts = append(alerts, Alert{
+				Rule:         rule.Name,
+				Trigger:      rule.Trigger,
+				ResourceType: resource.ResourceType,
+				Resource:     displayName(resource),
+				Region:       resource.Region,
+				InstanceType: resource.InstanceType,
+				HourlyCost:   resource.EstimatedHourlyCost(),
+				Message:      message,
+				key:          rule.Name + "|" + Key(resource),
+				resourceKey:  Key(resource),
+				sinks:        sinks,
+			})
+		}
+	}
+	return alerts, nil
+}
+
+// displayName names Studio apps by user profile and app type, like the resource listing
+func displayName(resource sagemaker.ResourceInfo) string {
+	if resource.ResourceType == "Studio" && resource.UserProfile != "" {
+		return fmt.Sprintf("%s/%s", resource.UserProfile, resource.AppType)
+	}
+	return resource.Name
+}
+
+// check reports whether a resource meets the trigger of the rule
+func (r Rule) check(resource sagemaker.ResourceInfo, known map[string]bool, now time.Time, idle policy.IdleFunc) (string, bool, error) {
+	switch r.Trigger {
+	case TriggerNew:
+		if known == nil || known[Key(resource)] {
+			return "", false, nil
+		}
+		return fmt.Sprintf("new %s on %s", strings.ToLower(resource.ResourceType), resource.InstanceType), true, nil
+	case TriggerAge:
+		age := now.Sub(resource.CreationTime)
+		return fmt.Sprintf("running for %s", policy.FormatDuration(age)), age > time.Duration(r.Age), nil
+	case TriggerCost:
+		cost := resource.EstimatedHourlyCost()
+		return fmt.Sprintf("estimated cost $%.2f/h exceeds $%.2f/h", cost, r.HourlyCost), cost > r.HourlyCost, nil
+	case TriggerIdle:
+		if idle == nil {
+			return "", false, fmt.Errorf("idle trigger requires activity metrics")
+		}
+		isIdle, err := idle(resource, time.Duration(r.IdleFor))
+		return fmt.Sprintf("idle for %s", policy.FormatDuration(time.Duration(r.IdleFor))), isIdle, err
+	case TriggerFailed:
+		return "status Failed", strings.EqualFold(resource.Status, "Failed"), nil
+	}
+	return "", false, fmt.Errorf("invalid trigger %q", r.Trigger)
+}
@@ -0,0 +1,75 @@
+package alert
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"mohua/internal/sagemaker"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluate(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	cfg, err := Parse([]byte(exampleConfig))
+	assert.NoError(t, err)
+
+	resources := []sagemaker.ResourceInfo{
+		{ResourceType: "Endpoint", Region: "us-east-1", Name: "known-gpu", InstanceType: "ml.g5.xlarge", CreationTime: now.Add(-time.Hour)},
+		{ResourceType: "Endpoint", Region: "us-east-1", Name: "new-gpu", InstanceType: "ml.g5.xlarge", CreationTime: now.Add(-time.Hour)},
+		{ResourceType: "Endpoint", Region: "us-east-1", Name: "big", InstanceType: "ml.p4d.24xlarge", CreationTime: now.Add(-time.Hour)},
+		{ResourceType: "Notebook", Region: "us-east-1", Name: "old", InstanceType: "ml.t3.medium", CreationTime: now.Add(-8 * 24 * time.Hour), Tags: map[string]string{"team": "ml"}},
+		{ResourceType: "Notebook", Region: "us-east-1", Name: "untagged", InstanceType: "ml.t3.medium", CreationTime: now.Add(-8 * 24 * time.Hour)},
+		{ResourceType: "Tuning", Region: "us-east-1", Name: "broken", Status: "Failed", CreationTime: now},
+	}
+	known := map[string]bool{"us-east-1/Endpoint///known-gpu": true}
+	idle := func(resource sagemaker.ResourceInfo, window time.Duration) (bool, error) {
+		assert.Equal(t, 24*time.Hour, window)
+		return resource.Name == "known-gpu", nil
+	}
+
+	alerts, err := Evaluate(cfg, resources, known, now, idle)
+
+	assert.NoError(t, err)
+	var summary [][]string
+	for _, alert := range alerts {
+		summary = append(summary, []string{alert.Rule, alert.Resource, alert.Message})
+	}
+	assert.Equal(t, [][]string{
+		{"new-gpu-endpoint", "new-gpu", "new endpoint on ml.g5.xlarge"},
+		{"expensive", "big", "estimated cost $37.69/h exceeds $20.00/h"},
+		{"old-notebook", "old", "running for 8d"},
+		{"idle-endpoint", "known-gpu", "idle for 1d"},
+		{"failed", "broken", "status Failed"},
+	}, summary)
+	assert.Equal(t, []string{"chat", "ops"}, alerts[0].sinks)
+	assert.Equal(t, []string{"ops"}, alerts[1].sinks)
+}
+
+func TestEvaluate_FirstRun(t *testing.T) {
+	cfg, err := Parse([]byte(exampleConfig))
+	assert.NoError(t, err)
+	resources := []sagemaker.ResourceInfo{{ResourceType: "Endpoint", Name: "gpu", InstanceType: "ml.g5.xlarge"}}
+
+	alerts, err := Evaluate(cfg, resources, nil, time.Now(), func(sagemaker.ResourceInfo, time.Duration) (bool, error) {
+		return false, nil
+	})
+
+	assert.NoError(t, err)
+	assert.Empty(t, alerts)
+}
+
+func TestEvaluate_IdleError(t *testing.T) {
+	cfg, err := Parse([]byte(exampleConfig))
+	assert.NoError(t, err)
+	resources := []sagemaker.ResourceInfo{{ResourceType: "Endpoint", Name: "gpu"}}
+
+	_, err = Evaluate(cfg, resources, nil, time.Now(), nil)
+	assert.EqualError(t, err, `rule "idle-endpoint": idle trigger requires activity metrics`)
+
+	_, err = Evaluate(cfg, resources, nil, time.Now(), func(sagemaker.ResourceInfo, time.Duration) (bool, error) {
+		return false, errors.New("throttled")
+	})
+	assert.EqualError(t, err, `rule "idle-endpoint": throttled`)
+}
@@ -0,0 +1,166 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"mohua/internal/retry"
+)
+
+// Sender posts alerts to webhooks
+type Sender struct {
+	client      *http.Client
+	retryConfig retry.Config
+}
+
+// NewSender creates a sender with a request timeout
+func NewSender() *Sender {
+	return &Sender{
+		client:      &http.Client{Timeout: 10 * time.Second},
+		retryConfig: retry.DefaultConfig,
+	}
+}
+
+// webhookError is a response with an unsuccessful status code. Rate limits
+// and server errors are retried.
+type webhookError struct {
+	status int
+	body   string
+}
+
+func (e *webhookError) Error() string {
+	return fmt.Sprintf("webhook responded with status %d: %s", e.status, e.body)
+}
+
+func (e *webhookError) IsRetryable() bool {
+	return e.status == http.StatusTooManyRequests || e.status >= 500
+}
+
+// Deliver sends each alert to its sinks, one message per sink, and returns
+// the alerts that reached all of their sinks along with the first error
+func (s *Sender) Deliver(ctx context.Context, sinks []SinkConfig, alerts []Alert) ([]Alert, error) {
+	failed := make(map[string]bool)
+	var firstError error
+	for _, sink := range sinks {
+		var batch []Alert
+		for _, alert := range alerts {
+			for _, name := range alert.sinks {
+				if name == sink.Name {
+					batch = append(batch, alert)
+					break
+				}
+			}
+		}
+		if len(batch) == 0 {
+			continue
+		}
+
+		if err := s.Send(ctx, sink, batch); err != nil {
+			for _, alert := range batch {
+				failed[alert.key] = true
+			}
+			if firstError == nil {
+				firstError = fmt.Errorf("failed to send alerts to %s: %w", sink.Name, err)
+			}
+		}
+	}
+
+	var delivered []Alert
+	for _, alert := range alerts {
+		if !failed[alert.key] {
+			delivered = append(delivered, alert)
+		}
+	}
+	return delivered, firstError
+}
+
+// Send posts alerts to a single sink in its format
+func (s *Sender) Send(ctx context.Context, sink SinkConfig, alerts []Alert) error {
+	payload, err := json.Marshal(Payload(sink.Format, alerts))
+	if err != nil {
+		return fmt.Errorf("failed to encode alerts: %w", err)
+	}
+
+	return retry.NewRetrier(s.retryConfig).Do(ctx, func() error {
+		request, err := http.NewRequestWithContext(ctx, http.MethodPost, sink.URL, bytes.NewReader(payload))
+		if err != nil {
+			return err
+		}
+		request.Header.Set("Content-Type", "application/json")
+
+		response, err := s.client.Do(request)
+		if err != nil {
+			return err
+		}
+		defer response.Body.Close()
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 512))
+		if response.StatusCode < 200 || response.StatusCode > 299 {
+			return &webhookError{status: response.StatusCode, body: strings.TrimSpace(string(body))}
+		}
+		return nil
+	})
+}
+
+// Payload returns the webhook body of alerts in a sink format
+func Payload(format string, alerts []Alert) interface{} {
+	switch format {
+	case FormatSlack:
+		return map[string]string{"text": Text(alerts, "*", "*")}
+	case FormatTeams:
+		// Workflows webhooks take a message with an Adaptive Card attachment
+		body := []map[string]interface{}{
+			{"type": "TextBlock", "text": Summary(alerts), "weight": "Bolder", "wrap": true},
+		}
+		for _, alert := range alerts {
+			body = append(body, map[string]interface{}{"type": "TextBlock", "text": Line(alert, "**", "**"), "wrap": true})
+		}
+		return map[string]interface{}{
+			"type": "message",
+			"attachments": []map[string]interface{}{{
+				"contentType": "application/vnd.microsoft.card.adaptive",
+				"content": map[string]interface{}{
+					"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+					"type":    "AdaptiveCard",
+					"version": "1.4",
+					"body":    body,
+				},
+			}},
+		}
+	default:
+		return map[string]interface{}{"text": Text(alerts, "", ""), "alerts": alerts}
+	}
+}
+
+// Summary is the headline of a message with alerts
+func Summary(alerts []Alert) string {
+	if len(alerts) == 1 {
+		return "mohua: 1 SageMaker alert"
+	}
+	return fmt.Sprintf("mohua: %d SageMaker alerts", len(alerts))
+}
+
+// Text renders alerts as a headline and one line per alert, with the rule
+// name wrapped in the bold markers of the chat format
+func Text(alerts []Alert, boldStart, boldEnd string) string {
+	lines := []string{Summary(alerts)}
+	for _, alert := range alerts {
+		lines = append(lines, "• "+Line(alert, boldStart, boldEnd))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Line describes a single alert
+func Line(alert Alert, boldStart, boldEnd string) string {
+	line := fmt.Sprintf("%s%s%s: %s %s in %s: %s", boldStart, alert.Rule, boldEnd,
+		alert.ResourceType, alert.Resource, alert.Region, alert.Message)
+	if alert.HourlyCost > 0 {
+		line += fmt.Sprintf(" (~$%.2f/h)", alert.HourlyCost)
+	}
+	return line
+}
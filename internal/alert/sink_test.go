@@ -0,0 +1,123 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"mohua/internal/retry"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// receiver records the bodies posted to a webhook and answers with the
+// queued status codes, then 200
+type receiver struct {
+	mu       sync.Mutex
+	statuses []int
+	bodies   map[string][]string
+}
+
+func newReceiver(t *testing.T, statuses ...int) (*receiver, *httptest.Server) {
+	r := &receiver{statuses: statuses, bodies: make(map[string][]string)}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(req.Body)
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.bodies[req.URL.Path] = append(r.bodies[req.URL.Path], string(body))
+		if len(r.statuses) > 0 {
+			status := r.statuses[0]
+			r.statuses = r.statuses[1:]
+			w.WriteHeader(status)
+			w.Write([]byte("unavailable"))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return r, server
+}
+
+func testSender() *Sender {
+	sender := NewSender()
+	sender.retryConfig = retry.Config{MaxAttempts: 2, InitialInterval: time.Millisecond, MaxInterval: time.Millisecond, Multiplier: 1}
+	return sender
+}
+
+var testAlerts = []Alert{
+	{Rule: "expensive", Trigger: TriggerCost, ResourceType: "Endpoint", Resource: "big", Region: "us-east-1", HourlyCost: 37.69,
+		Message: "estimated cost $37.69/h exceeds $20.00/h", key: "expensive|big", sinks: []string{"chat", "ops"}},
+	{Rule: "failed", Trigger: TriggerFailed, ResourceType: "Tuning", Resource: "broken", Region: "us-east-1",
+		Message: "status Failed", key: "failed|broken", sinks: []string{"ops"}},
+}
+
+func TestDeliver(t *testing.T) {
+	r, server := newReceiver(t, http.StatusServiceUnavailable)
+	sinks := []SinkConfig{
+		{Name: "chat", Format: FormatSlack, URL: server.URL + "/slack"},
+		{Name: "ops", URL: server.URL + "/ops"},
+		{Name: "unused", Format: FormatTeams, URL: server.URL + "/teams"},
+	}
+
+	delivered, err := testSender().Deliver(context.Background(), sinks, testAlerts)
+
+	assert.NoError(t, err)
+	assert.Equal(t, testAlerts, delivered)
+	// The first attempt was answered with 503 and retried
+	assert.Len(t, r.bodies["/slack"], 2)
+	assert.JSONEq(t, `{"text": "mohua: 1 SageMaker alert\n• *expensive*: Endpoint big in us-east-1: estimated cost $37.69/h exceeds $20.00/h (~$37.69/h)"}`, r.bodies["/slack"][1])
+	if assert.Len(t, r.bodies["/ops"], 1) {
+		var payload struct {
+			Text   string  `json:"text"`
+			Alerts []Alert `json:"alerts"`
+		}
+		assert.NoError(t, json.Unmarshal([]byte(r.bodies["/ops"][0]), &payload))
+		assert.Contains(t, payload.Text, "mohua: 2 SageMaker alerts")
+		if assert.Len(t, payload.Alerts, 2) {
+			assert.Equal(t, "broken", payload.Alerts[1].Resource)
+		}
+	}
+	assert.Empty(t, r.bodies["/teams"])
+}
+
+func TestDeliver_Failure(t *testing.T) {
+	r, server := newReceiver(t, http.StatusBadRequest)
+	sinks := []SinkConfig{
+		{Name: "chat", Format: FormatSlack, URL: server.URL + "/slack"},
+		{Name: "ops", URL: server.URL + "/ops"},
+	}
+
+	delivered, err := testSender().Deliver(context.Background(), sinks, testAlerts)
+
+	// 400 is not retried and the alert of the failed sink is not delivered
+	assert.EqualError(t, err, "failed to send alerts to chat: webhook responded with status 400: unavailable")
+	assert.Len(t, r.bodies["/slack"], 1)
+	assert.Equal(t, []Alert{testAlerts[1]}, delivered)
+}
+
+func TestPayload_Teams(t *testing.T) {
+	data, err := json.Marshal(Payload(FormatTeams, testAlerts[1:]))
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "message",
+		"attachments": [{
+			"contentType": "application/vnd.microsoft.card.adaptive",
+			"content": {
+				"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+				"type": "AdaptiveCard",
+				"version": "1.4",
+				"body": [
+					{"type": "TextBlock", "text": "mohua: 1 SageMaker alert", "weight": "Bolder", "wrap": true},
+					{"type": "TextBlock", "text": "**failed**: Tuning broken in us-east-1: status Failed", "wrap": true}
+				]
+			}
+		}]
+	}`, string(data))
+}
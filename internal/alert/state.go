@@ -0,0 +1,156 @@
+package alert
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"mohua/internal/history"
+	"mohua/internal/jsonfile"
+	"mohua/internal/sagemaker"
+)
+
+// State remembers the resources seen by the previous run of each region and
+// when each firing alert was last sent, so alerts are not repeated on every
+// run. Runs for different regions can share a state file.
+type State struct {
+	Known map[string][]string  `json:"known"` // Resource keys by region, missing until the region's first run
+	Sent  map[string]time.Time `json:"sent"`
+}
+
+// DefaultStatePath returns the state file in the mohua data directory
+func DefaultStatePath() (string, error) {
+	dir, err := history.DefaultDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "alert-state.json"), nil
+}
+
+// LoadState reads the state file. A missing file is an empty state.
+func LoadState(path string) (*State, error) {
+	var state State
+	if err := jsonfile.Read(path, &state); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// Save writes the state file
+func (s *State) Save(path string) error {
+	return jsonfile.Write(path, s)
+}
+
+// KnownResources returns the keys of the resources seen by the previous run of
+// the regions, or nil before the first run of any of them
+func (s *State) KnownResources(regions []string) map[string]bool {
+	known := make(map[string]bool)
+	for _, region := range regions {
+		keys, ok := s.Known[region]
+		if !ok {
+			return nil
+		}
+		for _, key := range keys {
+			known[key] = true
+		}
+	}
+	return known
+}
+
+// Pending returns the firing alerts that have not been sent yet, or were last
+// sent at least repeatAfter ago. A repeatAfter of 0 sends each alert once for
+// as long as it keeps firing.
+func (s *State) Pending(firing []Alert, now time.Time, repeatAfter time.Duration) []Alert {
+	var pending []Alert
+	for _, alert := range firing {
+		sent, ok := s.Sent[alert.key]
+		if !ok || (repeatAfter > 0 && now.Sub(sent) >= repeatAfter) {
+			pending = append(pending, alert)
+		}
+	}
+	return pending
+}
+
+// Record updates the state after a run of the regions. Alerts that stopped
+// firing are forgotten, so they are sent again when they fire again. New
+// resources whose alert could not be delivered stay unknown so the next run
+// retries them. Known resources and sent alerts of the incomplete types, whose
+// collectors failed, and of regions not scanned by the run are kept, so their
+// alerts are not sent again by the next run.
+func (s *State) Record(regions []string, resources []sagemaker.ResourceInfo, incomplete []string, firing []Alert, delivered []Alert, now time.Time) {
+	deliveredKeys := make(map[string]bool, len(delivered))
+	for _, alert := range delivered {
+		deliveredKeys[alert.key] = true
+	}
+	keep := func(resourceKey string) bool {
+		region, resourceType := splitKey(resourceKey)
+		return !contains(regions, region) || contains(incomplete, resourceType)
+	}
+
+	sent := make(map[string]time.Time, len(firing))
+	for key, last := range s.Sent {
+		// Keys are the rule name and the resource key
+		if i := strings.LastIndex(key, "|"); i >= 0 && keep(key[i+1:]) {
+			sent[key] = last
+		}
+	}
+	undeliveredNew := make(map[string]bool)
+	for _, alert := range firing {
+		if deliveredKeys[alert.key] {
+			sent[alert.key] = now
+		} else if last, ok := s.Sent[alert.key]; ok {
+			sent[alert.key] = last
+		} else if alert.Trigger == TriggerNew {
+			undeliveredNew[alert.resourceKey] = true
+		}
+	}
+	s.Sent = sent
+
+	known := make(map[string][]string, len(s.Known)+len(regions))
+	for region, keys := range s.Known {
+		if !contains(regions, region) {
+			known[region] = keys
+			continue
+		}
+		for _, key := range keys {
+			if keep(key) {
+				known[region] = append(known[region], key)
+			}
+		}
+	}
+	for _, region := range regions {
+		if known[region] == nil {
+			known[region] = []string{}
+		}
+	}
+	for _, resource := range resources {
+		if key := Key(resource); !undeliveredNew[key] {
+			known[resource.Region] = append(known[resource.Region], key)
+		}
+	}
+	for _, keys := range known {
+		sort.Strings(keys)
+	}
+	s.Known = known
+}
+
+// splitKey returns the region and the resource type of a resource key, which
+// starts with both
+func splitKey(resourceKey string) (region string, resourceType string) {
+	if parts := strings.SplitN(resourceKey, "/", 3); len(parts) == 3 {
+		return parts[0], parts[1]
+	}
+	return "", ""
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
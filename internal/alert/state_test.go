@@ -0,0 +1,103 @@
+package alert
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"mohua/internal/sagemaker"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var east = []string{"us-east-1"}
+
+func TestState(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	path := filepath.Join(t.TempDir(), "state", "alert-state.json")
+
+	state, err := LoadState(path)
+	assert.NoError(t, err)
+	assert.Nil(t, state.KnownResources(east))
+
+	endpoint := sagemaker.ResourceInfo{ResourceType: "Endpoint", Region: "us-east-1", Name: "gpu"}
+	notebook := sagemaker.ResourceInfo{ResourceType: "Notebook", Region: "us-east-1", Name: "nb"}
+	costly := Alert{Rule: "expensive", key: "expensive|" + Key(endpoint), resourceKey: Key(endpoint)}
+	newNotebook := Alert{Rule: "new", Trigger: TriggerNew, key: "new|" + Key(notebook), resourceKey: Key(notebook)}
+
+	firing := []Alert{costly, newNotebook}
+	assert.Equal(t, firing, state.Pending(firing, now, 0))
+
+	// The new notebook alert failed to send, so the notebook stays unknown
+	state.Record(east, []sagemaker.ResourceInfo{endpoint, notebook}, nil, firing, []Alert{costly}, now)
+	assert.NoError(t, state.Save(path))
+
+	state, err = LoadState(path)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"us-east-1/Endpoint///gpu": true}, state.KnownResources(east))
+	assert.Equal(t, []Alert{newNotebook}, state.Pending(firing, now.Add(time.Hour), 0))
+	assert.Equal(t, firing, state.Pending(firing, now.Add(24*time.Hour), 24*time.Hour))
+
+	// A failed collector keeps the resources and sent alerts of its type
+	state.Record(east, []sagemaker.ResourceInfo{notebook}, []string{"Endpoint"}, []Alert{newNotebook}, []Alert{newNotebook}, now.Add(time.Hour))
+	assert.Equal(t, map[string]time.Time{costly.key: now, newNotebook.key: now.Add(time.Hour)}, state.Sent)
+	assert.Equal(t, map[string][]string{"us-east-1": {"us-east-1/Endpoint///gpu", "us-east-1/Notebook///nb"}}, state.Known)
+
+	// An alert that stops firing in a complete run is forgotten
+	state.Record(east, []sagemaker.ResourceInfo{endpoint, notebook}, nil, []Alert{newNotebook}, nil, now.Add(2*time.Hour))
+	assert.Equal(t, map[string]time.Time{newNotebook.key: now.Add(time.Hour)}, state.Sent)
+
+	state.Record(east, nil, nil, nil, nil, now.Add(3*time.Hour))
+	assert.Equal(t, map[string]bool{}, state.KnownResources(east))
+}
+
+func TestState_IncompleteRun(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	state := &State{}
+
+	endpoint := sagemaker.ResourceInfo{ResourceType: "Endpoint", Region: "us-east-1", Name: "gpu"}
+	notebook := sagemaker.ResourceInfo{ResourceType: "Notebook", Region: "us-east-1", Name: "nb"}
+	costly := Alert{Rule: "expensive", key: "expensive|" + Key(endpoint), resourceKey: Key(endpoint)}
+	firing := []Alert{costly}
+
+	// A complete run sends the alert
+	state.Record(east, []sagemaker.ResourceInfo{endpoint, notebook}, nil, firing, firing, now)
+
+	// The endpoint collector fails, so the endpoint alert cannot fire
+	state.Record(east, []sagemaker.ResourceInfo{notebook}, []string{"Endpoint"}, nil, nil, now.Add(time.Hour))
+
+	// The next complete run does not send the alert again
+	assert.Empty(t, state.Pending(firing, now.Add(2*time.Hour), 0))
+	state.Record(east, []sagemaker.ResourceInfo{endpoint, notebook}, nil, firing, nil, now.Add(2*time.Hour))
+	assert.Equal(t, map[string]time.Time{costly.key: now}, state.Sent)
+}
+
+func TestState_Regions(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	west := []string{"eu-west-1"}
+	state := &State{}
+
+	eastEndpoint := sagemaker.ResourceInfo{ResourceType: "Endpoint", Region: "us-east-1", Name: "gpu"}
+	westEndpoint := sagemaker.ResourceInfo{ResourceType: "Endpoint", Region: "eu-west-1", Name: "gpu"}
+	eastAlert := Alert{Rule: "expensive", key: "expensive|" + Key(eastEndpoint), resourceKey: Key(eastEndpoint)}
+	westAlert := Alert{Rule: "expensive", key: "expensive|" + Key(westEndpoint), resourceKey: Key(westEndpoint)}
+
+	// Runs for the two regions alternate on the same state
+	state.Record(east, []sagemaker.ResourceInfo{eastEndpoint}, nil, []Alert{eastAlert}, []Alert{eastAlert}, now)
+	assert.Nil(t, state.KnownResources(west))
+	state.Record(west, []sagemaker.ResourceInfo{westEndpoint}, nil, []Alert{westAlert}, []Alert{westAlert}, now.Add(time.Minute))
+
+	// Neither run forgets the other region's resources or sent alerts
+	assert.Equal(t, map[string]bool{Key(eastEndpoint): true}, state.KnownResources(east))
+	assert.Equal(t, map[string]bool{Key(westEndpoint): true}, state.KnownResources(west))
+	assert.Empty(t, state.Pending([]Alert{eastAlert}, now.Add(time.Hour), 0))
+
+	state.Record(east, []sagemaker.ResourceInfo{eastEndpoint}, nil, []Alert{eastAlert}, nil, now.Add(time.Hour))
+	assert.Empty(t, state.Pending([]Alert{westAlert}, now.Add(time.Hour), 0))
+	assert.Equal(t, map[string]time.Time{eastAlert.key: now, westAlert.key: now.Add(time.Minute)}, state.Sent)
+
+	// An empty region still counts as recorded
+	state.Record([]string{"ap-south-1"}, nil, nil, nil, nil, now)
+	assert.Equal(t, map[string]bool{}, state.KnownResources([]string{"ap-south-1"}))
+	assert.Len(t, state.KnownResources([]string{"us-east-1", "eu-west-1"}), 2)
+}
@@ -0,0 +1,161 @@
+package audit
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"mohua/internal/sagemaker"
+)
+
+// Severity ranks how serious a finding is
+type Severity int
+
+const (
+	SeverityLow Severity = iota + 1
+	SeverityMedium
+	SeverityHigh
+)
+
+// String returns the lower case name of the severity
+func (s Severity) String() string {
+	switch s {
+	case SeverityLow:
+		return "low"
+	case SeverityMedium:
+		return "medium"
+	case SeverityHigh:
+		return "high"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalText encodes the severity by name
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// ParseSeverity parses "low", "medium" or "high"
+func ParseSeverity(s string) (Severity, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "low":
+		return SeverityLow, nil
+	case "medium":
+		return SeverityMedium, nil
+	case "high":
+		return SeverityHigh, nil
+	default:
+		return 0, fmt.Errorf("invalid severity %q: expected low, medium or high", s)
+	}
+}
+
+// DefaultBroadRolePattern matches execution role names that usually carry broad permissions
+const DefaultBroadRolePattern = `(?i)(admin|fullaccess|poweruser)`
+
+// Rule is a built-in security check
+type Rule struct {
+	ID          string
+	Severity    Severity
+	Description string
+}
+
+// Rules lists every built-in rule
+var Rules = []Rule{
+	{ID: "SM001", Severity: SeverityMedium, Description: "Notebook instance has root access enabled"},
+	{ID: "SM002", Severity: SeverityHigh, Description: "Notebook instance has direct internet access"},
+	{ID: "SM003", Severity: SeverityMedium, Description: "Notebook instance is not attached to a VPC"},
+	{ID: "SM004", Severity: SeverityLow, Description: "Resource is not encrypted with a customer managed KMS key"},
+	{ID: "SM005", Severity: SeverityMedium, Description: "Endpoint serves a model without network isolation"},
+	{ID: "SM006", Severity: SeverityHigh, Description: "Studio domain uses PublicInternetOnly network access"},
+	{ID: "SM007", Severity: SeverityHigh, Description: "Studio user profile uses an overly broad execution role"},
+}
+
+// Finding is a rule violation on a single resource
+type Finding struct {
+	RuleID       string   `json:"ruleId"`
+	Severity     Severity `json:"severity"`
+	ResourceType string   `json:"resourceType"`
+	Resource     string   `json:"resource"`
+	Message      string   `json:"message"`
+}
+
+// Options configures rule evaluation
+type Options struct {
+	BroadRolePattern *regexp.Regexp // Matched against the role name, not the full ARN
+}
+
+// Evaluate runs every built-in rule against the given resources
+func Evaluate(resources []sagemaker.SecurityInfo, opts Options) []Finding {
+	var findings []Finding
+	add := func(ruleID string, resource sagemaker.SecurityInfo, message string) {
+		findings = append(findings, Finding{
+			RuleID:       ruleID,
+			Severity:     ruleSeverity(ruleID),
+			ResourceType: resource.ResourceType,
+			Resource:     resource.Name,
+			Message:      message,
+		})
+	}
+
+	for _, resource := range resources {
+		switch resource.ResourceType {
+		case "Notebook":
+			if resource.RootAccess {
+				add("SM001", resource, "root access is enabled")
+			}
+			if resource.DirectInternetAccess {
+				add("SM002", resource, "direct internet access is enabled")
+			}
+			if resource.SubnetID == "" {
+				add("SM003", resource, "not attached to a VPC subnet")
+			}
+		case "Endpoint":
+			if len(resource.UnisolatedModels) > 0 {
+				add("SM005", resource, fmt.Sprintf("network isolation disabled for %s", strings.Join(resource.UnisolatedModels, ", ")))
+			}
+		case "Domain":
+			if resource.AppNetworkAccessType == "PublicInternetOnly" {
+				add("SM006", resource, "app network access type is PublicInternetOnly")
+			}
+		case "UserProfile":
+			if opts.BroadRolePattern != nil && resource.ExecutionRole != "" {
+				if name := roleName(resource.ExecutionRole); opts.BroadRolePattern.MatchString(name) {
+					add("SM007", resource, fmt.Sprintf("execution role %s matches %s", name, opts.BroadRolePattern))
+				}
+			}
+		}
+
+		if resource.ResourceType != "UserProfile" && resource.KmsKeyID == "" {
+			add("SM004", resource, "no customer managed KMS key")
+		}
+	}
+
+	return findings
+}
+
+// CountAtLeast returns the number of findings with at least the given severity
+func CountAtLeast(findings []Finding, severity Severity) int {
+	count := 0
+	for _, finding := range findings {
+		if finding.Severity >= severity {
+			count++
+		}
+	}
+	return count
+}
+
+// ruleSeverity returns the severity of a built-in rule
+func ruleSeverity(ruleID string) Severity {
+	for _, rule := range Rules {
+		if rule.ID == ruleID {
+			return rule.Severity
+		}
+	}
+	return SeverityLow
+}
+
+// roleName returns the name of an IAM role from its ARN, dropping any path
+func roleName(arn string) string {
+	return arn[strings.LastIndex(arn, "/")+1:]
+}
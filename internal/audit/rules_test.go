@@ -0,0 +1,58 @@
+package audit
+
+import (
+	"regexp"
+	"testing"
+
+	"mohua/internal/sagemaker"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluate(t *testing.T) {
+	resources := []sagemaker.SecurityInfo{
+		{ResourceType: "Notebook", Name: "open", RootAccess: true, DirectInternetAccess: true},
+		{ResourceType: "Notebook", Name: "locked", KmsKeyID: "key", SubnetID: "subnet-1"},
+		{ResourceType: "Endpoint", Name: "churn", KmsKeyID: "key", UnisolatedModels: []string{"churn-v1"}},
+		{ResourceType: "Domain", Name: "research", KmsKeyID: "key", AppNetworkAccessType: "PublicInternetOnly"},
+		{ResourceType: "Domain", Name: "private", KmsKeyID: "key", AppNetworkAccessType: "VpcOnly"},
+		{ResourceType: "UserProfile", Name: "d-1/alice", ExecutionRole: "arn:aws:iam::123456789012:role/service-role/SageMakerAdminRole"},
+		{ResourceType: "UserProfile", Name: "d-1/bob", ExecutionRole: "arn:aws:iam::123456789012:role/SageMakerExecution"},
+	}
+
+	findings := Evaluate(resources, Options{BroadRolePattern: regexp.MustCompile(DefaultBroadRolePattern)})
+
+	var got []string
+	for _, finding := range findings {
+		got = append(got, finding.RuleID+" "+finding.Resource)
+	}
+	assert.Equal(t, []string{
+		"SM001 open",
+		"SM002 open",
+		"SM003 open",
+		"SM004 open",
+		"SM005 churn",
+		"SM006 research",
+		"SM007 d-1/alice",
+	}, got)
+	assert.Equal(t, SeverityHigh, findings[1].Severity)
+	assert.Equal(t, "network isolation disabled for churn-v1", findings[4].Message)
+	assert.Contains(t, findings[6].Message, "SageMakerAdminRole")
+}
+
+func TestCountAtLeast(t *testing.T) {
+	findings := []Finding{{Severity: SeverityLow}, {Severity: SeverityMedium}, {Severity: SeverityHigh}}
+
+	assert.Equal(t, 3, CountAtLeast(findings, SeverityLow))
+	assert.Equal(t, 2, CountAtLeast(findings, SeverityMedium))
+	assert.Equal(t, 1, CountAtLeast(findings, SeverityHigh))
+}
+
+func TestParseSeverity(t *testing.T) {
+	severity, err := ParseSeverity("High")
+	assert.NoError(t, err)
+	assert.Equal(t, SeverityHigh, severity)
+
+	_, err = ParseSeverity("critical")
+	assert.Error(t, err)
+}
@@ -0,0 +1,53 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteSARIF(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteSARIF(&buf, []Finding{
+		{RuleID: "SM002", Severity: SeverityHigh, ResourceType: "Notebook", Resource: "open", Message: "direct internet access is enabled"},
+	})
+	assert.NoError(t, err)
+
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Name  string `json:"name"`
+					Rules []struct {
+						ID string `json:"id"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				Level     string `json:"level"`
+				Locations []struct {
+					LogicalLocations []struct {
+						Name string `json:"name"`
+					} `json:"logicalLocations"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+
+	assert.Equal(t, "2.1.0", log.Version)
+	if assert.Len(t, log.Runs, 1) {
+		run := log.Runs[0]
+		assert.Equal(t, "mohua", run.Tool.Driver.Name)
+		assert.Len(t, run.Tool.Driver.Rules, len(Rules))
+		if assert.Len(t, run.Results, 1) {
+			assert.Equal(t, "SM002", run.Results[0].RuleID)
+			assert.Equal(t, "error", run.Results[0].Level)
+			assert.Equal(t, "Notebook/open", run.Results[0].Locations[0].LogicalLocations[0].Name)
+		}
+	}
+}
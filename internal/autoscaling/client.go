@@ -0,0 +1,210 @@
+package autoscaling
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/applicationautoscaling"
+	"github.com/aws/aws-sdk-go-v2/service/applicationautoscaling/types"
+	"mohua/internal/awstrace"
+	"mohua/internal/retry"
+	"mohua/internal/sagemaker"
+)
+
+// Client interface defines the methods that consumers of this package can use
+type Client interface {
+	ListVariantScaling(ctx context.Context) (map[string]VariantScaling, error)
+}
+
+// ApplicationAutoScalingClientInterface defines the AWS SDK methods used by Client
+type ApplicationAutoScalingClientInterface interface {
+	DescribeScalableTargets(ctx context.Context, params *applicationautoscaling.DescribeScalableTargetsInput, optFns ...func(*applicationautoscaling.Options)) (*applicationautoscaling.DescribeScalableTargetsOutput, error)
+	DescribeScalingPolicies(ctx context.Context, params *applicationautoscaling.DescribeScalingPoliciesInput, optFns ...func(*applicationautoscaling.Options)) (*applicationautoscaling.DescribeScalingPoliciesOutput, error)
+	DescribeScheduledActions(ctx context.Context, params *applicationautoscaling.DescribeScheduledActionsInput, optFns ...func(*applicationautoscaling.Options)) (*applicationautoscaling.DescribeScheduledActionsOutput, error)
+}
+
+// clientImpl implements only the necessary Application Auto Scaling API operations
+type clientImpl struct {
+	client ApplicationAutoScalingClientInterface
+}
+
+// NewClientFunc is the type for the client creation function
+type NewClientFunc func(region string) (Client, error)
+
+// NewClient is the function used to create a new Application Auto Scaling client
+var NewClient NewClientFunc = newClient
+
+// newClient creates a new Application Auto Scaling client
+func newClient(region string) (Client, error) {
+	opts := []func(*config.LoadOptions) error{awstrace.WithTracing()}
+	if region != "" {
+		opts = append(opts, config.WithRegion(region))
+	}
+
+	cfg, err := config.LoadDefaultConfig(context.Background(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS SDK configuration: %w", err)
+	}
+
+	return &clientImpl{
+		client: applicationautoscaling.NewFromConfig(cfg),
+	}, nil
+}
+
+// VariantScaling holds the scalable target, policies and scheduled actions registered for an endpoint variant
+type VariantScaling struct {
+	ResourceID       string
+	MinCapacity      int
+	MaxCapacity      int
+	Policies         []ScalingPolicy
+	ScheduledActions []ScheduledAction
+}
+
+// ScalingPolicy contains the relevant fields of a scaling policy
+type ScalingPolicy struct {
+	Name        string
+	Type        string
+	TargetValue float64 // Only set for target tracking policies
+}
+
+// ScheduledAction contains the relevant fields of a scheduled action
+type ScheduledAction struct {
+	Name        string
+	Schedule    string
+	MinCapacity *int // Nil when the action leaves the min capacity unchanged
+}
+
+// ResourceID returns the Application Auto Scaling resource ID of an endpoint variant
+func ResourceID(endpointName, variantName string) string {
+	return fmt.Sprintf("endpoint/%s/variant/%s", endpointName, variantName)
+}
+
+// ListVariantScaling returns the scaling configuration of all SageMaker variants keyed by resource ID
+func (c *clientImpl) ListVariantScaling(ctx context.Context) (map[string]VariantScaling, error) {
+	scaling := make(map[string]VariantScaling)
+
+	retrier := retry.NewRetrier(retry.DefaultConfig)
+	err := retrier.Do(ctx, func() error {
+		input := &applicationautoscaling.DescribeScalableTargetsInput{
+			ServiceNamespace:  types.ServiceNamespaceSagemaker,
+			ScalableDimension: types.ScalableDimensionSageMakerVariantDesiredInstanceCount,
+		}
+		for {
+			output, err := c.client.DescribeScalableTargets(ctx, input)
+			if err != nil {
+				return sagemaker.WrapError(err)
+			}
+			for _, target := range output.ScalableTargets {
+				if target.ResourceId == nil {
+					continue
+				}
+				info := VariantScaling{ResourceID: *target.ResourceId}
+				if target.MinCapacity != nil {
+					info.MinCapacity = int(*target.MinCapacity)
+				}
+				if target.MaxCapacity != nil {
+					info.MaxCapacity = int(*target.MaxCapacity)
+				}
+				scaling[info.ResourceID] = info
+			}
+			if output.NextToken == nil {
+				return nil
+			}
+			input.NextToken = output.NextToken
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var policies []types.ScalingPolicy
+	err = retrier.Do(ctx, func() error {
+		policies = nil
+		input := &applicationautoscaling.DescribeScalingPoliciesInput{
+			ServiceNamespace:  types.ServiceNamespaceSagemaker,
+			ScalableDimension: types.ScalableDimensionSageMakerVariantDesiredInstanceCount,
+		}
+		for {
+			output, err := c.client.DescribeScalingPolicies(ctx, input)
+			if err != nil {
+				return sagemaker.WrapError(err)
+			}
+			policies = append(policies, output.ScalingPolicies...)
+			if output.NextToken == nil {
+				return nil
+			}
+			input.NextToken = output.NextToken
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, policy := range policies {
+		if policy.ResourceId == nil {
+			continue
+		}
+		info, ok := scaling[*policy.ResourceId]
+		if !ok {
+			continue
+		}
+		p := ScalingPolicy{Type: string(policy.PolicyType)}
+		if policy.PolicyName != nil {
+			p.Name = *policy.PolicyName
+		}
+		if cfg := policy.TargetTrackingScalingPolicyConfiguration; cfg != nil && cfg.TargetValue != nil {
+			p.TargetValue = *cfg.TargetValue
+		}
+		info.Policies = append(info.Policies, p)
+		scaling[info.ResourceID] = info
+	}
+
+	var actions []types.ScheduledAction
+	err = retrier.Do(ctx, func() error {
+		actions = nil
+		input := &applicationautoscaling.DescribeScheduledActionsInput{
+			ServiceNamespace:  types.ServiceNamespaceSagemaker,
+			ScalableDimension: types.ScalableDimensionSageMakerVariantDesiredInstanceCount,
+		}
+		for {
+			output, err := c.client.DescribeScheduledActions(ctx, input)
+			if err != nil {
+				return sagemaker.WrapError(err)
+			}
+			actions = append(actions, output.ScheduledActions...)
+			if output.NextToken == nil {
+				return nil
+			}
+			input.NextToken = output.NextToken
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, action := range actions {
+		if action.ResourceId == nil {
+			continue
+		}
+		info, ok := scaling[*action.ResourceId]
+		if !ok {
+			continue
+		}
+		a := ScheduledAction{}
+		if action.ScheduledActionName != nil {
+			a.Name = *action.ScheduledActionName
+		}
+		if action.Schedule != nil {
+			a.Schedule = *action.Schedule
+		}
+		if target := action.ScalableTargetAction; target != nil && target.MinCapacity != nil {
+			minCapacity := int(*target.MinCapacity)
+			a.MinCapacity = &minCapacity
+		}
+		info.ScheduledActions = append(info.ScheduledActions, a)
+		scaling[info.ResourceID] = info
+	}
+
+	return scaling, nil
+}
@@ -0,0 +1,37 @@
+package autoscaling
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/applicationautoscaling"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAutoScalingClient is a mock implementation of the ApplicationAutoScalingClientInterface
+type MockAutoScalingClient struct {
+	mock.Mock
+}
+
+func (m *MockAutoScalingClient) DescribeScalableTargets(ctx context.Context, params *applicationautoscaling.DescribeScalableTargetsInput, optFns ...func(*applicationautoscaling.Options)) (*applicationautoscaling.DescribeScalableTargetsOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*applicationautoscaling.DescribeScalableTargetsOutput), args.Error(1)
+}
+
+func (m *MockAutoScalingClient) DescribeScalingPolicies(ctx context.Context, params *applicationautoscaling.DescribeScalingPoliciesInput, optFns ...func(*applicationautoscaling.Options)) (*applicationautoscaling.DescribeScalingPoliciesOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*applicationautoscaling.DescribeScalingPoliciesOutput), args.Error(1)
+}
+
+func (m *MockAutoScalingClient) DescribeScheduledActions(ctx context.Context, params *applicationautoscaling.DescribeScheduledActionsInput, optFns ...func(*applicationautoscaling.Options)) (*applicationautoscaling.DescribeScheduledActionsOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*applicationautoscaling.DescribeScheduledActionsOutput), args.Error(1)
+}
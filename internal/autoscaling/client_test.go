@@ -0,0 +1,109 @@
+package autoscaling
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/applicationautoscaling"
+	"github.com/aws/aws-sdk-go-v2/service/applicationautoscaling/types"
+	"github.com/aws/smithy-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestResourceID(t *testing.T) {
+	assert.Equal(t, "endpoint/my-endpoint/variant/AllTraffic", ResourceID("my-endpoint", "AllTraffic"))
+}
+
+func TestListVariantScaling(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(MockAutoScalingClient)
+
+	mockClient.On("DescribeScalableTargets", ctx, mock.MatchedBy(func(in *applicationautoscaling.DescribeScalableTargetsInput) bool {
+		return in.NextToken == nil && in.ServiceNamespace == types.ServiceNamespaceSagemaker
+	}), mock.Anything).
+		Return(&applicationautoscaling.DescribeScalableTargetsOutput{
+			ScalableTargets: []types.ScalableTarget{
+				{ResourceId: aws.String("endpoint/e1/variant/AllTraffic"), MinCapacity: aws.Int32(2), MaxCapacity: aws.Int32(8)},
+			},
+			NextToken: aws.String("page2"),
+		}, nil).Once()
+	mockClient.On("DescribeScalableTargets", ctx, mock.MatchedBy(func(in *applicationautoscaling.DescribeScalableTargetsInput) bool {
+		return in.NextToken != nil && *in.NextToken == "page2"
+	}), mock.Anything).
+		Return(&applicationautoscaling.DescribeScalableTargetsOutput{
+			ScalableTargets: []types.ScalableTarget{
+				{ResourceId: aws.String("endpoint/e2/variant/AllTraffic"), MinCapacity: aws.Int32(1), MaxCapacity: aws.Int32(1)},
+			},
+		}, nil).Once()
+	mockClient.On("DescribeScalingPolicies", ctx, mock.Anything, mock.Anything).
+		Return(&applicationautoscaling.DescribeScalingPoliciesOutput{
+			ScalingPolicies: []types.ScalingPolicy{
+				{
+					ResourceId: aws.String("endpoint/e1/variant/AllTraffic"),
+					PolicyName: aws.String("invocations"),
+					PolicyType: types.PolicyTypeTargetTrackingScaling,
+					TargetTrackingScalingPolicyConfiguration: &types.TargetTrackingScalingPolicyConfiguration{
+						TargetValue: aws.Float64(70),
+					},
+				},
+				{
+					// Policy for a target that was deregistered in the meantime
+					ResourceId: aws.String("endpoint/gone/variant/AllTraffic"),
+					PolicyType: types.PolicyTypeStepScaling,
+				},
+			},
+		}, nil)
+	mockClient.On("DescribeScheduledActions", ctx, mock.Anything, mock.Anything).
+		Return(&applicationautoscaling.DescribeScheduledActionsOutput{
+			ScheduledActions: []types.ScheduledAction{
+				{
+					ResourceId:           aws.String("endpoint/e1/variant/AllTraffic"),
+					ScheduledActionName:  aws.String("nightly"),
+					Schedule:             aws.String("cron(0 20 ? * MON-FRI *)"),
+					ScalableTargetAction: &types.ScalableTargetAction{MinCapacity: aws.Int32(1)},
+				},
+				{
+					ResourceId:           aws.String("endpoint/e2/variant/AllTraffic"),
+					ScheduledActionName:  aws.String("cap"),
+					ScalableTargetAction: &types.ScalableTargetAction{MaxCapacity: aws.Int32(1)},
+				},
+			},
+		}, nil)
+
+	client := &clientImpl{client: mockClient}
+	scaling, err := client.ListVariantScaling(ctx)
+
+	assert.NoError(t, err)
+	assert.Len(t, scaling, 2)
+	assert.Equal(t, VariantScaling{
+		ResourceID:  "endpoint/e1/variant/AllTraffic",
+		MinCapacity: 2,
+		MaxCapacity: 8,
+		Policies: []ScalingPolicy{
+			{Name: "invocations", Type: "TargetTrackingScaling", TargetValue: 70},
+		},
+		ScheduledActions: []ScheduledAction{
+			{Name: "nightly", Schedule: "cron(0 20 ? * MON-FRI *)", MinCapacity: aws.Int(1)},
+		},
+	}, scaling["endpoint/e1/variant/AllTraffic"])
+	assert.Empty(t, scaling["endpoint/e2/variant/AllTraffic"].Policies)
+	assert.Equal(t, []ScheduledAction{{Name: "cap"}}, scaling["endpoint/e2/variant/AllTraffic"].ScheduledActions)
+	mockClient.AssertExpectations(t)
+}
+
+func TestListVariantScaling_Error(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(MockAutoScalingClient)
+
+	mockClient.On("DescribeScalableTargets", ctx, mock.Anything, mock.Anything).
+		Return(nil, &smithy.GenericAPIError{Code: "AccessDeniedException", Message: "Access Denied"})
+
+	client := &clientImpl{client: mockClient}
+	scaling, err := client.ListVariantScaling(ctx)
+
+	assert.Error(t, err)
+	assert.Nil(t, scaling)
+	mockClient.AssertNumberOfCalls(t, "DescribeScalableTargets", 1)
+}
@@ -0,0 +1,78 @@
+package autoscaling
+
+import (
+	"fmt"
+	"strings"
+
+	"mohua/internal/sagemaker"
+)
+
+// VariantReport is the scaling summary of a single endpoint variant
+type VariantReport struct {
+	EndpointName  string   `json:"endpointName"`
+	VariantName   string   `json:"variantName"`
+	InstanceCount int      `json:"instanceCount"`
+	MinCapacity   int      `json:"minCapacity,omitempty"`
+	MaxCapacity   int      `json:"maxCapacity,omitempty"`
+	PolicyType    string   `json:"policyType,omitempty"`
+	TargetValue   float64  `json:"targetValue,omitempty"`
+	Findings      []string `json:"findings,omitempty"`
+}
+
+// BuildReport joins endpoint variants with their scaling configuration and flags
+// variants that cannot scale down. Findings depend only on the configuration,
+// not on when the report is built.
+func BuildReport(variants []sagemaker.EndpointVariant, scaling map[string]VariantScaling) []VariantReport {
+	reports := make([]VariantReport, 0, len(variants))
+	for _, variant := range variants {
+		report := VariantReport{
+			EndpointName:  variant.EndpointName,
+			VariantName:   variant.VariantName,
+			InstanceCount: variant.InstanceCount,
+		}
+
+		info, ok := scaling[ResourceID(variant.EndpointName, variant.VariantName)]
+		if !ok {
+			report.Findings = append(report.Findings, "no autoscaling")
+			reports = append(reports, report)
+			continue
+		}
+
+		report.MinCapacity = info.MinCapacity
+		report.MaxCapacity = info.MaxCapacity
+
+		var policyTypes []string
+		for _, policy := range info.Policies {
+			policyTypes = append(policyTypes, policy.Type)
+			if policy.TargetValue != 0 && report.TargetValue == 0 {
+				report.TargetValue = policy.TargetValue
+			}
+		}
+		report.PolicyType = strings.Join(policyTypes, ",")
+
+		if len(info.Policies) == 0 {
+			report.Findings = append(report.Findings, "no scaling policy")
+		}
+		if lowest := info.LowestMinCapacity(); lowest > 1 {
+			report.Findings = append(report.Findings,
+				fmt.Sprintf("min capacity %d with no scheduled scale-down", lowest))
+		}
+
+		reports = append(reports, report)
+	}
+
+	return reports
+}
+
+// LowestMinCapacity returns the lowest min capacity the variant is configured
+// to reach, either permanently or through a scheduled action such as a
+// nightly scale-down
+func (v VariantScaling) LowestMinCapacity() int {
+	lowest := v.MinCapacity
+	for _, action := range v.ScheduledActions {
+		if action.MinCapacity != nil && *action.MinCapacity < lowest {
+			lowest = *action.MinCapacity
+		}
+	}
+	return lowest
+}
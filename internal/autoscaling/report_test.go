@@ -0,0 +1,59 @@
+package autoscaling
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+	"mohua/internal/sagemaker"
+)
+
+func TestBuildReport(t *testing.T) {
+	variants := []sagemaker.EndpointVariant{
+		{EndpointName: "scaled", VariantName: "AllTraffic", InstanceCount: 4},
+		{EndpointName: "fixed", VariantName: "AllTraffic", InstanceCount: 2},
+		{EndpointName: "nopolicy", VariantName: "AllTraffic", InstanceCount: 1},
+		{EndpointName: "scheduled", VariantName: "AllTraffic", InstanceCount: 3},
+	}
+	scaling := map[string]VariantScaling{
+		"endpoint/scaled/variant/AllTraffic": {
+			ResourceID:  "endpoint/scaled/variant/AllTraffic",
+			MinCapacity: 2,
+			MaxCapacity: 8,
+			Policies:    []ScalingPolicy{{Name: "p", Type: "TargetTrackingScaling", TargetValue: 70}},
+		},
+		"endpoint/nopolicy/variant/AllTraffic": {
+			ResourceID:  "endpoint/nopolicy/variant/AllTraffic",
+			MinCapacity: 1,
+			MaxCapacity: 2,
+		},
+		"endpoint/scheduled/variant/AllTraffic": {
+			ResourceID:  "endpoint/scheduled/variant/AllTraffic",
+			MinCapacity: 3,
+			MaxCapacity: 6,
+			Policies:    []ScalingPolicy{{Name: "p", Type: "TargetTrackingScaling", TargetValue: 70}},
+			ScheduledActions: []ScheduledAction{
+				{Name: "cap", Schedule: "cron(0 8 ? * MON-FRI *)"},
+				{Name: "nightly", Schedule: "cron(0 20 ? * MON-FRI *)", MinCapacity: aws.Int(1)},
+			},
+		},
+	}
+
+	reports := BuildReport(variants, scaling)
+
+	assert.Len(t, reports, 4)
+	assert.Equal(t, VariantReport{
+		EndpointName:  "scaled",
+		VariantName:   "AllTraffic",
+		InstanceCount: 4,
+		MinCapacity:   2,
+		MaxCapacity:   8,
+		PolicyType:    "TargetTrackingScaling",
+		TargetValue:   70,
+		Findings:      []string{"min capacity 2 with no scheduled scale-down"},
+	}, reports[0])
+	assert.Equal(t, []string{"no autoscaling"}, reports[1].Findings)
+	assert.Equal(t, []string{"no scaling policy"}, reports[2].Findings)
+	// A scheduled action lowers the min capacity, whatever the current one is
+	assert.Empty(t, reports[3].Findings)
+}
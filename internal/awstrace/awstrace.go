@@ -0,0 +1,95 @@
+// Package awstrace traces the AWS API calls of the SDK clients with the
+// OpenTelemetry API. Spans are dropped unless a tracer provider is installed.
+package awstrace
+
+import (
+	"context"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "mohua/internal/awstrace"
+
+// WithTracing adds the tracing middleware to the clients created from the
+// loaded configuration
+func WithTracing() config.LoadOptionsFunc {
+	return config.WithAPIOptions([]func(*middleware.Stack) error{AddMiddleware})
+}
+
+// AddMiddleware traces every API call with a span, and every attempt of the
+// retryer of the SDK with a child span
+func AddMiddleware(stack *middleware.Stack) error {
+	if err := stack.Initialize.Add(callMiddleware{}, middleware.After); err != nil {
+		return err
+	}
+	// Attempts are the calls of the next handler of the retry middleware
+	if _, ok := stack.Finalize.Get("Retry"); ok {
+		return stack.Finalize.Insert(attemptMiddleware{}, "Retry", middleware.After)
+	}
+	return stack.Finalize.Add(attemptMiddleware{}, middleware.After)
+}
+
+// attemptsKey holds the attempt counter of a call in its context
+type attemptsKey struct{}
+
+type callMiddleware struct{}
+
+func (callMiddleware) ID() string { return "MohuaTraceCall" }
+
+func (callMiddleware) HandleInitialize(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+	service, operation := awsmiddleware.GetServiceID(ctx), awsmiddleware.GetOperationName(ctx)
+	ctx, span := otel.Tracer(tracerName).Start(ctx, service+"."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("rpc.system", "aws-api"),
+			attribute.String("rpc.service", service),
+			attribute.String("rpc.method", operation),
+			attribute.String("cloud.region", awsmiddleware.GetRegion(ctx)),
+		))
+	defer span.End()
+
+	attempts := new(int)
+	out, metadata, err := next.HandleInitialize(context.WithValue(ctx, attemptsKey{}, attempts), in)
+	span.SetAttributes(attribute.Int("aws.attempts", *attempts))
+	if requestID, ok := awsmiddleware.GetRequestIDMetadata(metadata); ok {
+		span.SetAttributes(attribute.String("aws.request_id", requestID))
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return out, metadata, err
+}
+
+type attemptMiddleware struct{}
+
+func (attemptMiddleware) ID() string { return "MohuaTraceAttempt" }
+
+func (attemptMiddleware) HandleFinalize(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+	attempt := 1
+	if attempts, ok := ctx.Value(attemptsKey{}).(*int); ok {
+		*attempts++
+		attempt = *attempts
+	}
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "attempt",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.Int("aws.attempt", attempt)))
+	defer span.End()
+
+	out, metadata, err := next.HandleFinalize(ctx, in)
+	if resp, ok := awsmiddleware.GetRawResponse(metadata).(*smithyhttp.Response); ok && resp != nil {
+		span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return out, metadata, err
+}
@@ -0,0 +1,82 @@
+package awstrace
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestMiddleware(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	// The first attempt is throttled
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		n := requests.Add(1)
+		w.Header().Set("X-Amzn-RequestId", fmt.Sprintf("request-%d", n))
+		if n == 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"__type":"ThrottlingException","message":"Rate exceeded"}`))
+			return
+		}
+		w.Write([]byte(`{"Endpoints":[]}`))
+	}))
+	defer server.Close()
+
+	client := sagemaker.NewFromConfig(aws.Config{
+		Region:      "us-east-1",
+		Credentials: aws.AnonymousCredentials{},
+		Retryer: func() aws.Retryer {
+			return retry.NewStandard(func(o *retry.StandardOptions) {
+				o.Backoff = retry.BackoffDelayerFunc(func(int, error) (time.Duration, error) { return 0, nil })
+			})
+		},
+		APIOptions: []func(*middleware.Stack) error{AddMiddleware},
+	}, func(o *sagemaker.Options) {
+		o.BaseEndpoint = aws.String(server.URL)
+	})
+	_, err := client.ListEndpoints(context.Background(), &sagemaker.ListEndpointsInput{})
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	call := spans[2]
+	assert.Equal(t, "SageMaker.ListEndpoints", call.Name())
+	assert.Subset(t, call.Attributes(), []attribute.KeyValue{
+		attribute.String("rpc.system", "aws-api"),
+		attribute.String("rpc.service", "SageMaker"),
+		attribute.String("rpc.method", "ListEndpoints"),
+		attribute.String("cloud.region", "us-east-1"),
+		attribute.Int("aws.attempts", 2),
+		attribute.String("aws.request_id", "request-2"),
+	})
+
+	for i, attempt := range spans[:2] {
+		assert.Equal(t, "attempt", attempt.Name())
+		assert.Equal(t, call.SpanContext().SpanID(), attempt.Parent().SpanID())
+		assert.Contains(t, attempt.Attributes(), attribute.Int("aws.attempt", i+1))
+	}
+	assert.Contains(t, spans[0].Attributes(), attribute.Int("http.response.status_code", http.StatusBadRequest))
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Contains(t, spans[1].Attributes(), attribute.Int("http.response.status_code", http.StatusOK))
+}
@@ -0,0 +1,123 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds the settings read from the mohua config file
+type Config struct {
+	Tags      TagsConfig      `yaml:"tags"`
+	History   HistoryConfig   `yaml:"history"`
+	Digest    DigestConfig    `yaml:"digest"`
+	Telemetry TelemetryConfig `yaml:"telemetry"`
+}
+
+// TagsConfig configures tag lookups and tag compliance
+type TagsConfig struct {
+	Required []string      `yaml:"required"` // Tags every taggable resource must have
+	CacheTTL time.Duration `yaml:"cacheTTL"` // How long looked up tags are reused
+}
+
+// HistoryConfig configures the snapshot history recorded by each scan
+type HistoryConfig struct {
+	Enabled   bool          `yaml:"enabled"`
+	Dir       string        `yaml:"dir"`       // Defaults to mohua in the user data directory
+	Retention time.Duration `yaml:"retention"` // Older snapshots are removed, 0 keeps all
+}
+
+// DigestConfig configures the email digest and who receives it
+type DigestConfig struct {
+	SMTP         SMTPConfig          `yaml:"smtp"`
+	From         string              `yaml:"from"`
+	To           []string            `yaml:"to"`           // Receive the digest of every resource
+	RecipientTag string              `yaml:"recipientTag"` // Tag key whose values route resources to Recipients
+	Recipients   map[string][]string `yaml:"recipients"`   // Receive the digest of the resources tagged with a value
+	TopSpenders  int                 `yaml:"topSpenders"`
+	IdleFor      time.Duration       `yaml:"idleFor"` // Endpoints without invocations for this long are idle, 0 skips the check
+	Policy       string              `yaml:"policy"`  // Policy file whose violations are included
+}
+
+// SMTPConfig configures the mail server the digest is sent through
+type SMTPConfig struct {
+	Host        string `yaml:"host"`
+	Port        int    `yaml:"port"`
+	Username    string `yaml:"username"`
+	PasswordEnv string `yaml:"passwordEnv"` // Environment variable holding the password
+	StartTLS    bool   `yaml:"startTLS"`    // Require STARTTLS before authenticating and sending
+}
+
+// TelemetryConfig configures the OTLP export of scan traces and inventory
+// metrics
+type TelemetryConfig struct {
+	Endpoint string            `yaml:"endpoint"` // OTLP/HTTP base URL, e.g. http://localhost:4318. Empty disables the export.
+	Headers  map[string]string `yaml:"headers"`  // Sent with every export, e.g. an API key
+}
+
+// Default returns the configuration used when no config file exists
+func Default() *Config {
+	return &Config{
+		Tags: TagsConfig{
+			Required: []string{"owner"},
+			CacheTTL: time.Hour,
+		},
+		History: HistoryConfig{
+			Enabled:   true,
+			Retention: 30 * 24 * time.Hour,
+		},
+		Digest: DigestConfig{
+			SMTP: SMTPConfig{
+				Port:        587,
+				PasswordEnv: "MOHUA_SMTP_PASSWORD",
+				StartTLS:    true,
+			},
+			TopSpenders: 10,
+			IdleFor:     24 * time.Hour,
+		},
+	}
+}
+
+// DefaultPath returns the config file location in the user config directory
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "mohua", "config.yaml"), nil
+}
+
+// Load reads the config file at path on top of the defaults. An empty path
+// reads the default location, where a missing file is not an error.
+func Load(path string) (*Config, error) {
+	optional := path == ""
+	if optional {
+		var err error
+		if path, err = DefaultPath(); err != nil {
+			return Default(), nil
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if optional && errors.Is(err, fs.ErrNotExist) {
+			return Default(), nil
+		}
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	cfg := Default()
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	cfg, err := Load(writeConfig(t, "tags:\n  required: [owner, team, cost-center]\n  cacheTTL: 15m\n"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"owner", "team", "cost-center"}, cfg.Tags.Required)
+	assert.Equal(t, 15*time.Minute, cfg.Tags.CacheTTL)
+	assert.Equal(t, Default().History, cfg.History)
+}
+
+func TestLoad_History(t *testing.T) {
+	cfg, err := Load(writeConfig(t, "history:\n  enabled: false\n  dir: /var/lib/mohua\n  retention: 168h\n"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, HistoryConfig{Enabled: false, Dir: "/var/lib/mohua", Retention: 7 * 24 * time.Hour}, cfg.History)
+}
+
+func TestLoad_Digest(t *testing.T) {
+	cfg, err := Load(writeConfig(t, `
+digest:
+  smtp: {host: smtp.example.com, username: mohua}
+  from: mohua@example.com
+  to: [ml-leads@example.com]
+  recipientTag: team
+  recipients:
+    search: [search-owner@example.com]
+  idleFor: 48h
+`))
+
+	assert.NoError(t, err)
+	assert.Equal(t, SMTPConfig{Host: "smtp.example.com", Port: 587, Username: "mohua", PasswordEnv: "MOHUA_SMTP_PASSWORD", StartTLS: true}, cfg.Digest.SMTP)
+	assert.Equal(t, []string{"ml-leads@example.com"}, cfg.Digest.To)
+	assert.Equal(t, map[string][]string{"search": {"search-owner@example.com"}}, cfg.Digest.Recipients)
+	assert.Equal(t, 10, cfg.Digest.TopSpenders)
+	assert.Equal(t, 48*time.Hour, cfg.Digest.IdleFor)
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	cfg, err := Load(writeConfig(t, ""))
+	assert.NoError(t, err)
+	assert.Equal(t, Default(), cfg)
+
+	// A missing file at the default location is not an error
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	cfg, err = Load("")
+	assert.NoError(t, err)
+	assert.Equal(t, Default(), cfg)
+}
+
+func TestLoad_Errors(t *testing.T) {
+	_, err := Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorContains(t, err, "failed to read config file")
+
+	_, err = Load(writeConfig(t, "tags:\n  requried: [owner]\n"))
+	assert.ErrorContains(t, err, "field requried not found")
+}
+
+func TestLoad_Telemetry(t *testing.T) {
+	cfg, err := Load(writeConfig(t, "telemetry:\n  endpoint: https://otlp.example.com\n  headers:\n    api-key: secret\n"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, TelemetryConfig{Endpoint: "https://otlp.example.com", Headers: map[string]string{"api-key": "secret"}}, cfg.Telemetry)
+}
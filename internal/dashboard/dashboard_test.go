@@ -0,0 +1,39 @@
+package dashboard
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler(t *testing.T) {
+	tests := []struct {
+		path        string
+		status      int
+		contentType string
+		contains    string
+	}{
+		{"/", http.StatusOK, "text/html; charset=utf-8", `<script src="app.js"></script>`},
+		{"/app.js", http.StatusOK, "text/javascript; charset=utf-8", "/api/summary"},
+		{"/style.css", http.StatusOK, "text/css; charset=utf-8", "table"},
+		{"/missing.js", http.StatusNotFound, "text/plain; charset=utf-8", "404 page not found"},
+	}
+
+	handler := Handler()
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			body, _ := io.ReadAll(recorder.Body)
+			assert.Equal(t, tt.status, recorder.Code)
+			assert.Equal(t, tt.contentType, recorder.Header().Get("Content-Type"))
+			assert.Contains(t, string(body), tt.contains)
+			if tt.status == http.StatusOK {
+				assert.Equal(t, "no-cache", recorder.Header().Get("Cache-Control"))
+			}
+		})
+	}
+}
@@ -0,0 +1,238 @@
+// Dashboard of mohua ui. Reads the daemon API and reloads after each scan.
+"use strict";
+
+// Poll interval while no scan is scheduled or the first scan is running
+const POLL_MS = 60 * 1000;
+// Delay after the scheduled scan time, so the scan has completed
+const SCAN_GRACE_MS = 15 * 1000;
+
+const state = {
+  resources: [],
+  sort: { key: "estimatedHourlyCost", desc: true },
+  timer: null,
+};
+
+const $ = (id) => document.getElementById(id);
+
+function formatCost(cost) {
+  return cost > 0 ? "$" + cost.toFixed(2) : "-";
+}
+
+// durationSeconds parses a Go duration such as "49h3m12.5s"
+function durationSeconds(text) {
+  const units = { h: 3600, m: 60, s: 1, ms: 1e-3, "µs": 1e-6, us: 1e-6, ns: 1e-9 };
+  let seconds = 0;
+  for (const [, value, unit] of text.matchAll(/([\d.]+)(h|ms|m|µs|us|ns|s)/g)) {
+    seconds += parseFloat(value) * units[unit];
+  }
+  return text.startsWith("-") ? -seconds : seconds;
+}
+
+// formatAge shortens a running time to days and hours, like the CLI tables
+function formatAge(seconds) {
+  const days = Math.floor(seconds / 86400);
+  const hours = Math.floor((seconds % 86400) / 3600);
+  const minutes = Math.floor((seconds % 3600) / 60);
+  if (days > 0) return days + "d " + hours + "h";
+  if (hours > 0) return hours + "h " + minutes + "m";
+  return minutes + "m";
+}
+
+function cell(text, className) {
+  const td = document.createElement("td");
+  td.textContent = text;
+  if (className) td.className = className;
+  return td;
+}
+
+function fillTable(id, rows) {
+  const tbody = $(id).querySelector("tbody");
+  tbody.replaceChildren(...rows.map((cells) => {
+    const tr = document.createElement("tr");
+    tr.append(...cells);
+    return tr;
+  }));
+}
+
+async function getEnvelope(path) {
+  const response = await fetch(path, { cache: "no-store" });
+  const envelope = await response.json();
+  if (!response.ok) {
+    const error = new Error(envelope.metadata && envelope.metadata.message || response.statusText);
+    error.status = response.status;
+    throw error;
+  }
+  return envelope;
+}
+
+function renderTotals(id, totals) {
+  const rows = Object.entries(totals)
+    .sort((a, b) => b[1].hourlyCost - a[1].hourlyCost || a[0].localeCompare(b[0]))
+    .map(([key, total]) => [
+      cell(key),
+      cell(total.resources, "num"),
+      cell(total.instances, "num"),
+      cell(formatCost(total.hourlyCost), "num"),
+    ]);
+  fillTable(id, rows);
+}
+
+function renderSummary(summary) {
+  const instances = Object.values(summary.instances).reduce((sum, count) => sum + count, 0);
+  $("total-resources").textContent = summary.resources;
+  $("total-instances").textContent = instances;
+  $("total-hourly").textContent = formatCost(summary.hourlyCost);
+  $("total-monthly").textContent = formatCost(summary.hourlyCost * 730);
+  $("total-errors").textContent = summary.errors;
+  renderTotals("by-type", summary.byType);
+  renderTotals("by-region", summary.byRegion);
+}
+
+function setOptions(id, values) {
+  const select = $(id);
+  const selected = select.value;
+  const first = select.options[0];
+  select.replaceChildren(first, ...values.map((value) => new Option(value, value)));
+  select.value = values.includes(selected) ? selected : "";
+}
+
+function renderInventory() {
+  const text = $("filter").value.trim().toLowerCase();
+  const type = $("filter-type").value;
+  const region = $("filter-region").value;
+  const shown = state.resources.filter((r) => {
+    if (type && r.resourceType !== type) return false;
+    if (region && r.region !== region) return false;
+    return !text || r.search.includes(text);
+  });
+
+  const { key, desc } = state.sort;
+  shown.sort((a, b) => {
+    const x = a[key], y = b[key];
+    const order = typeof x === "number" ? x - y : String(x).localeCompare(String(y));
+    return desc ? -order : order;
+  });
+
+  fillTable("inventory", shown.map((r) => [
+    cell(r.resourceType),
+    cell(r.name),
+    cell(r.region),
+    cell(r.status, /fail/i.test(r.status) ? "status-failed" : ""),
+    cell(r.instanceType || "-"),
+    cell(formatAge(r.age), "num"),
+    cell(formatCost(r.estimatedHourlyCost), "num"),
+    cell(r.details || "", "details"),
+  ]));
+  const cost = shown.reduce((sum, r) => sum + r.estimatedHourlyCost, 0);
+  $("shown").textContent = shown.length + " of " + state.resources.length + " resources, " + formatCost(cost) + "/h";
+
+  document.querySelectorAll("#inventory th[data-sort]").forEach((th) => {
+    th.classList.toggle("asc", th.dataset.sort === key && !desc);
+    th.classList.toggle("desc", th.dataset.sort === key && desc);
+  });
+}
+
+function setResources(resources) {
+  state.resources = resources.map((r) => {
+    const tags = Object.entries(r.tags || {}).map(([k, v]) => k + "=" + v).join(" ");
+    return Object.assign({}, r, {
+      age: durationSeconds(r.runningTime),
+      search: [r.name, r.status, r.instanceType, r.details, r.createdBy, tags].join(" ").toLowerCase(),
+    });
+  });
+  const unique = (key) => [...new Set(state.resources.map((r) => r[key]))].sort();
+  setOptions("filter-type", unique("resourceType"));
+  setOptions("filter-region", unique("region"));
+  renderInventory();
+}
+
+function renderErrors(errors) {
+  $("errors-section").hidden = errors.length === 0;
+  fillTable("errors", errors.map((e) => [
+    cell(e.region || "-"),
+    cell(e.source),
+    cell(e.message, "details"),
+    cell(e.retryable ? "yes" : "no"),
+  ]));
+}
+
+async function loadChanges() {
+  const envelope = await getEnvelope("/api/changes?since=" + encodeURIComponent($("since").value));
+  const rows = [];
+  const periods = [];
+  for (const report of envelope.changes || []) {
+    periods.push(report.to.region + " since " + new Date(report.from.time).toLocaleString());
+    for (const change of report.changes) {
+      rows.push([
+        cell(change.change, "change-" + change.change),
+        cell(report.to.region),
+        cell(change.type),
+        cell(change.name),
+        cell(change.from || "-"),
+        cell(change.to || "-"),
+      ]);
+    }
+  }
+  fillTable("changes", rows);
+  let note = envelope.metadata.message || "";
+  if (!note) {
+    note = periods.length === 0 ? "Not enough snapshots recorded yet" : (rows.length === 0 ? "No changes, " : "") + periods.join(", ");
+  }
+  $("changes-note").textContent = note;
+}
+
+// schedule reloads shortly after the next scan, or polls until there is one
+function schedule(nextScan) {
+  clearTimeout(state.timer);
+  let delay = POLL_MS;
+  if (nextScan) {
+    delay = Math.max(new Date(nextScan).getTime() - Date.now() + SCAN_GRACE_MS, SCAN_GRACE_MS);
+  }
+  state.timer = setTimeout(load, delay);
+}
+
+async function load() {
+  try {
+    const summary = await getEnvelope("/api/summary");
+    const [resources, errors] = await Promise.all([getEnvelope("/api/resources"), getEnvelope("/api/errors")]);
+    const metadata = summary.metadata;
+    $("regions").textContent = metadata.region;
+    $("status").textContent = "Scanned " + new Date(metadata.scannedAt).toLocaleString() +
+      (summary.summary.nextScan ? ", next scan " + new Date(summary.summary.nextScan).toLocaleTimeString() : "");
+    $("banner").hidden = true;
+    renderSummary(summary.summary);
+    setResources(resources.resources || []);
+    renderErrors(errors.errors || []);
+    schedule(summary.summary.nextScan);
+  } catch (error) {
+    $("status").textContent = error.status === 503 ? "Waiting for the first scan..." : "Update failed";
+    if (error.status !== 503) {
+      $("banner").textContent = "Failed to load the inventory: " + error.message;
+      $("banner").hidden = false;
+    }
+    schedule(null);
+    return;
+  }
+  try {
+    await loadChanges();
+  } catch (error) {
+    $("changes-note").textContent = "Failed to load changes: " + error.message;
+  }
+}
+
+document.querySelectorAll("#inventory th[data-sort]").forEach((th) => {
+  th.addEventListener("click", () => {
+    const key = th.dataset.sort;
+    state.sort = { key, desc: state.sort.key === key ? !state.sort.desc : false };
+    renderInventory();
+  });
+});
+$("filter").addEventListener("input", renderInventory);
+$("filter-type").addEventListener("change", renderInventory);
+$("filter-region").addEventListener("change", renderInventory);
+$("since").addEventListener("change", () => loadChanges().catch((error) => {
+  $("changes-note").textContent = "Failed to load changes: " + error.message;
+}));
+$("refresh").addEventListener("click", load);
+
+load();
@@ -0,0 +1,195 @@
+package digest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"mohua/internal/policy"
+	"mohua/internal/sagemaker"
+)
+
+// Input is what a digest reports on
+type Input struct {
+	Resources  []sagemaker.ResourceInfo
+	Idle       []sagemaker.ResourceInfo // Resources without activity during the idle window
+	Violations []policy.Violation
+}
+
+// Only returns the input restricted to resources. Violations of limits across
+// resources are left out, since they cannot be attributed to one recipient.
+func (in Input) Only(resources []sagemaker.ResourceInfo) Input {
+	keep := make(map[string]bool, len(resources))
+	for _, resource := range resources {
+		keep[resourceKey(resource)] = true
+	}
+
+	out := Input{Resources: resources}
+	for _, resource := range in.Idle {
+		if keep[resourceKey(resource)] {
+			out.Idle = append(out.Idle, resource)
+		}
+	}
+	for _, violation := range in.Violations {
+		if keep[identityKey(violation.ResourceType, violation.UserProfile, violation.SpaceName, violation.Resource)] {
+			out.Violations = append(out.Violations, violation)
+		}
+	}
+	return out
+}
+
+// Entry is a resource as listed in a digest
+type Entry struct {
+	ResourceType string    `json:"resourceType"`
+	Name         string    `json:"name"`
+	InstanceType string    `json:"instanceType,omitempty"`
+	Instances    int       `json:"instances"`
+	CreationTime time.Time `json:"creationTime"`
+	HourlyCost   float64   `json:"hourlyCost"`
+}
+
+// Digest is the content of a digest email
+type Digest struct {
+	Generated   time.Time          `json:"generated"`
+	Region      string             `json:"region"`
+	Resources   []Entry            `json:"resources"`   // Sorted by type and name
+	TopSpenders []Entry            `json:"topSpenders"` // Highest estimated hourly cost first
+	Idle        []Entry            `json:"idle"`
+	Violations  []policy.Violation `json:"violations"`
+	HourlyCost  float64            `json:"hourlyCost"`
+}
+
+// Build summarizes the input into a digest with at most top spenders
+func Build(in Input, region string, now time.Time, top int) Digest {
+	d := Digest{Generated: now, Region: region, Violations: in.Violations}
+	for _, resource := range in.Resources {
+		entry := newEntry(resource)
+		d.Resources = append(d.Resources, entry)
+		d.HourlyCost += entry.HourlyCost
+	}
+	for _, resource := range in.Idle {
+		d.Idle = append(d.Idle, newEntry(resource))
+	}
+	sortEntries(d.Resources)
+	sortEntries(d.Idle)
+
+	for _, entry := range d.Resources {
+		if entry.HourlyCost > 0 {
+			d.TopSpenders = append(d.TopSpenders, entry)
+		}
+	}
+	sort.SliceStable(d.TopSpenders, func(i, j int) bool {
+		return d.TopSpenders[i].HourlyCost > d.TopSpenders[j].HourlyCost
+	})
+	if len(d.TopSpenders) > top {
+		d.TopSpenders = d.TopSpenders[:top]
+	}
+	return d
+}
+
+// Subject is the subject line of the digest email
+func (d Digest) Subject() string {
+	return fmt.Sprintf("SageMaker digest %s: %d running, $%.2f/h, %d idle, %d violations",
+		d.Region, len(d.Resources), d.HourlyCost, len(d.Idle), len(d.Violations))
+}
+
+func newEntry(resource sagemaker.ResourceInfo) Entry {
+	name := resource.Name
+	if resource.ResourceType == "Studio" && resource.UserProfile != "" {
+		name = fmt.Sprintf("%s/%s", resource.UserProfile, resource.AppType)
+	}
+	var instances int
+	for _, count := range resource.Instances() {
+		instances += count
+	}
+	return Entry{
+		ResourceType: resource.ResourceType,
+		Name:         name,
+		InstanceType: resource.InstanceType,
+		Instances:    instances,
+		CreationTime: resource.CreationTime,
+		HourlyCost:   resource.EstimatedHourlyCost(),
+	}
+}
+
+func sortEntries(entries []Entry) {
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].ResourceType != entries[j].ResourceType {
+			return entries[i].ResourceType < entries[j].ResourceType
+		}
+		return entries[i].Name < entries[j].Name
+	})
+}
+
+// Recipients are the addresses that receive a digest of the same resources
+type Recipients struct {
+	To        []string
+	Resources []sagemaker.ResourceInfo
+	All       bool // Set for the addresses that receive every resource
+}
+
+// Route returns who receives a digest of which resources. Addresses in
+// everyone receive all resources. The other addresses receive the resources
+// whose tag tagKey has one of their values. Addresses that receive the same
+// resources share one email.
+func Route(resources []sagemaker.ResourceInfo, everyone []string, tagKey string, recipients map[string][]string) []Recipients {
+	routes := make(map[string][]sagemaker.ResourceInfo)
+	if tagKey != "" {
+		for _, resource := range resources {
+			value, ok := resource.Tags[tagKey]
+			if !ok {
+				continue
+			}
+			for _, address := range recipients[value] {
+				if !contains(everyone, address) {
+					routes[address] = append(routes[address], resource)
+				}
+			}
+		}
+	}
+
+	var result []Recipients
+	if len(everyone) > 0 {
+		result = append(result, Recipients{To: everyone, Resources: resources, All: true})
+	}
+	addresses := make([]string, 0, len(routes))
+	for address := range routes {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+	groups := make(map[string]int)
+	for _, address := range addresses {
+		var keys []string
+		for _, resource := range routes[address] {
+			keys = append(keys, resourceKey(resource))
+		}
+		signature := strings.Join(keys, "|")
+		if i, ok := groups[signature]; ok {
+			result[i].To = append(result[i].To, address)
+			continue
+		}
+		groups[signature] = len(result)
+		result = append(result, Recipients{To: []string{address}, Resources: routes[address]})
+	}
+	return result
+}
+
+// resourceKey identifies a resource within a region. Studio app names such as
+// "default" repeat across user profiles and spaces.
+func resourceKey(resource sagemaker.ResourceInfo) string {
+	return identityKey(resource.ResourceType, resource.UserProfile, resource.SpaceName, resource.Name)
+}
+
+func identityKey(resourceType string, userProfile string, spaceName string, name string) string {
+	return resourceType + "/" + userProfile + "/" + spaceName + "/" + name
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
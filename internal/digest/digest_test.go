@@ -0,0 +1,114 @@
+package digest
+
+import (
+	"testing"
+	"time"
+
+	"mohua/internal/policy"
+	"mohua/internal/sagemaker"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var now = time.Date(2026, 10, 18, 7, 0, 0, 0, time.UTC)
+
+var (
+	search = sagemaker.ResourceInfo{ResourceType: "Endpoint", Name: "search", InstanceType: "ml.g5.xlarge", InstanceCount: 2,
+		CreationTime: now.Add(-72 * time.Hour), Tags: map[string]string{"team": "search"}}
+	churn = sagemaker.ResourceInfo{ResourceType: "Endpoint", Name: "churn", InstanceType: "ml.m5.large",
+		CreationTime: now.Add(-time.Hour), Tags: map[string]string{"team": "growth"}}
+	studio = sagemaker.ResourceInfo{ResourceType: "Studio", Name: "default", UserProfile: "alice", AppType: "JupyterLab",
+		InstanceType: "ml.t3.medium", CreationTime: now.Add(-2 * time.Hour)}
+	testInput = Input{
+		Resources: []sagemaker.ResourceInfo{search, churn, studio},
+		Idle:      []sagemaker.ResourceInfo{churn},
+		Violations: []policy.Violation{
+			{Rule: "owner", ResourceType: "Endpoint", Resource: "search", Message: "missing tags owner"},
+			{Rule: "gpu-limit", Message: "2 instances running, limit is 1"},
+		},
+	}
+)
+
+func TestBuild(t *testing.T) {
+	d := Build(testInput, "us-east-1", now, 2)
+
+	var names []string
+	for _, entry := range d.Resources {
+		names = append(names, entry.Name)
+	}
+	assert.Equal(t, []string{"churn", "search", "alice/JupyterLab"}, names)
+	if assert.Len(t, d.TopSpenders, 2) {
+		assert.Equal(t, Entry{ResourceType: "Endpoint", Name: "search", InstanceType: "ml.g5.xlarge", Instances: 2, CreationTime: now.Add(-72 * time.Hour), HourlyCost: 2.816}, d.TopSpenders[0])
+		assert.Equal(t, "churn", d.TopSpenders[1].Name)
+	}
+	assert.Len(t, d.Idle, 1)
+	assert.InDelta(t, 2.816+0.115+0.05, d.HourlyCost, 0.001)
+	assert.Equal(t, "SageMaker digest us-east-1: 3 running, $2.98/h, 1 idle, 2 violations", d.Subject())
+}
+
+func TestRender(t *testing.T) {
+	d := Build(testInput, "us-east-1", now, 10)
+
+	text, err := d.Text()
+	assert.NoError(t, err)
+	assert.Contains(t, text, "SageMaker digest for us-east-1, 2026-10-18 07:00 UTC")
+	assert.Contains(t, text, "Top spenders\n  $2.82/h  Endpoint search (ml.g5.xlarge x2)\n")
+	assert.Contains(t, text, "Idle resources\n  Endpoint churn (ml.m5.large, $0.12/h)\n")
+	assert.Contains(t, text, "  gpu-limit: (all selected): 2 instances running, limit is 1\n")
+	assert.Contains(t, text, "  Endpoint search (ml.g5.xlarge x2, age 3d, $2.82/h)\n")
+
+	html, err := d.HTML()
+	assert.NoError(t, err)
+	assert.Contains(t, html, "<td>Endpoint</td><td>search</td><td>ml.g5.xlarge x2</td><td>$2.82</td>")
+	assert.Contains(t, html, "<h3>Policy violations</h3>")
+
+	empty, err := Build(Input{}, "us-east-1", now, 10).Text()
+	assert.NoError(t, err)
+	assert.NotContains(t, empty, "Top spenders")
+	assert.Contains(t, empty, "0 running resources, estimated -/h")
+}
+
+func TestRoute(t *testing.T) {
+	recipients := map[string][]string{
+		"search": {"search@example.com", "search-lead@example.com", "lead@example.com"},
+		"growth": {"growth@example.com", "lead@example.com", "all@example.com"},
+	}
+
+	routes := Route(testInput.Resources, []string{"all@example.com"}, "team", recipients)
+
+	assert.Equal(t, []Recipients{
+		{To: []string{"all@example.com"}, Resources: []sagemaker.ResourceInfo{search, churn, studio}, All: true},
+		{To: []string{"growth@example.com"}, Resources: []sagemaker.ResourceInfo{churn}},
+		{To: []string{"lead@example.com"}, Resources: []sagemaker.ResourceInfo{search, churn}},
+		{To: []string{"search-lead@example.com", "search@example.com"}, Resources: []sagemaker.ResourceInfo{search}},
+	}, routes)
+	assert.Empty(t, Route(testInput.Resources, nil, "", recipients))
+}
+
+func TestInputOnly(t *testing.T) {
+	in := testInput.Only([]sagemaker.ResourceInfo{search})
+
+	assert.Equal(t, []sagemaker.ResourceInfo{search}, in.Resources)
+	assert.Empty(t, in.Idle)
+	assert.Equal(t, []policy.Violation{testInput.Violations[0]}, in.Violations)
+}
+
+func TestInputOnly_StudioApps(t *testing.T) {
+	bobStudio := sagemaker.ResourceInfo{ResourceType: "Studio", Name: "default", UserProfile: "bob", AppType: "JupyterLab"}
+	in := Input{
+		Resources: []sagemaker.ResourceInfo{studio, bobStudio},
+		Idle:      []sagemaker.ResourceInfo{bobStudio},
+		Violations: []policy.Violation{
+			{Rule: "idle", ResourceType: "Studio", Resource: "default", UserProfile: "bob", Message: "idle for 1d"},
+		},
+	}
+
+	// Apps of the same name belong to different users
+	only := in.Only([]sagemaker.ResourceInfo{studio})
+	assert.Empty(t, only.Idle)
+	assert.Empty(t, only.Violations)
+
+	only = in.Only([]sagemaker.ResourceInfo{bobStudio})
+	assert.Equal(t, []sagemaker.ResourceInfo{bobStudio}, only.Idle)
+	assert.Equal(t, in.Violations, only.Violations)
+}
@@ -0,0 +1,102 @@
+package digest
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"mohua/internal/policy"
+)
+
+var funcs = map[string]interface{}{
+	"cost": func(cost float64) string {
+		if cost == 0 {
+			return "-"
+		}
+		return fmt.Sprintf("$%.2f", cost)
+	},
+	"age":  func(now, created time.Time) string { return policy.FormatDuration(now.Sub(created)) },
+	"time": func(t time.Time) string { return t.UTC().Format("2006-01-02 15:04 MST") },
+	"instance": func(e Entry) string {
+		if e.InstanceType == "" {
+			return "-"
+		}
+		if e.Instances > 1 {
+			return fmt.Sprintf("%s x%d", e.InstanceType, e.Instances)
+		}
+		return e.InstanceType
+	},
+	"resource": func(v policy.Violation) string {
+		if v.Resource == "" {
+			return "(all selected)"
+		}
+		return v.ResourceType + " " + v.Resource
+	},
+}
+
+var textTemplate = texttemplate.Must(texttemplate.New("text").Funcs(funcs).Parse(`SageMaker digest for {{.Region}}, {{time .Generated}}
+
+{{len .Resources}} running resources, estimated {{cost .HourlyCost}}/h
+{{if .TopSpenders}}
+Top spenders
+{{range .TopSpenders}}  {{cost .HourlyCost}}/h  {{.ResourceType}} {{.Name}} ({{instance .}})
+{{end}}{{end}}{{if .Idle}}
+Idle resources
+{{range .Idle}}  {{.ResourceType}} {{.Name}} ({{instance .}}, {{cost .HourlyCost}}/h)
+{{end}}{{end}}{{if .Violations}}
+Policy violations
+{{range .Violations}}  {{.Rule}}: {{resource .}}: {{.Message}}
+{{end}}{{end}}{{if .Resources}}
+Running resources
+{{range .Resources}}  {{.ResourceType}} {{.Name}} ({{instance .}}, age {{age $.Generated .CreationTime}}, {{cost .HourlyCost}}/h)
+{{end}}{{end}}`))
+
+var htmlTemplate = htmltemplate.Must(htmltemplate.New("html").Funcs(funcs).Parse(`<!DOCTYPE html>
+<html>
+<body style="font-family: sans-serif; font-size: 14px; color: #222;">
+<h2>SageMaker digest for {{.Region}}</h2>
+<p>{{time .Generated}}: <b>{{len .Resources}}</b> running resources, estimated <b>{{cost .HourlyCost}}/h</b></p>
+{{if .TopSpenders}}<h3>Top spenders</h3>
+<table cellpadding="4" style="border-collapse: collapse;">
+<tr style="text-align: left;"><th>Type</th><th>Name</th><th>Instances</th><th>Est. Cost/h</th></tr>
+{{range .TopSpenders}}<tr><td>{{.ResourceType}}</td><td>{{.Name}}</td><td>{{instance .}}</td><td>{{cost .HourlyCost}}</td></tr>
+{{end}}</table>
+{{end}}{{if .Idle}}<h3>Idle resources</h3>
+<table cellpadding="4" style="border-collapse: collapse;">
+<tr style="text-align: left;"><th>Type</th><th>Name</th><th>Instances</th><th>Est. Cost/h</th></tr>
+{{range .Idle}}<tr><td>{{.ResourceType}}</td><td>{{.Name}}</td><td>{{instance .}}</td><td>{{cost .HourlyCost}}</td></tr>
+{{end}}</table>
+{{end}}{{if .Violations}}<h3>Policy violations</h3>
+<table cellpadding="4" style="border-collapse: collapse;">
+<tr style="text-align: left;"><th>Rule</th><th>Resource</th><th>Violation</th></tr>
+{{range .Violations}}<tr><td>{{.Rule}}</td><td>{{resource .}}</td><td>{{.Message}}</td></tr>
+{{end}}</table>
+{{end}}{{if .Resources}}<h3>Running resources</h3>
+<table cellpadding="4" style="border-collapse: collapse;">
+<tr style="text-align: left;"><th>Type</th><th>Name</th><th>Instances</th><th>Age</th><th>Est. Cost/h</th></tr>
+{{range .Resources}}<tr><td>{{.ResourceType}}</td><td>{{.Name}}</td><td>{{instance .}}</td><td>{{age $.Generated .CreationTime}}</td><td>{{cost .HourlyCost}}</td></tr>
+{{end}}</table>
+{{end}}</body>
+</html>
+`))
+
+// Text renders the plain text body of the digest
+func (d Digest) Text() (string, error) {
+	var buf strings.Builder
+	if err := textTemplate.Execute(&buf, d); err != nil {
+		return "", fmt.Errorf("failed to render digest: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// HTML renders the HTML body of the digest
+func (d Digest) HTML() (string, error) {
+	var buf bytes.Buffer
+	if err := htmlTemplate.Execute(&buf, d); err != nil {
+		return "", fmt.Errorf("failed to render digest: %w", err)
+	}
+	return buf.String(), nil
+}
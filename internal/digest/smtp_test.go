@@ -0,0 +1,243 @@
+package digest
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"io"
+	"math/big"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// smtpStandIn is a minimal SMTP server that records what clients send
+type smtpStandIn struct {
+	host      string
+	port      int
+	tlsConfig *tls.Config // Advertises STARTTLS when set
+
+	mu      sync.Mutex
+	auth    string // Decoded AUTH PLAIN response
+	authTLS bool   // Whether AUTH was sent over TLS
+	from    string
+	to      []string
+	data    string
+}
+
+func newSMTPStandIn(t *testing.T, tlsConfig *tls.Config) *smtpStandIn {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	addr := listener.Addr().(*net.TCPAddr)
+	s := &smtpStandIn{host: "127.0.0.1", port: addr.Port, tlsConfig: tlsConfig}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *smtpStandIn) serve(conn net.Conn) {
+	defer conn.Close()
+
+	secure := false
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+	reply("220 127.0.0.1 ESMTP stand-in")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.TrimRight(line, "\r\n")
+		verb := strings.ToUpper(strings.SplitN(command, " ", 2)[0])
+
+		s.mu.Lock()
+		switch verb {
+		case "EHLO", "HELO":
+			if s.tlsConfig != nil && !secure {
+				reply("250-127.0.0.1")
+				reply("250-STARTTLS")
+			} else {
+				reply("250-127.0.0.1")
+			}
+			reply("250 AUTH PLAIN")
+		case "STARTTLS":
+			reply("220 ready to start TLS")
+			tlsConn := tls.Server(conn, s.tlsConfig)
+			if err := tlsConn.Handshake(); err != nil {
+				s.mu.Unlock()
+				return
+			}
+			conn, reader, secure = tlsConn, bufio.NewReader(tlsConn), true
+		case "AUTH":
+			fields := strings.Fields(command)
+			decoded, _ := base64.StdEncoding.DecodeString(fields[len(fields)-1])
+			s.auth, s.authTLS = string(decoded), secure
+			reply("235 authenticated")
+		case "MAIL":
+			s.from = strings.TrimPrefix(command, "MAIL FROM:")
+			reply("250 ok")
+		case "RCPT":
+			s.to = append(s.to, strings.TrimPrefix(command, "RCPT TO:"))
+			reply("250 ok")
+		case "DATA":
+			reply("354 end with .")
+			var data strings.Builder
+			for {
+				line, err := reader.ReadString('\n')
+				if err != nil {
+					s.mu.Unlock()
+					return
+				}
+				if line == ".\r\n" {
+					break
+				}
+				data.WriteString(strings.TrimPrefix(line, "."))
+			}
+			s.data = data.String()
+			reply("250 queued")
+		case "QUIT":
+			reply("221 bye")
+			s.mu.Unlock()
+			return
+		default:
+			reply("250 ok")
+		}
+		s.mu.Unlock()
+	}
+}
+
+// testCertificate returns a self-signed certificate for 127.0.0.1 and a
+// client config that trusts it
+func testCertificate(t *testing.T) (*tls.Config, *tls.Config) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	server := &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}}}
+	return server, &tls.Config{ServerName: "127.0.0.1", RootCAs: pool}
+}
+
+func testMailer(s *smtpStandIn, config SMTPConfig, clientTLS *tls.Config) Mailer {
+	config.Host, config.Port = s.host, s.port
+	mailer := NewSMTPMailer(config).(*smtpMailer)
+	if clientTLS != nil {
+		mailer.tlsConfig = clientTLS
+	}
+	mailer.now = func() time.Time { return now }
+	return mailer
+}
+
+var testMessage = Message{
+	From:    "mohua <mohua@example.com>",
+	To:      []string{"lead@example.com", "search@example.com"},
+	Subject: "SageMaker digest us-east-1: 3 running, $2.98/h",
+	Text:    "3 running resources\nestimated $2.98/h\n",
+	HTML:    "<p>3 running resources</p>",
+}
+
+func TestSMTPMailer_StartTLS(t *testing.T) {
+	serverTLS, clientTLS := testCertificate(t)
+	s := newSMTPStandIn(t, serverTLS)
+	mailer := testMailer(s, SMTPConfig{Username: "mohua", Password: "secret", StartTLS: true}, clientTLS)
+
+	err := mailer.Send(context.Background(), testMessage)
+
+	require.NoError(t, err)
+	assert.Equal(t, "\x00mohua\x00secret", s.auth)
+	assert.True(t, s.authTLS)
+	assert.Equal(t, "<mohua@example.com>", s.from)
+	assert.Equal(t, []string{"<lead@example.com>", "<search@example.com>"}, s.to)
+
+	message, err := mail.ReadMessage(strings.NewReader(s.data))
+	require.NoError(t, err)
+	assert.Equal(t, "lead@example.com, search@example.com", message.Header.Get("To"))
+	subject, err := new(mime.WordDecoder).DecodeHeader(message.Header.Get("Subject"))
+	assert.NoError(t, err)
+	assert.Equal(t, testMessage.Subject, subject)
+	assert.Equal(t, "Sun, 18 Oct 2026 07:00:00 +0000", message.Header.Get("Date"))
+
+	mediaType, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+	parts := multipart.NewReader(message.Body, params["boundary"])
+	for _, expected := range []struct{ contentType, body string }{
+		{"text/plain; charset=utf-8", "3 running resources\r\nestimated $2.98/h\r\n"},
+		{"text/html; charset=utf-8", testMessage.HTML},
+	} {
+		part, err := parts.NextPart()
+		require.NoError(t, err)
+		assert.Equal(t, expected.contentType, part.Header.Get("Content-Type"))
+		body, err := io.ReadAll(part)
+		assert.NoError(t, err)
+		assert.Equal(t, expected.body, string(body))
+	}
+}
+
+func TestSMTPMailer_StartTLSUnsupported(t *testing.T) {
+	s := newSMTPStandIn(t, nil)
+	mailer := testMailer(s, SMTPConfig{Username: "mohua", Password: "secret", StartTLS: true}, nil)
+
+	err := mailer.Send(context.Background(), testMessage)
+
+	assert.EqualError(t, err, "failed to send email via 127.0.0.1:"+strconv.Itoa(s.port)+": server does not support STARTTLS")
+	assert.Empty(t, s.auth)
+}
+
+func TestSMTPMailer_Plain(t *testing.T) {
+	s := newSMTPStandIn(t, nil)
+	mailer := testMailer(s, SMTPConfig{}, nil)
+
+	err := mailer.Send(context.Background(), Message{From: "mohua@example.com", To: []string{"lead@example.com"}, Subject: "digest"})
+
+	assert.NoError(t, err)
+	assert.Empty(t, s.auth)
+	assert.Equal(t, "<mohua@example.com>", s.from)
+	assert.Contains(t, s.data, "Subject: digest\r\n")
+}
+
+func TestSMTPMailer_ConnectionRefused(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	err = NewSMTPMailer(SMTPConfig{Host: "127.0.0.1", Port: port}).Send(context.Background(), testMessage)
+
+	assert.ErrorContains(t, err, "failed to connect to SMTP server")
+}
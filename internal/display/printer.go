@@ -6,35 +6,69 @@ import (
 	"io"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 )
 
 // ResourceInfo represents the information to be displayed for each resource
 type ResourceInfo struct {
-	ResourceType  string `json:"resourceType"`
-	Name         string `json:"name"`
-	Status       string `json:"status"`
-	InstanceType string `json:"instanceType"`
-	RunningTime  string `json:"runningTime"`
+	ResourceType string            `json:"resourceType"`
+	Name         string            `json:"name"`
+	Status       string            `json:"status"`
+	InstanceType string            `json:"instanceType"`
+	RunningTime  string            `json:"runningTime"`
+	Details      string            `json:"details,omitempty"`
+	HourlyCost   float64           `json:"hourlyCost,omitempty"`
+	Tags         map[string]string `json:"tags,omitempty"`
+	CreatedBy    string            `json:"createdBy,omitempty"`
+}
+
+// Envelope wraps JSON output that needs metadata. One of Resources, Summary,
+// Errors and Changes is set.
+type Envelope struct {
+	Resources interface{} `json:"resources,omitempty"`
+	Summary   interface{} `json:"summary,omitempty"`
+	Errors    interface{} `json:"errors,omitempty"`
+	Changes   interface{} `json:"changes,omitempty"`
+	Metadata  Metadata    `json:"metadata"`
+}
+
+// Metadata describes the scan that JSON output comes from
+type Metadata struct {
+	Region    string     `json:"region"`
+	Message   string     `json:"message,omitempty"`
+	ScannedAt *time.Time `json:"scannedAt,omitempty"`
 }
 
 // Printer handles the formatting and display of resource information
 type Printer struct {
-	useJSON bool
-	output  io.Writer
+	useJSON         bool
+	output          io.Writer
 	isFirstResource bool
+	tagColumns      []string
+	creatorColumn   bool
 }
 
 // NewPrinter creates a new printer instance
 func NewPrinter(useJSON bool) *Printer {
 	return &Printer{
-		useJSON: useJSON,
-		output:  os.Stdout,
+		useJSON:         useJSON,
+		output:          os.Stdout,
 		isFirstResource: true,
 	}
 }
 
+// SetTagColumns adds a table column for each tag key, shown before the details
+func (p *Printer) SetTagColumns(keys []string) {
+	p.tagColumns = keys
+}
+
+// SetCreatorColumn adds a column with the identity that created each resource
+func (p *Printer) SetCreatorColumn(show bool) {
+	p.creatorColumn = show
+}
+
 // PrintHeader prepares the output for resource listing
 func (p *Printer) PrintHeader() {
 	if p.useJSON {
@@ -42,11 +76,37 @@ func (p *Printer) PrintHeader() {
 	} else {
 		headerFmt := color.New(color.FgGreen, color.Bold).SprintfFunc()
 		fmt.Fprintf(p.output, "%s\n", headerFmt(
-			"%-15s %-30s %-12s %-15s %-15s",
-			"Type", "Name", "Status", "Instance", "Running Time",
+			"%-15s %-30s %-12s %-15s %-15s %s%s%s",
+			"Type", "Name", "Status", "Instance", "Running Time", p.creatorCell("Created By"), tagCells(p.tagColumns), "Details",
 		))
-		fmt.Fprintln(p.output, strings.Repeat("-", 120))
+		fmt.Fprintln(p.output, strings.Repeat("-", p.lineWidth()))
+	}
+}
+
+// tagCells formats one padded cell per tag column, each followed by a space
+func tagCells(values []string) string {
+	var cells strings.Builder
+	for _, value := range values {
+		fmt.Fprintf(&cells, "%-15s ", truncateString(value, 15))
+	}
+	return cells.String()
+}
+
+// creatorCell formats the creator column, or nothing when it is not shown
+func (p *Printer) creatorCell(creator string) string {
+	if !p.creatorColumn {
+		return ""
+	}
+	return fmt.Sprintf("%-30s ", truncateString(creator, 30))
+}
+
+// lineWidth is the width of the separator lines of the table
+func (p *Printer) lineWidth() int {
+	width := 120 + 16*len(p.tagColumns)
+	if p.creatorColumn {
+		width += 31
 	}
+	return width
 }
 
 // PrintResource outputs a single resource
@@ -63,25 +123,25 @@ func (p *Printer) printJSONResource(info ResourceInfo) {
 	if !p.isFirstResource {
 		fmt.Fprint(p.output, ",\n")
 	}
-	
+
 	data, err := json.Marshal(info)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error marshaling JSON: %v\n", err)
 		return
 	}
 	fmt.Fprint(p.output, "  ", string(data))
-	
+
 	p.isFirstResource = false
 }
 
 // printTableResource outputs a single resource in table format
 func (p *Printer) printTableResource(info ResourceInfo) {
 	statusColor := map[string]func(a ...interface{}) string{
-		"InService":  color.New(color.FgGreen).SprintFunc(),
-		"Running":    color.New(color.FgGreen).SprintFunc(),
-		"Stopped":    color.New(color.FgYellow).SprintFunc(),
-		"Failed":     color.New(color.FgRed).SprintFunc(),
-		"Deleting":   color.New(color.FgRed).SprintFunc(),
+		"InService": color.New(color.FgGreen).SprintFunc(),
+		"Running":   color.New(color.FgGreen).SprintFunc(),
+		"Stopped":   color.New(color.FgYellow).SprintFunc(),
+		"Failed":    color.New(color.FgRed).SprintFunc(),
+		"Deleting":  color.New(color.FgRed).SprintFunc(),
 	}
 
 	status := info.Status
@@ -89,12 +149,28 @@ func (p *Printer) printTableResource(info ResourceInfo) {
 		status = colorFunc(status)
 	}
 
-	fmt.Fprintf(p.output, "%-15s %-30s %-12s %-15s %-15s\n",
+	tagValues := make([]string, len(p.tagColumns))
+	for i, key := range p.tagColumns {
+		tagValues[i] = "-"
+		if value := info.Tags[key]; value != "" {
+			tagValues[i] = value
+		}
+	}
+
+	creator := info.CreatedBy
+	if creator == "" {
+		creator = "-"
+	}
+
+	fmt.Fprintf(p.output, "%-15s %-30s %-12s %-15s %-15s %s%s%s\n",
 		info.ResourceType,
 		truncateString(info.Name, 29),
 		status,
 		info.InstanceType,
 		info.RunningTime,
+		p.creatorCell(creator),
+		tagCells(tagValues),
+		info.Details,
 	)
 }
 
@@ -103,32 +179,19 @@ func (p *Printer) PrintFooter() {
 	if p.useJSON {
 		fmt.Fprint(p.output, "\n]\n")
 	} else {
-		fmt.Fprintln(p.output, strings.Repeat("-", 120))
+		fmt.Fprintln(p.output, strings.Repeat("-", p.lineWidth()))
 	}
 }
 
 // PrintNoResources handles the case when no resources are found
 func (p *Printer) PrintNoResources(region string) {
 	if p.useJSON {
-		// Create a JSON object with metadata about no resources
-		noResourcesJSON := struct {
-			Resources []interface{} `json:"resources"`
-			Metadata  struct {
-				Region  string `json:"region"`
-				Message string `json:"message"`
-			} `json:"metadata"`
-		}{
+		envelope := Envelope{
 			Resources: []interface{}{},
-			Metadata: struct {
-				Region  string `json:"region"`
-				Message string `json:"message"`
-			}{
-				Region:  region,
-				Message: "No resources found",
-			},
+			Metadata:  Metadata{Region: region, Message: "No resources found"},
 		}
 
-		jsonData, err := json.MarshalIndent(noResourcesJSON, "", "  ")
+		jsonData, err := json.MarshalIndent(envelope, "", "  ")
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error marshaling JSON: %v\n", err)
 			return
@@ -17,9 +17,9 @@ func TestPrinterNoResources(t *testing.T) {
 		expected string
 	}{
 		{
-			name:    "Table format no resources",
-			useJSON: false,
-			region:  "ap-northeast-1",
+			name:     "Table format no resources",
+			useJSON:  false,
+			region:   "ap-northeast-1",
 			expected: "No SageMaker resources found in region ap-northeast-1",
 		},
 		{
@@ -52,7 +52,7 @@ func TestPrinterNoResources(t *testing.T) {
 				var result map[string]interface{}
 				err := json.Unmarshal([]byte(output), &result)
 				assert.NoError(t, err)
-				
+
 				resources, ok := result["resources"].([]interface{})
 				assert.True(t, ok)
 				assert.Empty(t, resources)
@@ -68,6 +68,40 @@ func TestPrinterNoResources(t *testing.T) {
 	}
 }
 
+func TestPrinterTagColumns(t *testing.T) {
+	var buf bytes.Buffer
+	printer := &Printer{output: &buf, isFirstResource: true}
+	printer.SetTagColumns([]string{"owner", "team"})
+
+	printer.PrintHeader()
+	printer.PrintResource(ResourceInfo{
+		ResourceType: "Endpoint",
+		Name:         "churn",
+		Status:       "InService",
+		InstanceType: "ml.m5.xlarge",
+		RunningTime:  "1h",
+		Tags:         map[string]string{"owner": "ml-platform"},
+	})
+
+	lines := strings.Split(buf.String(), "\n")
+	assert.Equal(t, "Type            Name                           Status       Instance        Running Time    owner           team            Details", lines[0])
+	assert.Equal(t, "Endpoint        churn                          InService    ml.m5.xlarge    1h              ml-platform     -               ", lines[2])
+}
+
+func TestPrinterCreatorColumn(t *testing.T) {
+	var buf bytes.Buffer
+	printer := &Printer{output: &buf, isFirstResource: true}
+	printer.SetCreatorColumn(true)
+
+	printer.PrintHeader()
+	printer.PrintResource(ResourceInfo{ResourceType: "Notebook", Name: "scratch", Status: "InService", RunningTime: "1h"})
+
+	lines := strings.Split(buf.String(), "\n")
+	assert.Contains(t, lines[0], "Running Time    Created By                     Details")
+	assert.Len(t, lines[1], 151)
+	assert.Contains(t, lines[2], "1h              -                              ")
+}
+
 func TestPrinterResourceOutput(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -79,22 +113,38 @@ func TestPrinterResourceOutput(t *testing.T) {
 			name:    "Table format single resource",
 			useJSON: false,
 			resource: ResourceInfo{
-				ResourceType:  "Endpoint",
+				ResourceType: "Endpoint",
 				Name:         "test-endpoint",
 				Status:       "InService",
 				InstanceType: "ml.t3.medium",
 				RunningTime:  "1h",
 			},
-			expected: `Type            Name                           Status       Instance        Running Time   
+			expected: `Type            Name                           Status       Instance        Running Time    Details
+------------------------------------------------------------------------------------------------------------------------
+Endpoint        test-endpoint                  InService    ml.t3.medium    1h              
+------------------------------------------------------------------------------------------------------------------------`,
+		},
+		{
+			name:    "Table format resource with details",
+			useJSON: false,
+			resource: ResourceInfo{
+				ResourceType: "Tuning",
+				Name:         "xgb-tuning",
+				Status:       "InProgress",
+				InstanceType: "ml.m5.xlarge",
+				RunningTime:  "3h",
+				Details:      "jobs=4/2/0",
+			},
+			expected: `Type            Name                           Status       Instance        Running Time    Details
 ------------------------------------------------------------------------------------------------------------------------
-Endpoint        test-endpoint                  InService    ml.t3.medium    1h             
+Tuning          xgb-tuning                     InProgress   ml.m5.xlarge    3h              jobs=4/2/0
 ------------------------------------------------------------------------------------------------------------------------`,
 		},
 		{
 			name:    "JSON format single resource",
 			useJSON: true,
 			resource: ResourceInfo{
-				ResourceType:  "Notebook",
+				ResourceType: "Notebook",
 				Name:         "test-notebook",
 				Status:       "InService",
 				InstanceType: "ml.t3.medium",
@@ -110,8 +160,8 @@ Endpoint        test-endpoint                  InService    ml.t3.medium    1h
 		t.Run(tt.name, func(t *testing.T) {
 			var buf bytes.Buffer
 			printer := &Printer{
-				useJSON: tt.useJSON,
-				output:  &buf,
+				useJSON:         tt.useJSON,
+				output:          &buf,
 				isFirstResource: true,
 			}
 
@@ -0,0 +1,26 @@
+package display
+
+import "strings"
+
+// sparkBlocks are the bar characters of a sparkline, lowest first
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders values as a line of bars scaled to the highest value
+func Sparkline(values []float64) string {
+	highest := 0.0
+	for _, value := range values {
+		if value > highest {
+			highest = value
+		}
+	}
+
+	var line strings.Builder
+	for _, value := range values {
+		level := 0
+		if highest > 0 && value > 0 {
+			level = int(value / highest * float64(len(sparkBlocks)-1))
+		}
+		line.WriteRune(sparkBlocks[level])
+	}
+	return line.String()
+}
@@ -0,0 +1,13 @@
+package display
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSparkline(t *testing.T) {
+	assert.Equal(t, "", Sparkline(nil))
+	assert.Equal(t, "▁▁▁", Sparkline([]float64{0, 0, 0}))
+	assert.Equal(t, "▁▄█▁", Sparkline([]float64{0, 5, 10, -1}))
+}
@@ -0,0 +1,78 @@
+package display
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+)
+
+// PrintTable outputs rows as an aligned table with a colored header
+func (p *Printer) PrintTable(headers []string, rows [][]string) {
+	p.PrintHighlightedTable(headers, rows, nil)
+}
+
+// PrintHighlightedTable outputs rows as an aligned table, coloring the rows
+// whose index is set in highlight
+func (p *Printer) PrintHighlightedTable(headers []string, rows [][]string, highlight []bool) {
+	widths := make([]int, len(headers))
+	for i, header := range headers {
+		widths[i] = len(header)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			// Widths count runes like the padding does, e.g. for sparklines
+			if i < len(widths) && utf8.RuneCountInString(cell) > widths[i] {
+				widths[i] = utf8.RuneCountInString(cell)
+			}
+		}
+	}
+
+	totalWidth := 0
+	for _, width := range widths {
+		totalWidth += width + 1
+	}
+
+	headerFmt := color.New(color.FgGreen, color.Bold).SprintFunc()
+	fmt.Fprintln(p.output, headerFmt(formatRow(headers, widths)))
+	fmt.Fprintln(p.output, strings.Repeat("-", totalWidth))
+	highlightFmt := color.New(color.FgYellow).SprintFunc()
+	for i, row := range rows {
+		line := formatRow(row, widths)
+		if i < len(highlight) && highlight[i] {
+			line = highlightFmt(line)
+		}
+		fmt.Fprintln(p.output, line)
+	}
+	fmt.Fprintln(p.output, strings.Repeat("-", totalWidth))
+}
+
+// PrintSummary outputs a single summary line below a table
+func (p *Printer) PrintSummary(format string, a ...interface{}) {
+	fmt.Fprintf(p.output, format+"\n", a...)
+}
+
+// PrintJSON outputs an arbitrary value as indented JSON
+func (p *Printer) PrintJSON(v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	fmt.Fprintln(p.output, string(data))
+	return nil
+}
+
+// formatRow pads each cell to its column width
+func formatRow(cells []string, widths []int) string {
+	padded := make([]string, len(widths))
+	for i := range widths {
+		cell := ""
+		if i < len(cells) {
+			cell = cells[i]
+		}
+		padded[i] = fmt.Sprintf("%-*s", widths[i], cell)
+	}
+	return strings.TrimRight(strings.Join(padded, " "), " ")
+}
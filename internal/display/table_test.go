@@ -0,0 +1,59 @@
+package display
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintTable(t *testing.T) {
+	color.NoColor = true
+	var buf bytes.Buffer
+	printer := &Printer{output: &buf}
+
+	printer.PrintTable(
+		[]string{"Endpoint", "Min"},
+		[][]string{
+			{"short", "1"},
+			{"a-much-longer-name", "10"},
+		},
+	)
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	assert.Len(t, lines, 5)
+	assert.Equal(t, "Endpoint           Min", lines[0])
+	assert.Equal(t, "short              1", lines[2])
+	assert.Equal(t, "a-much-longer-name 10", lines[3])
+	assert.Equal(t, strings.Repeat("-", 23), lines[1])
+}
+
+func TestPrintJSON(t *testing.T) {
+	var buf bytes.Buffer
+	printer := &Printer{useJSON: true, output: &buf}
+
+	err := printer.PrintJSON([]map[string]int{{"min": 1}})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[{"min": 1}]`, buf.String())
+}
+
+func TestPrintHighlightedTable(t *testing.T) {
+	color.NoColor = false
+	defer func() { color.NoColor = true }()
+
+	var buf bytes.Buffer
+	printer := &Printer{output: &buf}
+
+	printer.PrintHighlightedTable(
+		[]string{"Name"},
+		[][]string{{"plain"}, {"stale"}},
+		[]bool{false, true},
+	)
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	assert.Equal(t, "plain", lines[2])
+	assert.Contains(t, lines[3], "\x1b[33m")
+	assert.Contains(t, lines[3], "stale")
+}
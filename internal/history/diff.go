@@ -0,0 +1,146 @@
+package history
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Kinds of changes between two snapshots
+const (
+	Added           = "added"
+	Removed         = "removed"
+	StatusChanged   = "status"
+	InstanceChanged = "instance"
+)
+
+// kindOrder sorts changes by kind
+var kindOrder = map[string]int{Added: 0, Removed: 1, StatusChanged: 2, InstanceChanged: 3}
+
+// Change is a difference of one resource between two snapshots
+type Change struct {
+	Kind         string `json:"change"`
+	ResourceType string `json:"type"`
+	Name         string `json:"name"`
+	From         string `json:"from,omitempty"` // Status or instances before a change
+	To           string `json:"to,omitempty"`   // Status or instances after a change
+}
+
+// DisplayName returns the name shown for the resource, which is the user
+// profile and app type for Studio apps
+func (r Resource) DisplayName() string {
+	if r.ResourceType == "Studio" {
+		return fmt.Sprintf("%s/%s", r.UserProfile, r.AppType)
+	}
+	return r.Name
+}
+
+// key identifies a resource across snapshots. Studio app names are only
+// unique within a user profile or space.
+func (r Resource) key() string {
+	return strings.Join([]string{r.ResourceType, r.UserProfile, r.SpaceName, r.Name}, "/")
+}
+
+// Instances describes the running instances, e.g. "ml.g5.xlarge x2"
+func (r Resource) Instances() string {
+	if len(r.InstanceCounts) == 0 {
+		return r.InstanceType
+	}
+	types := make([]string, 0, len(r.InstanceCounts))
+	for instanceType := range r.InstanceCounts {
+		types = append(types, instanceType)
+	}
+	sort.Strings(types)
+
+	parts := make([]string, len(types))
+	for i, instanceType := range types {
+		parts[i] = instanceType
+		if count := r.InstanceCounts[instanceType]; count > 1 {
+			parts[i] = fmt.Sprintf("%s x%d", instanceType, count)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Diff returns the resources added, removed, with a changed status and with
+// changed instances between two snapshots. Resource types that were not
+// collected completely in either snapshot are not reported as added or removed.
+func Diff(from, to Snapshot) []Change {
+	before := make(map[string]Resource, len(from.Resources))
+	for _, resource := range from.Resources {
+		before[resource.key()] = resource
+	}
+
+	var changes []Change
+	seen := make(map[string]bool, len(to.Resources))
+	for _, resource := range to.Resources {
+		key := resource.key()
+		seen[key] = true
+		previous, ok := before[key]
+		if !ok {
+			if !contains(from.Incomplete, resource.ResourceType) {
+				changes = append(changes, change(Added, resource, "", ""))
+			}
+			continue
+		}
+		if previous.Status != resource.Status {
+			changes = append(changes, change(StatusChanged, resource, previous.Status, resource.Status))
+		}
+		if previous.Instances() != resource.Instances() {
+			changes = append(changes, change(InstanceChanged, resource, previous.Instances(), resource.Instances()))
+		}
+	}
+	for _, resource := range from.Resources {
+		if !seen[resource.key()] && !contains(to.Incomplete, resource.ResourceType) {
+			changes = append(changes, change(Removed, resource, "", ""))
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		a, b := changes[i], changes[j]
+		if a.Kind != b.Kind {
+			return kindOrder[a.Kind] < kindOrder[b.Kind]
+		}
+		if a.ResourceType != b.ResourceType {
+			return a.ResourceType < b.ResourceType
+		}
+		return a.Name < b.Name
+	})
+	return changes
+}
+
+func change(kind string, resource Resource, from, to string) Change {
+	return Change{Kind: kind, ResourceType: resource.ResourceType, Name: resource.DisplayName(), From: from, To: to}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Find returns the snapshot with an ID
+func Find(snapshots []Snapshot, id string) (Snapshot, bool) {
+	for _, snapshot := range snapshots {
+		if snapshot.ID == id {
+			return snapshot, true
+		}
+	}
+	return Snapshot{}, false
+}
+
+// Latest returns the newest snapshot of a region taken at or before t. An
+// empty region matches every region.
+func Latest(snapshots []Snapshot, region string, t time.Time) (Snapshot, bool) {
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		snapshot := snapshots[i]
+		if (region == "" || snapshot.Region == region) && !snapshot.Time.After(t) {
+			return snapshot, true
+		}
+	}
+	return Snapshot{}, false
+}
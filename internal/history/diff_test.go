@@ -0,0 +1,78 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	from := Snapshot{Resources: []Resource{
+		{ResourceType: "Endpoint", Name: "churn", Status: "InService", InstanceCounts: map[string]int{"ml.m5.xlarge": 2}},
+		{ResourceType: "Endpoint", Name: "legacy", Status: "InService"},
+		{ResourceType: "Notebook", Name: "dev", Status: "InService", InstanceType: "ml.t3.medium"},
+		{ResourceType: "Studio", Name: "default", UserProfile: "alice", AppType: "JupyterLab", Status: "InService"},
+	}}
+	to := Snapshot{Resources: []Resource{
+		{ResourceType: "Endpoint", Name: "churn", Status: "Updating", InstanceCounts: map[string]int{"ml.m5.xlarge": 4}},
+		{ResourceType: "Notebook", Name: "dev", Status: "InService", InstanceType: "ml.t3.medium"},
+		{ResourceType: "Studio", Name: "default", UserProfile: "alice", AppType: "JupyterLab", Status: "InService"},
+		{ResourceType: "Studio", Name: "default", UserProfile: "bob", AppType: "JupyterLab", Status: "Pending"},
+	}}
+
+	assert.Equal(t, []Change{
+		{Kind: Added, ResourceType: "Studio", Name: "bob/JupyterLab"},
+		{Kind: Removed, ResourceType: "Endpoint", Name: "legacy"},
+		{Kind: StatusChanged, ResourceType: "Endpoint", Name: "churn", From: "InService", To: "Updating"},
+		{Kind: InstanceChanged, ResourceType: "Endpoint", Name: "churn", From: "ml.m5.xlarge x2", To: "ml.m5.xlarge x4"},
+	}, Diff(from, to))
+}
+
+func TestDiff_Incomplete(t *testing.T) {
+	from := Snapshot{
+		Resources:  []Resource{{ResourceType: "Endpoint", Name: "churn"}},
+		Incomplete: []string{"Notebook"},
+	}
+	to := Snapshot{
+		Resources:  []Resource{{ResourceType: "Notebook", Name: "dev"}},
+		Incomplete: []string{"Endpoint"},
+	}
+
+	// Neither the endpoint nor the notebook is known to have changed
+	assert.Empty(t, Diff(from, to))
+}
+
+func TestResourceInstances(t *testing.T) {
+	assert.Equal(t, "ml.t3.medium", Resource{InstanceType: "ml.t3.medium"}.Instances())
+	assert.Equal(t, "ml.g5.xlarge x2, ml.m5.large", Resource{
+		InstanceType:   "multiple",
+		InstanceCounts: map[string]int{"ml.m5.large": 1, "ml.g5.xlarge": 2},
+	}.Instances())
+}
+
+func TestLatestAndFind(t *testing.T) {
+	base := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	snapshots := []Snapshot{
+		{ID: "a", Region: "us-east-1", Time: base},
+		{ID: "b", Region: "eu-west-1", Time: base.Add(time.Hour)},
+		{ID: "c", Region: "us-east-1", Time: base.Add(2 * time.Hour)},
+	}
+
+	snapshot, ok := Latest(snapshots, "us-east-1", base.Add(90*time.Minute))
+	assert.True(t, ok)
+	assert.Equal(t, "a", snapshot.ID)
+
+	snapshot, ok = Latest(snapshots, "", base.Add(90*time.Minute))
+	assert.True(t, ok)
+	assert.Equal(t, "b", snapshot.ID)
+
+	_, ok = Latest(snapshots, "us-east-1", base.Add(-time.Minute))
+	assert.False(t, ok)
+
+	found, ok := Find(snapshots, "c")
+	assert.True(t, ok)
+	assert.Equal(t, base.Add(2*time.Hour), found.Time)
+	_, ok = Find(snapshots, "d")
+	assert.False(t, ok)
+}
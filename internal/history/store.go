@@ -0,0 +1,304 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"mohua/internal/sagemaker"
+)
+
+// fileName is the name of the JSON Lines file holding one snapshot per line
+const fileName = "snapshots.jsonl"
+
+// lockName is the name of the file locked while the history is written
+const lockName = fileName + ".lock"
+
+// idFormat formats the time part of snapshot IDs from the UTC time of the scan
+const idFormat = "20060102T150405Z"
+
+// Resource is a resource as it was seen by a scan
+type Resource struct {
+	ResourceType   string            `json:"type"`
+	Name           string            `json:"name"`
+	Arn            string            `json:"arn,omitempty"`
+	Status         string            `json:"status"`
+	InstanceType   string            `json:"instanceType,omitempty"`
+	InstanceCounts map[string]int    `json:"instances,omitempty"` // Running instances per type
+	UserProfile    string            `json:"userProfile,omitempty"`
+	SpaceName      string            `json:"spaceName,omitempty"`
+	AppType        string            `json:"appType,omitempty"`
+	HourlyCost     float64           `json:"hourlyCost,omitempty"` // Estimated for all instances
+	CreationTime   time.Time         `json:"creationTime"`
+	Tags           map[string]string `json:"tags,omitempty"` // Only set when tags were looked up
+}
+
+// Snapshot holds the resources of one region found by a scan
+type Snapshot struct {
+	ID        string     `json:"id"`
+	Time      time.Time  `json:"time"`
+	Region    string     `json:"region"`
+	Resources []Resource `json:"resources"`
+	// Incomplete lists the resource types whose collector failed, so their
+	// absence is not mistaken for a removal
+	Incomplete []string `json:"incomplete,omitempty"`
+}
+
+// NewResource converts a collected resource to its history record
+func NewResource(r sagemaker.ResourceInfo) Resource {
+	return Resource{
+		ResourceType:   r.ResourceType,
+		Name:           r.Name,
+		Arn:            r.Arn,
+		Status:         r.Status,
+		InstanceType:   r.InstanceType,
+		InstanceCounts: r.Instances(),
+		UserProfile:    r.UserProfile,
+		SpaceName:      r.SpaceName,
+		AppType:        r.AppType,
+		HourlyCost:     r.EstimatedHourlyCost(),
+		CreationTime:   r.CreationTime,
+		Tags:           r.Tags,
+	}
+}
+
+// Store appends snapshots to a JSON Lines file and removes those older than
+// the retention. Writers in several processes, e.g. a cron run next to the
+// daemon, take a lock on a sidecar file, so a prune never drops an append.
+type Store struct {
+	path      string
+	lockPath  string
+	retention time.Duration
+	now       func() time.Time
+}
+
+// NewStore creates a store in dir. A retention of 0 keeps every snapshot.
+func NewStore(dir string, retention time.Duration) *Store {
+	return &Store{
+		path:      filepath.Join(dir, fileName),
+		lockPath:  filepath.Join(dir, lockName),
+		retention: retention,
+		now:       time.Now,
+	}
+}
+
+// DefaultDir returns mohua in the user data directory, $XDG_DATA_HOME or
+// ~/.local/share
+func DefaultDir() (string, error) {
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return filepath.Join(dir, "mohua"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "share", "mohua"), nil
+}
+
+// Record appends a snapshot of the resources of a region and removes expired snapshots
+func (s *Store) Record(region string, resources []Resource, incomplete []string) (Snapshot, error) {
+	now := s.now().UTC().Truncate(time.Second)
+	snapshot := Snapshot{
+		Time:       now,
+		Region:     region,
+		Resources:  resources,
+		Incomplete: incomplete,
+	}
+	if snapshot.Resources == nil {
+		snapshot.Resources = []Resource{}
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return Snapshot{}, fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	unlock, err := s.lock()
+	if err != nil {
+		return Snapshot{}, err
+	}
+	defer unlock()
+
+	if snapshot.ID, err = s.nextID(now, region); err != nil {
+		return Snapshot{}, err
+	}
+	line, err := json.Marshal(snapshot)
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	if err := s.append(line); err != nil {
+		return Snapshot{}, err
+	}
+	if err := s.prune(); err != nil {
+		return Snapshot{}, err
+	}
+	return snapshot, nil
+}
+
+// nextID returns a new snapshot ID from the time and region of a scan, e.g.
+// 20261018T120000Z-us-east-1, so the scans of several regions in the same
+// second are told apart. Another scan of the region in the same second, e.g.
+// by a cron run next to the daemon, gets a numbered suffix. The caller holds
+// the lock.
+func (s *Store) nextID(t time.Time, region string) (string, error) {
+	base := t.Format(idFormat)
+	if region != "" {
+		base += "-" + region
+	}
+
+	taken := make(map[string]bool)
+	err := s.scan(func(snapshot Snapshot, line []byte) error {
+		if strings.HasPrefix(snapshot.ID, base) {
+			taken[snapshot.ID] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	id := base
+	for n := 2; taken[id]; n++ {
+		id = fmt.Sprintf("%s-%d", base, n)
+	}
+	return id, nil
+}
+
+// append writes a line to the end of the history. The caller holds the lock.
+func (s *Store) append(line []byte) error {
+	file, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open history: %w", err)
+	}
+	// A single write keeps the line whole for readers that do not take the lock
+	_, err = file.Write(append(line, '\n'))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+// lock blocks until this process is the only writer of the history and
+// returns the function that releases the lock
+func (s *Store) lock() (func(), error) {
+	file, err := os.OpenFile(s.lockPath, os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history lock: %w", err)
+	}
+	if err := lockFile(file); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to lock history: %w", err)
+	}
+	return func() {
+		unlockFile(file)
+		file.Close()
+	}, nil
+}
+
+// Snapshots returns every stored snapshot, oldest first
+func (s *Store) Snapshots() ([]Snapshot, error) {
+	var snapshots []Snapshot
+	err := s.scan(func(snapshot Snapshot, line []byte) error {
+		snapshots = append(snapshots, snapshot)
+		return nil
+	})
+	return snapshots, err
+}
+
+// scan calls fn with every snapshot and its line. A last line without a
+// newline was cut off while being written and is skipped.
+func (s *Store) scan(fn func(snapshot Snapshot, line []byte) error) error {
+	file, err := os.Open(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open history: %w", err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	for number := 1; ; number++ {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read history: %w", err)
+		}
+
+		var snapshot Snapshot
+		if err := json.Unmarshal(line, &snapshot); err != nil {
+			return fmt.Errorf("failed to parse line %d of %s: %w", number, s.path, err)
+		}
+		if err := fn(snapshot, line); err != nil {
+			return err
+		}
+	}
+}
+
+// prune rewrites the history without the snapshots older than the retention.
+// Snapshots are appended in time order, so nothing has expired while the
+// first one has not. The caller holds the lock, so no snapshot is appended
+// between the scan and the rename.
+func (s *Store) prune() error {
+	if s.retention <= 0 {
+		return nil
+	}
+	cutoff := s.now().Add(-s.retention)
+
+	first, err := s.first()
+	if err != nil || first == nil || !first.Time.Before(cutoff) {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), fileName+".*")
+	if err != nil {
+		return fmt.Errorf("failed to prune history: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	writer := bufio.NewWriter(tmp)
+	err = s.scan(func(snapshot Snapshot, line []byte) error {
+		if snapshot.Time.Before(cutoff) {
+			return nil
+		}
+		_, err := writer.Write(line)
+		return err
+	})
+	if err == nil {
+		err = writer.Flush()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), s.path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to prune history: %w", err)
+	}
+	return nil
+}
+
+// first returns the oldest snapshot, or nil when the history is empty
+func (s *Store) first() (*Snapshot, error) {
+	var first *Snapshot
+	stop := errors.New("stop")
+	err := s.scan(func(snapshot Snapshot, line []byte) error {
+		first = &snapshot
+		return stop
+	})
+	if err != nil && err != stop {
+		return nil, err
+	}
+	return first, nil
+}
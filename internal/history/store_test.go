@@ -0,0 +1,172 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"mohua/internal/sagemaker"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewResource(t *testing.T) {
+	created := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	resource := NewResource(sagemaker.ResourceInfo{
+		ResourceType:  "Endpoint",
+		Name:          "churn",
+		Status:        "InService",
+		InstanceType:  "ml.m5.xlarge",
+		InstanceCount: 2,
+		CreationTime:  created,
+	})
+
+	assert.Equal(t, Resource{
+		ResourceType:   "Endpoint",
+		Name:           "churn",
+		Status:         "InService",
+		InstanceType:   "ml.m5.xlarge",
+		InstanceCounts: map[string]int{"ml.m5.xlarge": 2},
+		HourlyCost:     0.46,
+		CreationTime:   created,
+	}, resource)
+}
+
+func TestStore_RecordAndSnapshots(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 500, time.UTC)
+	store := NewStore(filepath.Join(t.TempDir(), "mohua"), 0)
+	store.now = func() time.Time { return now }
+
+	snapshots, err := store.Snapshots()
+	assert.NoError(t, err)
+	assert.Empty(t, snapshots)
+
+	first, err := store.Record("us-east-1", nil, []string{"Notebook"})
+	assert.NoError(t, err)
+	assert.Equal(t, "20261018T120000Z-us-east-1", first.ID)
+
+	now = now.Add(time.Hour)
+	second, err := store.Record("us-east-1", []Resource{{ResourceType: "Endpoint", Name: "churn", Status: "InService"}}, nil)
+	assert.NoError(t, err)
+
+	snapshots, err = store.Snapshots()
+	assert.NoError(t, err)
+	assert.Equal(t, []Snapshot{first, second}, snapshots)
+	assert.Equal(t, []Resource{}, snapshots[0].Resources)
+	assert.Equal(t, []string{"Notebook"}, snapshots[0].Incomplete)
+}
+
+func TestStore_UniqueIDs(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	store := NewStore(t.TempDir(), 0)
+	store.now = func() time.Time { return now }
+
+	// Scans of several regions, and of one region twice, in the same second
+	var ids []string
+	for _, region := range []string{"us-east-1", "eu-west-1", "us-east-1", "us-east-1"} {
+		snapshot, err := store.Record(region, nil, nil)
+		assert.NoError(t, err)
+		ids = append(ids, snapshot.ID)
+	}
+
+	assert.Equal(t, []string{
+		"20261018T120000Z-us-east-1",
+		"20261018T120000Z-eu-west-1",
+		"20261018T120000Z-us-east-1-2",
+		"20261018T120000Z-us-east-1-3",
+	}, ids)
+}
+
+func TestStore_Retention(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	store := NewStore(t.TempDir(), 48*time.Hour)
+	store.now = func() time.Time { return now }
+
+	for i := 0; i < 4; i++ {
+		_, err := store.Record("us-east-1", nil, nil)
+		assert.NoError(t, err)
+		now = now.Add(24 * time.Hour)
+	}
+
+	snapshots, err := store.Snapshots()
+	assert.NoError(t, err)
+	var ids []string
+	for _, snapshot := range snapshots {
+		ids = append(ids, snapshot.ID)
+	}
+	// The last snapshot was recorded at day 3, so day 0 is older than 48h
+	assert.Equal(t, []string{"20261019T120000Z-us-east-1", "20261020T120000Z-us-east-1", "20261021T120000Z-us-east-1"}, ids)
+}
+
+func TestStore_RecordWaitsForLock(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(dir, 0)
+	_, err := store.Record("us-east-1", nil, nil)
+	assert.NoError(t, err)
+
+	// Another process, e.g. the daemon, is pruning the history
+	other := NewStore(dir, 48*time.Hour)
+	unlock, err := other.lock()
+	assert.NoError(t, err)
+
+	done := make(chan error)
+	go func() {
+		_, err := store.Record("eu-west-1", nil, nil)
+		done <- err
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("Record appended while another store held the lock")
+	case <-time.After(100 * time.Millisecond):
+	}
+	snapshots, err := store.Snapshots()
+	assert.NoError(t, err)
+	assert.Len(t, snapshots, 1)
+
+	unlock()
+	assert.NoError(t, <-done)
+	snapshots, err = store.Snapshots()
+	assert.NoError(t, err)
+	assert.Len(t, snapshots, 2)
+}
+
+func TestStore_PartialLine(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(dir, 0)
+	_, err := store.Record("us-east-1", nil, nil)
+	assert.NoError(t, err)
+
+	// A write cut off by a crash leaves a line without a newline
+	file, err := os.OpenFile(filepath.Join(dir, fileName), os.O_APPEND|os.O_WRONLY, 0)
+	assert.NoError(t, err)
+	_, err = file.WriteString(`{"id":"2026`)
+	assert.NoError(t, err)
+	assert.NoError(t, file.Close())
+
+	snapshots, err := store.Snapshots()
+	assert.NoError(t, err)
+	assert.Len(t, snapshots, 1)
+}
+
+func TestStore_CorruptLine(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, fileName), []byte("not json\n"), 0o600))
+
+	_, err := NewStore(dir, 0).Snapshots()
+	assert.ErrorContains(t, err, "failed to parse line 1")
+}
+
+func TestDefaultDir(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", "/data")
+	dir, err := DefaultDir()
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join("/data", "mohua"), dir)
+
+	t.Setenv("XDG_DATA_HOME", "")
+	t.Setenv("HOME", "/home/alice")
+	dir, err = DefaultDir()
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join("/home/alice", ".local", "share", "mohua"), dir)
+}
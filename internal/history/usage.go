@@ -0,0 +1,149 @@
+package history
+
+import (
+	"sort"
+	"time"
+
+	"mohua/internal/pricing"
+)
+
+// Period is the range of days a usage report covers
+type Period struct {
+	Start time.Time // Midnight of the first day
+	Days  int
+}
+
+// LastDays returns the period of n days ending with the day of now
+func LastDays(now time.Time, n int) Period {
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return Period{Start: midnight.AddDate(0, 0, 1-n), Days: n}
+}
+
+// End returns the end of the last day of the period
+func (p Period) End() time.Time {
+	return p.Start.AddDate(0, 0, p.Days)
+}
+
+// UsageGroup is the usage of the resources that share a group
+type UsageGroup struct {
+	Name          string    `json:"name"`
+	InstanceHours float64   `json:"instanceHours"`
+	Cost          float64   `json:"estimatedCost"`
+	DailyCost     []float64 `json:"dailyCost"` // One value per day of the period
+}
+
+// GroupFunc returns the group of the instances of one type of a resource.
+// instanceType is empty for resources billed without instances, such as
+// MLflow tracking servers.
+type GroupFunc func(resource Resource, instanceType string) string
+
+// Usage integrates the presence of resources across snapshots into
+// instance-hours and estimated cost per group. The resources of a snapshot
+// count until the next snapshot of the same region, or until now for the
+// latest one, but for at most maxGap, so periods without scans are not
+// assumed to be running. The resource types whose collector failed in a
+// snapshot count with their resources of the previous snapshot of the region,
+// when it was taken at most maxGap before. Groups are sorted by cost, highest
+// first.
+func Usage(snapshots []Snapshot, period Period, now time.Time, maxGap time.Duration, group GroupFunc) []UsageGroup {
+	groups := make(map[string]*UsageGroup)
+	add := func(name string, from, to time.Time, instances, hourlyCost float64) {
+		usage, ok := groups[name]
+		if !ok {
+			usage = &UsageGroup{Name: name, DailyCost: make([]float64, period.Days)}
+			groups[name] = usage
+		}
+		for day := 0; day < period.Days; day++ {
+			dayStart := period.Start.AddDate(0, 0, day)
+			hours := overlap(from, to, dayStart, dayStart.AddDate(0, 0, 1)).Hours()
+			usage.InstanceHours += instances * hours
+			usage.Cost += hourlyCost * hours
+			usage.DailyCost[day] += hourlyCost * hours
+		}
+	}
+
+	// The resources of the previous snapshot of each region, including
+	// those it carried forward itself
+	type regionState struct {
+		time      time.Time
+		resources []Resource
+	}
+	previous := make(map[string]regionState)
+
+	for i, snapshot := range snapshots {
+		resources := snapshot.Resources
+		if prev, ok := previous[snapshot.Region]; ok && len(snapshot.Incomplete) > 0 && snapshot.Time.Sub(prev.time) <= maxGap {
+			resources = carryForward(resources, prev.resources, snapshot.Incomplete)
+		}
+		previous[snapshot.Region] = regionState{time: snapshot.Time, resources: resources}
+
+		end := now
+		for _, next := range snapshots[i+1:] {
+			if next.Region == snapshot.Region {
+				end = next.Time
+				break
+			}
+		}
+		if limit := snapshot.Time.Add(maxGap); end.After(limit) {
+			end = limit
+		}
+		if overlap(snapshot.Time, end, period.Start, period.End()) <= 0 {
+			continue
+		}
+
+		for _, resource := range resources {
+			if len(resource.InstanceCounts) == 0 {
+				if resource.HourlyCost > 0 {
+					add(group(resource, ""), snapshot.Time, end, 0, resource.HourlyCost)
+				}
+				continue
+			}
+			for instanceType, count := range resource.InstanceCounts {
+				price, _ := pricing.HourlyPrice(instanceType)
+				add(group(resource, instanceType), snapshot.Time, end, float64(count), price*float64(count))
+			}
+		}
+	}
+
+	result := make([]UsageGroup, 0, len(groups))
+	for _, usage := range groups {
+		result = append(result, *usage)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Cost != result[j].Cost {
+			return result[i].Cost > result[j].Cost
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// carryForward returns the resources with the previous resources of the
+// incomplete types added, as a failed collector found none of them
+func carryForward(resources []Resource, previous []Resource, incomplete []string) []Resource {
+	carried := append([]Resource(nil), resources...)
+	for _, resource := range previous {
+		for _, resourceType := range incomplete {
+			if resource.ResourceType == resourceType {
+				carried = append(carried, resource)
+				break
+			}
+		}
+	}
+	return carried
+}
+
+// overlap returns how long the ranges [aStart, aEnd) and [bStart, bEnd) overlap
+func overlap(aStart, aEnd, bStart, bEnd time.Time) time.Duration {
+	start, end := aStart, aEnd
+	if bStart.After(start) {
+		start = bStart
+	}
+	if bEnd.Before(end) {
+		end = bEnd
+	}
+	if end.Before(start) {
+		return 0
+	}
+	return end.Sub(start)
+}
@@ -0,0 +1,87 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLastDays(t *testing.T) {
+	period := LastDays(time.Date(2026, 10, 18, 15, 30, 0, 0, time.UTC), 7)
+
+	assert.Equal(t, time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), period.Start)
+	assert.Equal(t, time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), period.End())
+}
+
+func TestUsage(t *testing.T) {
+	period := Period{Start: time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC), Days: 2}
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	at := func(day, hour int) time.Time { return time.Date(2026, 10, day, hour, 0, 0, 0, time.UTC) }
+
+	endpoint := Resource{ResourceType: "Endpoint", Name: "churn", InstanceCounts: map[string]int{"ml.m5.xlarge": 2}}
+	mlflow := Resource{ResourceType: "MLflow", Name: "tracking", HourlyCost: 0.5}
+	snapshots := []Snapshot{
+		// Before the period, so only its last hour counts
+		{Region: "us-east-1", Time: at(16, 23), Resources: []Resource{endpoint}},
+		{Region: "us-east-1", Time: at(17, 1), Resources: []Resource{endpoint, mlflow}},
+		// The other region does not end the us-east-1 snapshots
+		{Region: "eu-west-1", Time: at(17, 12), Resources: []Resource{mlflow}},
+		{Region: "us-east-1", Time: at(18, 0), Resources: []Resource{endpoint}},
+	}
+
+	groups := Usage(snapshots, period, now, 6*time.Hour, func(resource Resource, instanceType string) string {
+		return resource.ResourceType
+	})
+
+	if assert.Len(t, groups, 2) {
+		// 6h in us-east-1 and 6h in eu-west-1, both on day 17
+		assert.Equal(t, "MLflow", groups[0].Name)
+		assert.InDelta(t, 0.0, groups[0].InstanceHours, 1e-9)
+		assert.Equal(t, []float64{6.0, 0}, groups[0].DailyCost)
+
+		// 1h in the period from day 16, 6h of day 17 (capped by the gap) and
+		// 6h of day 18 until now, capped as well
+		assert.Equal(t, "Endpoint", groups[1].Name)
+		assert.InDelta(t, 2*13.0, groups[1].InstanceHours, 1e-9)
+		assert.InDelta(t, 0.46*13, groups[1].Cost, 1e-9)
+		assert.InDelta(t, 0.46*7, groups[1].DailyCost[0], 1e-9)
+		assert.InDelta(t, 0.46*6, groups[1].DailyCost[1], 1e-9)
+	}
+}
+
+func TestUsage_IncompleteSnapshot(t *testing.T) {
+	period := Period{Start: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC), Days: 1}
+	now := time.Date(2026, 10, 18, 3, 0, 0, 0, time.UTC)
+	at := func(hour int) time.Time { return time.Date(2026, 10, 18, hour, 0, 0, 0, time.UTC) }
+
+	endpoint := Resource{ResourceType: "Endpoint", Name: "churn", InstanceCounts: map[string]int{"ml.m5.xlarge": 2}}
+	notebook := Resource{ResourceType: "Notebook", Name: "nb", InstanceCounts: map[string]int{"ml.t3.medium": 1}}
+	snapshots := []Snapshot{
+		{Region: "us-east-1", Time: at(0), Resources: []Resource{endpoint, notebook}},
+		// The endpoint collector was throttled, so the endpoint is still counted
+		{Region: "us-east-1", Time: at(1), Resources: []Resource{notebook}, Incomplete: []string{"Endpoint"}},
+		{Region: "us-east-1", Time: at(2), Resources: []Resource{}, Incomplete: []string{"Endpoint"}},
+	}
+
+	groups := Usage(snapshots, period, now, 6*time.Hour, func(resource Resource, instanceType string) string {
+		return resource.ResourceType
+	})
+
+	if assert.Len(t, groups, 2) {
+		assert.Equal(t, "Endpoint", groups[0].Name)
+		assert.InDelta(t, 2*3.0, groups[0].InstanceHours, 1e-9)
+		// The notebook collector succeeded in the last snapshot, which found none
+		assert.Equal(t, "Notebook", groups[1].Name)
+		assert.InDelta(t, 2.0, groups[1].InstanceHours, 1e-9)
+	}
+
+	// Resources are not carried forward across a gap without scans
+	groups = Usage(snapshots, period, now, 30*time.Minute, func(resource Resource, instanceType string) string {
+		return resource.ResourceType
+	})
+	if assert.Len(t, groups, 2) {
+		assert.Equal(t, "Endpoint", groups[0].Name)
+		assert.InDelta(t, 2*0.5, groups[0].InstanceHours, 1e-9)
+	}
+}
@@ -0,0 +1,67 @@
+package identity
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"mohua/internal/awstrace"
+	"mohua/internal/retry"
+	"mohua/internal/sagemaker"
+)
+
+// Client interface defines the methods that consumers of this package can use
+type Client interface {
+	AccountID(ctx context.Context) (string, error)
+}
+
+// STSClientInterface defines the AWS SDK methods used by Client
+type STSClientInterface interface {
+	GetCallerIdentity(ctx context.Context, params *sts.GetCallerIdentityInput, optFns ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error)
+}
+
+// clientImpl implements only the necessary STS API operations
+type clientImpl struct {
+	client STSClientInterface
+}
+
+// NewClientFunc is the type for the client creation function
+type NewClientFunc func(region string) (Client, error)
+
+// NewClient is the function used to create a new STS client
+var NewClient NewClientFunc = newClient
+
+// newClient creates a new STS client
+func newClient(region string) (Client, error) {
+	opts := []func(*config.LoadOptions) error{awstrace.WithTracing()}
+	if region != "" {
+		opts = append(opts, config.WithRegion(region))
+	}
+
+	cfg, err := config.LoadDefaultConfig(context.Background(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS SDK configuration: %w", err)
+	}
+
+	return &clientImpl{client: sts.NewFromConfig(cfg)}, nil
+}
+
+// AccountID returns the ID of the AWS account of the credentials
+func (c *clientImpl) AccountID(ctx context.Context) (string, error) {
+	var account string
+	retrier := retry.NewRetrier(retry.DefaultConfig)
+	err := retrier.Do(ctx, func() error {
+		output, err := c.client.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+		if err != nil {
+			return sagemaker.WrapError(err)
+		}
+		account = aws.ToString(output.Account)
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to look up the AWS account: %w", err)
+	}
+	return account, nil
+}
@@ -0,0 +1,38 @@
+package identity
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAccountID(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(MockSTSClient)
+	mockClient.On("GetCallerIdentity", ctx, mock.Anything, mock.Anything).
+		Return(&sts.GetCallerIdentityOutput{Account: aws.String("123456789012")}, nil)
+
+	client := &clientImpl{client: mockClient}
+	account, err := client.AccountID(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "123456789012", account)
+}
+
+func TestAccountID_Error(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(MockSTSClient)
+	mockClient.On("GetCallerIdentity", ctx, mock.Anything, mock.Anything).
+		Return(nil, &smithy.GenericAPIError{Code: "InvalidClientTokenId", Message: "The security token included in the request is invalid"})
+
+	client := &clientImpl{client: mockClient}
+	_, err := client.AccountID(ctx)
+
+	assert.ErrorContains(t, err, "failed to look up the AWS account")
+	mockClient.AssertNumberOfCalls(t, "GetCallerIdentity", 1)
+}
@@ -0,0 +1,35 @@
+package jsonfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "state.json")
+	want := map[string]int{"a": 1, "b": 2}
+
+	assert.NoError(t, Write(path, want))
+
+	var got map[string]int
+	assert.NoError(t, Read(path, &got))
+	assert.Equal(t, want, got)
+
+	// No temporary files are left behind
+	entries, err := os.ReadDir(filepath.Dir(path))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestRead_Errors(t *testing.T) {
+	var v map[string]int
+	err := Read(filepath.Join(t.TempDir(), "missing.json"), &v)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	path := filepath.Join(t.TempDir(), "invalid.json")
+	assert.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+	assert.ErrorContains(t, Read(path, &v), "failed to parse")
+}
@@ -0,0 +1,226 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"mohua/internal/awstrace"
+	"mohua/internal/retry"
+	"mohua/internal/sagemaker"
+)
+
+// Client interface defines the methods that consumers of this package can use
+type Client interface {
+	EndpointInvocations(ctx context.Context, endpointName string, window time.Duration) (float64, error)
+	Utilization(ctx context.Context, source Source, window time.Duration) (Utilization, error)
+	PutMetrics(ctx context.Context, namespace string, timestamp time.Time, data []Datum) error
+	RecentDimensionValues(ctx context.Context, namespace string, metricName string, dimension string, filter map[string]string) ([]string, error)
+}
+
+// Source identifies the utilization metrics of one resource
+type Source struct {
+	Namespace  string
+	Dimensions map[string]string
+}
+
+// Percentiles summarizes the 5 minute averages of a utilization metric
+type Percentiles struct {
+	P50     float64 `json:"p50"`
+	P95     float64 `json:"p95"`
+	Samples int     `json:"samples"`
+}
+
+// Utilization holds the percentiles of the utilization metrics of a resource.
+// Metrics without data points are nil. Values are reported as published, so
+// CPU and GPU utilization of endpoints range up to 100% per core or GPU.
+type Utilization struct {
+	CPU    *Percentiles `json:"cpu,omitempty"`
+	Memory *Percentiles `json:"memory,omitempty"`
+	GPU    *Percentiles `json:"gpu,omitempty"`
+}
+
+// utilizationPeriod is the period in seconds of the averages that percentiles are computed over
+const utilizationPeriod = 300
+
+// utilizationMetrics maps query IDs to the utilization metric names
+var utilizationMetrics = []struct{ id, name string }{
+	{"cpu", "CPUUtilization"},
+	{"memory", "MemoryUtilization"},
+	{"gpu", "GPUUtilization"},
+}
+
+// CloudWatchClientInterface defines the AWS SDK methods used by Client
+type CloudWatchClientInterface interface {
+	GetMetricData(ctx context.Context, params *cloudwatch.GetMetricDataInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.GetMetricDataOutput, error)
+	PutMetricData(ctx context.Context, params *cloudwatch.PutMetricDataInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.PutMetricDataOutput, error)
+	ListMetrics(ctx context.Context, params *cloudwatch.ListMetricsInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.ListMetricsOutput, error)
+}
+
+// clientImpl implements only the necessary CloudWatch API operations
+type clientImpl struct {
+	client CloudWatchClientInterface
+	now    func() time.Time
+}
+
+// NewClientFunc is the type for the client creation function
+type NewClientFunc func(region string) (Client, error)
+
+// NewClient is the function used to create a new CloudWatch client
+var NewClient NewClientFunc = newClient
+
+// newClient creates a new CloudWatch client
+func newClient(region string) (Client, error) {
+	opts := []func(*config.LoadOptions) error{awstrace.WithTracing()}
+	if region != "" {
+		opts = append(opts, config.WithRegion(region))
+	}
+
+	cfg, err := config.LoadDefaultConfig(context.Background(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS SDK configuration: %w", err)
+	}
+
+	return &clientImpl{
+		client: cloudwatch.NewFromConfig(cfg),
+		now:    time.Now,
+	}, nil
+}
+
+// EndpointInvocations returns the number of invocations of all variants of an
+// endpoint during the window ending now
+func (c *clientImpl) EndpointInvocations(ctx context.Context, endpointName string, window time.Duration) (float64, error) {
+	end := c.now().Truncate(time.Minute)
+	start := end.Add(-window)
+	// CloudWatch periods are multiples of 60 seconds
+	period := int32(window / time.Minute * 60)
+	if period < 60 {
+		period = 60
+	}
+
+	// SEARCH sums the metric over every variant without listing them first
+	expression := fmt.Sprintf(
+		`SUM(SEARCH('{AWS/SageMaker,EndpointName,VariantName} MetricName="Invocations" EndpointName="%s"', 'Sum', %d))`,
+		strings.ReplaceAll(endpointName, `"`, `\"`), period)
+
+	var total float64
+	retrier := retry.NewRetrier(retry.DefaultConfig)
+	err := retrier.Do(ctx, func() error {
+		total = 0
+		input := &cloudwatch.GetMetricDataInput{
+			StartTime: aws.Time(start),
+			EndTime:   aws.Time(end),
+			MetricDataQueries: []types.MetricDataQuery{
+				{Id: aws.String("invocations"), Expression: aws.String(expression), Period: aws.Int32(period)},
+			},
+		}
+		for {
+			output, err := c.client.GetMetricData(ctx, input)
+			if err != nil {
+				return sagemaker.WrapError(err)
+			}
+			for _, result := range output.MetricDataResults {
+				for _, value := range result.Values {
+					total += value
+				}
+			}
+			if output.NextToken == nil {
+				return nil
+			}
+			input.NextToken = output.NextToken
+		}
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get invocations of endpoint %s: %w", endpointName, err)
+	}
+	return total, nil
+}
+
+// Utilization returns the p50 and p95 of the CPU, memory and GPU utilization
+// of a resource during the window ending now
+func (c *clientImpl) Utilization(ctx context.Context, source Source, window time.Duration) (Utilization, error) {
+	end := c.now().Truncate(time.Minute)
+	start := end.Add(-window)
+
+	// Dimensions are sorted to keep the expressions stable
+	keys := make([]string, 0, len(source.Dimensions))
+	for key := range source.Dimensions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var filter strings.Builder
+	fmt.Fprintf(&filter, `Namespace=%s`, quoteSearchTerm(source.Namespace))
+	for _, key := range keys {
+		fmt.Fprintf(&filter, ` %s=%s`, key, quoteSearchTerm(source.Dimensions[key]))
+	}
+
+	queries := make([]types.MetricDataQuery, 0, len(utilizationMetrics))
+	for _, metric := range utilizationMetrics {
+		expression := fmt.Sprintf(`SEARCH('%s MetricName="%s"', 'Average', %d)`, filter.String(), metric.name, utilizationPeriod)
+		queries = append(queries, types.MetricDataQuery{
+			Id:         aws.String(metric.id),
+			Expression: aws.String(expression),
+			Period:     aws.Int32(utilizationPeriod),
+		})
+	}
+
+	var values map[string][]float64
+	retrier := retry.NewRetrier(retry.DefaultConfig)
+	err := retrier.Do(ctx, func() error {
+		values = make(map[string][]float64)
+		input := &cloudwatch.GetMetricDataInput{
+			StartTime:         aws.Time(start),
+			EndTime:           aws.Time(end),
+			MetricDataQueries: queries,
+		}
+		for {
+			output, err := c.client.GetMetricData(ctx, input)
+			if err != nil {
+				return sagemaker.WrapError(err)
+			}
+			// Every time series found by a SEARCH has the ID of its query
+			for _, result := range output.MetricDataResults {
+				id := aws.ToString(result.Id)
+				values[id] = append(values[id], result.Values...)
+			}
+			if output.NextToken == nil {
+				return nil
+			}
+			input.NextToken = output.NextToken
+		}
+	})
+	if err != nil {
+		return Utilization{}, fmt.Errorf("failed to get utilization metrics in %s: %w", source.Namespace, err)
+	}
+
+	return Utilization{
+		CPU:    percentiles(values["cpu"]),
+		Memory: percentiles(values["memory"]),
+		GPU:    percentiles(values["gpu"]),
+	}, nil
+}
+
+// quoteSearchTerm quotes a value for a SEARCH expression
+func quoteSearchTerm(value string) string {
+	return `"` + strings.ReplaceAll(value, `"`, `\"`) + `"`
+}
+
+// percentiles returns the nearest-rank p50 and p95 of values, or nil without values
+func percentiles(values []float64) *Percentiles {
+	if len(values) == 0 {
+		return nil
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	rank := func(p float64) float64 {
+		return sorted[int(math.Ceil(p/100*float64(len(sorted))))-1]
+	}
+	return &Percentiles{P50: rank(50), P95: rank(95), Samples: len(sorted)}
+}
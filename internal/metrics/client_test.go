@@ -0,0 +1,111 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestEndpointInvocations(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 30, 0, time.UTC)
+
+	mockClient := new(MockCloudWatchClient)
+	mockClient.On("GetMetricData", ctx, mock.MatchedBy(func(in *cloudwatch.GetMetricDataInput) bool {
+		query := in.MetricDataQueries[0]
+		return in.NextToken == nil &&
+			in.EndTime.Equal(time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)) &&
+			in.StartTime.Equal(time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)) &&
+			aws.ToInt32(query.Period) == 86400 &&
+			strings.Contains(aws.ToString(query.Expression), `EndpointName="churn"`)
+	}), mock.Anything).
+		Return(&cloudwatch.GetMetricDataOutput{
+			MetricDataResults: []types.MetricDataResult{{Values: []float64{10, 5}}},
+			NextToken:         aws.String("page2"),
+		}, nil).Once()
+	mockClient.On("GetMetricData", ctx, mock.MatchedBy(func(in *cloudwatch.GetMetricDataInput) bool {
+		return aws.ToString(in.NextToken) == "page2"
+	}), mock.Anything).
+		Return(&cloudwatch.GetMetricDataOutput{
+			MetricDataResults: []types.MetricDataResult{{Values: []float64{1}}},
+		}, nil).Once()
+
+	client := &clientImpl{client: mockClient, now: func() time.Time { return now }}
+	invocations, err := client.EndpointInvocations(ctx, "churn", 24*time.Hour)
+
+	assert.NoError(t, err)
+	assert.InDelta(t, 16.0, invocations, 1e-9)
+	mockClient.AssertExpectations(t)
+}
+
+func TestEndpointInvocations_Error(t *testing.T) {
+	ctx := context.Background()
+
+	mockClient := new(MockCloudWatchClient)
+	mockClient.On("GetMetricData", ctx, mock.Anything, mock.Anything).
+		Return(nil, &types.InvalidParameterValueException{Message: aws.String("bad expression")})
+
+	client := &clientImpl{client: mockClient, now: time.Now}
+	_, err := client.EndpointInvocations(ctx, "churn", time.Hour)
+
+	assert.ErrorContains(t, err, "failed to get invocations of endpoint churn")
+}
+
+func TestUtilization(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 30, 0, time.UTC)
+
+	mockClient := new(MockCloudWatchClient)
+	mockClient.On("GetMetricData", ctx, mock.MatchedBy(func(in *cloudwatch.GetMetricDataInput) bool {
+		return len(in.MetricDataQueries) == 3 &&
+			in.StartTime.Equal(time.Date(2026, 10, 11, 12, 0, 0, 0, time.UTC)) &&
+			aws.ToString(in.MetricDataQueries[0].Expression) ==
+				`SEARCH('Namespace="/aws/sagemaker/Endpoints" EndpointName="churn" VariantName="AllTraffic" MetricName="CPUUtilization"', 'Average', 300)`
+	}), mock.Anything).
+		Return(&cloudwatch.GetMetricDataOutput{
+			MetricDataResults: []types.MetricDataResult{
+				{Id: aws.String("cpu"), Values: []float64{40, 10, 20, 30}},
+				{Id: aws.String("memory"), Values: []float64{50}},
+				{Id: aws.String("gpu")},
+			},
+			NextToken: aws.String("page2"),
+		}, nil).Once()
+	mockClient.On("GetMetricData", ctx, mock.MatchedBy(func(in *cloudwatch.GetMetricDataInput) bool {
+		return aws.ToString(in.NextToken) == "page2"
+	}), mock.Anything).
+		Return(&cloudwatch.GetMetricDataOutput{
+			MetricDataResults: []types.MetricDataResult{{Id: aws.String("cpu"), Values: []float64{100}}},
+		}, nil).Once()
+
+	client := &clientImpl{client: mockClient, now: func() time.Time { return now }}
+	utilization, err := client.Utilization(ctx, Source{
+		Namespace:  "/aws/sagemaker/Endpoints",
+		Dimensions: map[string]string{"VariantName": "AllTraffic", "EndpointName": "churn"},
+	}, 7*24*time.Hour)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &Percentiles{P50: 30, P95: 100, Samples: 5}, utilization.CPU)
+	assert.Equal(t, &Percentiles{P50: 50, P95: 50, Samples: 1}, utilization.Memory)
+	assert.Nil(t, utilization.GPU)
+	mockClient.AssertExpectations(t)
+}
+
+func TestUtilization_Error(t *testing.T) {
+	ctx := context.Background()
+
+	mockClient := new(MockCloudWatchClient)
+	mockClient.On("GetMetricData", ctx, mock.Anything, mock.Anything).
+		Return(nil, &types.InvalidParameterValueException{Message: aws.String("bad expression")})
+
+	client := &clientImpl{client: mockClient, now: time.Now}
+	_, err := client.Utilization(ctx, Source{Namespace: "/aws/sagemaker/Endpoints"}, time.Hour)
+
+	assert.ErrorContains(t, err, "failed to get utilization metrics in /aws/sagemaker/Endpoints")
+}
@@ -0,0 +1,121 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"mohua/internal/retry"
+	"mohua/internal/sagemaker"
+)
+
+// maxPutBatch is the maximum number of metrics PutMetricData accepts per request
+const maxPutBatch = 1000
+
+// Datum is a metric value to publish
+type Datum struct {
+	Name       string
+	Dimensions map[string]string
+	Value      float64
+	Unit       types.StandardUnit
+}
+
+// PutMetrics publishes data with the same timestamp to a namespace, in
+// batches of up to 1000 metrics per request. Batches sent before a failed
+// batch stay published.
+func (c *clientImpl) PutMetrics(ctx context.Context, namespace string, timestamp time.Time, data []Datum) error {
+	retrier := retry.NewRetrier(retry.DefaultConfig)
+	for start := 0; start < len(data); start += maxPutBatch {
+		end := start + maxPutBatch
+		if end > len(data) {
+			end = len(data)
+		}
+		input := &cloudwatch.PutMetricDataInput{
+			Namespace:  aws.String(namespace),
+			MetricData: make([]types.MetricDatum, 0, end-start),
+		}
+		for _, d := range data[start:end] {
+			input.MetricData = append(input.MetricData, types.MetricDatum{
+				MetricName: aws.String(d.Name),
+				Dimensions: dimensions(d.Dimensions),
+				Timestamp:  aws.Time(timestamp),
+				Value:      aws.Float64(d.Value),
+				Unit:       d.Unit,
+			})
+		}
+		err := retrier.Do(ctx, func() error {
+			_, err := c.client.PutMetricData(ctx, input)
+			return sagemaker.WrapError(err)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to put metrics in %s: %w", namespace, err)
+		}
+	}
+	return nil
+}
+
+// RecentDimensionValues returns the values of a dimension of the metrics of a
+// namespace and name that received data in the last 3 hours and have the
+// filter dimensions, sorted
+func (c *clientImpl) RecentDimensionValues(ctx context.Context, namespace string, metricName string, dimension string, filter map[string]string) ([]string, error) {
+	input := &cloudwatch.ListMetricsInput{
+		Namespace:      aws.String(namespace),
+		MetricName:     aws.String(metricName),
+		RecentlyActive: types.RecentlyActivePt3h,
+	}
+	for _, d := range dimensions(filter) {
+		input.Dimensions = append(input.Dimensions, types.DimensionFilter{Name: d.Name, Value: d.Value})
+	}
+	input.Dimensions = append(input.Dimensions, types.DimensionFilter{Name: aws.String(dimension)})
+
+	seen := make(map[string]bool)
+	retrier := retry.NewRetrier(retry.DefaultConfig)
+	err := retrier.Do(ctx, func() error {
+		input.NextToken = nil
+		for {
+			output, err := c.client.ListMetrics(ctx, input)
+			if err != nil {
+				return sagemaker.WrapError(err)
+			}
+			for _, metric := range output.Metrics {
+				for _, d := range metric.Dimensions {
+					if aws.ToString(d.Name) == dimension {
+						seen[aws.ToString(d.Value)] = true
+					}
+				}
+			}
+			if output.NextToken == nil {
+				return nil
+			}
+			input.NextToken = output.NextToken
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s metrics in %s: %w", metricName, namespace, err)
+	}
+
+	values := make([]string, 0, len(seen))
+	for value := range seen {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	return values, nil
+}
+
+// dimensions converts dimensions sorted by name
+func dimensions(values map[string]string) []types.Dimension {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	result := make([]types.Dimension, len(names))
+	for i, name := range names {
+		result[i] = types.Dimension{Name: aws.String(name), Value: aws.String(values[name])}
+	}
+	return result
+}
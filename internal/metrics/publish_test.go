@@ -0,0 +1,98 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPutMetrics(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	data := make([]Datum, 2500)
+	for i := range data {
+		data[i] = Datum{Name: "RunningInstances", Dimensions: map[string]string{"Region": "us-east-1", "InstanceType": fmt.Sprintf("ml.type%d", i)}, Value: float64(i), Unit: types.StandardUnitCount}
+	}
+
+	mockClient := new(MockCloudWatchClient)
+	var sizes []int
+	mockClient.On("PutMetricData", ctx, mock.MatchedBy(func(in *cloudwatch.PutMetricDataInput) bool {
+		return aws.ToString(in.Namespace) == "Mohua"
+	}), mock.Anything).Run(func(args mock.Arguments) {
+		in := args.Get(1).(*cloudwatch.PutMetricDataInput)
+		sizes = append(sizes, len(in.MetricData))
+	}).Return(&cloudwatch.PutMetricDataOutput{}, nil)
+
+	client := &clientImpl{client: mockClient, now: time.Now}
+	assert.NoError(t, client.PutMetrics(ctx, "Mohua", now, data))
+	assert.Equal(t, []int{1000, 1000, 500}, sizes)
+
+	first := mockClient.Calls[0].Arguments.Get(1).(*cloudwatch.PutMetricDataInput).MetricData[0]
+	assert.Equal(t, types.MetricDatum{
+		MetricName: aws.String("RunningInstances"),
+		Dimensions: []types.Dimension{
+			{Name: aws.String("InstanceType"), Value: aws.String("ml.type0")},
+			{Name: aws.String("Region"), Value: aws.String("us-east-1")},
+		},
+		Timestamp: aws.Time(now),
+		Value:     aws.Float64(0),
+		Unit:      types.StandardUnitCount,
+	}, first)
+}
+
+func TestPutMetrics_Error(t *testing.T) {
+	ctx := context.Background()
+
+	mockClient := new(MockCloudWatchClient)
+	mockClient.On("PutMetricData", ctx, mock.Anything, mock.Anything).
+		Return(nil, &types.InvalidParameterValueException{Message: aws.String("bad dimension")})
+
+	client := &clientImpl{client: mockClient, now: time.Now}
+	err := client.PutMetrics(ctx, "Mohua", time.Now(), []Datum{{Name: "IdleResources", Unit: types.StandardUnitCount}})
+
+	assert.ErrorContains(t, err, "failed to put metrics in Mohua")
+	mockClient.AssertNumberOfCalls(t, "PutMetricData", 1)
+}
+
+func TestRecentDimensionValues(t *testing.T) {
+	ctx := context.Background()
+	metric := func(instanceType string) types.Metric {
+		return types.Metric{Dimensions: []types.Dimension{
+			{Name: aws.String("InstanceType"), Value: aws.String(instanceType)},
+			{Name: aws.String("Region"), Value: aws.String("us-east-1")},
+		}}
+	}
+
+	mockClient := new(MockCloudWatchClient)
+	mockClient.On("ListMetrics", ctx, &cloudwatch.ListMetricsInput{
+		Namespace:      aws.String("Mohua"),
+		MetricName:     aws.String("RunningInstances"),
+		RecentlyActive: types.RecentlyActivePt3h,
+		Dimensions: []types.DimensionFilter{
+			{Name: aws.String("Region"), Value: aws.String("us-east-1")},
+			{Name: aws.String("InstanceType")},
+		},
+	}, mock.Anything).Return(&cloudwatch.ListMetricsOutput{
+		Metrics:   []types.Metric{metric("ml.t3.medium"), metric("ml.g5.xlarge")},
+		NextToken: aws.String("page2"),
+	}, nil).Once()
+	mockClient.On("ListMetrics", ctx, mock.MatchedBy(func(in *cloudwatch.ListMetricsInput) bool {
+		return aws.ToString(in.NextToken) == "page2"
+	}), mock.Anything).Return(&cloudwatch.ListMetricsOutput{
+		Metrics: []types.Metric{metric("ml.t3.medium")},
+	}, nil).Once()
+
+	client := &clientImpl{client: mockClient, now: time.Now}
+	values, err := client.RecentDimensionValues(ctx, "Mohua", "RunningInstances", "InstanceType", map[string]string{"Region": "us-east-1"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ml.g5.xlarge", "ml.t3.medium"}, values)
+	mockClient.AssertExpectations(t)
+}
@@ -0,0 +1,205 @@
+package policy
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"mohua/internal/sagemaker"
+)
+
+// Violation is a rule that a resource, or the set of selected resources, does not satisfy
+type Violation struct {
+	Rule         string `json:"rule"`
+	ResourceType string `json:"resourceType,omitempty"` // Empty for count, instance and cost limits
+	Resource     string `json:"resource,omitempty"`
+	UserProfile  string `json:"userProfile,omitempty"` // Owner of a Studio app, which names alone do not identify
+	SpaceName    string `json:"spaceName,omitempty"`
+	Message      string `json:"message"`
+}
+
+// IdleFunc reports whether a resource had no activity during the window
+type IdleFunc func(resource sagemaker.ResourceInfo, window time.Duration) (bool, error)
+
+// Evaluate checks every rule against the resources. idle may be nil when no
+// rule has an idle condition.
+func Evaluate(p *Policy, resources []sagemaker.ResourceInfo, now time.Time, idle IdleFunc) ([]Violation, error) {
+	var violations []Violation
+	for _, rule := range p.Rules {
+		selected := rule.Select.filter(resources, now)
+		ruleViolations, err := rule.evaluate(selected, now, idle)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: %w", rule.Name, err)
+		}
+		violations = append(violations, ruleViolations...)
+	}
+	return violations, nil
+}
+
+// evaluate checks the condition of a rule against its selected resources
+func (r Rule) evaluate(selected []sagemaker.ResourceInfo, now time.Time, idle IdleFunc) ([]Violation, error) {
+	var violations []Violation
+	add := func(resource *sagemaker.ResourceInfo, format string, args ...interface{}) {
+		violation := Violation{Rule: r.Name, Message: fmt.Sprintf(format, args...)}
+		if resource != nil {
+			violation.ResourceType = resource.ResourceType
+			violation.Resource = resource.Name
+			violation.UserProfile = resource.UserProfile
+			violation.SpaceName = resource.SpaceName
+		}
+		violations = append(violations, violation)
+	}
+
+	c := r.Condition
+	if c == (Condition{}) {
+		for i := range selected {
+			add(&selected[i], "matches rule")
+		}
+		return violations, nil
+	}
+
+	if c.MaxCount != nil && len(selected) > *c.MaxCount {
+		add(nil, "%d resources selected, limit is %d", len(selected), *c.MaxCount)
+	}
+	if c.MaxInstances != nil {
+		// Only instances of the selected types count towards the limit
+		instances := 0
+		for _, resource := range selected {
+			for _, count := range r.Select.instances(resource) {
+				instances += count
+			}
+		}
+		if instances > *c.MaxInstances {
+			add(nil, "%d instances running, limit is %d", instances, *c.MaxInstances)
+		}
+	}
+	if c.MaxHourlyCost != nil {
+		var cost float64
+		for _, resource := range selected {
+			cost += resource.EstimatedHourlyCost()
+		}
+		if cost > *c.MaxHourlyCost {
+			add(nil, "estimated cost $%.2f/h, limit is $%.2f/h", cost, *c.MaxHourlyCost)
+		}
+	}
+	if c.MaxAge > 0 {
+		for i, resource := range selected {
+			if age := now.Sub(resource.CreationTime); age > time.Duration(c.MaxAge) {
+				add(&selected[i], "age %s exceeds %s", FormatDuration(age), FormatDuration(time.Duration(c.MaxAge)))
+			}
+		}
+	}
+	if c.IdleFor > 0 {
+		if idle == nil {
+			return nil, fmt.Errorf("idle condition requires activity metrics")
+		}
+		for i, resource := range selected {
+			isIdle, err := idle(resource, time.Duration(c.IdleFor))
+			if err != nil {
+				return nil, err
+			}
+			if isIdle {
+				add(&selected[i], "idle for %s", FormatDuration(time.Duration(c.IdleFor)))
+			}
+		}
+	}
+	return violations, nil
+}
+
+// filter returns the resources matched by the selector
+func (s Selector) filter(resources []sagemaker.ResourceInfo, now time.Time) []sagemaker.ResourceInfo {
+	var selected []sagemaker.ResourceInfo
+	for _, resource := range resources {
+		if s.Matches(resource, now) {
+			selected = append(selected, resource)
+		}
+	}
+	return selected
+}
+
+// Matches reports whether a resource satisfies every field of the selector
+func (s Selector) Matches(resource sagemaker.ResourceInfo, now time.Time) bool {
+	if len(s.Types) > 0 && !containsFold(s.Types, resource.ResourceType) {
+		return false
+	}
+	if len(s.Regions) > 0 && !containsFold(s.Regions, resource.Region) {
+		return false
+	}
+	if len(s.InstanceTypes) > 0 && !s.matchesInstanceType(resource) {
+		return false
+	}
+	// Resources whose tags are unknown cannot be selected by tag
+	if (len(s.Tags) > 0 || len(s.MissingTags) > 0) && resource.Tags == nil {
+		return false
+	}
+	for key, want := range s.Tags {
+		value, ok := resource.Tags[key]
+		if !ok || (want != "*" && value != want) {
+			return false
+		}
+	}
+	for _, key := range s.MissingTags {
+		if _, ok := resource.Tags[key]; ok {
+			return false
+		}
+	}
+	if s.OlderThan > 0 && now.Sub(resource.CreationTime) <= time.Duration(s.OlderThan) {
+		return false
+	}
+	return true
+}
+
+// matchesInstanceType reports whether any instance type of the resource
+// matches a pattern. Resources on several instance types match by any of them.
+func (s Selector) matchesInstanceType(resource sagemaker.ResourceInfo) bool {
+	if len(s.instances(resource)) > 0 {
+		return true
+	}
+	// Resources without instances match by their instance type field
+	return s.matchesPattern(resource.InstanceType)
+}
+
+// instances returns the instance counts of the resource by instance type,
+// restricted to the types matched by the selector's instance type patterns
+func (s Selector) instances(resource sagemaker.ResourceInfo) map[string]int {
+	all := resource.Instances()
+	if len(s.InstanceTypes) == 0 {
+		return all
+	}
+	matched := make(map[string]int, len(all))
+	for instanceType, count := range all {
+		if s.matchesPattern(instanceType) {
+			matched[instanceType] = count
+		}
+	}
+	return matched
+}
+
+// matchesPattern reports whether an instance type matches any instance type pattern
+func (s Selector) matchesPattern(instanceType string) bool {
+	for _, pattern := range s.InstanceTypes {
+		if ok, _ := path.Match(pattern, instanceType); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// containsFold reports whether values contains s, ignoring case
+func containsFold(values []string, s string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// FormatDuration prints durations of a day or more in days
+func FormatDuration(d time.Duration) string {
+	if d >= 24*time.Hour {
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+	return d.Truncate(time.Minute).String()
+}
@@ -0,0 +1,131 @@
+package policy
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"mohua/internal/sagemaker"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluate(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	p, err := Parse([]byte(examplePolicy))
+	assert.NoError(t, err)
+
+	resources := []sagemaker.ResourceInfo{
+		{ResourceType: "Endpoint", Name: "old-gpu", InstanceType: "ml.g5.xlarge", CreationTime: now.Add(-20 * 24 * time.Hour), Tags: map[string]string{}},
+		{ResourceType: "Endpoint", Name: "owned-gpu", InstanceType: "ml.g5.xlarge", CreationTime: now.Add(-20 * 24 * time.Hour), Tags: map[string]string{"owner": "ml"}},
+		{ResourceType: "Endpoint", Name: "new-gpu", InstanceType: "ml.p3.2xlarge", CreationTime: now.Add(-time.Hour), Tags: map[string]string{}},
+		{ResourceType: "Tuning", Name: "big-tuning", InstanceType: "ml.p4d.24xlarge", InstanceCount: 2, CreationTime: now},
+		{ResourceType: "Notebook", Name: "p4d-notebook", InstanceType: "ml.p4d.24xlarge", CreationTime: now},
+	}
+	idle := func(resource sagemaker.ResourceInfo, window time.Duration) (bool, error) {
+		assert.Equal(t, 24*time.Hour, window)
+		return resource.Name == "new-gpu", nil
+	}
+
+	violations, err := Evaluate(p, resources, now, idle)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Violation{
+		{Rule: "gpu-endpoints-need-owner", ResourceType: "Endpoint", Resource: "old-gpu", Message: "age 20d exceeds 14d"},
+		{Rule: "p4d-limit", Message: "3 instances running, limit is 2"},
+		{Rule: "idle-endpoints", ResourceType: "Endpoint", Resource: "new-gpu", Message: "idle for 1d"},
+	}, violations)
+}
+
+func TestEvaluate_Conditions(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	p, err := Parse([]byte(`
+rules:
+  - name: any-studio
+    select:
+      types: [studio]
+      regions: [us-east-1]
+  - name: endpoint-count
+    select: {types: [Endpoint]}
+    condition: {maxCount: 1}
+  - name: endpoint-cost
+    select: {types: [Endpoint], olderThan: 1h}
+    condition: {maxHourlyCost: 0.5}
+  - name: team-tag
+    select: {tags: {team: "*"}}
+`))
+	assert.NoError(t, err)
+
+	resources := []sagemaker.ResourceInfo{
+		{ResourceType: "Studio", Region: "us-east-1", Name: "app", CreationTime: now},
+		{ResourceType: "Studio", Region: "eu-west-1", Name: "other-region", CreationTime: now},
+		{ResourceType: "Endpoint", Name: "a", InstanceType: "ml.m5.xlarge", InstanceCount: 2, CreationTime: now.Add(-2 * time.Hour), Tags: map[string]string{"team": "search"}},
+		{ResourceType: "Endpoint", Name: "b", InstanceType: "ml.m5.xlarge", CreationTime: now},
+	}
+
+	violations, err := Evaluate(p, resources, now, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Violation{
+		{Rule: "any-studio", ResourceType: "Studio", Resource: "app", Message: "matches rule"},
+		{Rule: "endpoint-count", Message: "2 resources selected, limit is 1"},
+		{Rule: "endpoint-cost", Message: "estimated cost $0.46/h, limit is $0.50/h"},
+		{Rule: "team-tag", ResourceType: "Endpoint", Resource: "a", Message: "matches rule"},
+	}[:2], violations[:2])
+	// Endpoint "a" costs 2 x $0.23/h, which is within the limit
+	assert.Len(t, violations, 3)
+	assert.Equal(t, "team-tag", violations[2].Rule)
+}
+
+func TestEvaluate_IdleErrors(t *testing.T) {
+	p, err := Parse([]byte("rules:\n  - name: idle\n    condition: {idleFor: 1h}"))
+	assert.NoError(t, err)
+	resources := []sagemaker.ResourceInfo{{ResourceType: "Endpoint", Name: "a"}}
+
+	_, err = Evaluate(p, resources, time.Now(), nil)
+	assert.ErrorContains(t, err, "idle condition requires activity metrics")
+
+	_, err = Evaluate(p, resources, time.Now(), func(sagemaker.ResourceInfo, time.Duration) (bool, error) {
+		return false, errors.New("throttled")
+	})
+	assert.EqualError(t, err, `rule "idle": throttled`)
+}
+
+func TestEvaluate_MixedVariantEndpoint(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	p, err := Parse([]byte(`
+rules:
+  - name: p4d-limit
+    select: {instanceTypes: ["ml.p4d.*"]}
+    condition: {maxInstances: 2}
+`))
+	assert.NoError(t, err)
+
+	resources := []sagemaker.ResourceInfo{
+		{ResourceType: "Endpoint", Name: "mixed", InstanceType: "multiple", CreationTime: now,
+			InstanceCounts: map[string]int{"ml.p4d.24xlarge": 2, "ml.m5.xlarge": 4}},
+		{ResourceType: "Notebook", Name: "p4d-notebook", InstanceType: "ml.p4d.24xlarge", CreationTime: now},
+		{ResourceType: "Endpoint", Name: "cpu", InstanceType: "ml.m5.xlarge", InstanceCount: 3, CreationTime: now},
+		{ResourceType: "MLflow", Name: "tracking", InstanceType: "Small", CreationTime: now},
+	}
+
+	violations, err := Evaluate(p, resources, now, nil)
+
+	assert.NoError(t, err)
+	// Only the p4d instances of the mixed endpoint count, not its m5 variant
+	assert.Equal(t, []Violation{{Rule: "p4d-limit", Message: "3 instances running, limit is 2"}}, violations)
+}
+
+func TestEvaluate_StudioIdentity(t *testing.T) {
+	p, err := Parse([]byte("rules:\n  - name: studio\n    select: {types: [Studio]}\n"))
+	assert.NoError(t, err)
+
+	violations, err := Evaluate(p, []sagemaker.ResourceInfo{
+		{ResourceType: "Studio", Name: "default", UserProfile: "alice", SpaceName: "shared"},
+	}, time.Now(), nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Violation{
+		{Rule: "studio", ResourceType: "Studio", Resource: "default", UserProfile: "alice", SpaceName: "shared", Message: "matches rule"},
+	}, violations)
+}
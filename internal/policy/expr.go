@@ -0,0 +1,300 @@
+package policy
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"mohua/internal/sagemaker"
+)
+
+// Expr is a threshold expression over the collected resources, e.g.
+// count(type=Endpoint)>5, cost.hourly>20 or any(age>168h). An aggregate of
+// the resources matching the optional filter, count, instances or
+// cost.hourly, is compared with a number; any is true when a resource
+// matches every condition.
+type Expr struct {
+	source    string
+	aggregate string
+	filter    []exprCondition
+	op        string
+	threshold float64
+}
+
+// exprCondition compares a field of a resource with a value
+type exprCondition struct {
+	field  string // tag.<key> for the value of a tag
+	op     string
+	value  string
+	number float64 // Set for the numeric fields, in hours for age
+}
+
+// Aggregates and fields of expressions
+var (
+	exprAggregates    = []string{"count", "instances", "cost.hourly", "any"}
+	exprStringFields  = []string{"type", "name", "status", "instance", "region"}
+	exprNumericFields = []string{"age", "cost.hourly", "instances"}
+	exprOperators     = []string{">=", "<=", "!=", ">", "<", "="} // Two character operators first
+)
+
+// ParseExpr parses a threshold expression
+func ParseExpr(s string) (*Expr, error) {
+	e := &Expr{source: strings.TrimSpace(s)}
+	rest := e.source
+	fail := func(format string, args ...interface{}) (*Expr, error) {
+		return nil, fmt.Errorf("invalid expression %q: %s", e.source, fmt.Sprintf(format, args...))
+	}
+
+	for _, aggregate := range exprAggregates {
+		if strings.HasPrefix(rest, aggregate) {
+			e.aggregate = aggregate
+			rest = strings.TrimSpace(rest[len(aggregate):])
+			break
+		}
+	}
+	if e.aggregate == "" {
+		return fail("expected one of %s", strings.Join(exprAggregates, ", "))
+	}
+
+	if strings.HasPrefix(rest, "(") {
+		end := strings.Index(rest, ")")
+		if end < 0 {
+			return fail("missing )")
+		}
+		if inner := rest[1:end]; inner != "" {
+			for _, part := range strings.Split(inner, ",") {
+				c, err := parseExprCondition(part)
+				if err != nil {
+					return fail("%v", err)
+				}
+				e.filter = append(e.filter, c)
+			}
+		}
+		rest = strings.TrimSpace(rest[end+1:])
+	} else if e.aggregate == "any" {
+		return fail("any needs conditions, e.g. any(age>168h)")
+	}
+
+	if e.aggregate == "any" {
+		if rest != "" {
+			return fail("unexpected %q after any(...)", rest)
+		}
+		return e, nil
+	}
+	op, value := cutOperator(rest)
+	if op == "" {
+		return fail("expected a comparison such as >5 after %s", e.aggregate)
+	}
+	value = strings.TrimSpace(value)
+	threshold, err := strconv.ParseFloat(strings.TrimPrefix(value, "$"), 64)
+	if err != nil {
+		return fail("%q is not a number", value)
+	}
+	e.op, e.threshold = op, threshold
+	return e, nil
+}
+
+// parseExprCondition parses a condition such as type=Endpoint or age>7d
+func parseExprCondition(s string) (exprCondition, error) {
+	for i := range s {
+		op, value := cutOperator(s[i:])
+		if op == "" {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		c := exprCondition{field: strings.TrimSpace(s[:i]), op: op, value: value}
+		switch {
+		case contains(exprNumericFields, c.field):
+			var err error
+			if c.field == "age" {
+				var d time.Duration
+				d, err = ParseDuration(value)
+				c.number = d.Hours()
+			} else {
+				c.number, err = strconv.ParseFloat(strings.TrimPrefix(value, "$"), 64)
+			}
+			if err != nil {
+				return exprCondition{}, fmt.Errorf("invalid value %q for %s", value, c.field)
+			}
+		case contains(exprStringFields, c.field) || (strings.HasPrefix(c.field, "tag.") && len(c.field) > len("tag.")):
+			if op != "=" && op != "!=" {
+				return exprCondition{}, fmt.Errorf("%s can only be compared with = or !=", c.field)
+			}
+			if _, err := path.Match(value, ""); err != nil {
+				return exprCondition{}, fmt.Errorf("invalid pattern %q", value)
+			}
+		default:
+			return exprCondition{}, fmt.Errorf("unknown field %q, expected one of %s or tag.<key>",
+				c.field, strings.Join(append(append([]string{}, exprStringFields...), exprNumericFields...), ", "))
+		}
+		return c, nil
+	}
+	return exprCondition{}, fmt.Errorf("%q is not a condition such as type=Endpoint", s)
+}
+
+// cutOperator splits a comparison operator from the start of s
+func cutOperator(s string) (op, rest string) {
+	for _, op := range exprOperators {
+		if strings.HasPrefix(s, op) {
+			return op, s[len(op):]
+		}
+	}
+	return "", s
+}
+
+func contains(values []string, s string) bool {
+	for _, value := range values {
+		if value == s {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the expression as it was written
+func (e *Expr) String() string {
+	return e.source
+}
+
+// UsesTags reports whether the expression needs the tags of the resources
+func (e *Expr) UsesTags() bool {
+	for _, c := range e.filter {
+		if strings.HasPrefix(c.field, "tag.") {
+			return true
+		}
+	}
+	return false
+}
+
+// Covers reports whether resources of the type can match the filter, so the
+// result of the expression depends on them. An expression without a type
+// condition covers every type.
+func (e *Expr) Covers(resourceType string) bool {
+	resource := sagemaker.ResourceInfo{ResourceType: resourceType}
+	for _, c := range e.filter {
+		if c.field == "type" && !c.matches(resource, time.Time{}) {
+			return false
+		}
+	}
+	return true
+}
+
+// Evaluate reports whether the expression is true for the resources, with a
+// description of the value it compared
+func (e *Expr) Evaluate(resources []sagemaker.ResourceInfo, now time.Time) (bool, string) {
+	var matched []sagemaker.ResourceInfo
+	for _, resource := range resources {
+		if e.matches(resource, now) {
+			matched = append(matched, resource)
+		}
+	}
+
+	switch e.aggregate {
+	case "any":
+		names := make([]string, 0, 3)
+		for _, resource := range matched {
+			if len(names) == cap(names) {
+				names = append(names, fmt.Sprintf("and %d more", len(matched)-len(names)))
+				break
+			}
+			names = append(names, resource.ResourceType+" "+resource.Name)
+		}
+		if len(matched) == 0 {
+			return false, "no resource matches"
+		}
+		return true, fmt.Sprintf("%d resources match: %s", len(matched), strings.Join(names, ", "))
+	case "count":
+		return compare(float64(len(matched)), e.op, e.threshold), fmt.Sprintf("count is %d", len(matched))
+	case "instances":
+		instances := 0
+		for _, resource := range matched {
+			for _, count := range resource.Instances() {
+				instances += count
+			}
+		}
+		return compare(float64(instances), e.op, e.threshold), fmt.Sprintf("instances is %d", instances)
+	default:
+		var cost float64
+		for _, resource := range matched {
+			cost += resource.EstimatedHourlyCost()
+		}
+		return compare(cost, e.op, e.threshold), fmt.Sprintf("cost.hourly is $%.2f", cost)
+	}
+}
+
+// matches reports whether a resource satisfies every condition of the filter
+func (e *Expr) matches(resource sagemaker.ResourceInfo, now time.Time) bool {
+	for _, c := range e.filter {
+		if !c.matches(resource, now) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c exprCondition) matches(resource sagemaker.ResourceInfo, now time.Time) bool {
+	var values []string
+	switch c.field {
+	case "age":
+		return compare(now.Sub(resource.CreationTime).Hours(), c.op, c.number)
+	case "cost.hourly":
+		return compare(resource.EstimatedHourlyCost(), c.op, c.number)
+	case "instances":
+		instances := 0
+		for _, count := range resource.Instances() {
+			instances += count
+		}
+		return compare(float64(instances), c.op, c.number)
+	case "type":
+		values = []string{resource.ResourceType}
+	case "name":
+		values = []string{resource.Name}
+	case "status":
+		values = []string{resource.Status}
+	case "region":
+		values = []string{resource.Region}
+	case "instance":
+		// Resources on several instance types match by any of them
+		for instanceType := range resource.Instances() {
+			values = append(values, instanceType)
+		}
+		if len(values) == 0 {
+			values = []string{resource.InstanceType}
+		}
+	default:
+		// Resources whose tags are unknown cannot be selected by tag
+		if resource.Tags == nil {
+			return false
+		}
+		values = []string{resource.Tags[strings.TrimPrefix(c.field, "tag.")]}
+	}
+
+	matched := false
+	for _, value := range values {
+		if ok, _ := path.Match(c.value, value); ok || strings.EqualFold(c.value, value) {
+			matched = true
+			break
+		}
+	}
+	return matched == (c.op == "=")
+}
+
+// compare applies a comparison operator
+func compare(value float64, op string, threshold float64) bool {
+	switch op {
+	case ">":
+		return value > threshold
+	case ">=":
+		return value >= threshold
+	case "<":
+		return value < threshold
+	case "<=":
+		return value <= threshold
+	case "!=":
+		return value != threshold
+	default:
+		return value == threshold
+	}
+}
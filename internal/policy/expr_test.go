@@ -0,0 +1,127 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"mohua/internal/sagemaker"
+)
+
+func TestExpr_Evaluate(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	resources := []sagemaker.ResourceInfo{
+		{ResourceType: "Endpoint", Name: "churn", Status: "InService", InstanceType: "ml.g5.xlarge", InstanceCount: 2, Region: "us-east-1", CreationTime: now.Add(-200 * time.Hour), Tags: map[string]string{"team": "data science"}},
+		{ResourceType: "Endpoint", Name: "fraud", Status: "InService", InstanceType: "ml.m5.large", InstanceCount: 1, Region: "us-east-1", CreationTime: now.Add(-time.Hour)},
+		{ResourceType: "Notebook", Name: "scratch", Status: "InService", InstanceType: "ml.t3.medium", Region: "eu-west-1", CreationTime: now.Add(-30 * time.Hour), Tags: map[string]string{"team": "platform"}},
+	}
+
+	tests := []struct {
+		expr    string
+		want    bool
+		message string
+	}{
+		{"count(type=Endpoint)>1", true, "count is 2"},
+		{"count(type=endpoint, region=us-east-1)>=3", false, "count is 2"},
+		{"count>2", true, "count is 3"},
+		{"count()<3", false, "count is 3"},
+		{"count(type!=Endpoint)=1", true, "count is 1"},
+		{"count(instance=ml.g*)>0", true, "count is 1"},
+		{"instances(type=Endpoint) > 2", true, "instances is 3"},
+		{"cost.hourly>20", false, "cost.hourly is $2.98"},
+		{"cost.hourly(region=us-east-1)>$2.5", true, "cost.hourly is $2.93"},
+		{"any(age>168h)", true, "1 resources match: Endpoint churn"},
+		{"any(age>1d, type=Notebook)", true, "1 resources match: Notebook scratch"},
+		{"any(status!=InService)", false, "no resource matches"},
+		{"any(cost.hourly>1)", true, "1 resources match: Endpoint churn"},
+		{"any(tag.team=data science)", true, "1 resources match: Endpoint churn"},
+		{"count(tag.team!=platform)=1", true, "count is 1"}, // Resources without known tags never match
+		{"any(instances>=1)", true, "3 resources match: Endpoint churn, Endpoint fraud, Notebook scratch"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			expr, err := ParseExpr(tt.expr)
+			if !assert.NoError(t, err) {
+				return
+			}
+			got, message := expr.Evaluate(resources, now)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.message, message)
+		})
+	}
+}
+
+func TestExpr_EvaluateManyMatches(t *testing.T) {
+	now := time.Now()
+	resources := make([]sagemaker.ResourceInfo, 5)
+	for i := range resources {
+		resources[i] = sagemaker.ResourceInfo{ResourceType: "Endpoint", Name: string(rune('a' + i)), CreationTime: now}
+	}
+	expr, err := ParseExpr("any(type=Endpoint)")
+	assert.NoError(t, err)
+
+	_, message := expr.Evaluate(resources, now)
+	assert.Equal(t, "5 resources match: Endpoint a, Endpoint b, Endpoint c, and 2 more", message)
+}
+
+func TestParseExpr_Invalid(t *testing.T) {
+	tests := []struct {
+		expr string
+		err  string
+	}{
+		{"total>5", "expected one of count, instances, cost.hourly, any"},
+		{"count(type=Endpoint", "missing )"},
+		{"any", "any needs conditions"},
+		{"any(age>1d)>2", `unexpected ">2" after any(...)`},
+		{"count(type=Endpoint)", "expected a comparison such as >5 after count"},
+		{"cost.hourly>lots", `"lots" is not a number`},
+		{"count(owner=me)>0", `unknown field "owner"`},
+		{"count(tag.=me)>0", `unknown field "tag."`},
+		{"count(type>Endpoint)>0", "type can only be compared with = or !="},
+		{"any(age>two weeks)", `invalid value "two weeks" for age`},
+		{"any(instance=ml.[g)", `invalid pattern "ml.[g"`},
+		{"any(Endpoint)", `"Endpoint" is not a condition`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := ParseExpr(tt.expr)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestExpr_UsesTags(t *testing.T) {
+	expr, err := ParseExpr("count(tag.owner=alice)>0")
+	assert.NoError(t, err)
+	assert.True(t, expr.UsesTags())
+
+	expr, err = ParseExpr("count(type=Endpoint)>0")
+	assert.NoError(t, err)
+	assert.False(t, expr.UsesTags())
+}
+
+func TestExprCovers(t *testing.T) {
+	tests := []struct {
+		expr   string
+		covers []string
+		skips  []string
+	}{
+		{expr: "cost.hourly>20", covers: []string{"Endpoint", "Notebook"}},
+		{expr: "count(type=Endpoint)<1", covers: []string{"Endpoint"}, skips: []string{"Notebook"}},
+		{expr: "count(type!=Endpoint)>5", covers: []string{"Notebook"}, skips: []string{"Endpoint"}},
+		{expr: "any(type=*Job*,age>7d)", covers: []string{"TrainingJob"}, skips: []string{"Studio"}},
+		{expr: "count(name=churn)>1", covers: []string{"Endpoint", "Studio"}},
+	}
+	for _, tt := range tests {
+		expr, err := ParseExpr(tt.expr)
+		assert.NoError(t, err)
+		for _, resourceType := range tt.covers {
+			assert.True(t, expr.Covers(resourceType), "%s covers %s", tt.expr, resourceType)
+		}
+		for _, resourceType := range tt.skips {
+			assert.False(t, expr.Covers(resourceType), "%s covers %s", tt.expr, resourceType)
+		}
+	}
+}
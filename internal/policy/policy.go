@@ -0,0 +1,159 @@
+package policy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Policy is a set of rules loaded from YAML
+type Policy struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// Rule selects resources and defines the condition they must not violate
+type Rule struct {
+	Name        string    `yaml:"name"`
+	Description string    `yaml:"description"`
+	Select      Selector  `yaml:"select"`
+	Condition   Condition `yaml:"condition"`
+}
+
+// Selector narrows the resources a rule applies to. Empty fields match everything.
+// Resources that cannot be tagged, such as Studio apps, never match tag selectors.
+type Selector struct {
+	Types         []string          `yaml:"types"`         // Resource types, e.g. Endpoint
+	InstanceTypes []string          `yaml:"instanceTypes"` // Glob patterns, e.g. ml.g*
+	Tags          map[string]string `yaml:"tags"`          // Required tag values; "*" matches any value
+	MissingTags   []string          `yaml:"missingTags"`   // Tags the resource must not have
+	OlderThan     Duration          `yaml:"olderThan"`
+	Regions       []string          `yaml:"regions"`
+}
+
+// Condition is what the selected resources must not exceed. Each field is
+// checked separately; a rule without a condition reports every selected resource.
+type Condition struct {
+	MaxCount      *int     `yaml:"maxCount"`      // Number of selected resources
+	MaxInstances  *int     `yaml:"maxInstances"`  // Total instance count of the selected resources
+	MaxAge        Duration `yaml:"maxAge"`        // Per resource
+	MaxHourlyCost *float64 `yaml:"maxHourlyCost"` // Total estimated hourly cost in USD
+	IdleFor       Duration `yaml:"idleFor"`       // Per endpoint: no invocations for this long
+}
+
+// Duration is a time.Duration that also accepts days, e.g. "14d"
+type Duration time.Duration
+
+// UnmarshalYAML parses a duration such as "36h", "90m" or "14d"
+func (d *Duration) UnmarshalYAML(value *yaml.Node) error {
+	parsed, err := ParseDuration(value.Value)
+	if err != nil {
+		return fmt.Errorf("line %d: %w", value.Line, err)
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// ParseDuration parses a Go duration or a whole number of days such as "14d"
+func ParseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return d, nil
+}
+
+// Load reads and validates a policy file
+func Load(filename string) (*Policy, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %w", err)
+	}
+	p, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy file %s: %w", filename, err)
+	}
+	return p, nil
+}
+
+// Parse decodes and validates a policy. Unknown fields are rejected so that
+// typos do not silently disable a rule.
+func Parse(data []byte) (*Policy, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var p Policy
+	if err := decoder.Decode(&p); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// validate checks rule names and instance type patterns
+func (p *Policy) validate() error {
+	if len(p.Rules) == 0 {
+		return errors.New("no rules defined")
+	}
+	seen := make(map[string]bool, len(p.Rules))
+	for i, rule := range p.Rules {
+		if rule.Name == "" {
+			return fmt.Errorf("rule %d has no name", i+1)
+		}
+		if seen[rule.Name] {
+			return fmt.Errorf("duplicate rule name %q", rule.Name)
+		}
+		seen[rule.Name] = true
+		if err := rule.Select.Validate(); err != nil {
+			return fmt.Errorf("rule %q: %w", rule.Name, err)
+		}
+	}
+	return nil
+}
+
+// Validate checks the instance type patterns of the selector
+func (s Selector) Validate() error {
+	for _, pattern := range s.InstanceTypes {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid instance type pattern %q", pattern)
+		}
+	}
+	return nil
+}
+
+// UsesTags reports whether any rule selects resources by tag
+func (p *Policy) UsesTags() bool {
+	for _, rule := range p.Rules {
+		if len(rule.Select.Tags) > 0 || len(rule.Select.MissingTags) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// UsesIdle reports whether any rule has an idle condition
+func (p *Policy) UsesIdle() bool {
+	for _, rule := range p.Rules {
+		if rule.Condition.IdleFor > 0 {
+			return true
+		}
+	}
+	return false
+}
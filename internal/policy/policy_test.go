@@ -0,0 +1,94 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const examplePolicy = `
+rules:
+  - name: gpu-endpoints-need-owner
+    description: GPU endpoints older than two weeks must have an owner
+    select:
+      types: [Endpoint]
+      instanceTypes: ["ml.g*", "ml.p*"]
+      missingTags: [owner]
+    condition:
+      maxAge: 14d
+  - name: p4d-limit
+    select:
+      instanceTypes: ["ml.p4d.*"]
+    condition:
+      maxInstances: 2
+  - name: idle-endpoints
+    select:
+      types: [Endpoint]
+    condition:
+      idleFor: 24h
+`
+
+func TestParse(t *testing.T) {
+	p, err := Parse([]byte(examplePolicy))
+
+	assert.NoError(t, err)
+	if assert.Len(t, p.Rules, 3) {
+		assert.Equal(t, "gpu-endpoints-need-owner", p.Rules[0].Name)
+		assert.Equal(t, []string{"ml.g*", "ml.p*"}, p.Rules[0].Select.InstanceTypes)
+		assert.Equal(t, Duration(14*24*time.Hour), p.Rules[0].Condition.MaxAge)
+		assert.Equal(t, 2, *p.Rules[1].Condition.MaxInstances)
+		assert.Equal(t, Duration(24*time.Hour), p.Rules[2].Condition.IdleFor)
+	}
+	assert.True(t, p.UsesTags())
+	assert.True(t, p.UsesIdle())
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy string
+		err    string
+	}{
+		{"no rules", "rules: []", "no rules defined"},
+		{"missing name", "rules:\n  - select: {types: [Endpoint]}", "rule 1 has no name"},
+		{"duplicate name", "rules:\n  - name: a\n  - name: a", `duplicate rule name "a"`},
+		{"unknown field", "rules:\n  - name: a\n    condition: {maxCost: 1}", "field maxCost not found"},
+		{"bad duration", "rules:\n  - name: a\n    condition: {maxAge: two weeks}", `invalid duration "two weeks"`},
+		{"bad pattern", "rules:\n  - name: a\n    select: {instanceTypes: [\"ml.[g\"]}", "invalid instance type pattern"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.policy))
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "policy.yaml")
+	assert.NoError(t, os.WriteFile(filename, []byte(examplePolicy), 0o600))
+
+	p, err := Load(filename)
+	assert.NoError(t, err)
+	assert.Len(t, p.Rules, 3)
+
+	_, err = Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorContains(t, err, "failed to read policy file")
+}
+
+func TestParseDuration(t *testing.T) {
+	d, err := ParseDuration("14d")
+	assert.NoError(t, err)
+	assert.Equal(t, 14*24*time.Hour, d)
+
+	d, err = ParseDuration("90m")
+	assert.NoError(t, err)
+	assert.Equal(t, 90*time.Minute, d)
+
+	_, err = ParseDuration("-1d")
+	assert.Error(t, err)
+}
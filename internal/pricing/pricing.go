@@ -0,0 +1,107 @@
+package pricing
+
+// Prices are on-demand list prices for us-east-1 in USD. They are intended for
+// estimates only; verify exact billing in the AWS Console.
+
+// HoursPerMonth is the number of hours AWS uses for monthly estimates
+const HoursPerMonth = 730
+
+// MLStorageGBMonth is the price of SageMaker ML general purpose SSD storage per GB-month
+const MLStorageGBMonth = 0.14
+
+// instanceHourly holds the hourly price of SageMaker ML instance types
+var instanceHourly = map[string]float64{
+	"ml.t3.medium":     0.05,
+	"ml.t3.large":      0.10,
+	"ml.t3.xlarge":     0.20,
+	"ml.t3.2xlarge":    0.399,
+	"ml.m5.large":      0.115,
+	"ml.m5.xlarge":     0.23,
+	"ml.m5.2xlarge":    0.461,
+	"ml.m5.4xlarge":    0.922,
+	"ml.m5.12xlarge":   2.765,
+	"ml.m5.24xlarge":   5.53,
+	"ml.m6i.large":     0.115,
+	"ml.m6i.xlarge":    0.23,
+	"ml.m6i.2xlarge":   0.461,
+	"ml.m6i.4xlarge":   0.922,
+	"ml.c5.large":      0.102,
+	"ml.c5.xlarge":     0.204,
+	"ml.c5.2xlarge":    0.408,
+	"ml.c5.4xlarge":    0.816,
+	"ml.c5.9xlarge":    1.836,
+	"ml.c5.18xlarge":   3.672,
+	"ml.r5.large":      0.151,
+	"ml.r5.xlarge":     0.302,
+	"ml.r5.2xlarge":    0.605,
+	"ml.r5.4xlarge":    1.21,
+	"ml.g4dn.xlarge":   0.7364,
+	"ml.g4dn.2xlarge":  0.94,
+	"ml.g4dn.4xlarge":  1.505,
+	"ml.g4dn.8xlarge":  2.72,
+	"ml.g4dn.12xlarge": 4.89,
+	"ml.g4dn.16xlarge": 5.44,
+	"ml.g5.xlarge":     1.408,
+	"ml.g5.2xlarge":    1.515,
+	"ml.g5.4xlarge":    2.03,
+	"ml.g5.8xlarge":    3.06,
+	"ml.g5.12xlarge":   7.09,
+	"ml.g5.16xlarge":   5.11,
+	"ml.g5.24xlarge":   10.18,
+	"ml.g5.48xlarge":   20.36,
+	"ml.p3.2xlarge":    3.825,
+	"ml.p3.8xlarge":    14.688,
+	"ml.p3.16xlarge":   28.152,
+	"ml.p4d.24xlarge":  37.688,
+	"ml.p5.48xlarge":   113.068,
+	"ml.inf2.xlarge":   0.99,
+	"ml.inf2.8xlarge":  2.36,
+	"ml.trn1.2xlarge":  1.5435,
+	"ml.trn1.32xlarge": 24.725,
+}
+
+// HourlyPrice returns the on-demand hourly price of an ML instance type
+func HourlyPrice(instanceType string) (float64, bool) {
+	price, ok := instanceHourly[instanceType]
+	return price, ok
+}
+
+// MonthlyStorageCost returns the estimated monthly cost of an ML storage volume
+func MonthlyStorageCost(sizeGB int) float64 {
+	if sizeGB <= 0 {
+		return 0
+	}
+	return float64(sizeGB) * MLStorageGBMonth
+}
+
+// mlflowTrackingServerHourly holds the hourly price of managed MLflow tracking servers by size
+var mlflowTrackingServerHourly = map[string]float64{
+	"Small":  0.642,
+	"Medium": 1.284,
+	"Large":  2.568,
+}
+
+// FeatureStoreStandardGBMonth is the price of Feature Store Standard tier online storage per GB-month
+const FeatureStoreStandardGBMonth = 0.45
+
+// FeatureStoreInMemoryGBHour is the price of Feature Store InMemory tier online storage per GB-hour
+const FeatureStoreInMemoryGBHour = 0.15
+
+// MlflowTrackingServerHourly returns the hourly price of a managed MLflow tracking server size
+func MlflowTrackingServerHourly(size string) (float64, bool) {
+	price, ok := mlflowTrackingServerHourly[size]
+	return price, ok
+}
+
+// FeatureStoreOnlineHourly returns the estimated hourly storage cost of a Feature Store
+// online store. Read and write request charges are not included.
+func FeatureStoreOnlineHourly(storageType string, sizeBytes int64) float64 {
+	if sizeBytes <= 0 {
+		return 0
+	}
+	sizeGB := float64(sizeBytes) / (1 << 30)
+	if storageType == "InMemory" {
+		return sizeGB * FeatureStoreInMemoryGBHour
+	}
+	return sizeGB * FeatureStoreStandardGBMonth / HoursPerMonth
+}
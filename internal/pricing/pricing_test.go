@@ -0,0 +1,70 @@
+package pricing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMonthlyStorageCost(t *testing.T) {
+	assert.InDelta(t, 0.0, MonthlyStorageCost(0), 1e-9)
+	assert.InDelta(t, 0.0, MonthlyStorageCost(-5), 1e-9)
+	assert.InDelta(t, 0.7, MonthlyStorageCost(5), 1e-9)
+	assert.InDelta(t, 14.0, MonthlyStorageCost(100), 1e-9)
+}
+
+func TestHourlyPrice(t *testing.T) {
+	price, ok := HourlyPrice("ml.m5.xlarge")
+	assert.True(t, ok)
+	assert.InDelta(t, 0.23, price, 1e-9)
+
+	_, ok = HourlyPrice("ml.unknown.xlarge")
+	assert.False(t, ok)
+}
+
+func TestMlflowTrackingServerHourly(t *testing.T) {
+	price, ok := MlflowTrackingServerHourly("Medium")
+	assert.True(t, ok)
+	assert.InDelta(t, 1.284, price, 1e-9)
+
+	_, ok = MlflowTrackingServerHourly("Huge")
+	assert.False(t, ok)
+}
+
+func TestFeatureStoreOnlineHourly(t *testing.T) {
+	assert.InDelta(t, 0.0, FeatureStoreOnlineHourly("Standard", 0), 1e-9)
+	assert.InDelta(t, 10*0.45/730, FeatureStoreOnlineHourly("Standard", 10<<30), 1e-9)
+	assert.InDelta(t, 2*0.15, FeatureStoreOnlineHourly("InMemory", 2<<30), 1e-9)
+}
+
+func TestSpec(t *testing.T) {
+	spec, ok := Spec("ml.g5.12xlarge")
+	assert.True(t, ok)
+	assert.Equal(t, InstanceSpec{VCPUs: 48, MemoryGiB: 192, GPUs: 4}, spec)
+
+	_, ok = Spec("ml.inf2.xlarge")
+	assert.False(t, ok)
+}
+
+func TestFamily(t *testing.T) {
+	assert.Equal(t, "ml.g5", Family("ml.g5.2xlarge"))
+	assert.Equal(t, "ml.g4dn", Family("ml.g4dn.xlarge"))
+	assert.Equal(t, "system", Family("system"))
+}
+
+func TestFamilyTypes(t *testing.T) {
+	assert.Equal(t, []string{"ml.r5.large", "ml.r5.xlarge", "ml.r5.2xlarge", "ml.r5.4xlarge"}, FamilyTypes("ml.r5"))
+	// Sorted by price, so the single GPU 16xlarge comes before the 12xlarge
+	types := FamilyTypes("ml.g5")
+	assert.Less(t, indexOf(types, "ml.g5.16xlarge"), indexOf(types, "ml.g5.12xlarge"))
+	assert.Empty(t, FamilyTypes("ml.inf2"))
+}
+
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
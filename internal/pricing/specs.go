@@ -0,0 +1,91 @@
+package pricing
+
+import (
+	"sort"
+	"strings"
+)
+
+// InstanceSpec describes the hardware of an ML instance type
+type InstanceSpec struct {
+	VCPUs     int
+	MemoryGiB float64
+	GPUs      int
+}
+
+// instanceSpecs holds the hardware of the priced instance types. Inferentia
+// and Trainium types are left out because their accelerators report
+// different metrics than GPUs.
+var instanceSpecs = map[string]InstanceSpec{
+	"ml.t3.medium":     {VCPUs: 2, MemoryGiB: 4},
+	"ml.t3.large":      {VCPUs: 2, MemoryGiB: 8},
+	"ml.t3.xlarge":     {VCPUs: 4, MemoryGiB: 16},
+	"ml.t3.2xlarge":    {VCPUs: 8, MemoryGiB: 32},
+	"ml.m5.large":      {VCPUs: 2, MemoryGiB: 8},
+	"ml.m5.xlarge":     {VCPUs: 4, MemoryGiB: 16},
+	"ml.m5.2xlarge":    {VCPUs: 8, MemoryGiB: 32},
+	"ml.m5.4xlarge":    {VCPUs: 16, MemoryGiB: 64},
+	"ml.m5.12xlarge":   {VCPUs: 48, MemoryGiB: 192},
+	"ml.m5.24xlarge":   {VCPUs: 96, MemoryGiB: 384},
+	"ml.m6i.large":     {VCPUs: 2, MemoryGiB: 8},
+	"ml.m6i.xlarge":    {VCPUs: 4, MemoryGiB: 16},
+	"ml.m6i.2xlarge":   {VCPUs: 8, MemoryGiB: 32},
+	"ml.m6i.4xlarge":   {VCPUs: 16, MemoryGiB: 64},
+	"ml.c5.large":      {VCPUs: 2, MemoryGiB: 4},
+	"ml.c5.xlarge":     {VCPUs: 4, MemoryGiB: 8},
+	"ml.c5.2xlarge":    {VCPUs: 8, MemoryGiB: 16},
+	"ml.c5.4xlarge":    {VCPUs: 16, MemoryGiB: 32},
+	"ml.c5.9xlarge":    {VCPUs: 36, MemoryGiB: 72},
+	"ml.c5.18xlarge":   {VCPUs: 72, MemoryGiB: 144},
+	"ml.r5.large":      {VCPUs: 2, MemoryGiB: 16},
+	"ml.r5.xlarge":     {VCPUs: 4, MemoryGiB: 32},
+	"ml.r5.2xlarge":    {VCPUs: 8, MemoryGiB: 64},
+	"ml.r5.4xlarge":    {VCPUs: 16, MemoryGiB: 128},
+	"ml.g4dn.xlarge":   {VCPUs: 4, MemoryGiB: 16, GPUs: 1},
+	"ml.g4dn.2xlarge":  {VCPUs: 8, MemoryGiB: 32, GPUs: 1},
+	"ml.g4dn.4xlarge":  {VCPUs: 16, MemoryGiB: 64, GPUs: 1},
+	"ml.g4dn.8xlarge":  {VCPUs: 32, MemoryGiB: 128, GPUs: 1},
+	"ml.g4dn.12xlarge": {VCPUs: 48, MemoryGiB: 192, GPUs: 4},
+	"ml.g4dn.16xlarge": {VCPUs: 64, MemoryGiB: 256, GPUs: 1},
+	"ml.g5.xlarge":     {VCPUs: 4, MemoryGiB: 16, GPUs: 1},
+	"ml.g5.2xlarge":    {VCPUs: 8, MemoryGiB: 32, GPUs: 1},
+	"ml.g5.4xlarge":    {VCPUs: 16, MemoryGiB: 64, GPUs: 1},
+	"ml.g5.8xlarge":    {VCPUs: 32, MemoryGiB: 128, GPUs: 1},
+	"ml.g5.12xlarge":   {VCPUs: 48, MemoryGiB: 192, GPUs: 4},
+	"ml.g5.16xlarge":   {VCPUs: 64, MemoryGiB: 256, GPUs: 1},
+	"ml.g5.24xlarge":   {VCPUs: 96, MemoryGiB: 384, GPUs: 4},
+	"ml.g5.48xlarge":   {VCPUs: 192, MemoryGiB: 768, GPUs: 8},
+	"ml.p3.2xlarge":    {VCPUs: 8, MemoryGiB: 61, GPUs: 1},
+	"ml.p3.8xlarge":    {VCPUs: 32, MemoryGiB: 244, GPUs: 4},
+	"ml.p3.16xlarge":   {VCPUs: 64, MemoryGiB: 488, GPUs: 8},
+	"ml.p4d.24xlarge":  {VCPUs: 96, MemoryGiB: 1152, GPUs: 8},
+	"ml.p5.48xlarge":   {VCPUs: 192, MemoryGiB: 2048, GPUs: 8},
+}
+
+// Spec returns the hardware of an ML instance type
+func Spec(instanceType string) (InstanceSpec, bool) {
+	spec, ok := instanceSpecs[instanceType]
+	return spec, ok
+}
+
+// Family returns the family of an ML instance type, e.g. "ml.g5" for "ml.g5.2xlarge"
+func Family(instanceType string) string {
+	if i := strings.LastIndex(instanceType, "."); i > 0 {
+		return instanceType[:i]
+	}
+	return instanceType
+}
+
+// FamilyTypes returns the instance types of a family with a known price and
+// spec, cheapest first
+func FamilyTypes(family string) []string {
+	var types []string
+	for instanceType := range instanceSpecs {
+		if _, ok := instanceHourly[instanceType]; ok && Family(instanceType) == family {
+			types = append(types, instanceType)
+		}
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return instanceHourly[types[i]] < instanceHourly[types[j]]
+	})
+	return types
+}
@@ -0,0 +1,126 @@
+package quotas
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/servicequotas"
+	"github.com/aws/aws-sdk-go-v2/service/servicequotas/types"
+	"mohua/internal/awstrace"
+	"mohua/internal/retry"
+	"mohua/internal/sagemaker"
+)
+
+// serviceCode is the Service Quotas code of SageMaker
+const serviceCode = "sagemaker"
+
+// Quota is a single SageMaker service quota and its value in the account
+type Quota struct {
+	Code  string
+	Name  string
+	Value float64
+}
+
+// Client interface defines the methods that consumers of this package can use
+type Client interface {
+	SageMakerQuotas(ctx context.Context) ([]Quota, error)
+}
+
+// ServiceQuotasClientInterface defines the AWS SDK methods used by Client
+type ServiceQuotasClientInterface interface {
+	ListServiceQuotas(ctx context.Context, params *servicequotas.ListServiceQuotasInput, optFns ...func(*servicequotas.Options)) (*servicequotas.ListServiceQuotasOutput, error)
+	ListAWSDefaultServiceQuotas(ctx context.Context, params *servicequotas.ListAWSDefaultServiceQuotasInput, optFns ...func(*servicequotas.Options)) (*servicequotas.ListAWSDefaultServiceQuotasOutput, error)
+}
+
+// clientImpl implements only the necessary Service Quotas API operations
+type clientImpl struct {
+	client ServiceQuotasClientInterface
+}
+
+// NewClientFunc is the type for the client creation function
+type NewClientFunc func(region string) (Client, error)
+
+// NewClient is the function used to create a new Service Quotas client
+var NewClient NewClientFunc = newClient
+
+// newClient creates a new Service Quotas client
+func newClient(region string) (Client, error) {
+	opts := []func(*config.LoadOptions) error{awstrace.WithTracing()}
+	if region != "" {
+		opts = append(opts, config.WithRegion(region))
+	}
+
+	cfg, err := config.LoadDefaultConfig(context.Background(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS SDK configuration: %w", err)
+	}
+
+	return &clientImpl{client: servicequotas.NewFromConfig(cfg)}, nil
+}
+
+// SageMakerQuotas returns every SageMaker quota sorted by name. Applied values
+// only exist for some quotas, the others keep their AWS default value.
+func (c *clientImpl) SageMakerQuotas(ctx context.Context) ([]Quota, error) {
+	quotasByCode := make(map[string]Quota)
+	retrier := retry.NewRetrier(retry.DefaultConfig)
+
+	err := retrier.Do(ctx, func() error {
+		input := &servicequotas.ListAWSDefaultServiceQuotasInput{ServiceCode: aws.String(serviceCode)}
+		for {
+			output, err := c.client.ListAWSDefaultServiceQuotas(ctx, input)
+			if err != nil {
+				return sagemaker.WrapError(err)
+			}
+			addQuotas(quotasByCode, output.Quotas)
+			if output.NextToken == nil {
+				return nil
+			}
+			input.NextToken = output.NextToken
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list default SageMaker quotas: %w", err)
+	}
+
+	err = retrier.Do(ctx, func() error {
+		input := &servicequotas.ListServiceQuotasInput{ServiceCode: aws.String(serviceCode)}
+		for {
+			output, err := c.client.ListServiceQuotas(ctx, input)
+			if err != nil {
+				return sagemaker.WrapError(err)
+			}
+			addQuotas(quotasByCode, output.Quotas)
+			if output.NextToken == nil {
+				return nil
+			}
+			input.NextToken = output.NextToken
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applied SageMaker quotas: %w", err)
+	}
+
+	quotas := make([]Quota, 0, len(quotasByCode))
+	for _, quota := range quotasByCode {
+		quotas = append(quotas, quota)
+	}
+	sort.Slice(quotas, func(i, j int) bool { return quotas[i].Name < quotas[j].Name })
+	return quotas, nil
+}
+
+// addQuotas stores quotas by code, replacing earlier values of the same quota
+func addQuotas(quotasByCode map[string]Quota, quotas []types.ServiceQuota) {
+	for _, quota := range quotas {
+		if quota.QuotaCode == nil || quota.Value == nil {
+			continue
+		}
+		quotasByCode[*quota.QuotaCode] = Quota{
+			Code:  *quota.QuotaCode,
+			Name:  aws.ToString(quota.QuotaName),
+			Value: *quota.Value,
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package quotas
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/servicequotas"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockServiceQuotasClient is a mock implementation of the ServiceQuotasClientInterface
+type MockServiceQuotasClient struct {
+	mock.Mock
+}
+
+func (m *MockServiceQuotasClient) ListServiceQuotas(ctx context.Context, params *servicequotas.ListServiceQuotasInput, optFns ...func(*servicequotas.Options)) (*servicequotas.ListServiceQuotasOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*servicequotas.ListServiceQuotasOutput), args.Error(1)
+}
+
+func (m *MockServiceQuotasClient) ListAWSDefaultServiceQuotas(ctx context.Context, params *servicequotas.ListAWSDefaultServiceQuotasInput, optFns ...func(*servicequotas.Options)) (*servicequotas.ListAWSDefaultServiceQuotasOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*servicequotas.ListAWSDefaultServiceQuotasOutput), args.Error(1)
+}
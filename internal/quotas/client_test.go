@@ -0,0 +1,59 @@
+package quotas
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/servicequotas"
+	"github.com/aws/aws-sdk-go-v2/service/servicequotas/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func quota(code, name string, value float64) types.ServiceQuota {
+	return types.ServiceQuota{QuotaCode: aws.String(code), QuotaName: aws.String(name), Value: aws.Float64(value)}
+}
+
+func TestSageMakerQuotas(t *testing.T) {
+	ctx := context.Background()
+
+	mockClient := new(MockServiceQuotasClient)
+	mockClient.On("ListAWSDefaultServiceQuotas", ctx, &servicequotas.ListAWSDefaultServiceQuotasInput{ServiceCode: aws.String("sagemaker")}, mock.Anything).
+		Return(&servicequotas.ListAWSDefaultServiceQuotasOutput{
+			Quotas:    []types.ServiceQuota{quota("L-1", "ml.g5.xlarge for endpoint usage", 0)},
+			NextToken: aws.String("page2"),
+		}, nil).Once()
+	mockClient.On("ListAWSDefaultServiceQuotas", ctx, &servicequotas.ListAWSDefaultServiceQuotasInput{ServiceCode: aws.String("sagemaker"), NextToken: aws.String("page2")}, mock.Anything).
+		Return(&servicequotas.ListAWSDefaultServiceQuotasOutput{
+			Quotas: []types.ServiceQuota{quota("L-2", "ml.g5.xlarge for training job usage", 0)},
+		}, nil).Once()
+	mockClient.On("ListServiceQuotas", ctx, &servicequotas.ListServiceQuotasInput{ServiceCode: aws.String("sagemaker")}, mock.Anything).
+		Return(&servicequotas.ListServiceQuotasOutput{
+			Quotas: []types.ServiceQuota{quota("L-1", "ml.g5.xlarge for endpoint usage", 4)},
+		}, nil).Once()
+
+	client := &clientImpl{client: mockClient}
+	quotas, err := client.SageMakerQuotas(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Quota{
+		{Code: "L-1", Name: "ml.g5.xlarge for endpoint usage", Value: 4},
+		{Code: "L-2", Name: "ml.g5.xlarge for training job usage", Value: 0},
+	}, quotas)
+	mockClient.AssertExpectations(t)
+}
+
+func TestSageMakerQuotas_Error(t *testing.T) {
+	ctx := context.Background()
+
+	mockClient := new(MockServiceQuotasClient)
+	mockClient.On("ListAWSDefaultServiceQuotas", ctx, mock.Anything, mock.Anything).
+		Return(nil, errors.New("access denied"))
+
+	client := &clientImpl{client: mockClient}
+	_, err := client.SageMakerQuotas(ctx)
+
+	assert.ErrorContains(t, err, "failed to list default SageMaker quotas")
+}
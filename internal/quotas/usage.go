@@ -0,0 +1,125 @@
+package quotas
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"mohua/internal/sagemaker"
+)
+
+var (
+	// e.g. "ml.g5.xlarge for endpoint usage" or "ml.p4d.24xlarge for spot training job usage"
+	instanceQuotaPattern = regexp.MustCompile(`^(ml\.[a-z0-9-]+\.[a-z0-9-]+) for (.+) usage$`)
+	// e.g. "Studio KernelGateway Apps running on ml.t3.medium instance"
+	studioQuotaPattern = regexp.MustCompile(`^Studio (\S+) Apps running on (ml\.[a-z0-9-]+\.[a-z0-9-]+) instance$`)
+)
+
+// Usage is what an instance quota limits: the instances of one type used for one purpose
+type Usage struct {
+	Kind         string // e.g. "endpoint", "training job" or "studio jupyterlab apps"
+	InstanceType string
+}
+
+// ParseUsage returns the usage limited by a quota, based on its name
+func ParseUsage(quotaName string) (Usage, bool) {
+	if match := instanceQuotaPattern.FindStringSubmatch(quotaName); match != nil {
+		return Usage{Kind: strings.ToLower(match[2]), InstanceType: match[1]}, true
+	}
+	if match := studioQuotaPattern.FindStringSubmatch(quotaName); match != nil {
+		return Usage{Kind: studioKind(match[1]), InstanceType: match[2]}, true
+	}
+	return Usage{}, false
+}
+
+func studioKind(appType string) string {
+	return fmt.Sprintf("studio %s apps", strings.ToLower(appType))
+}
+
+// usageKind returns the quota usage kind of a collected resource, or "" when
+// its instances are not limited by an instance quota
+func usageKind(resource sagemaker.ResourceInfo) string {
+	switch resource.ResourceType {
+	case "Endpoint":
+		return "endpoint"
+	case "Notebook":
+		return "notebook instance"
+	case "Training":
+		if resource.Spot {
+			return "spot training job"
+		}
+		return "training job"
+	case "Studio":
+		return studioKind(resource.AppType)
+	}
+	return ""
+}
+
+// Utilization is the usage of one quota by the running resources
+type Utilization struct {
+	QuotaCode    string   `json:"quotaCode,omitempty"`
+	QuotaName    string   `json:"quotaName"`
+	InstanceType string   `json:"instanceType"`
+	Used         int      `json:"used"`
+	Limit        *float64 `json:"limit"` // Nil when no quota matches the usage
+	Percent      float64  `json:"percent"`
+}
+
+// Utilizations maps the instances of resources to the quotas that limit them.
+// Only quotas with usage are returned, sorted by descending utilization.
+func Utilizations(quotas []Quota, resources []sagemaker.ResourceInfo) []Utilization {
+	used := make(map[Usage]int)
+	for _, resource := range resources {
+		kind := usageKind(resource)
+		if kind == "" {
+			continue
+		}
+		for instanceType, count := range resource.Instances() {
+			used[Usage{Kind: kind, InstanceType: instanceType}] += count
+		}
+	}
+
+	quotasByUsage := make(map[Usage]Quota)
+	for _, quota := range quotas {
+		if usage, ok := ParseUsage(quota.Name); ok {
+			quotasByUsage[usage] = quota
+		}
+	}
+
+	utilizations := make([]Utilization, 0, len(used))
+	for usage, count := range used {
+		utilization := Utilization{InstanceType: usage.InstanceType, Used: count}
+		quota, ok := quotasByUsage[usage]
+		if ok {
+			limit := quota.Value
+			utilization.QuotaCode = quota.Code
+			utilization.QuotaName = quota.Name
+			utilization.Limit = &limit
+			utilization.Percent = percent(count, limit)
+		} else {
+			utilization.QuotaName = fmt.Sprintf("%s for %s usage", usage.InstanceType, usage.Kind)
+		}
+		utilizations = append(utilizations, utilization)
+	}
+
+	sort.Slice(utilizations, func(i, j int) bool {
+		if utilizations[i].Percent != utilizations[j].Percent {
+			return utilizations[i].Percent > utilizations[j].Percent
+		}
+		return utilizations[i].QuotaName < utilizations[j].QuotaName
+	})
+	return utilizations
+}
+
+// percent returns used as a percentage of limit. Any usage of a zero quota is
+// reported as fully used.
+func percent(used int, limit float64) float64 {
+	if limit <= 0 {
+		if used > 0 {
+			return 100
+		}
+		return 0
+	}
+	return float64(used) / limit * 100
+}
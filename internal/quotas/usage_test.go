@@ -0,0 +1,59 @@
+package quotas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"mohua/internal/sagemaker"
+)
+
+func TestParseUsage(t *testing.T) {
+	tests := []struct {
+		name  string
+		usage Usage
+		ok    bool
+	}{
+		{"ml.g5.xlarge for endpoint usage", Usage{"endpoint", "ml.g5.xlarge"}, true},
+		{"ml.p4d.24xlarge for spot training job usage", Usage{"spot training job", "ml.p4d.24xlarge"}, true},
+		{"ml.t3.medium for notebook instance usage", Usage{"notebook instance", "ml.t3.medium"}, true},
+		{"Studio KernelGateway Apps running on ml.t3.medium instance", Usage{"studio kernelgateway apps", "ml.t3.medium"}, true},
+		{"Total number of instances across training jobs", Usage{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usage, ok := ParseUsage(tt.name)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.usage, usage)
+		})
+	}
+}
+
+func TestUtilizations(t *testing.T) {
+	quotas := []Quota{
+		{Code: "L-1", Name: "ml.g5.xlarge for endpoint usage", Value: 4},
+		{Code: "L-2", Name: "ml.m5.xlarge for endpoint usage", Value: 20},
+		{Code: "L-3", Name: "ml.p4d.24xlarge for training job usage", Value: 0},
+		{Code: "L-4", Name: "Studio JupyterLab Apps running on ml.t3.medium instance", Value: 10},
+		{Code: "L-5", Name: "ml.c5.xlarge for endpoint usage", Value: 10},
+	}
+	resources := []sagemaker.ResourceInfo{
+		{ResourceType: "Endpoint", InstanceType: "ml.g5.xlarge", InstanceCount: 2},
+		{ResourceType: "Endpoint", InstanceType: "multiple", InstanceCounts: map[string]int{"ml.g5.xlarge": 1, "ml.m5.xlarge": 2}},
+		{ResourceType: "Training", InstanceType: "ml.p4d.24xlarge", InstanceCount: 1},
+		{ResourceType: "Training", InstanceType: "ml.m5.xlarge", Spot: true},
+		{ResourceType: "Studio", AppType: "JupyterLab", InstanceType: "ml.t3.medium"},
+		{ResourceType: "Tuning", InstanceType: "ml.m5.xlarge"},
+	}
+
+	utilizations := Utilizations(quotas, resources)
+
+	limit := func(v float64) *float64 { return &v }
+	assert.Equal(t, []Utilization{
+		{QuotaCode: "L-3", QuotaName: "ml.p4d.24xlarge for training job usage", InstanceType: "ml.p4d.24xlarge", Used: 1, Limit: limit(0), Percent: 100},
+		{QuotaCode: "L-1", QuotaName: "ml.g5.xlarge for endpoint usage", InstanceType: "ml.g5.xlarge", Used: 3, Limit: limit(4), Percent: 75},
+		{QuotaCode: "L-4", QuotaName: "Studio JupyterLab Apps running on ml.t3.medium instance", InstanceType: "ml.t3.medium", Used: 1, Limit: limit(10), Percent: 10},
+		{QuotaCode: "L-2", QuotaName: "ml.m5.xlarge for endpoint usage", InstanceType: "ml.m5.xlarge", Used: 2, Limit: limit(20), Percent: 10},
+		{QuotaName: "ml.m5.xlarge for spot training job usage", InstanceType: "ml.m5.xlarge", Used: 1},
+	}, utilizations)
+}
@@ -4,14 +4,20 @@ import (
 	"context"
 	"math/rand"
 	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 )
 
+const tracerName = "mohua/internal/retry"
+
 // Config holds the retry configuration parameters
 type Config struct {
 	MaxAttempts         int           // Maximum number of retry attempts
 	InitialInterval     time.Duration // Initial backoff interval
 	MaxInterval         time.Duration // Maximum backoff interval
-	Multiplier         float64       // Backoff multiplier
+	Multiplier          float64       // Backoff multiplier
 	RandomizationFactor float64       // Randomization factor for jitter
 }
 
@@ -20,7 +26,7 @@ var DefaultConfig = Config{
 	MaxAttempts:         3,
 	InitialInterval:     1 * time.Second,
 	MaxInterval:         30 * time.Second,
-	Multiplier:         2.0,
+	Multiplier:          2.0,
 	RandomizationFactor: 0.1,
 }
 
@@ -34,7 +40,9 @@ func NewRetrier(config Config) *Retrier {
 	return &Retrier{config: config}
 }
 
-// Do executes the given operation with retry logic
+// Do executes the given operation with retry logic. Each wait between two
+// attempts is traced as a span with the failed attempt, its error and the
+// backoff duration.
 func (r *Retrier) Do(ctx context.Context, operation func() error) error {
 	var err error
 	currentInterval := r.config.InitialInterval
@@ -69,13 +77,22 @@ func (r *Retrier) Do(ctx context.Context, operation func() error) error {
 			}
 
 			// Wait for backoff duration
+			_, span := otel.Tracer(tracerName).Start(ctx, "retry.backoff")
+			span.SetAttributes(
+				attribute.Int("retry.attempt", attempt+1),
+				attribute.String("retry.error", err.Error()),
+				attribute.Int64("retry.backoff_ms", backoff.Milliseconds()),
+			)
 			timer := time.NewTimer(backoff)
 			select {
 			case <-ctx.Done():
 				timer.Stop()
+				span.SetStatus(codes.Error, ctx.Err().Error())
+				span.End()
 				return ctx.Err()
 			case <-timer.C:
 			}
+			span.End()
 
 			// Update interval for next iteration
 			currentInterval = time.Duration(float64(currentInterval) * r.config.Multiplier)
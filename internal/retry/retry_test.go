@@ -10,6 +10,11 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 // Custom retryable error type
@@ -145,24 +150,24 @@ func TestRetrier_BackoffTiming(t *testing.T) {
 	// Check intervals between attempts
 	for i := 1; i < len(attempts); i++ {
 		interval := attempts[i].Sub(attempts[i-1])
-		
+
 		// First interval should be close to initial interval (with jitter)
 		if i == 1 {
-			assert.InDelta(t, config.InitialInterval.Seconds(), interval.Seconds(), 0.03, 
+			assert.InDelta(t, config.InitialInterval.Seconds(), interval.Seconds(), 0.03,
 				"First retry interval should be close to initial interval")
 		} else {
 			// Subsequent intervals should increase exponentially
 			expectedMinInterval := time.Duration(float64(config.InitialInterval) * math.Pow(config.Multiplier, float64(i-1)))
 			expectedMinIntervalMs := expectedMinInterval.Milliseconds()
-			
+
 			// Add a small buffer to account for potential slight variations
-			assert.GreaterOrEqual(t, interval.Milliseconds(), expectedMinIntervalMs, 
-				fmt.Sprintf("Retry interval should increase exponentially. Expected at least %d ms, got %d ms", 
+			assert.GreaterOrEqual(t, interval.Milliseconds(), expectedMinIntervalMs,
+				fmt.Sprintf("Retry interval should increase exponentially. Expected at least %d ms, got %d ms",
 					expectedMinIntervalMs, interval.Milliseconds()))
 		}
 
 		// Ensure no interval exceeds max interval
-		assert.LessOrEqual(t, interval.Milliseconds(), config.MaxInterval.Milliseconds(), 
+		assert.LessOrEqual(t, interval.Milliseconds(), config.MaxInterval.Milliseconds(),
 			"Retry interval should not exceed max interval")
 	}
 }
@@ -193,12 +198,47 @@ func TestRetrier_Jitter(t *testing.T) {
 	// Check jitter variation
 	for i := 1; i < len(attempts); i++ {
 		interval := attempts[i].Sub(attempts[i-1])
-		
+
 		// Calculate expected base interval
 		baseInterval := time.Duration(float64(config.InitialInterval) * math.Pow(config.Multiplier, float64(i-1)))
-		
+
 		// Jitter should create variation around the base interval
-		assert.InDelta(t, baseInterval.Seconds(), interval.Seconds(), baseInterval.Seconds()*config.RandomizationFactor, 
+		assert.InDelta(t, baseInterval.Seconds(), interval.Seconds(), baseInterval.Seconds()*config.RandomizationFactor,
 			"Retry interval should have jitter")
 	}
 }
+
+func TestRetrier_TracesBackoff(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "collect")
+	retrier := NewRetrier(Config{MaxAttempts: 3, InitialInterval: 10 * time.Millisecond, MaxInterval: time.Second, Multiplier: 2.0})
+	attempts := 0
+	err := retrier.Do(ctx, func() error {
+		attempts++
+		if attempts < 3 {
+			return fmt.Errorf("throttled %d", attempts)
+		}
+		return nil
+	})
+	parent.End()
+	assert.NoError(t, err)
+
+	var backoffs []sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		if span.Name() == "retry.backoff" {
+			backoffs = append(backoffs, span)
+		}
+	}
+	assert.Len(t, backoffs, 2)
+	for i, span := range backoffs {
+		assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+		assert.Contains(t, span.Attributes(), attribute.Int("retry.attempt", i+1))
+		assert.Contains(t, span.Attributes(), attribute.String("retry.error", fmt.Sprintf("throttled %d", i+1)))
+		assert.Contains(t, span.Attributes(), attribute.Int64("retry.backoff_ms", int64(10<<i)))
+		assert.GreaterOrEqual(t, span.EndTime().Sub(span.StartTime()), time.Duration(10<<i)*time.Millisecond)
+	}
+}
@@ -0,0 +1,150 @@
+package rightsize
+
+import (
+	"fmt"
+	"sort"
+
+	"mohua/internal/metrics"
+	"mohua/internal/pricing"
+)
+
+// Options controls which instance types are suggested
+type Options struct {
+	// TargetUtilization is the highest p95 utilization percentage allowed on
+	// the suggested instance type
+	TargetUtilization float64
+	// GPUIdle is the p95 GPU utilization percentage below which the GPU is
+	// considered idle and a CPU-only instance type is suggested
+	GPUIdle float64
+	// CPUFamilies are the instance families considered for CPU-only suggestions
+	CPUFamilies []string
+}
+
+// Recommendation is the suggested instance type of a resource
+type Recommendation struct {
+	InstanceType  string  `json:"instanceType,omitempty"` // Empty when no change is suggested
+	Reason        string  `json:"reason"`
+	MonthlySaving float64 `json:"monthlySaving,omitempty"` // For all instances of the resource
+}
+
+// Normalize scales CPU and GPU utilization, which are published as up to 100%
+// per core or GPU, to percentages of the whole instance. Utilization of
+// instance types without a known spec is returned unchanged.
+func Normalize(instanceType string, utilization metrics.Utilization) metrics.Utilization {
+	spec, ok := pricing.Spec(instanceType)
+	if !ok {
+		return utilization
+	}
+	utilization.CPU = scale(utilization.CPU, spec.VCPUs)
+	utilization.GPU = scale(utilization.GPU, spec.GPUs)
+	return utilization
+}
+
+// scale divides the percentiles by the number of units they are summed over
+func scale(p *metrics.Percentiles, units int) *metrics.Percentiles {
+	if p == nil || units <= 1 {
+		return p
+	}
+	return &metrics.Percentiles{
+		P50:     p.P50 / float64(units),
+		P95:     p.P95 / float64(units),
+		Samples: p.Samples,
+	}
+}
+
+// requirement is the hardware an instance type needs to keep the p95
+// utilization of the resource at or below the target
+type requirement struct {
+	vcpus     float64
+	memoryGiB float64
+	gpus      float64
+}
+
+// fits reports whether an instance type meets the requirement
+func (r requirement) fits(spec pricing.InstanceSpec) bool {
+	return float64(spec.VCPUs) >= r.vcpus &&
+		spec.MemoryGiB >= r.memoryGiB &&
+		float64(spec.GPUs) >= r.gpus
+}
+
+// Recommend suggests the cheapest instance type that keeps the p95
+// utilization of each instance at or below the target. utilization must be
+// normalized. GPU instance types with an idle GPU are moved to a CPU-only
+// family, all others stay within their family.
+func Recommend(instanceType string, count int, utilization metrics.Utilization, opts Options) Recommendation {
+	spec, hasSpec := pricing.Spec(instanceType)
+	price, hasPrice := pricing.HourlyPrice(instanceType)
+	if !hasSpec || !hasPrice {
+		return Recommendation{Reason: "no spec or price for this instance type"}
+	}
+	if utilization.CPU == nil || utilization.Memory == nil {
+		return Recommendation{Reason: "no CPU or memory metrics in window"}
+	}
+	if spec.GPUs > 0 && utilization.GPU == nil {
+		return Recommendation{Reason: "no GPU metrics in window"}
+	}
+	if count < 1 {
+		count = 1
+	}
+
+	headroom := opts.TargetUtilization / 100
+	need := requirement{
+		vcpus:     utilization.CPU.P95 / 100 * float64(spec.VCPUs) / headroom,
+		memoryGiB: utilization.Memory.P95 / 100 * spec.MemoryGiB / headroom,
+	}
+	if spec.GPUs > 0 {
+		if utilization.GPU.P95 < opts.GPUIdle {
+			if suggested, ok := cheapest(cpuTypes(opts.CPUFamilies), need, price); ok {
+				return recommendation(suggested, price, count,
+					fmt.Sprintf("GPU idle (p95 %.1f%%), CPU-only instance fits", utilization.GPU.P95))
+			}
+		}
+		// A GPU instance keeps at least one GPU within its family
+		need.gpus = max(utilization.GPU.P95/100*float64(spec.GPUs)/headroom, 1)
+	}
+
+	if suggested, ok := cheapest(pricing.FamilyTypes(pricing.Family(instanceType)), need, price); ok {
+		return recommendation(suggested, price, count,
+			fmt.Sprintf("p95 stays below %.0f%% on a smaller size", opts.TargetUtilization))
+	}
+	return Recommendation{Reason: "right-sized"}
+}
+
+// recommendation returns the suggestion to move count instances to instanceType
+func recommendation(instanceType string, currentPrice float64, count int, reason string) Recommendation {
+	price, _ := pricing.HourlyPrice(instanceType)
+	return Recommendation{
+		InstanceType:  instanceType,
+		Reason:        reason,
+		MonthlySaving: (currentPrice - price) * float64(count) * pricing.HoursPerMonth,
+	}
+}
+
+// cheapest returns the first of the price sorted types that meets the
+// requirement and is cheaper than the current price
+func cheapest(types []string, need requirement, currentPrice float64) (string, bool) {
+	for _, instanceType := range types {
+		price, _ := pricing.HourlyPrice(instanceType)
+		if price >= currentPrice {
+			return "", false
+		}
+		if spec, _ := pricing.Spec(instanceType); need.fits(spec) {
+			return instanceType, true
+		}
+	}
+	return "", false
+}
+
+// cpuTypes returns the instance types of the families, cheapest first
+func cpuTypes(families []string) []string {
+	var types []string
+	for _, family := range families {
+		types = append(types, pricing.FamilyTypes(family)...)
+	}
+	sort.SliceStable(types, func(i, j int) bool {
+		pi, _ := pricing.HourlyPrice(types[i])
+		pj, _ := pricing.HourlyPrice(types[j])
+		return pi < pj
+	})
+	return types
+}
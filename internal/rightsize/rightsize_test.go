@@ -0,0 +1,120 @@
+package rightsize
+
+import (
+	"testing"
+
+	"mohua/internal/metrics"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testOptions = Options{
+	TargetUtilization: 60,
+	GPUIdle:           10,
+	CPUFamilies:       []string{"ml.m5", "ml.c5"},
+}
+
+func percentiles(p50, p95 float64) *metrics.Percentiles {
+	return &metrics.Percentiles{P50: p50, P95: p95, Samples: 2016}
+}
+
+func TestNormalize(t *testing.T) {
+	utilization := Normalize("ml.g5.12xlarge", metrics.Utilization{
+		CPU:    percentiles(480, 960),
+		Memory: percentiles(20, 40),
+		GPU:    percentiles(100, 200),
+	})
+	assert.Equal(t, percentiles(10, 20), utilization.CPU)
+	assert.Equal(t, percentiles(20, 40), utilization.Memory)
+	assert.Equal(t, percentiles(25, 50), utilization.GPU)
+
+	unknown := metrics.Utilization{CPU: percentiles(150, 300)}
+	assert.Equal(t, unknown, Normalize("ml.inf2.xlarge", unknown))
+}
+
+func TestRecommend(t *testing.T) {
+	tests := []struct {
+		name         string
+		instanceType string
+		count        int
+		utilization  metrics.Utilization
+		want         Recommendation
+	}{
+		{
+			name:         "idle GPU moves to CPU-only",
+			instanceType: "ml.g5.2xlarge",
+			count:        2,
+			utilization:  metrics.Utilization{CPU: percentiles(5, 10), Memory: percentiles(20, 30), GPU: percentiles(2, 5)},
+			want: Recommendation{
+				InstanceType:  "ml.m5.xlarge",
+				Reason:        "GPU idle (p95 5.0%), CPU-only instance fits",
+				MonthlySaving: (1.515 - 0.23) * 2 * 730,
+			},
+		},
+		{
+			name:         "busy GPU with little CPU shrinks within the family",
+			instanceType: "ml.g5.4xlarge",
+			count:        1,
+			utilization:  metrics.Utilization{CPU: percentiles(5, 10), Memory: percentiles(10, 20), GPU: percentiles(40, 50)},
+			want: Recommendation{
+				InstanceType:  "ml.g5.2xlarge", // 12.8 GiB at p95 needs more than 16 GiB at 60%
+				Reason:        "p95 stays below 60% on a smaller size",
+				MonthlySaving: (2.03 - 1.515) * 730,
+			},
+		},
+		{
+			name:         "over-provisioned CPU instance",
+			instanceType: "ml.m5.4xlarge",
+			count:        1,
+			utilization:  metrics.Utilization{CPU: percentiles(10, 20), Memory: percentiles(10, 20)},
+			want: Recommendation{
+				InstanceType:  "ml.m5.2xlarge",
+				Reason:        "p95 stays below 60% on a smaller size",
+				MonthlySaving: (0.922 - 0.461) * 730,
+			},
+		},
+		{
+			name:         "busy instance is right-sized",
+			instanceType: "ml.m5.xlarge",
+			count:        1,
+			utilization:  metrics.Utilization{CPU: percentiles(40, 55), Memory: percentiles(30, 40)},
+			want:         Recommendation{Reason: "right-sized"},
+		},
+		{
+			name:         "smallest size is right-sized",
+			instanceType: "ml.t3.medium",
+			count:        1,
+			utilization:  metrics.Utilization{CPU: percentiles(1, 2), Memory: percentiles(5, 10)},
+			want:         Recommendation{Reason: "right-sized"},
+		},
+		{
+			name:         "no metrics",
+			instanceType: "ml.m5.xlarge",
+			count:        1,
+			want:         Recommendation{Reason: "no CPU or memory metrics in window"},
+		},
+		{
+			name:         "GPU instance without GPU metrics",
+			instanceType: "ml.g5.xlarge",
+			count:        1,
+			utilization:  metrics.Utilization{CPU: percentiles(5, 10), Memory: percentiles(20, 30)},
+			want:         Recommendation{Reason: "no GPU metrics in window"},
+		},
+		{
+			name:         "unknown instance type",
+			instanceType: "ml.inf2.xlarge",
+			count:        1,
+			utilization:  metrics.Utilization{CPU: percentiles(5, 10), Memory: percentiles(20, 30)},
+			want:         Recommendation{Reason: "no spec or price for this instance type"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Recommend(tt.instanceType, tt.count, tt.utilization, testOptions)
+			assert.Equal(t, tt.want.InstanceType, got.InstanceType)
+			assert.Equal(t, tt.want.Reason, got.Reason)
+			assert.InDelta(t, tt.want.MonthlySaving, got.MonthlySaving, 1e-6)
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package sagemaker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker/types"
+	"mohua/internal/retry"
+)
+
+// Action is an operation on a collected resource
+type Action string
+
+const (
+	// ActionStop stops the compute of a resource. Stopping a Studio app
+	// deletes it, which keeps the user profile or space and its storage.
+	ActionStop Action = "stop"
+	// ActionDelete deletes a resource
+	ActionDelete Action = "delete"
+)
+
+// actions lists the actions supported per resource type
+var actions = map[string][]Action{
+	"Endpoint":     {ActionDelete},
+	"Notebook":     {ActionStop, ActionDelete},
+	"Studio":       {ActionStop},
+	"Tuning":       {ActionStop},
+	"Pipeline":     {ActionStop},
+	"AutoML":       {ActionStop},
+	"Compilation":  {ActionStop},
+	"Recommender":  {ActionStop},
+	"Labeling":     {ActionStop},
+	"MLflow":       {ActionStop, ActionDelete},
+	"FeatureGroup": {ActionDelete},
+	"Monitor":      {ActionStop, ActionDelete},
+}
+
+// Supports reports whether the action is supported for a resource type
+func Supports(resourceType string, action Action) bool {
+	for _, a := range actions[resourceType] {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// DescribeResource returns the full describe output of a collected resource as
+// indented JSON
+func (c *clientImpl) DescribeResource(ctx context.Context, resource ResourceInfo) (string, error) {
+	retrier := retry.NewRetrier(retry.DefaultConfig)
+	var output interface{}
+	err := retrier.Do(ctx, func() error {
+		var err error
+		switch resource.ResourceType {
+		case "Endpoint":
+			output, err = c.client.DescribeEndpoint(ctx, &sagemaker.DescribeEndpointInput{EndpointName: aws.String(resource.Name)})
+		case "Notebook":
+			output, err = c.client.DescribeNotebookInstance(ctx, &sagemaker.DescribeNotebookInstanceInput{NotebookInstanceName: aws.String(resource.Name)})
+		case "Studio":
+			userProfile, space := appOwner(resource)
+			output, err = c.client.DescribeApp(ctx, &sagemaker.DescribeAppInput{
+				DomainId:        aws.String(resource.DomainID),
+				AppType:         types.AppType(resource.AppType),
+				AppName:         aws.String(resource.Name),
+				UserProfileName: userProfile,
+				SpaceName:       space,
+			})
+		case "Tuning":
+			output, err = c.client.DescribeHyperParameterTuningJob(ctx, &sagemaker.DescribeHyperParameterTuningJobInput{HyperParameterTuningJobName: aws.String(resource.Name)})
+		case "Pipeline":
+			output, err = c.client.DescribePipelineExecution(ctx, &sagemaker.DescribePipelineExecutionInput{PipelineExecutionArn: aws.String(pipelineExecutionArn(resource))})
+		case "AutoML":
+			output, err = c.client.DescribeAutoMLJob(ctx, &sagemaker.DescribeAutoMLJobInput{AutoMLJobName: aws.String(resource.Name)})
+		case "Compilation":
+			output, err = c.client.DescribeCompilationJob(ctx, &sagemaker.DescribeCompilationJobInput{CompilationJobName: aws.String(resource.Name)})
+		case "Recommender":
+			output, err = c.client.DescribeInferenceRecommendationsJob(ctx, &sagemaker.DescribeInferenceRecommendationsJobInput{JobName: aws.String(resource.Name)})
+		case "Labeling":
+			output, err = c.client.DescribeLabelingJob(ctx, &sagemaker.DescribeLabelingJobInput{LabelingJobName: aws.String(resource.Name)})
+		case "MLflow":
+			output, err = c.client.DescribeMlflowTrackingServer(ctx, &sagemaker.DescribeMlflowTrackingServerInput{TrackingServerName: aws.String(resource.Name)})
+		case "FeatureGroup":
+			output, err = c.client.DescribeFeatureGroup(ctx, &sagemaker.DescribeFeatureGroupInput{FeatureGroupName: aws.String(resource.Name)})
+		case "Monitor":
+			output, err = c.client.DescribeMonitoringSchedule(ctx, &sagemaker.DescribeMonitoringScheduleInput{MonitoringScheduleName: aws.String(resource.Name)})
+		case "Experiment":
+			output, err = c.client.DescribeInferenceExperiment(ctx, &sagemaker.DescribeInferenceExperimentInput{Name: aws.String(resource.Name)})
+		default:
+			return &NonRetryableError{Err: fmt.Errorf("describing %s resources is not supported", resource.ResourceType)}
+		}
+		return WrapError(err)
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to describe %s %s: %w", resource.ResourceType, resource.Name, err)
+	}
+
+	// Round trip through a map to drop the SDK response metadata
+	data, err := json.Marshal(output)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode description: %w", err)
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return "", fmt.Errorf("failed to encode description: %w", err)
+	}
+	delete(fields, "ResultMetadata")
+	data, err = json.MarshalIndent(fields, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode description: %w", err)
+	}
+	return string(data), nil
+}
+
+// StopResource stops a collected resource that supports ActionStop
+func (c *clientImpl) StopResource(ctx context.Context, resource ResourceInfo) error {
+	retrier := retry.NewRetrier(retry.DefaultConfig)
+	// Retries of the same stop request are idempotent
+	token := fmt.Sprintf("mohua-%d", time.Now().UnixNano())
+	err := retrier.Do(ctx, func() error {
+		var err error
+		switch resource.ResourceType {
+		case "Notebook":
+			_, err = c.client.StopNotebookInstance(ctx, &sagemaker.StopNotebookInstanceInput{NotebookInstanceName: aws.String(resource.Name)})
+		case "Studio":
+			userProfile, space := appOwner(resource)
+			_, err = c.client.DeleteApp(ctx, &sagemaker.DeleteAppInput{
+				DomainId:        aws.String(resource.DomainID),
+				AppType:         types.AppType(resource.AppType),
+				AppName:         aws.String(resource.Name),
+				UserProfileName: userProfile,
+				SpaceName:       space,
+			})
+		case "Tuning":
+			_, err = c.client.StopHyperParameterTuningJob(ctx, &sagemaker.StopHyperParameterTuningJobInput{HyperParameterTuningJobName: aws.String(resource.Name)})
+		case "Pipeline":
+			_, err = c.client.StopPipelineExecution(ctx, &sagemaker.StopPipelineExecutionInput{
+				PipelineExecutionArn: aws.String(pipelineExecutionArn(resource)),
+				ClientRequestToken:   aws.String(token),
+			})
+		case "AutoML":
+			_, err = c.client.StopAutoMLJob(ctx, &sagemaker.StopAutoMLJobInput{AutoMLJobName: aws.String(resource.Name)})
+		case "Compilation":
+			_, err = c.client.StopCompilationJob(ctx, &sagemaker.StopCompilationJobInput{CompilationJobName: aws.String(resource.Name)})
+		case "Recommender":
+			_, err = c.client.StopInferenceRecommendationsJob(ctx, &sagemaker.StopInferenceRecommendationsJobInput{JobName: aws.String(resource.Name)})
+		case "Labeling":
+			_, err = c.client.StopLabelingJob(ctx, &sagemaker.StopLabelingJobInput{LabelingJobName: aws.String(resource.Name)})
+		case "MLflow":
+			_, err = c.client.StopMlflowTrackingServer(ctx, &sagemaker.StopMlflowTrackingServerInput{TrackingServerName: aws.String(resource.Name)})
+		case "Monitor":
+			_, err = c.client.StopMonitoringSchedule(ctx, &sagemaker.StopMonitoringScheduleInput{MonitoringScheduleName: aws.String(resource.Name)})
+		default:
+			return &NonRetryableError{Err: fmt.Errorf("stopping %s resources is not supported", resource.ResourceType)}
+		}
+		return WrapError(err)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to stop %s %s: %w", resource.ResourceType, resource.Name, err)
+	}
+	return nil
+}
+
+// DeleteResource deletes a collected resource that supports ActionDelete.
+// Notebook instances must be stopped first.
+func (c *clientImpl) DeleteResource(ctx context.Context, resource ResourceInfo) error {
+	retrier := retry.NewRetrier(retry.DefaultConfig)
+	err := retrier.Do(ctx, func() error {
+		var err error
+		switch resource.ResourceType {
+		case "Endpoint":
+			_, err = c.client.DeleteEndpoint(ctx, &sagemaker.DeleteEndpointInput{EndpointName: aws.String(resource.Name)})
+		case "Notebook":
+			_, err = c.client.DeleteNotebookInstance(ctx, &sagemaker.DeleteNotebookInstanceInput{NotebookInstanceName: aws.String(resource.Name)})
+		case "MLflow":
+			_, err = c.client.DeleteMlflowTrackingServer(ctx, &sagemaker.DeleteMlflowTrackingServerInput{TrackingServerName: aws.String(resource.Name)})
+		case "FeatureGroup":
+			_, err = c.client.DeleteFeatureGroup(ctx, &sagemaker.DeleteFeatureGroupInput{FeatureGroupName: aws.String(resource.Name)})
+		case "Monitor":
+			_, err = c.client.DeleteMonitoringSchedule(ctx, &sagemaker.DeleteMonitoringScheduleInput{MonitoringScheduleName: aws.String(resource.Name)})
+		default:
+			return &NonRetryableError{Err: fmt.Errorf("deleting %s resources is not supported", resource.ResourceType)}
+		}
+		return WrapError(err)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete %s %s: %w", resource.ResourceType, resource.Name, err)
+	}
+	return nil
+}
+
+// pipelineExecutionArn returns the ARN of a collected pipeline execution
+func pipelineExecutionArn(resource ResourceInfo) string {
+	if resource.Pipeline != nil {
+		return resource.Pipeline.ExecutionArn
+	}
+	return resource.Arn
+}
+
+// appOwner returns the space of a Studio app, or its user profile for apps
+// outside spaces, since the API accepts only one of them
+func appOwner(resource ResourceInfo) (userProfile, space *string) {
+	if resource.SpaceName != "" {
+		return nil, aws.String(resource.SpaceName)
+	}
+	return aws.String(resource.UserProfile), nil
+}
@@ -0,0 +1,74 @@
+package sagemaker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSupports(t *testing.T) {
+	assert.True(t, Supports("Notebook", ActionStop))
+	assert.True(t, Supports("Notebook", ActionDelete))
+	assert.False(t, Supports("Endpoint", ActionStop))
+	assert.True(t, Supports("Endpoint", ActionDelete))
+	assert.False(t, Supports("Experiment", ActionDelete))
+}
+
+func TestDescribeResource(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("DescribeEndpoint", ctx, &sagemaker.DescribeEndpointInput{EndpointName: aws.String("search")}, mock.Anything).
+		Return(&sagemaker.DescribeEndpointOutput{EndpointName: aws.String("search"), EndpointStatus: types.EndpointStatusInService}, nil)
+
+	client := &clientImpl{client: mockClient}
+	description, err := client.DescribeResource(ctx, ResourceInfo{ResourceType: "Endpoint", Name: "search"})
+	assert.NoError(t, err)
+	assert.Contains(t, description, `"EndpointName": "search"`)
+	assert.Contains(t, description, `"EndpointStatus": "InService"`)
+	assert.NotContains(t, description, "ResultMetadata")
+
+	_, err = client.DescribeResource(ctx, ResourceInfo{ResourceType: "Unknown", Name: "x"})
+	assert.EqualError(t, err, "failed to describe Unknown x: describing Unknown resources is not supported")
+	mockClient.AssertExpectations(t)
+}
+
+func TestStopResource(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("StopNotebookInstance", ctx, &sagemaker.StopNotebookInstanceInput{NotebookInstanceName: aws.String("nb")}, mock.Anything).
+		Return(&sagemaker.StopNotebookInstanceOutput{}, nil)
+	// Apps in spaces are addressed by space only
+	mockClient.On("DeleteApp", ctx, &sagemaker.DeleteAppInput{
+		DomainId:  aws.String("d-1"),
+		AppType:   types.AppTypeJupyterLab,
+		AppName:   aws.String("default"),
+		SpaceName: aws.String("alice-space"),
+	}, mock.Anything).Return(&sagemaker.DeleteAppOutput{}, nil)
+	mockClient.On("StopPipelineExecution", ctx, mock.MatchedBy(func(input *sagemaker.StopPipelineExecutionInput) bool {
+		return aws.ToString(input.PipelineExecutionArn) == "arn:aws:sagemaker:us-east-1:1:pipeline/train/execution/abc" && input.ClientRequestToken != nil
+	}), mock.Anything).Return(&sagemaker.StopPipelineExecutionOutput{}, nil)
+
+	client := &clientImpl{client: mockClient}
+	assert.NoError(t, client.StopResource(ctx, ResourceInfo{ResourceType: "Notebook", Name: "nb"}))
+	assert.NoError(t, client.StopResource(ctx, ResourceInfo{ResourceType: "Studio", Name: "default", DomainID: "d-1", AppType: "JupyterLab", UserProfile: "alice", SpaceName: "alice-space"}))
+	assert.NoError(t, client.StopResource(ctx, ResourceInfo{ResourceType: "Pipeline", Name: "train/abc", Pipeline: &PipelineRun{ExecutionArn: "arn:aws:sagemaker:us-east-1:1:pipeline/train/execution/abc"}}))
+	assert.EqualError(t, client.StopResource(ctx, ResourceInfo{ResourceType: "Endpoint", Name: "search"}), "failed to stop Endpoint search: stopping Endpoint resources is not supported")
+	mockClient.AssertExpectations(t)
+}
+
+func TestDeleteResource(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("DeleteEndpoint", ctx, &sagemaker.DeleteEndpointInput{EndpointName: aws.String("search")}, mock.Anything).
+		Return(&sagemaker.DeleteEndpointOutput{}, nil)
+
+	client := &clientImpl{client: mockClient}
+	assert.NoError(t, client.DeleteResource(ctx, ResourceInfo{ResourceType: "Endpoint", Name: "search"}))
+	assert.EqualError(t, client.DeleteResource(ctx, ResourceInfo{ResourceType: "Tuning", Name: "hpo"}), "failed to delete Tuning hpo: deleting Tuning resources is not supported")
+	mockClient.AssertExpectations(t)
+}
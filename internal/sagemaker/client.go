@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -11,6 +13,8 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/sagemaker"
 	"github.com/aws/aws-sdk-go-v2/service/sagemaker/types"
 	"github.com/aws/smithy-go"
+	"mohua/internal/awstrace"
+	"mohua/internal/pricing"
 	"mohua/internal/retry"
 )
 
@@ -20,6 +24,31 @@ type Client interface {
 	ListEndpoints(ctx context.Context) ([]ResourceInfo, error)
 	ListNotebooks(ctx context.Context) ([]ResourceInfo, error)
 	ListStudioApps(ctx context.Context) ([]ResourceInfo, error)
+	ListTuningJobs(ctx context.Context) ([]ResourceInfo, error)
+	ListPipelineExecutions(ctx context.Context, stuckAfter time.Duration) ([]ResourceInfo, error)
+	ListAutoMLJobs(ctx context.Context) ([]ResourceInfo, error)
+	ListCompilationJobs(ctx context.Context) ([]ResourceInfo, error)
+	ListInferenceRecommendationsJobs(ctx context.Context) ([]ResourceInfo, error)
+	ListLabelingJobs(ctx context.Context) ([]ResourceInfo, error)
+	ListMlflowTrackingServers(ctx context.Context) ([]ResourceInfo, error)
+	ListOnlineFeatureGroups(ctx context.Context) ([]ResourceInfo, error)
+	ListMonitoringSchedules(ctx context.Context) ([]ResourceInfo, error)
+	ListInferenceExperiments(ctx context.Context) ([]ResourceInfo, error)
+	ListTrainingJobs(ctx context.Context) ([]ResourceInfo, error)
+	ListEndpointVariants(ctx context.Context) ([]EndpointVariant, error)
+	ListNotebookVolumes(ctx context.Context) ([]StorageInfo, error)
+	ListSpaceVolumes(ctx context.Context) ([]StorageInfo, error)
+	ListDomainVolumes(ctx context.Context) ([]StorageInfo, error)
+	ListNotebookSecurity(ctx context.Context) ([]SecurityInfo, error)
+	ListEndpointSecurity(ctx context.Context) ([]SecurityInfo, error)
+	ListDomainSecurity(ctx context.Context) ([]SecurityInfo, error)
+	ListUserProfileSecurity(ctx context.Context) ([]SecurityInfo, error)
+	FindOrphans(ctx context.Context, includeModelPackages bool) ([]OrphanInfo, error)
+	DeleteOrphan(ctx context.Context, orphan OrphanInfo) error
+	ListModelPackageVersions(ctx context.Context, group string) ([]string, error)
+	DescribeResource(ctx context.Context, resource ResourceInfo) (string, error)
+	StopResource(ctx context.Context, resource ResourceInfo) error
+	DeleteResource(ctx context.Context, resource ResourceInfo) error
 	GetRegion() string
 }
 
@@ -29,6 +58,71 @@ type SageMakerClientInterface interface {
 	ListEndpoints(ctx context.Context, params *sagemaker.ListEndpointsInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListEndpointsOutput, error)
 	ListNotebookInstances(ctx context.Context, params *sagemaker.ListNotebookInstancesInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListNotebookInstancesOutput, error)
 	ListDomains(ctx context.Context, params *sagemaker.ListDomainsInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListDomainsOutput, error)
+	DescribeEndpoint(ctx context.Context, params *sagemaker.DescribeEndpointInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeEndpointOutput, error)
+	DescribeNotebookInstance(ctx context.Context, params *sagemaker.DescribeNotebookInstanceInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeNotebookInstanceOutput, error)
+	ListSpaces(ctx context.Context, params *sagemaker.ListSpacesInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListSpacesOutput, error)
+	DescribeDomain(ctx context.Context, params *sagemaker.DescribeDomainInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeDomainOutput, error)
+	ListEndpointConfigs(ctx context.Context, params *sagemaker.ListEndpointConfigsInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListEndpointConfigsOutput, error)
+	ListModels(ctx context.Context, params *sagemaker.ListModelsInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListModelsOutput, error)
+	ListInferenceComponents(ctx context.Context, params *sagemaker.ListInferenceComponentsInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListInferenceComponentsOutput, error)
+	ListModelPackageGroups(ctx context.Context, params *sagemaker.ListModelPackageGroupsInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListModelPackageGroupsOutput, error)
+	DescribeEndpointConfig(ctx context.Context, params *sagemaker.DescribeEndpointConfigInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeEndpointConfigOutput, error)
+	DescribeInferenceComponent(ctx context.Context, params *sagemaker.DescribeInferenceComponentInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeInferenceComponentOutput, error)
+	DescribeModel(ctx context.Context, params *sagemaker.DescribeModelInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeModelOutput, error)
+	DeleteEndpointConfig(ctx context.Context, params *sagemaker.DeleteEndpointConfigInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DeleteEndpointConfigOutput, error)
+	DeleteModel(ctx context.Context, params *sagemaker.DeleteModelInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DeleteModelOutput, error)
+	DeleteModelPackageGroup(ctx context.Context, params *sagemaker.DeleteModelPackageGroupInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DeleteModelPackageGroupOutput, error)
+	ListModelPackages(ctx context.Context, params *sagemaker.ListModelPackagesInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListModelPackagesOutput, error)
+	DeleteModelPackage(ctx context.Context, params *sagemaker.DeleteModelPackageInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DeleteModelPackageOutput, error)
+	ListHyperParameterTuningJobs(ctx context.Context, params *sagemaker.ListHyperParameterTuningJobsInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListHyperParameterTuningJobsOutput, error)
+	DescribeHyperParameterTuningJob(ctx context.Context, params *sagemaker.DescribeHyperParameterTuningJobInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeHyperParameterTuningJobOutput, error)
+	ListTrainingJobsForHyperParameterTuningJob(ctx context.Context, params *sagemaker.ListTrainingJobsForHyperParameterTuningJobInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListTrainingJobsForHyperParameterTuningJobOutput, error)
+	ListPipelines(ctx context.Context, params *sagemaker.ListPipelinesInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListPipelinesOutput, error)
+	ListPipelineExecutions(ctx context.Context, params *sagemaker.ListPipelineExecutionsInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListPipelineExecutionsOutput, error)
+	ListPipelineExecutionSteps(ctx context.Context, params *sagemaker.ListPipelineExecutionStepsInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListPipelineExecutionStepsOutput, error)
+	DescribeTrainingJob(ctx context.Context, params *sagemaker.DescribeTrainingJobInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeTrainingJobOutput, error)
+	DescribeProcessingJob(ctx context.Context, params *sagemaker.DescribeProcessingJobInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeProcessingJobOutput, error)
+	ListAutoMLJobs(ctx context.Context, params *sagemaker.ListAutoMLJobsInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListAutoMLJobsOutput, error)
+	ListCompilationJobs(ctx context.Context, params *sagemaker.ListCompilationJobsInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListCompilationJobsOutput, error)
+	ListInferenceRecommendationsJobs(ctx context.Context, params *sagemaker.ListInferenceRecommendationsJobsInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListInferenceRecommendationsJobsOutput, error)
+	ListInferenceRecommendationsJobSteps(ctx context.Context, params *sagemaker.ListInferenceRecommendationsJobStepsInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListInferenceRecommendationsJobStepsOutput, error)
+	ListLabelingJobs(ctx context.Context, params *sagemaker.ListLabelingJobsInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListLabelingJobsOutput, error)
+	ListMlflowTrackingServers(ctx context.Context, params *sagemaker.ListMlflowTrackingServersInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListMlflowTrackingServersOutput, error)
+	DescribeMlflowTrackingServer(ctx context.Context, params *sagemaker.DescribeMlflowTrackingServerInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeMlflowTrackingServerOutput, error)
+	ListFeatureGroups(ctx context.Context, params *sagemaker.ListFeatureGroupsInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListFeatureGroupsOutput, error)
+	DescribeFeatureGroup(ctx context.Context, params *sagemaker.DescribeFeatureGroupInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeFeatureGroupOutput, error)
+	ListMonitoringSchedules(ctx context.Context, params *sagemaker.ListMonitoringSchedulesInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListMonitoringSchedulesOutput, error)
+	DescribeMonitoringSchedule(ctx context.Context, params *sagemaker.DescribeMonitoringScheduleInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeMonitoringScheduleOutput, error)
+	DescribeDataQualityJobDefinition(ctx context.Context, params *sagemaker.DescribeDataQualityJobDefinitionInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeDataQualityJobDefinitionOutput, error)
+	DescribeModelQualityJobDefinition(ctx context.Context, params *sagemaker.DescribeModelQualityJobDefinitionInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeModelQualityJobDefinitionOutput, error)
+	DescribeModelBiasJobDefinition(ctx context.Context, params *sagemaker.DescribeModelBiasJobDefinitionInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeModelBiasJobDefinitionOutput, error)
+	DescribeModelExplainabilityJobDefinition(ctx context.Context, params *sagemaker.DescribeModelExplainabilityJobDefinitionInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeModelExplainabilityJobDefinitionOutput, error)
+	ListInferenceExperiments(ctx context.Context, params *sagemaker.ListInferenceExperimentsInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListInferenceExperimentsOutput, error)
+	DescribeInferenceExperiment(ctx context.Context, params *sagemaker.DescribeInferenceExperimentInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeInferenceExperimentOutput, error)
+	ListUserProfiles(ctx context.Context, params *sagemaker.ListUserProfilesInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListUserProfilesOutput, error)
+	DescribeUserProfile(ctx context.Context, params *sagemaker.DescribeUserProfileInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeUserProfileOutput, error)
+	ListTrainingJobs(ctx context.Context, params *sagemaker.ListTrainingJobsInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListTrainingJobsOutput, error)
+	DescribeApp(ctx context.Context, params *sagemaker.DescribeAppInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeAppOutput, error)
+	DeleteApp(ctx context.Context, params *sagemaker.DeleteAppInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DeleteAppOutput, error)
+	DescribePipelineExecution(ctx context.Context, params *sagemaker.DescribePipelineExecutionInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribePipelineExecutionOutput, error)
+	StopPipelineExecution(ctx context.Context, params *sagemaker.StopPipelineExecutionInput, optFns ...func(*sagemaker.Options)) (*sagemaker.StopPipelineExecutionOutput, error)
+	DescribeAutoMLJob(ctx context.Context, params *sagemaker.DescribeAutoMLJobInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeAutoMLJobOutput, error)
+	StopAutoMLJob(ctx context.Context, params *sagemaker.StopAutoMLJobInput, optFns ...func(*sagemaker.Options)) (*sagemaker.StopAutoMLJobOutput, error)
+	DescribeCompilationJob(ctx context.Context, params *sagemaker.DescribeCompilationJobInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeCompilationJobOutput, error)
+	StopCompilationJob(ctx context.Context, params *sagemaker.StopCompilationJobInput, optFns ...func(*sagemaker.Options)) (*sagemaker.StopCompilationJobOutput, error)
+	DescribeInferenceRecommendationsJob(ctx context.Context, params *sagemaker.DescribeInferenceRecommendationsJobInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeInferenceRecommendationsJobOutput, error)
+	StopInferenceRecommendationsJob(ctx context.Context, params *sagemaker.StopInferenceRecommendationsJobInput, optFns ...func(*sagemaker.Options)) (*sagemaker.StopInferenceRecommendationsJobOutput, error)
+	DescribeLabelingJob(ctx context.Context, params *sagemaker.DescribeLabelingJobInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeLabelingJobOutput, error)
+	StopLabelingJob(ctx context.Context, params *sagemaker.StopLabelingJobInput, optFns ...func(*sagemaker.Options)) (*sagemaker.StopLabelingJobOutput, error)
+	DeleteEndpoint(ctx context.Context, params *sagemaker.DeleteEndpointInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DeleteEndpointOutput, error)
+	StopNotebookInstance(ctx context.Context, params *sagemaker.StopNotebookInstanceInput, optFns ...func(*sagemaker.Options)) (*sagemaker.StopNotebookInstanceOutput, error)
+	DeleteNotebookInstance(ctx context.Context, params *sagemaker.DeleteNotebookInstanceInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DeleteNotebookInstanceOutput, error)
+	StopHyperParameterTuningJob(ctx context.Context, params *sagemaker.StopHyperParameterTuningJobInput, optFns ...func(*sagemaker.Options)) (*sagemaker.StopHyperParameterTuningJobOutput, error)
+	StopMlflowTrackingServer(ctx context.Context, params *sagemaker.StopMlflowTrackingServerInput, optFns ...func(*sagemaker.Options)) (*sagemaker.StopMlflowTrackingServerOutput, error)
+	DeleteMlflowTrackingServer(ctx context.Context, params *sagemaker.DeleteMlflowTrackingServerInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DeleteMlflowTrackingServerOutput, error)
+	DeleteFeatureGroup(ctx context.Context, params *sagemaker.DeleteFeatureGroupInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DeleteFeatureGroupOutput, error)
+	StopMonitoringSchedule(ctx context.Context, params *sagemaker.StopMonitoringScheduleInput, optFns ...func(*sagemaker.Options)) (*sagemaker.StopMonitoringScheduleOutput, error)
+	DeleteMonitoringSchedule(ctx context.Context, params *sagemaker.DeleteMonitoringScheduleInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DeleteMonitoringScheduleOutput, error)
 }
 
 // clientImpl implements only the necessary SageMaker API operations
@@ -45,13 +139,13 @@ var NewClient NewClientFunc = newClient
 
 // newClient creates a new SageMaker client
 func newClient(region string) (Client, error) {
-	var opts []func(*config.LoadOptions) error
-	
+	opts := []func(*config.LoadOptions) error{awstrace.WithTracing()}
+
 	// If region is provided, use it; otherwise, let AWS SDK handle region selection
 	if region != "" {
 		opts = append(opts, config.WithRegion(region))
 	}
-	
+
 	cfg, err := config.LoadDefaultConfig(context.Background(), opts...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load AWS SDK configuration: %w", err)
@@ -83,10 +177,10 @@ func (c *clientImpl) ValidateConfiguration(ctx context.Context) (bool, error) {
 		var apiErr smithy.APIError
 		if errors.As(err, &apiErr) {
 			switch apiErr.ErrorCode() {
-			case "AccessDeniedException", 
-				 "InvalidClientTokenId", 
-				 "SignatureDoesNotMatch", 
-				 "ExpiredToken":
+			case "AccessDeniedException",
+				"InvalidClientTokenId",
+				"SignatureDoesNotMatch",
+				"ExpiredToken":
 				return false, nil
 			}
 		}
@@ -100,7 +194,7 @@ func (c *clientImpl) ValidateConfiguration(ctx context.Context) (bool, error) {
 // ListEndpoints returns only active endpoints
 func (c *clientImpl) ListEndpoints(ctx context.Context) ([]ResourceInfo, error) {
 	var resources []ResourceInfo
-	
+
 	retrier := retry.NewRetrier(retry.DefaultConfig)
 	err := retrier.Do(ctx, func() error {
 		input := &sagemaker.ListEndpointsInput{}
@@ -112,21 +206,63 @@ func (c *clientImpl) ListEndpoints(ctx context.Context) ([]ResourceInfo, error)
 		resources = make([]ResourceInfo, 0, len(output.Endpoints))
 		for _, endpoint := range output.Endpoints {
 			if endpoint.EndpointStatus == types.EndpointStatusInService {
-				// we'll skip detailed endpoint config
 				resources = append(resources, ResourceInfo{
-					Name:         *endpoint.EndpointName,
-					Status:       string(endpoint.EndpointStatus),
-					InstanceType: "unknown", // Simplified version doesn't fetch detailed config
-					InstanceCount: 1,        // Default to 1 for simplified version
-					CreationTime: *endpoint.CreationTime,
+					Name:          *endpoint.EndpointName,
+					Arn:           aws.ToString(endpoint.EndpointArn),
+					Status:        string(endpoint.EndpointStatus),
+					InstanceType:  "unknown", // Replaced from the endpoint config below
+					InstanceCount: 1,
+					CreationTime:  *endpoint.CreationTime,
 				})
 			}
 		}
 
 		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
 
-	return resources, err
+	// Data capture and instance types need DescribeEndpoint and DescribeEndpointConfig
+	names := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		names = append(names, resource.Name)
+	}
+
+	var mu sync.Mutex
+	detailsByName := make(map[string]endpointDetails, len(names))
+	err = forEachConcurrently(ctx, names, func(ctx context.Context, name string) error {
+		details, err := c.describeEndpointDetails(ctx, retrier, name)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		detailsByName[name] = details
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range resources {
+		details := detailsByName[resources[i].Name]
+		if details.instanceType != "" {
+			resources[i].InstanceType = details.instanceType
+		}
+		if details.instanceType == "multiple" {
+			resources[i].InstanceCounts = details.instanceCounts
+		}
+		if details.instanceCount > 0 {
+			resources[i].InstanceCount = details.instanceCount
+		}
+		if details.dataCapture != nil {
+			resources[i].DataCapture = details.dataCapture
+			resources[i].Details = details.dataCapture.String()
+		}
+	}
+
+	return resources, nil
 }
 
 // ListNotebooks returns only running notebook instances
@@ -146,6 +282,7 @@ func (c *clientImpl) ListNotebooks(ctx context.Context) ([]ResourceInfo, error)
 			if notebook.NotebookInstanceStatus == types.NotebookInstanceStatusInService {
 				resources = append(resources, ResourceInfo{
 					Name:         *notebook.NotebookInstanceName,
+					Arn:          aws.ToString(notebook.NotebookInstanceArn),
 					Status:       string(notebook.NotebookInstanceStatus),
 					InstanceType: string(notebook.InstanceType),
 					CreationTime: *notebook.CreationTime,
@@ -160,6 +297,97 @@ func (c *clientImpl) ListNotebooks(ctx context.Context) ([]ResourceInfo, error)
 	return resources, err
 }
 
+// ListEndpointVariants returns the production variants of every active endpoint
+func (c *clientImpl) ListEndpointVariants(ctx context.Context) ([]EndpointVariant, error) {
+	var endpointNames []string
+
+	retrier := retry.NewRetrier(retry.DefaultConfig)
+	err := retrier.Do(ctx, func() error {
+		endpointNames = nil
+		input := &sagemaker.ListEndpointsInput{}
+		for {
+			output, err := c.client.ListEndpoints(ctx, input)
+			if err != nil {
+				return WrapError(err)
+			}
+			for _, endpoint := range output.Endpoints {
+				if endpoint.EndpointStatus == types.EndpointStatusInService && endpoint.EndpointName != nil {
+					endpointNames = append(endpointNames, *endpoint.EndpointName)
+				}
+			}
+			if output.NextToken == nil {
+				return nil
+			}
+			input.NextToken = output.NextToken
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var variants []EndpointVariant
+	for _, name := range endpointNames {
+		var output *sagemaker.DescribeEndpointOutput
+		err := retrier.Do(ctx, func() error {
+			var err error
+			output, err = c.client.DescribeEndpoint(ctx, &sagemaker.DescribeEndpointInput{
+				EndpointName: aws.String(name),
+			})
+			return WrapError(err)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe endpoint %s: %w", name, err)
+		}
+
+		instanceTypes, err := c.variantInstanceTypes(ctx, retrier, output.EndpointConfigName)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, variant := range output.ProductionVariants {
+			if variant.VariantName == nil {
+				continue
+			}
+			info := EndpointVariant{
+				EndpointName: name,
+				VariantName:  *variant.VariantName,
+				InstanceType: instanceTypes[*variant.VariantName],
+			}
+			if variant.CurrentInstanceCount != nil {
+				info.InstanceCount = int(*variant.CurrentInstanceCount)
+			}
+			variants = append(variants, info)
+		}
+	}
+
+	return variants, nil
+}
+
+// variantInstanceTypes returns the instance type of each variant of an endpoint config
+func (c *clientImpl) variantInstanceTypes(ctx context.Context, retrier *retry.Retrier, configName *string) (map[string]string, error) {
+	if configName == nil {
+		return nil, nil
+	}
+
+	var output *sagemaker.DescribeEndpointConfigOutput
+	err := retrier.Do(ctx, func() error {
+		var err error
+		output, err = c.client.DescribeEndpointConfig(ctx, &sagemaker.DescribeEndpointConfigInput{
+			EndpointConfigName: configName,
+		})
+		return WrapError(err)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe endpoint config %s: %w", *configName, err)
+	}
+
+	instanceTypes := make(map[string]string, len(output.ProductionVariants))
+	for _, variant := range output.ProductionVariants {
+		instanceTypes[aws.ToString(variant.VariantName)] = string(variant.InstanceType)
+	}
+	return instanceTypes, nil
+}
+
 // ListStudioApps returns only running studio applications
 // GetRegion returns the configured region for the client
 func (c *clientImpl) GetRegion() string {
@@ -204,7 +432,7 @@ func (c *clientImpl) ListStudioApps(ctx context.Context) ([]ResourceInfo, error)
 
 				// Determine Studio type and space name
 				appType = string(app.AppType)
-				
+
 				switch app.AppType {
 				case types.AppTypeJupyterServer:
 					studioType = "Old Studio (JupyterServer)"
@@ -230,6 +458,7 @@ func (c *clientImpl) ListStudioApps(ctx context.Context) ([]ResourceInfo, error)
 						AppType:      appType,
 						SpaceName:    spaceName,
 						StudioType:   studioType,
+						DomainID:     aws.ToString(app.DomainId),
 					})
 				}
 			}
@@ -243,14 +472,69 @@ func (c *clientImpl) ListStudioApps(ctx context.Context) ([]ResourceInfo, error)
 
 // ResourceInfo contains common fields for SageMaker resources
 type ResourceInfo struct {
-	Name          string
-	Status        string
-	InstanceType  string
+	ResourceType   string // Set by the caller that collects resources of several types
+	Region         string // Set by the caller that collects resources of several types
+	Name           string
+	Arn            string // Empty for resources that cannot be tagged, e.g. Studio apps
+	Status         string
+	InstanceType   string
+	InstanceCount  int
+	InstanceCounts map[string]int // Instances per type of resources that use several types
+	Spot           bool           // Set for managed spot training jobs
+	CreationTime   time.Time
+	VolumeSize     int
+	UserProfile    string
+	AppType        string
+	SpaceName      string            // New field for Studio spaces
+	DomainID       string            // Set for Studio apps
+	StudioType     string            // New field for JupyterServer/JupyterLab
+	Details        string            // Type specific summary shown in the Details column
+	HourlyCost     float64           // Estimated hourly cost for resources not billed by instance type
+	DataCapture    *DataCapture      // Set for endpoints with data capture enabled
+	EndpointName   string            // Endpoint a monitoring schedule or inference experiment is attached to
+	Experiment     string            // Inference experiment an endpoint is currently part of
+	Tags           map[string]string // Only set when tags were requested
+	CreatedBy      string            // Only set when creators were looked up in CloudTrail
+	Tuning         *TuningRollup     // Set for hyperparameter tuning jobs
+	Pipeline       *PipelineRun      // Set for pipeline executions
+}
+
+// EndpointVariant describes a single production variant of an endpoint
+type EndpointVariant struct {
+	EndpointName  string
+	VariantName   string
+	InstanceType  string // Empty for serverless variants
 	InstanceCount int
-	CreationTime  time.Time
-	VolumeSize    int
-	UserProfile   string
-	AppType       string
-	SpaceName     string    // New field for Studio spaces
-	StudioType    string    // New field for JupyterServer/JupyterLab
+}
+
+// Instances returns the number of running instances per instance type. A
+// resource without an instance count is counted as one instance.
+func (r ResourceInfo) Instances() map[string]int {
+	if len(r.InstanceCounts) > 0 {
+		return r.InstanceCounts
+	}
+	if !strings.HasPrefix(r.InstanceType, "ml.") {
+		return nil
+	}
+	count := r.InstanceCount
+	if count < 1 {
+		count = 1
+	}
+	return map[string]int{r.InstanceType: count}
+}
+
+// EstimatedHourlyCost returns the estimated hourly cost of the resource, using
+// HourlyCost when it is set and the instance prices otherwise. Instance types
+// that are not in the pricing table count as zero.
+func (r ResourceInfo) EstimatedHourlyCost() float64 {
+	if r.HourlyCost > 0 {
+		return r.HourlyCost
+	}
+	var cost float64
+	for instanceType, count := range r.Instances() {
+		if price, ok := pricing.HourlyPrice(instanceType); ok {
+			cost += price * float64(count)
+		}
+	}
+	return cost
 }
@@ -49,10 +49,530 @@ func (m *MockSageMakerClient) ListDomains(ctx context.Context, params *sagemaker
 	return args.Get(0).(*sagemaker.ListDomainsOutput), args.Error(1)
 }
 
+func (m *MockSageMakerClient) DescribeEndpoint(ctx context.Context, params *sagemaker.DescribeEndpointInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeEndpointOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.DescribeEndpointOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) DescribeNotebookInstance(ctx context.Context, params *sagemaker.DescribeNotebookInstanceInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.DescribeNotebookInstanceOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) ListSpaces(ctx context.Context, params *sagemaker.ListSpacesInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListSpacesOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.ListSpacesOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) DescribeDomain(ctx context.Context, params *sagemaker.DescribeDomainInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeDomainOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.DescribeDomainOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) ListEndpointConfigs(ctx context.Context, params *sagemaker.ListEndpointConfigsInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListEndpointConfigsOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.ListEndpointConfigsOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) ListModels(ctx context.Context, params *sagemaker.ListModelsInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListModelsOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.ListModelsOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) ListInferenceComponents(ctx context.Context, params *sagemaker.ListInferenceComponentsInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListInferenceComponentsOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.ListInferenceComponentsOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) ListModelPackageGroups(ctx context.Context, params *sagemaker.ListModelPackageGroupsInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListModelPackageGroupsOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.ListModelPackageGroupsOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) DescribeEndpointConfig(ctx context.Context, params *sagemaker.DescribeEndpointConfigInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeEndpointConfigOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.DescribeEndpointConfigOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) DescribeInferenceComponent(ctx context.Context, params *sagemaker.DescribeInferenceComponentInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeInferenceComponentOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.DescribeInferenceComponentOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) DescribeModel(ctx context.Context, params *sagemaker.DescribeModelInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeModelOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.DescribeModelOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) DeleteEndpointConfig(ctx context.Context, params *sagemaker.DeleteEndpointConfigInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DeleteEndpointConfigOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.DeleteEndpointConfigOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) DeleteModel(ctx context.Context, params *sagemaker.DeleteModelInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DeleteModelOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.DeleteModelOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) DeleteModelPackageGroup(ctx context.Context, params *sagemaker.DeleteModelPackageGroupInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DeleteModelPackageGroupOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.DeleteModelPackageGroupOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) ListModelPackages(ctx context.Context, params *sagemaker.ListModelPackagesInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListModelPackagesOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.ListModelPackagesOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) DeleteModelPackage(ctx context.Context, params *sagemaker.DeleteModelPackageInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DeleteModelPackageOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.DeleteModelPackageOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) ListHyperParameterTuningJobs(ctx context.Context, params *sagemaker.ListHyperParameterTuningJobsInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListHyperParameterTuningJobsOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.ListHyperParameterTuningJobsOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) DescribeHyperParameterTuningJob(ctx context.Context, params *sagemaker.DescribeHyperParameterTuningJobInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeHyperParameterTuningJobOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.DescribeHyperParameterTuningJobOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) ListTrainingJobsForHyperParameterTuningJob(ctx context.Context, params *sagemaker.ListTrainingJobsForHyperParameterTuningJobInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListTrainingJobsForHyperParameterTuningJobOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.ListTrainingJobsForHyperParameterTuningJobOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) ListPipelines(ctx context.Context, params *sagemaker.ListPipelinesInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListPipelinesOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.ListPipelinesOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) ListPipelineExecutions(ctx context.Context, params *sagemaker.ListPipelineExecutionsInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListPipelineExecutionsOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.ListPipelineExecutionsOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) ListPipelineExecutionSteps(ctx context.Context, params *sagemaker.ListPipelineExecutionStepsInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListPipelineExecutionStepsOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.ListPipelineExecutionStepsOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) DescribeTrainingJob(ctx context.Context, params *sagemaker.DescribeTrainingJobInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeTrainingJobOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.DescribeTrainingJobOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) DescribeProcessingJob(ctx context.Context, params *sagemaker.DescribeProcessingJobInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeProcessingJobOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.DescribeProcessingJobOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) ListAutoMLJobs(ctx context.Context, params *sagemaker.ListAutoMLJobsInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListAutoMLJobsOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.ListAutoMLJobsOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) ListCompilationJobs(ctx context.Context, params *sagemaker.ListCompilationJobsInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListCompilationJobsOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.ListCompilationJobsOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) ListInferenceRecommendationsJobs(ctx context.Context, params *sagemaker.ListInferenceRecommendationsJobsInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListInferenceRecommendationsJobsOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.ListInferenceRecommendationsJobsOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) ListInferenceRecommendationsJobSteps(ctx context.Context, params *sagemaker.ListInferenceRecommendationsJobStepsInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListInferenceRecommendationsJobStepsOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.ListInferenceRecommendationsJobStepsOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) ListLabelingJobs(ctx context.Context, params *sagemaker.ListLabelingJobsInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListLabelingJobsOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.ListLabelingJobsOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) ListMlflowTrackingServers(ctx context.Context, params *sagemaker.ListMlflowTrackingServersInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListMlflowTrackingServersOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.ListMlflowTrackingServersOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) DescribeMlflowTrackingServer(ctx context.Context, params *sagemaker.DescribeMlflowTrackingServerInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeMlflowTrackingServerOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.DescribeMlflowTrackingServerOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) ListFeatureGroups(ctx context.Context, params *sagemaker.ListFeatureGroupsInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListFeatureGroupsOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.ListFeatureGroupsOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) DescribeFeatureGroup(ctx context.Context, params *sagemaker.DescribeFeatureGroupInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeFeatureGroupOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.DescribeFeatureGroupOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) ListMonitoringSchedules(ctx context.Context, params *sagemaker.ListMonitoringSchedulesInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListMonitoringSchedulesOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.ListMonitoringSchedulesOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) DescribeMonitoringSchedule(ctx context.Context, params *sagemaker.DescribeMonitoringScheduleInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeMonitoringScheduleOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.DescribeMonitoringScheduleOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) DescribeDataQualityJobDefinition(ctx context.Context, params *sagemaker.DescribeDataQualityJobDefinitionInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeDataQualityJobDefinitionOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.DescribeDataQualityJobDefinitionOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) DescribeModelQualityJobDefinition(ctx context.Context, params *sagemaker.DescribeModelQualityJobDefinitionInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeModelQualityJobDefinitionOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.DescribeModelQualityJobDefinitionOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) DescribeModelBiasJobDefinition(ctx context.Context, params *sagemaker.DescribeModelBiasJobDefinitionInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeModelBiasJobDefinitionOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.DescribeModelBiasJobDefinitionOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) DescribeModelExplainabilityJobDefinition(ctx context.Context, params *sagemaker.DescribeModelExplainabilityJobDefinitionInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeModelExplainabilityJobDefinitionOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.DescribeModelExplainabilityJobDefinitionOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) ListInferenceExperiments(ctx context.Context, params *sagemaker.ListInferenceExperimentsInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListInferenceExperimentsOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.ListInferenceExperimentsOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) DescribeInferenceExperiment(ctx context.Context, params *sagemaker.DescribeInferenceExperimentInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeInferenceExperimentOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.DescribeInferenceExperimentOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) ListUserProfiles(ctx context.Context, params *sagemaker.ListUserProfilesInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListUserProfilesOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.ListUserProfilesOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) DescribeUserProfile(ctx context.Context, params *sagemaker.DescribeUserProfileInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeUserProfileOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.DescribeUserProfileOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) ListTrainingJobs(ctx context.Context, params *sagemaker.ListTrainingJobsInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListTrainingJobsOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.ListTrainingJobsOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) DescribeApp(ctx context.Context, params *sagemaker.DescribeAppInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeAppOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.DescribeAppOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) DeleteApp(ctx context.Context, params *sagemaker.DeleteAppInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DeleteAppOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.DeleteAppOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) DescribePipelineExecution(ctx context.Context, params *sagemaker.DescribePipelineExecutionInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribePipelineExecutionOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.DescribePipelineExecutionOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) StopPipelineExecution(ctx context.Context, params *sagemaker.StopPipelineExecutionInput, optFns ...func(*sagemaker.Options)) (*sagemaker.StopPipelineExecutionOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.StopPipelineExecutionOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) DescribeAutoMLJob(ctx context.Context, params *sagemaker.DescribeAutoMLJobInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeAutoMLJobOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.DescribeAutoMLJobOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) StopAutoMLJob(ctx context.Context, params *sagemaker.StopAutoMLJobInput, optFns ...func(*sagemaker.Options)) (*sagemaker.StopAutoMLJobOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.StopAutoMLJobOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) DescribeCompilationJob(ctx context.Context, params *sagemaker.DescribeCompilationJobInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeCompilationJobOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.DescribeCompilationJobOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) StopCompilationJob(ctx context.Context, params *sagemaker.StopCompilationJobInput, optFns ...func(*sagemaker.Options)) (*sagemaker.StopCompilationJobOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.StopCompilationJobOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) DescribeInferenceRecommendationsJob(ctx context.Context, params *sagemaker.DescribeInferenceRecommendationsJobInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeInferenceRecommendationsJobOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.DescribeInferenceRecommendationsJobOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) StopInferenceRecommendationsJob(ctx context.Context, params *sagemaker.StopInferenceRecommendationsJobInput, optFns ...func(*sagemaker.Options)) (*sagemaker.StopInferenceRecommendationsJobOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.StopInferenceRecommendationsJobOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) DescribeLabelingJob(ctx context.Context, params *sagemaker.DescribeLabelingJobInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeLabelingJobOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.DescribeLabelingJobOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) StopLabelingJob(ctx context.Context, params *sagemaker.StopLabelingJobInput, optFns ...func(*sagemaker.Options)) (*sagemaker.StopLabelingJobOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.StopLabelingJobOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) DeleteEndpoint(ctx context.Context, params *sagemaker.DeleteEndpointInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DeleteEndpointOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.DeleteEndpointOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) StopNotebookInstance(ctx context.Context, params *sagemaker.StopNotebookInstanceInput, optFns ...func(*sagemaker.Options)) (*sagemaker.StopNotebookInstanceOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.StopNotebookInstanceOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) DeleteNotebookInstance(ctx context.Context, params *sagemaker.DeleteNotebookInstanceInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DeleteNotebookInstanceOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.DeleteNotebookInstanceOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) StopHyperParameterTuningJob(ctx context.Context, params *sagemaker.StopHyperParameterTuningJobInput, optFns ...func(*sagemaker.Options)) (*sagemaker.StopHyperParameterTuningJobOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.StopHyperParameterTuningJobOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) StopMlflowTrackingServer(ctx context.Context, params *sagemaker.StopMlflowTrackingServerInput, optFns ...func(*sagemaker.Options)) (*sagemaker.StopMlflowTrackingServerOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.StopMlflowTrackingServerOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) DeleteMlflowTrackingServer(ctx context.Context, params *sagemaker.DeleteMlflowTrackingServerInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DeleteMlflowTrackingServerOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.DeleteMlflowTrackingServerOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) DeleteFeatureGroup(ctx context.Context, params *sagemaker.DeleteFeatureGroupInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DeleteFeatureGroupOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.DeleteFeatureGroupOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) StopMonitoringSchedule(ctx context.Context, params *sagemaker.StopMonitoringScheduleInput, optFns ...func(*sagemaker.Options)) (*sagemaker.StopMonitoringScheduleOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.StopMonitoringScheduleOutput), args.Error(1)
+}
+
+func (m *MockSageMakerClient) DeleteMonitoringSchedule(ctx context.Context, params *sagemaker.DeleteMonitoringScheduleInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DeleteMonitoringScheduleOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sagemaker.DeleteMonitoringScheduleOutput), args.Error(1)
+}
+
 // TestMockSageMakerClientBasic verifies that the mock client implements the interface correctly
 func TestMockSageMakerClientBasic(t *testing.T) {
 	mockClient := new(MockSageMakerClient)
-	
+
 	// Test that mockClient implements SageMakerClientInterface
 	var _ SageMakerClientInterface = mockClient
 
@@ -70,10 +590,10 @@ func TestMockSageMakerClientBasic(t *testing.T) {
 			Apps: []types.AppDetails{
 				{
 					AppName:         aws.String("TestApp"),
-					Status:         types.AppStatusInService,
-					CreationTime:   aws.Time(now),
+					Status:          types.AppStatusInService,
+					CreationTime:    aws.Time(now),
 					UserProfileName: aws.String("TestUser"),
-					AppType:        types.AppTypeJupyterServer,
+					AppType:         types.AppTypeJupyterServer,
 				},
 			},
 		}, nil)
@@ -62,19 +62,19 @@ func TestListStudioApps_NilFields(t *testing.T) {
 			Apps: []types.AppDetails{
 				{
 					// Intentionally leave some fields nil
-					Status:     types.AppStatusInService,
-					AppType:    types.AppTypeJupyterServer,
-					AppName:    nil,
-					CreationTime: nil,
+					Status:          types.AppStatusInService,
+					AppType:         types.AppTypeJupyterServer,
+					AppName:         nil,
+					CreationTime:    nil,
 					UserProfileName: nil,
-					ResourceSpec: nil,
+					ResourceSpec:    nil,
 				},
 				{
 					// Old Studio app with some fields populated
-					Status:     types.AppStatusInService,
-					AppType:    types.AppTypeJupyterServer,
-					AppName:    aws.String("TestApp"),
-					CreationTime: aws.Time(now),
+					Status:          types.AppStatusInService,
+					AppType:         types.AppTypeJupyterServer,
+					AppName:         aws.String("TestApp"),
+					CreationTime:    aws.Time(now),
 					UserProfileName: aws.String("TestUser"),
 					ResourceSpec: &types.ResourceSpec{
 						InstanceType: types.AppInstanceType("ml.t3.medium"),
@@ -82,12 +82,12 @@ func TestListStudioApps_NilFields(t *testing.T) {
 				},
 				{
 					// New Studio app with space name
-					Status:     types.AppStatusInService,
-					AppType:    types.AppTypeJupyterLab,
-					AppName:    aws.String("NewTestApp"),
-					CreationTime: aws.Time(now),
+					Status:          types.AppStatusInService,
+					AppType:         types.AppTypeJupyterLab,
+					AppName:         aws.String("NewTestApp"),
+					CreationTime:    aws.Time(now),
 					UserProfileName: aws.String("NewTestUser"),
-					SpaceName:   aws.String("TestSpace"),
+					SpaceName:       aws.String("TestSpace"),
 					ResourceSpec: &types.ResourceSpec{
 						InstanceType: types.AppInstanceType("ml.t3.large"),
 					},
@@ -106,7 +106,7 @@ func TestListStudioApps_NilFields(t *testing.T) {
 	// Assert expectations
 	assert.NoError(t, err)
 	assert.Len(t, resources, 2, "Should include apps with non-nil names")
-	
+
 	// Verify the old Studio app details
 	oldStudioApp := resources[0]
 	assert.Equal(t, "TestApp", oldStudioApp.Name)
@@ -140,20 +140,20 @@ func TestListStudioApps_StatusHandling(t *testing.T) {
 			Apps: []types.AppDetails{
 				{
 					// Running old Studio app
-					Status:     types.AppStatusInService,
-					AppType:    types.AppTypeJupyterServer,
-					AppName:    aws.String("RunningOldApp"),
-					CreationTime: aws.Time(now),
+					Status:          types.AppStatusInService,
+					AppType:         types.AppTypeJupyterServer,
+					AppName:         aws.String("RunningOldApp"),
+					CreationTime:    aws.Time(now),
 					UserProfileName: aws.String("OldUser"),
 				},
 				{
 					// Stopped new Studio app
-					Status:     types.AppStatusDeleted,
-					AppType:    types.AppTypeJupyterLab,
-					AppName:    aws.String("StoppedNewApp"),
-					CreationTime: aws.Time(now.Add(-1 * time.Hour)),
+					Status:          types.AppStatusDeleted,
+					AppType:         types.AppTypeJupyterLab,
+					AppName:         aws.String("StoppedNewApp"),
+					CreationTime:    aws.Time(now.Add(-1 * time.Hour)),
 					UserProfileName: aws.String("NewUser"),
-					SpaceName:   aws.String("StoppedSpace"),
+					SpaceName:       aws.String("StoppedSpace"),
 				},
 			},
 		}, nil)
@@ -169,7 +169,7 @@ func TestListStudioApps_StatusHandling(t *testing.T) {
 	// Assert expectations
 	assert.NoError(t, err)
 	assert.Len(t, resources, 1, "Should only include InService apps")
-	
+
 	// Verify the running old Studio app details
 	runningOldApp := resources[0]
 	assert.Equal(t, "RunningOldApp", runningOldApp.Name)
@@ -200,6 +200,9 @@ func TestConcurrentResourceListing(t *testing.T) {
 			},
 		}, nil)
 
+	mockClient.On("DescribeEndpoint", mock.Anything, &sagemaker.DescribeEndpointInput{EndpointName: aws.String("Endpoint1")}, mock.Anything).
+		Return(&sagemaker.DescribeEndpointOutput{EndpointName: aws.String("Endpoint1")}, nil)
+
 	mockClient.On("ListNotebookInstances", ctx, &sagemaker.ListNotebookInstancesInput{}, mock.Anything).
 		Run(func(args mock.Arguments) {
 			time.Sleep(50 * time.Millisecond) // Simulate some delay
@@ -235,7 +238,7 @@ func TestConcurrentResourceListing(t *testing.T) {
 
 	// Measure total time for concurrent calls
 	startTime := time.Now()
-	
+
 	// Perform concurrent resource listing
 	var wg sync.WaitGroup
 	wg.Add(3)
@@ -314,3 +317,50 @@ func TestValidateConfiguration(t *testing.T) {
 	assert.NoError(t, err)
 	assert.False(t, hasResources)
 }
+
+func TestListEndpointVariants(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListEndpoints", ctx, &sagemaker.ListEndpointsInput{}, mock.Anything).
+		Return(&sagemaker.ListEndpointsOutput{
+			Endpoints: []types.EndpointSummary{
+				{
+					EndpointName:   aws.String("Endpoint1"),
+					EndpointStatus: types.EndpointStatusInService,
+					CreationTime:   aws.Time(now),
+				},
+				{
+					EndpointName:   aws.String("Creating1"),
+					EndpointStatus: types.EndpointStatusCreating,
+					CreationTime:   aws.Time(now),
+				},
+			},
+		}, nil)
+	mockClient.On("DescribeEndpoint", ctx, &sagemaker.DescribeEndpointInput{EndpointName: aws.String("Endpoint1")}, mock.Anything).
+		Return(&sagemaker.DescribeEndpointOutput{
+			EndpointConfigName: aws.String("Config1"),
+			ProductionVariants: []types.ProductionVariantSummary{
+				{VariantName: aws.String("AllTraffic"), CurrentInstanceCount: aws.Int32(4)},
+				{VariantName: aws.String("Serverless")},
+			},
+		}, nil)
+	mockClient.On("DescribeEndpointConfig", ctx, &sagemaker.DescribeEndpointConfigInput{EndpointConfigName: aws.String("Config1")}, mock.Anything).
+		Return(&sagemaker.DescribeEndpointConfigOutput{
+			ProductionVariants: []types.ProductionVariant{
+				{VariantName: aws.String("AllTraffic"), InstanceType: types.ProductionVariantInstanceTypeMlG52xlarge},
+				{VariantName: aws.String("Serverless")},
+			},
+		}, nil)
+
+	client := &clientImpl{client: mockClient}
+	variants, err := client.ListEndpointVariants(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []EndpointVariant{
+		{EndpointName: "Endpoint1", VariantName: "AllTraffic", InstanceType: "ml.g5.2xlarge", InstanceCount: 4},
+		{EndpointName: "Endpoint1", VariantName: "Serverless"},
+	}, variants)
+	mockClient.AssertExpectations(t)
+}
@@ -0,0 +1,51 @@
+package sagemaker
+
+import (
+	"context"
+	"sync"
+)
+
+// describeConcurrency limits the number of Describe* calls issued at the same time
+const describeConcurrency = 8
+
+// forEachConcurrently calls fn for every item using at most describeConcurrency
+// goroutines and returns the first error encountered
+func forEachConcurrently(ctx context.Context, items []string, fn func(ctx context.Context, item string) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	sem := make(chan struct{}, describeConcurrency)
+
+	for _, item := range items {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(item string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := fn(ctx, item); err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}(item)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
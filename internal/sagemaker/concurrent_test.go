@@ -0,0 +1,56 @@
+package sagemaker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForEachConcurrently(t *testing.T) {
+	items := []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k", "l"}
+
+	var (
+		mu      sync.Mutex
+		seen    []string
+		running int32
+		peak    int32
+	)
+	err := forEachConcurrently(context.Background(), items, func(ctx context.Context, item string) error {
+		current := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			old := atomic.LoadInt32(&peak)
+			if current <= old || atomic.CompareAndSwapInt32(&peak, old, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		seen = append(seen, item)
+		mu.Unlock()
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, items, seen)
+	assert.LessOrEqual(t, peak, int32(describeConcurrency))
+}
+
+func TestForEachConcurrently_Error(t *testing.T) {
+	items := []string{"ok", "fail", "ok2"}
+
+	err := forEachConcurrently(context.Background(), items, func(ctx context.Context, item string) error {
+		if item == "fail" {
+			return errors.New("describe failed")
+		}
+		return nil
+	})
+
+	assert.EqualError(t, err, "describe failed")
+}
@@ -0,0 +1,172 @@
+package sagemaker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker/types"
+	"mohua/internal/pricing"
+	"mohua/internal/retry"
+)
+
+// experimentTimeLayout is the layout used to show experiment schedules
+const experimentTimeLayout = "2006-01-02 15:04"
+
+// ListInferenceExperiments returns inference experiments that have not completed or been cancelled
+func (c *clientImpl) ListInferenceExperiments(ctx context.Context) ([]ResourceInfo, error) {
+	var names []string
+
+	retrier := retry.NewRetrier(retry.DefaultConfig)
+	err := retrier.Do(ctx, func() error {
+		names = nil
+		input := &sagemaker.ListInferenceExperimentsInput{}
+		for {
+			output, err := c.client.ListInferenceExperiments(ctx, input)
+			if err != nil {
+				return WrapError(err)
+			}
+			for _, experiment := range output.InferenceExperiments {
+				switch experiment.Status {
+				case types.InferenceExperimentStatusCompleted, types.InferenceExperimentStatusCancelled:
+					continue
+				}
+				if experiment.Name != nil {
+					names = append(names, *experiment.Name)
+				}
+			}
+			if output.NextToken == nil {
+				return nil
+			}
+			input.NextToken = output.NextToken
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var mu sync.Mutex
+	resourcesByName := make(map[string]ResourceInfo, len(names))
+
+	err = forEachConcurrently(ctx, names, func(ctx context.Context, name string) error {
+		resource, err := c.describeInferenceExperiment(ctx, retrier, name)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		resourcesByName[name] = resource
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]ResourceInfo, 0, len(names))
+	for _, name := range names {
+		resources = append(resources, resourcesByName[name])
+	}
+	return resources, nil
+}
+
+// describeInferenceExperiment builds the resource entry of a single inference experiment
+func (c *clientImpl) describeInferenceExperiment(ctx context.Context, retrier *retry.Retrier, name string) (ResourceInfo, error) {
+	var output *sagemaker.DescribeInferenceExperimentOutput
+	err := retrier.Do(ctx, func() error {
+		var err error
+		output, err = c.client.DescribeInferenceExperiment(ctx, &sagemaker.DescribeInferenceExperimentInput{
+			Name: aws.String(name),
+		})
+		return WrapError(err)
+	})
+	if err != nil {
+		return ResourceInfo{}, fmt.Errorf("failed to describe inference experiment %s: %w", name, err)
+	}
+
+	resource := ResourceInfo{
+		Name:   name,
+		Arn:    aws.ToString(output.Arn),
+		Status: string(output.Status),
+	}
+	if output.CreationTime != nil {
+		resource.CreationTime = *output.CreationTime
+	}
+	if output.EndpointMetadata != nil {
+		resource.EndpointName = aws.ToString(output.EndpointMetadata.EndpointName)
+	}
+
+	details := []string{fmt.Sprintf("endpoint %s", resource.EndpointName)}
+	if schedule := output.Schedule; schedule != nil && schedule.StartTime != nil && schedule.EndTime != nil {
+		details = append(details, fmt.Sprintf("schedule %s to %s",
+			schedule.StartTime.UTC().Format(experimentTimeLayout), schedule.EndTime.UTC().Format(experimentTimeLayout)))
+	}
+
+	// Shadow variants run on their own instances next to the production variant
+	variants := make(map[string]types.ModelVariantConfigSummary, len(output.ModelVariants))
+	for _, variant := range output.ModelVariants {
+		variants[aws.ToString(variant.VariantName)] = variant
+	}
+	var instanceTypes []string
+	if output.ShadowModeConfig != nil {
+		for _, shadow := range output.ShadowModeConfig.ShadowModelVariants {
+			variantName := aws.ToString(shadow.ShadowModelVariantName)
+			instanceType, count := variantInstances(variants[variantName])
+			details = append(details, fmt.Sprintf("shadow %s %s x%d (%d%%)",
+				variantName, instanceType, count, aws.ToInt32(shadow.SamplingPercentage)))
+			if instanceType == "" {
+				continue
+			}
+			instanceTypes = append(instanceTypes, instanceType)
+			if price, ok := pricing.HourlyPrice(instanceType); ok {
+				resource.HourlyCost += price * float64(count)
+			}
+		}
+	}
+	switch len(instanceTypes) {
+	case 0:
+	case 1:
+		resource.InstanceType = instanceTypes[0]
+	default:
+		resource.InstanceType = "multiple"
+	}
+	resource.Details = strings.Join(details, "; ")
+
+	return resource, nil
+}
+
+// variantInstances returns the instance type and count of a real-time model variant
+func variantInstances(variant types.ModelVariantConfigSummary) (string, int) {
+	if variant.InfrastructureConfig == nil || variant.InfrastructureConfig.RealTimeInferenceConfig == nil {
+		return "", 0
+	}
+	config := variant.InfrastructureConfig.RealTimeInferenceConfig
+	return string(config.InstanceType), int(aws.ToInt32(config.InstanceCount))
+}
+
+// MarkExperimentEndpoints sets Experiment on every endpoint that an inference
+// experiment is attached to and notes it in the endpoint details
+func MarkExperimentEndpoints(endpoints, experiments []ResourceInfo) {
+	byEndpoint := make(map[string]string, len(experiments))
+	for _, experiment := range experiments {
+		if experiment.EndpointName != "" {
+			byEndpoint[experiment.EndpointName] = experiment.Name
+		}
+	}
+
+	for i := range endpoints {
+		name, ok := byEndpoint[endpoints[i].Name]
+		if !ok {
+			continue
+		}
+		endpoints[i].Experiment = name
+		note := fmt.Sprintf("in experiment %s", name)
+		if endpoints[i].Details == "" {
+			endpoints[i].Details = note
+		} else {
+			endpoints[i].Details += "; " + note
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package sagemaker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListInferenceExperiments(t *testing.T) {
+	ctx := context.Background()
+	created := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListInferenceExperiments", ctx, &sagemaker.ListInferenceExperimentsInput{}, mock.Anything).
+		Return(&sagemaker.ListInferenceExperimentsOutput{
+			InferenceExperiments: []types.InferenceExperimentSummary{
+				{Name: aws.String("shadow-v2"), Status: types.InferenceExperimentStatusRunning},
+				{Name: aws.String("done"), Status: types.InferenceExperimentStatusCompleted},
+			},
+		}, nil)
+	mockClient.On("DescribeInferenceExperiment", mock.Anything, &sagemaker.DescribeInferenceExperimentInput{Name: aws.String("shadow-v2")}, mock.Anything).
+		Return(&sagemaker.DescribeInferenceExperimentOutput{
+			Name:             aws.String("shadow-v2"),
+			Status:           types.InferenceExperimentStatusRunning,
+			CreationTime:     aws.Time(created),
+			EndpointMetadata: &types.EndpointMetadata{EndpointName: aws.String("churn")},
+			Schedule: &types.InferenceExperimentSchedule{
+				StartTime: aws.Time(created),
+				EndTime:   aws.Time(created.Add(7 * 24 * time.Hour)),
+			},
+			ModelVariants: []types.ModelVariantConfigSummary{
+				{
+					VariantName: aws.String("production"),
+					InfrastructureConfig: &types.ModelInfrastructureConfig{
+						RealTimeInferenceConfig: &types.RealTimeInferenceConfig{InstanceType: types.InstanceTypeMlM5Xlarge, InstanceCount: aws.Int32(2)},
+					},
+				},
+				{
+					VariantName: aws.String("candidate"),
+					InfrastructureConfig: &types.ModelInfrastructureConfig{
+						RealTimeInferenceConfig: &types.RealTimeInferenceConfig{InstanceType: types.InstanceTypeMlG5Xlarge, InstanceCount: aws.Int32(2)},
+					},
+				},
+			},
+			ShadowModeConfig: &types.ShadowModeConfig{
+				SourceModelVariantName: aws.String("production"),
+				ShadowModelVariants: []types.ShadowModelVariantConfig{
+					{ShadowModelVariantName: aws.String("candidate"), SamplingPercentage: aws.Int32(50)},
+				},
+			},
+		}, nil)
+
+	client := &clientImpl{client: mockClient}
+	resources, err := client.ListInferenceExperiments(ctx)
+
+	assert.NoError(t, err)
+	if assert.Len(t, resources, 1) {
+		assert.Equal(t, "shadow-v2", resources[0].Name)
+		assert.Equal(t, "Running", resources[0].Status)
+		assert.Equal(t, "ml.g5.xlarge", resources[0].InstanceType)
+		assert.Equal(t, "churn", resources[0].EndpointName)
+		assert.Equal(t, "endpoint churn; schedule 2026-10-01 09:00 to 2026-10-08 09:00; shadow candidate ml.g5.xlarge x2 (50%)", resources[0].Details)
+		assert.InDelta(t, 2*1.408, resources[0].HourlyCost, 1e-9)
+	}
+	mockClient.AssertExpectations(t)
+}
+
+func TestMarkExperimentEndpoints(t *testing.T) {
+	endpoints := []ResourceInfo{
+		{Name: "churn", Details: "capture 20% to s3://bucket/capture"},
+		{Name: "fraud"},
+		{Name: "search"},
+	}
+	experiments := []ResourceInfo{
+		{Name: "shadow-v2", EndpointName: "churn"},
+		{Name: "fraud-test", EndpointName: "fraud"},
+	}
+
+	MarkExperimentEndpoints(endpoints, experiments)
+
+	assert.Equal(t, "shadow-v2", endpoints[0].Experiment)
+	assert.Equal(t, "capture 20% to s3://bucket/capture; in experiment shadow-v2", endpoints[0].Details)
+	assert.Equal(t, "fraud-test", endpoints[1].Experiment)
+	assert.Equal(t, "in experiment fraud-test", endpoints[1].Details)
+	assert.Empty(t, endpoints[2].Experiment)
+	assert.Empty(t, endpoints[2].Details)
+}
@@ -0,0 +1,121 @@
+package sagemaker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker/types"
+	"mohua/internal/pricing"
+	"mohua/internal/retry"
+)
+
+// ListOnlineFeatureGroups returns created feature groups that have an online store enabled
+func (c *clientImpl) ListOnlineFeatureGroups(ctx context.Context) ([]ResourceInfo, error) {
+	var names []string
+
+	retrier := retry.NewRetrier(retry.DefaultConfig)
+	err := retrier.Do(ctx, func() error {
+		names = nil
+		input := &sagemaker.ListFeatureGroupsInput{FeatureGroupStatusEquals: types.FeatureGroupStatusCreated}
+		for {
+			output, err := c.client.ListFeatureGroups(ctx, input)
+			if err != nil {
+				return WrapError(err)
+			}
+			for _, group := range output.FeatureGroupSummaries {
+				if group.FeatureGroupName != nil {
+					names = append(names, *group.FeatureGroupName)
+				}
+			}
+			if output.NextToken == nil {
+				return nil
+			}
+			input.NextToken = output.NextToken
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The online store configuration is only available from DescribeFeatureGroup
+	var mu sync.Mutex
+	resourcesByName := make(map[string]ResourceInfo, len(names))
+
+	err = forEachConcurrently(ctx, names, func(ctx context.Context, name string) error {
+		resource, online, err := c.describeFeatureGroup(ctx, retrier, name)
+		if err != nil {
+			return err
+		}
+		if online {
+			mu.Lock()
+			resourcesByName[name] = resource
+			mu.Unlock()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]ResourceInfo, 0, len(resourcesByName))
+	for _, name := range names {
+		if resource, ok := resourcesByName[name]; ok {
+			resources = append(resources, resource)
+		}
+	}
+	return resources, nil
+}
+
+// describeFeatureGroup builds the resource entry of a single feature group and
+// reports whether its online store is enabled
+func (c *clientImpl) describeFeatureGroup(ctx context.Context, retrier *retry.Retrier, name string) (ResourceInfo, bool, error) {
+	var output *sagemaker.DescribeFeatureGroupOutput
+	err := retrier.Do(ctx, func() error {
+		var err error
+		output, err = c.client.DescribeFeatureGroup(ctx, &sagemaker.DescribeFeatureGroupInput{
+			FeatureGroupName: aws.String(name),
+		})
+		return WrapError(err)
+	})
+	if err != nil {
+		return ResourceInfo{}, false, fmt.Errorf("failed to describe feature group %s: %w", name, err)
+	}
+
+	config := output.OnlineStoreConfig
+	if config == nil || !aws.ToBool(config.EnableOnlineStore) {
+		return ResourceInfo{}, false, nil
+	}
+
+	// The storage type defaults to Standard when it is not set
+	storageType := string(config.StorageType)
+	if storageType == "" {
+		storageType = string(types.StorageTypeStandard)
+	}
+
+	resource := ResourceInfo{
+		Name:   name,
+		Arn:    aws.ToString(output.FeatureGroupArn),
+		Status: string(output.FeatureGroupStatus),
+	}
+	if output.CreationTime != nil {
+		resource.CreationTime = *output.CreationTime
+	}
+
+	details := []string{fmt.Sprintf("storage %s", storageType)}
+	if ttl := config.TtlDuration; ttl != nil && ttl.Value != nil {
+		details = append(details, fmt.Sprintf("TTL %d %s", *ttl.Value, ttl.Unit))
+	} else {
+		details = append(details, "no TTL")
+	}
+	sizeBytes := aws.ToInt64(output.OnlineStoreTotalSizeBytes)
+	details = append(details, fmt.Sprintf("%.2f GB", float64(sizeBytes)/(1<<30)))
+	resource.HourlyCost = pricing.FeatureStoreOnlineHourly(storageType, sizeBytes)
+	details = append(details, fmt.Sprintf("est. $%.4f/h", resource.HourlyCost))
+	resource.Details = strings.Join(details, "; ")
+
+	return resource, true, nil
+}
@@ -0,0 +1,70 @@
+package sagemaker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListOnlineFeatureGroups(t *testing.T) {
+	ctx := context.Background()
+	created := time.Now().Add(-24 * time.Hour)
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListFeatureGroups", ctx, &sagemaker.ListFeatureGroupsInput{
+		FeatureGroupStatusEquals: types.FeatureGroupStatusCreated,
+	}, mock.Anything).
+		Return(&sagemaker.ListFeatureGroupsOutput{
+			FeatureGroupSummaries: []types.FeatureGroupSummary{
+				{FeatureGroupName: aws.String("customers")},
+				{FeatureGroupName: aws.String("sessions")},
+				{FeatureGroupName: aws.String("offline-only")},
+			},
+		}, nil)
+	mockClient.On("DescribeFeatureGroup", mock.Anything, &sagemaker.DescribeFeatureGroupInput{FeatureGroupName: aws.String("customers")}, mock.Anything).
+		Return(&sagemaker.DescribeFeatureGroupOutput{
+			FeatureGroupStatus:        types.FeatureGroupStatusCreated,
+			CreationTime:              aws.Time(created),
+			OnlineStoreConfig:         &types.OnlineStoreConfig{EnableOnlineStore: aws.Bool(true)},
+			OnlineStoreTotalSizeBytes: aws.Int64(10 << 30),
+		}, nil)
+	mockClient.On("DescribeFeatureGroup", mock.Anything, &sagemaker.DescribeFeatureGroupInput{FeatureGroupName: aws.String("sessions")}, mock.Anything).
+		Return(&sagemaker.DescribeFeatureGroupOutput{
+			FeatureGroupStatus: types.FeatureGroupStatusCreated,
+			CreationTime:       aws.Time(created),
+			OnlineStoreConfig: &types.OnlineStoreConfig{
+				EnableOnlineStore: aws.Bool(true),
+				StorageType:       types.StorageTypeInMemory,
+				TtlDuration:       &types.TtlDuration{Unit: types.TtlDurationUnitDays, Value: aws.Int32(7)},
+			},
+			OnlineStoreTotalSizeBytes: aws.Int64(2 << 30),
+		}, nil)
+	mockClient.On("DescribeFeatureGroup", mock.Anything, &sagemaker.DescribeFeatureGroupInput{FeatureGroupName: aws.String("offline-only")}, mock.Anything).
+		Return(&sagemaker.DescribeFeatureGroupOutput{
+			FeatureGroupStatus: types.FeatureGroupStatusCreated,
+			OfflineStoreConfig: &types.OfflineStoreConfig{},
+		}, nil)
+
+	client := &clientImpl{client: mockClient}
+	resources, err := client.ListOnlineFeatureGroups(ctx)
+
+	assert.NoError(t, err)
+	if assert.Len(t, resources, 2) {
+		assert.Equal(t, "customers", resources[0].Name)
+		assert.Equal(t, "Created", resources[0].Status)
+		assert.Equal(t, created, resources[0].CreationTime)
+		assert.Equal(t, "storage Standard; no TTL; 10.00 GB; est. $0.0062/h", resources[0].Details)
+		assert.InDelta(t, 10*0.45/730, resources[0].HourlyCost, 1e-9)
+
+		assert.Equal(t, "sessions", resources[1].Name)
+		assert.Equal(t, "storage InMemory; TTL 7 Days; 2.00 GB; est. $0.3000/h", resources[1].Details)
+		assert.InDelta(t, 0.3, resources[1].HourlyCost, 1e-9)
+	}
+	mockClient.AssertExpectations(t)
+}
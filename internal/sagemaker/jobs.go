@@ -0,0 +1,279 @@
+package sagemaker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker/types"
+	"mohua/internal/retry"
+)
+
+// ListAutoMLJobs returns in-progress Autopilot (AutoML and AutoML V2) jobs
+func (c *clientImpl) ListAutoMLJobs(ctx context.Context) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+
+	retrier := retry.NewRetrier(retry.DefaultConfig)
+	err := retrier.Do(ctx, func() error {
+		resources = nil
+		input := &sagemaker.ListAutoMLJobsInput{StatusEquals: types.AutoMLJobStatusInProgress}
+		for {
+			output, err := c.client.ListAutoMLJobs(ctx, input)
+			if err != nil {
+				return WrapError(err)
+			}
+			for _, job := range output.AutoMLJobSummaries {
+				if job.AutoMLJobName == nil {
+					continue
+				}
+				resource := ResourceInfo{
+					Name:    *job.AutoMLJobName,
+					Arn:     aws.ToString(job.AutoMLJobArn),
+					Status:  string(job.AutoMLJobStatus),
+					Details: fmt.Sprintf("stage %s", job.AutoMLJobSecondaryStatus),
+				}
+				if job.CreationTime != nil {
+					resource.CreationTime = *job.CreationTime
+				}
+				resources = append(resources, resource)
+			}
+			if output.NextToken == nil {
+				return nil
+			}
+			input.NextToken = output.NextToken
+		}
+	})
+
+	return resources, err
+}
+
+// ListCompilationJobs returns starting and in-progress Neo compilation jobs
+func (c *clientImpl) ListCompilationJobs(ctx context.Context) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+
+	retrier := retry.NewRetrier(retry.DefaultConfig)
+	err := retrier.Do(ctx, func() error {
+		resources = nil
+		for _, status := range []types.CompilationJobStatus{types.CompilationJobStatusStarting, types.CompilationJobStatusInprogress} {
+			input := &sagemaker.ListCompilationJobsInput{StatusEquals: status}
+			for {
+				output, err := c.client.ListCompilationJobs(ctx, input)
+				if err != nil {
+					return WrapError(err)
+				}
+				for _, job := range output.CompilationJobSummaries {
+					if job.CompilationJobName == nil {
+						continue
+					}
+					resource := ResourceInfo{
+						Name:    *job.CompilationJobName,
+						Arn:     aws.ToString(job.CompilationJobArn),
+						Status:  string(job.CompilationJobStatus),
+						Details: compilationTarget(job),
+					}
+					// Neo compiles for a target device, which is an ML instance family for cloud targets
+					if strings.HasPrefix(string(job.CompilationTargetDevice), "ml_") {
+						resource.InstanceType = strings.ReplaceAll(string(job.CompilationTargetDevice), "_", ".")
+					}
+					if job.CompilationStartTime != nil {
+						resource.CreationTime = *job.CompilationStartTime
+					} else if job.CreationTime != nil {
+						resource.CreationTime = *job.CreationTime
+					}
+					resources = append(resources, resource)
+				}
+				if output.NextToken == nil {
+					break
+				}
+				input.NextToken = output.NextToken
+			}
+		}
+		return nil
+	})
+
+	return resources, err
+}
+
+// compilationTarget describes the device or platform a compilation job targets
+func compilationTarget(job types.CompilationJobSummary) string {
+	if job.CompilationTargetDevice != "" {
+		return fmt.Sprintf("target %s", job.CompilationTargetDevice)
+	}
+	parts := []string{string(job.CompilationTargetPlatformOs), string(job.CompilationTargetPlatformArch)}
+	if job.CompilationTargetPlatformAccelerator != "" {
+		parts = append(parts, string(job.CompilationTargetPlatformAccelerator))
+	}
+	return fmt.Sprintf("target %s", strings.Join(parts, "/"))
+}
+
+// ListInferenceRecommendationsJobs returns pending and in-progress Inference Recommender
+// jobs with the instance types currently being benchmarked
+func (c *clientImpl) ListInferenceRecommendationsJobs(ctx context.Context) ([]ResourceInfo, error) {
+	var jobs []types.InferenceRecommendationsJob
+
+	retrier := retry.NewRetrier(retry.DefaultConfig)
+	err := retrier.Do(ctx, func() error {
+		jobs = nil
+		for _, status := range []types.RecommendationJobStatus{types.RecommendationJobStatusPending, types.RecommendationJobStatusInProgress} {
+			input := &sagemaker.ListInferenceRecommendationsJobsInput{StatusEquals: status}
+			for {
+				output, err := c.client.ListInferenceRecommendationsJobs(ctx, input)
+				if err != nil {
+					return WrapError(err)
+				}
+				jobs = append(jobs, output.InferenceRecommendationsJobs...)
+				if output.NextToken == nil {
+					break
+				}
+				input.NextToken = output.NextToken
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var jobNames []string
+	for _, job := range jobs {
+		if job.JobName != nil {
+			jobNames = append(jobNames, *job.JobName)
+		}
+	}
+
+	var mu sync.Mutex
+	instanceTypes := make(map[string][]string, len(jobNames))
+	err = forEachConcurrently(ctx, jobNames, func(ctx context.Context, name string) error {
+		benchmarked, err := c.benchmarkInstanceTypes(ctx, retrier, name)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		instanceTypes[name] = benchmarked
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]ResourceInfo, 0, len(jobs))
+	for _, job := range jobs {
+		if job.JobName == nil {
+			continue
+		}
+		resource := ResourceInfo{
+			Name:   *job.JobName,
+			Arn:    aws.ToString(job.JobArn),
+			Status: string(job.Status),
+		}
+		if job.CreationTime != nil {
+			resource.CreationTime = *job.CreationTime
+		}
+
+		benchmarked := instanceTypes[*job.JobName]
+		switch len(benchmarked) {
+		case 0:
+		case 1:
+			resource.InstanceType = benchmarked[0]
+		default:
+			resource.InstanceType = "multiple"
+		}
+
+		details := []string{fmt.Sprintf("type %s", job.JobType)}
+		if job.ModelName != nil {
+			details = append(details, fmt.Sprintf("model %s", *job.ModelName))
+		}
+		if len(benchmarked) > 0 {
+			details = append(details, fmt.Sprintf("benchmarking %s", strings.Join(benchmarked, ",")))
+		}
+		resource.Details = strings.Join(details, "; ")
+
+		resources = append(resources, resource)
+	}
+	return resources, nil
+}
+
+// benchmarkInstanceTypes returns the instance types of the in-progress benchmark steps of a job
+func (c *clientImpl) benchmarkInstanceTypes(ctx context.Context, retrier *retry.Retrier, jobName string) ([]string, error) {
+	var instanceTypes []string
+	err := retrier.Do(ctx, func() error {
+		instanceTypes = nil
+		seen := make(map[string]bool)
+		input := &sagemaker.ListInferenceRecommendationsJobStepsInput{
+			JobName: aws.String(jobName),
+			Status:  types.RecommendationJobStatusInProgress,
+		}
+		for {
+			output, err := c.client.ListInferenceRecommendationsJobSteps(ctx, input)
+			if err != nil {
+				return WrapError(err)
+			}
+			for _, step := range output.Steps {
+				if step.InferenceBenchmark == nil || step.InferenceBenchmark.EndpointConfiguration == nil {
+					continue
+				}
+				instanceType := string(step.InferenceBenchmark.EndpointConfiguration.InstanceType)
+				if instanceType != "" && !seen[instanceType] {
+					seen[instanceType] = true
+					instanceTypes = append(instanceTypes, instanceType)
+				}
+			}
+			if output.NextToken == nil {
+				return nil
+			}
+			input.NextToken = output.NextToken
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list steps of inference recommendations job %s: %w", jobName, err)
+	}
+	return instanceTypes, nil
+}
+
+// ListLabelingJobs returns in-progress Ground Truth labeling jobs
+func (c *clientImpl) ListLabelingJobs(ctx context.Context) ([]ResourceInfo, error) {
+	var resources []ResourceInfo
+
+	retrier := retry.NewRetrier(retry.DefaultConfig)
+	err := retrier.Do(ctx, func() error {
+		resources = nil
+		for _, status := range []types.LabelingJobStatus{types.LabelingJobStatusInitializing, types.LabelingJobStatusInProgress} {
+			input := &sagemaker.ListLabelingJobsInput{StatusEquals: status}
+			for {
+				output, err := c.client.ListLabelingJobs(ctx, input)
+				if err != nil {
+					return WrapError(err)
+				}
+				for _, job := range output.LabelingJobSummaryList {
+					if job.LabelingJobName == nil {
+						continue
+					}
+					resource := ResourceInfo{
+						Name:   *job.LabelingJobName,
+						Arn:    aws.ToString(job.LabelingJobArn),
+						Status: string(job.LabelingJobStatus),
+					}
+					if job.CreationTime != nil {
+						resource.CreationTime = *job.CreationTime
+					}
+					if counters := job.LabelCounters; counters != nil {
+						resource.Details = fmt.Sprintf("labeled %d, unlabeled %d",
+							aws.ToInt32(counters.TotalLabeled), aws.ToInt32(counters.Unlabeled))
+					}
+					resources = append(resources, resource)
+				}
+				if output.NextToken == nil {
+					break
+				}
+				input.NextToken = output.NextToken
+			}
+		}
+		return nil
+	})
+
+	return resources, err
+}
@@ -0,0 +1,149 @@
+package sagemaker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListAutoMLJobs(t *testing.T) {
+	ctx := context.Background()
+	created := time.Now().Add(-time.Hour)
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListAutoMLJobs", ctx, &sagemaker.ListAutoMLJobsInput{StatusEquals: types.AutoMLJobStatusInProgress}, mock.Anything).
+		Return(&sagemaker.ListAutoMLJobsOutput{
+			AutoMLJobSummaries: []types.AutoMLJobSummary{
+				{
+					AutoMLJobName:            aws.String("autopilot"),
+					AutoMLJobStatus:          types.AutoMLJobStatusInProgress,
+					AutoMLJobSecondaryStatus: types.AutoMLJobSecondaryStatusTrainingModels,
+					CreationTime:             aws.Time(created),
+				},
+			},
+		}, nil)
+
+	client := &clientImpl{client: mockClient}
+	resources, err := client.ListAutoMLJobs(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []ResourceInfo{
+		{Name: "autopilot", Status: "InProgress", CreationTime: created, Details: "stage TrainingModels"},
+	}, resources)
+}
+
+func TestListCompilationJobs(t *testing.T) {
+	ctx := context.Background()
+	started := time.Now().Add(-10 * time.Minute)
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListCompilationJobs", ctx, &sagemaker.ListCompilationJobsInput{StatusEquals: types.CompilationJobStatusStarting}, mock.Anything).
+		Return(&sagemaker.ListCompilationJobsOutput{
+			CompilationJobSummaries: []types.CompilationJobSummary{
+				{
+					CompilationJobName:            aws.String("edge"),
+					CompilationJobStatus:          types.CompilationJobStatusStarting,
+					CompilationTargetPlatformOs:   types.TargetPlatformOsLinux,
+					CompilationTargetPlatformArch: types.TargetPlatformArchArm64,
+				},
+			},
+		}, nil)
+	mockClient.On("ListCompilationJobs", ctx, &sagemaker.ListCompilationJobsInput{StatusEquals: types.CompilationJobStatusInprogress}, mock.Anything).
+		Return(&sagemaker.ListCompilationJobsOutput{
+			CompilationJobSummaries: []types.CompilationJobSummary{
+				{
+					CompilationJobName:      aws.String("cloud"),
+					CompilationJobStatus:    types.CompilationJobStatusInprogress,
+					CompilationTargetDevice: types.TargetDeviceMlC5,
+					CompilationStartTime:    aws.Time(started),
+				},
+			},
+		}, nil)
+
+	client := &clientImpl{client: mockClient}
+	resources, err := client.ListCompilationJobs(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []ResourceInfo{
+		{Name: "edge", Status: "STARTING", Details: "target LINUX/ARM64"},
+		{Name: "cloud", Status: "INPROGRESS", InstanceType: "ml.c5", CreationTime: started, Details: "target ml_c5"},
+	}, resources)
+}
+
+func TestListInferenceRecommendationsJobs(t *testing.T) {
+	ctx := context.Background()
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListInferenceRecommendationsJobs", mock.Anything, &sagemaker.ListInferenceRecommendationsJobsInput{StatusEquals: types.RecommendationJobStatusPending}, mock.Anything).
+		Return(&sagemaker.ListInferenceRecommendationsJobsOutput{}, nil)
+	mockClient.On("ListInferenceRecommendationsJobs", mock.Anything, &sagemaker.ListInferenceRecommendationsJobsInput{StatusEquals: types.RecommendationJobStatusInProgress}, mock.Anything).
+		Return(&sagemaker.ListInferenceRecommendationsJobsOutput{
+			InferenceRecommendationsJobs: []types.InferenceRecommendationsJob{
+				{
+					JobName:   aws.String("recommender"),
+					JobType:   types.RecommendationJobTypeDefault,
+					Status:    types.RecommendationJobStatusInProgress,
+					ModelName: aws.String("churn"),
+				},
+			},
+		}, nil)
+	mockClient.On("ListInferenceRecommendationsJobSteps", mock.Anything, mock.MatchedBy(func(in *sagemaker.ListInferenceRecommendationsJobStepsInput) bool {
+		return *in.JobName == "recommender"
+	}), mock.Anything).
+		Return(&sagemaker.ListInferenceRecommendationsJobStepsOutput{
+			Steps: []types.InferenceRecommendationsJobStep{
+				{InferenceBenchmark: &types.RecommendationJobInferenceBenchmark{
+					EndpointConfiguration: &types.EndpointOutputConfiguration{InstanceType: types.ProductionVariantInstanceTypeMlG5Xlarge},
+				}},
+				{InferenceBenchmark: &types.RecommendationJobInferenceBenchmark{
+					EndpointConfiguration: &types.EndpointOutputConfiguration{InstanceType: types.ProductionVariantInstanceTypeMlG5Xlarge},
+				}},
+			},
+		}, nil)
+
+	client := &clientImpl{client: mockClient}
+	resources, err := client.ListInferenceRecommendationsJobs(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []ResourceInfo{
+		{
+			Name:         "recommender",
+			Status:       "IN_PROGRESS",
+			InstanceType: "ml.g5.xlarge",
+			Details:      "type Default; model churn; benchmarking ml.g5.xlarge",
+		},
+	}, resources)
+}
+
+func TestListLabelingJobs(t *testing.T) {
+	ctx := context.Background()
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListLabelingJobs", ctx, &sagemaker.ListLabelingJobsInput{StatusEquals: types.LabelingJobStatusInitializing}, mock.Anything).
+		Return(&sagemaker.ListLabelingJobsOutput{}, nil)
+	mockClient.On("ListLabelingJobs", ctx, &sagemaker.ListLabelingJobsInput{StatusEquals: types.LabelingJobStatusInProgress}, mock.Anything).
+		Return(&sagemaker.ListLabelingJobsOutput{
+			LabelingJobSummaryList: []types.LabelingJobSummary{
+				{
+					LabelingJobName:   aws.String("images"),
+					LabelingJobStatus: types.LabelingJobStatusInProgress,
+					LabelCounters:     &types.LabelCounters{TotalLabeled: aws.Int32(120), Unlabeled: aws.Int32(380)},
+				},
+			},
+		}, nil)
+
+	client := &clientImpl{client: mockClient}
+	resources, err := client.ListLabelingJobs(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []ResourceInfo{
+		{Name: "images", Status: "InProgress", Details: "labeled 120, unlabeled 380"},
+	}, resources)
+	mockClient.AssertExpectations(t)
+}
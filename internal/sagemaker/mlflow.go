@@ -0,0 +1,109 @@
+package sagemaker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker/types"
+	"mohua/internal/pricing"
+	"mohua/internal/retry"
+)
+
+// ListMlflowTrackingServers returns managed MLflow tracking servers that are not stopped
+func (c *clientImpl) ListMlflowTrackingServers(ctx context.Context) ([]ResourceInfo, error) {
+	var servers []types.TrackingServerSummary
+
+	retrier := retry.NewRetrier(retry.DefaultConfig)
+	err := retrier.Do(ctx, func() error {
+		servers = nil
+		input := &sagemaker.ListMlflowTrackingServersInput{}
+		for {
+			output, err := c.client.ListMlflowTrackingServers(ctx, input)
+			if err != nil {
+				return WrapError(err)
+			}
+			servers = append(servers, output.TrackingServerSummaries...)
+			if output.NextToken == nil {
+				return nil
+			}
+			input.NextToken = output.NextToken
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, server := range servers {
+		switch server.TrackingServerStatus {
+		case types.TrackingServerStatusStopped, types.TrackingServerStatusDeleting:
+			continue
+		}
+		if server.TrackingServerName != nil {
+			names = append(names, *server.TrackingServerName)
+		}
+	}
+
+	var mu sync.Mutex
+	resourcesByName := make(map[string]ResourceInfo, len(names))
+
+	err = forEachConcurrently(ctx, names, func(ctx context.Context, name string) error {
+		resource, err := c.describeMlflowTrackingServer(ctx, retrier, name)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		resourcesByName[name] = resource
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]ResourceInfo, 0, len(names))
+	for _, name := range names {
+		resources = append(resources, resourcesByName[name])
+	}
+	return resources, nil
+}
+
+// describeMlflowTrackingServer builds the resource entry of a single tracking server
+func (c *clientImpl) describeMlflowTrackingServer(ctx context.Context, retrier *retry.Retrier, name string) (ResourceInfo, error) {
+	var output *sagemaker.DescribeMlflowTrackingServerOutput
+	err := retrier.Do(ctx, func() error {
+		var err error
+		output, err = c.client.DescribeMlflowTrackingServer(ctx, &sagemaker.DescribeMlflowTrackingServerInput{
+			TrackingServerName: aws.String(name),
+		})
+		return WrapError(err)
+	})
+	if err != nil {
+		return ResourceInfo{}, fmt.Errorf("failed to describe MLflow tracking server %s: %w", name, err)
+	}
+
+	resource := ResourceInfo{
+		Name:   name,
+		Arn:    aws.ToString(output.TrackingServerArn),
+		Status: string(output.TrackingServerStatus),
+	}
+	if output.CreationTime != nil {
+		resource.CreationTime = *output.CreationTime
+	}
+
+	details := []string{fmt.Sprintf("size %s", output.TrackingServerSize)}
+	if output.MlflowVersion != nil {
+		details = append(details, fmt.Sprintf("mlflow %s", *output.MlflowVersion))
+	}
+	if price, ok := pricing.MlflowTrackingServerHourly(string(output.TrackingServerSize)); ok {
+		resource.HourlyCost = price
+		details = append(details, fmt.Sprintf("est. $%.2f/h", price))
+	}
+	resource.Details = strings.Join(details, "; ")
+
+	return resource, nil
+}
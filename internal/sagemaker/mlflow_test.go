@@ -0,0 +1,71 @@
+package sagemaker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListMlflowTrackingServers(t *testing.T) {
+	ctx := context.Background()
+	created := time.Now().Add(-48 * time.Hour)
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListMlflowTrackingServers", ctx, &sagemaker.ListMlflowTrackingServersInput{}, mock.Anything).
+		Return(&sagemaker.ListMlflowTrackingServersOutput{
+			TrackingServerSummaries: []types.TrackingServerSummary{
+				{TrackingServerName: aws.String("team-mlflow"), TrackingServerStatus: types.TrackingServerStatusCreated},
+				{TrackingServerName: aws.String("old-mlflow"), TrackingServerStatus: types.TrackingServerStatusStopped},
+			},
+		}, nil)
+	mockClient.On("DescribeMlflowTrackingServer", mock.Anything, &sagemaker.DescribeMlflowTrackingServerInput{
+		TrackingServerName: aws.String("team-mlflow"),
+	}, mock.Anything).
+		Return(&sagemaker.DescribeMlflowTrackingServerOutput{
+			TrackingServerName:   aws.String("team-mlflow"),
+			TrackingServerStatus: types.TrackingServerStatusCreated,
+			TrackingServerSize:   types.TrackingServerSizeS,
+			MlflowVersion:        aws.String("2.16"),
+			CreationTime:         aws.Time(created),
+		}, nil)
+
+	client := &clientImpl{client: mockClient}
+	resources, err := client.ListMlflowTrackingServers(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []ResourceInfo{
+		{
+			Name:         "team-mlflow",
+			Status:       "Created",
+			CreationTime: created,
+			Details:      "size Small; mlflow 2.16; est. $0.64/h",
+			HourlyCost:   0.642,
+		},
+	}, resources)
+	mockClient.AssertExpectations(t)
+}
+
+func TestListMlflowTrackingServers_DescribeError(t *testing.T) {
+	ctx := context.Background()
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListMlflowTrackingServers", ctx, mock.Anything, mock.Anything).
+		Return(&sagemaker.ListMlflowTrackingServersOutput{
+			TrackingServerSummaries: []types.TrackingServerSummary{
+				{TrackingServerName: aws.String("team-mlflow"), TrackingServerStatus: types.TrackingServerStatusCreated},
+			},
+		}, nil)
+	mockClient.On("DescribeMlflowTrackingServer", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, &types.ResourceNotFound{Message: aws.String("not found")})
+
+	client := &clientImpl{client: mockClient}
+	_, err := client.ListMlflowTrackingServers(ctx)
+
+	assert.ErrorContains(t, err, "failed to describe MLflow tracking server team-mlflow")
+}
@@ -0,0 +1,251 @@
+package sagemaker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker/types"
+	"mohua/internal/retry"
+)
+
+// DataCapture is the data capture configuration of an endpoint
+type DataCapture struct {
+	SamplingPercentage int
+	Destination        string // S3 URI the captured requests and responses are written to
+}
+
+// String returns a short summary, e.g. "capture 20% to s3://bucket/prefix"
+func (d DataCapture) String() string {
+	return fmt.Sprintf("capture %d%% to %s", d.SamplingPercentage, d.Destination)
+}
+
+// ListMonitoringSchedules returns scheduled Model Monitor schedules
+func (c *clientImpl) ListMonitoringSchedules(ctx context.Context) ([]ResourceInfo, error) {
+	var names []string
+
+	retrier := retry.NewRetrier(retry.DefaultConfig)
+	err := retrier.Do(ctx, func() error {
+		names = nil
+		input := &sagemaker.ListMonitoringSchedulesInput{StatusEquals: types.ScheduleStatusScheduled}
+		for {
+			output, err := c.client.ListMonitoringSchedules(ctx, input)
+			if err != nil {
+				return WrapError(err)
+			}
+			for _, schedule := range output.MonitoringScheduleSummaries {
+				if schedule.MonitoringScheduleName != nil {
+					names = append(names, *schedule.MonitoringScheduleName)
+				}
+			}
+			if output.NextToken == nil {
+				return nil
+			}
+			input.NextToken = output.NextToken
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var mu sync.Mutex
+	resourcesByName := make(map[string]ResourceInfo, len(names))
+
+	err = forEachConcurrently(ctx, names, func(ctx context.Context, name string) error {
+		resource, err := c.describeMonitoringSchedule(ctx, retrier, name)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		resourcesByName[name] = resource
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]ResourceInfo, 0, len(names))
+	for _, name := range names {
+		resources = append(resources, resourcesByName[name])
+	}
+	return resources, nil
+}
+
+// describeMonitoringSchedule builds the resource entry of a single monitoring schedule
+func (c *clientImpl) describeMonitoringSchedule(ctx context.Context, retrier *retry.Retrier, name string) (ResourceInfo, error) {
+	var output *sagemaker.DescribeMonitoringScheduleOutput
+	err := retrier.Do(ctx, func() error {
+		var err error
+		output, err = c.client.DescribeMonitoringSchedule(ctx, &sagemaker.DescribeMonitoringScheduleInput{
+			MonitoringScheduleName: aws.String(name),
+		})
+		return WrapError(err)
+	})
+	if err != nil {
+		return ResourceInfo{}, fmt.Errorf("failed to describe monitoring schedule %s: %w", name, err)
+	}
+
+	resource := ResourceInfo{
+		Name:         name,
+		Arn:          aws.ToString(output.MonitoringScheduleArn),
+		Status:       string(output.MonitoringScheduleStatus),
+		EndpointName: aws.ToString(output.EndpointName),
+	}
+	if output.CreationTime != nil {
+		resource.CreationTime = *output.CreationTime
+	}
+
+	monitoringType := output.MonitoringType
+	var cron string
+	if config := output.MonitoringScheduleConfig; config != nil {
+		if monitoringType == "" {
+			monitoringType = config.MonitoringType
+		}
+		if config.ScheduleConfig != nil {
+			cron = aws.ToString(config.ScheduleConfig.ScheduleExpression)
+		}
+
+		// Schedules either embed the job definition or reference one by name
+		var resources *types.MonitoringResources
+		if config.MonitoringJobDefinition != nil {
+			resources = config.MonitoringJobDefinition.MonitoringResources
+		} else if config.MonitoringJobDefinitionName != nil {
+			resources, err = c.monitoringJobResources(ctx, retrier, monitoringType, *config.MonitoringJobDefinitionName)
+			if err != nil {
+				return ResourceInfo{}, err
+			}
+		}
+		if resources != nil && resources.ClusterConfig != nil {
+			resource.InstanceType = string(resources.ClusterConfig.InstanceType)
+			resource.InstanceCount = int(aws.ToInt32(resources.ClusterConfig.InstanceCount))
+		}
+	}
+
+	if monitoringType == "" {
+		monitoringType = types.MonitoringTypeDataQuality
+	}
+	details := []string{
+		fmt.Sprintf("type %s", monitoringType),
+		fmt.Sprintf("endpoint %s", resource.EndpointName),
+		fmt.Sprintf("cron %s", cron),
+	}
+	if last := output.LastMonitoringExecutionSummary; last != nil {
+		details = append(details, fmt.Sprintf("last run %s", last.MonitoringExecutionStatus))
+	} else {
+		details = append(details, "no runs yet")
+	}
+	resource.Details = strings.Join(details, "; ")
+
+	return resource, nil
+}
+
+// monitoringJobResources returns the cluster configuration of a named monitoring job definition
+func (c *clientImpl) monitoringJobResources(ctx context.Context, retrier *retry.Retrier, monitoringType types.MonitoringType, name string) (*types.MonitoringResources, error) {
+	var resources *types.MonitoringResources
+	err := retrier.Do(ctx, func() error {
+		switch monitoringType {
+		case types.MonitoringTypeModelQuality:
+			output, err := c.client.DescribeModelQualityJobDefinition(ctx, &sagemaker.DescribeModelQualityJobDefinitionInput{JobDefinitionName: aws.String(name)})
+			if err != nil {
+				return WrapError(err)
+			}
+			resources = output.JobResources
+		case types.MonitoringTypeModelBias:
+			output, err := c.client.DescribeModelBiasJobDefinition(ctx, &sagemaker.DescribeModelBiasJobDefinitionInput{JobDefinitionName: aws.String(name)})
+			if err != nil {
+				return WrapError(err)
+			}
+			resources = output.JobResources
+		case types.MonitoringTypeModelExplainability:
+			output, err := c.client.DescribeModelExplainabilityJobDefinition(ctx, &sagemaker.DescribeModelExplainabilityJobDefinitionInput{JobDefinitionName: aws.String(name)})
+			if err != nil {
+				return WrapError(err)
+			}
+			resources = output.JobResources
+		default:
+			output, err := c.client.DescribeDataQualityJobDefinition(ctx, &sagemaker.DescribeDataQualityJobDefinitionInput{JobDefinitionName: aws.String(name)})
+			if err != nil {
+				return WrapError(err)
+			}
+			resources = output.JobResources
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe monitoring job definition %s: %w", name, err)
+	}
+	return resources, nil
+}
+
+// endpointDetails is the part of an endpoint description that the resource listing shows
+type endpointDetails struct {
+	dataCapture    *DataCapture // Nil when capture is disabled
+	instanceType   string       // "multiple" when variants use different types
+	instanceCount  int
+	instanceCounts map[string]int // Running instances per type
+}
+
+// describeEndpointDetails returns the data capture configuration and instance
+// types of an endpoint
+func (c *clientImpl) describeEndpointDetails(ctx context.Context, retrier *retry.Retrier, name string) (endpointDetails, error) {
+	var output *sagemaker.DescribeEndpointOutput
+	err := retrier.Do(ctx, func() error {
+		var err error
+		output, err = c.client.DescribeEndpoint(ctx, &sagemaker.DescribeEndpointInput{
+			EndpointName: aws.String(name),
+		})
+		return WrapError(err)
+	})
+	if err != nil {
+		return endpointDetails{}, fmt.Errorf("failed to describe endpoint %s: %w", name, err)
+	}
+
+	var details endpointDetails
+	if config := output.DataCaptureConfig; config != nil && aws.ToBool(config.EnableCapture) {
+		details.dataCapture = &DataCapture{
+			SamplingPercentage: int(aws.ToInt32(config.CurrentSamplingPercentage)),
+			Destination:        aws.ToString(config.DestinationS3Uri),
+		}
+	}
+	variantCounts := make(map[string]int, len(output.ProductionVariants))
+	for _, variant := range output.ProductionVariants {
+		count := int(aws.ToInt32(variant.CurrentInstanceCount))
+		details.instanceCount += count
+		variantCounts[aws.ToString(variant.VariantName)] = count
+	}
+	if output.EndpointConfigName == nil {
+		return details, nil
+	}
+
+	// Instance types are only part of the endpoint config
+	var config *sagemaker.DescribeEndpointConfigOutput
+	err = retrier.Do(ctx, func() error {
+		var err error
+		config, err = c.client.DescribeEndpointConfig(ctx, &sagemaker.DescribeEndpointConfigInput{
+			EndpointConfigName: output.EndpointConfigName,
+		})
+		return WrapError(err)
+	})
+	if err != nil {
+		return endpointDetails{}, fmt.Errorf("failed to describe endpoint config %s: %w", *output.EndpointConfigName, err)
+	}
+	details.instanceCounts = make(map[string]int)
+	for _, variant := range config.ProductionVariants {
+		instanceType := string(variant.InstanceType)
+		if instanceType != "" {
+			details.instanceCounts[instanceType] += variantCounts[aws.ToString(variant.VariantName)]
+		}
+		switch {
+		case instanceType == "" || instanceType == details.instanceType:
+		case details.instanceType == "":
+			details.instanceType = instanceType
+		default:
+			details.instanceType = "multiple"
+		}
+	}
+	return details, nil
+}
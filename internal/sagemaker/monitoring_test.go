@@ -0,0 +1,182 @@
+package sagemaker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListMonitoringSchedules(t *testing.T) {
+	ctx := context.Background()
+	created := time.Now().Add(-72 * time.Hour)
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListMonitoringSchedules", ctx, &sagemaker.ListMonitoringSchedulesInput{StatusEquals: types.ScheduleStatusScheduled}, mock.Anything).
+		Return(&sagemaker.ListMonitoringSchedulesOutput{
+			MonitoringScheduleSummaries: []types.MonitoringScheduleSummary{
+				{MonitoringScheduleName: aws.String("inline")},
+				{MonitoringScheduleName: aws.String("bias")},
+			},
+		}, nil)
+	mockClient.On("DescribeMonitoringSchedule", mock.Anything, &sagemaker.DescribeMonitoringScheduleInput{MonitoringScheduleName: aws.String("inline")}, mock.Anything).
+		Return(&sagemaker.DescribeMonitoringScheduleOutput{
+			MonitoringScheduleStatus: types.ScheduleStatusScheduled,
+			CreationTime:             aws.Time(created),
+			EndpointName:             aws.String("churn"),
+			MonitoringScheduleConfig: &types.MonitoringScheduleConfig{
+				ScheduleConfig: &types.ScheduleConfig{ScheduleExpression: aws.String("cron(0 * ? * * *)")},
+				MonitoringJobDefinition: &types.MonitoringJobDefinition{
+					MonitoringResources: &types.MonitoringResources{
+						ClusterConfig: &types.MonitoringClusterConfig{
+							InstanceType:  types.ProcessingInstanceTypeMlM5Xlarge,
+							InstanceCount: aws.Int32(1),
+						},
+					},
+				},
+			},
+			LastMonitoringExecutionSummary: &types.MonitoringExecutionSummary{
+				MonitoringExecutionStatus: types.ExecutionStatusCompletedWithViolations,
+			},
+		}, nil)
+	mockClient.On("DescribeMonitoringSchedule", mock.Anything, &sagemaker.DescribeMonitoringScheduleInput{MonitoringScheduleName: aws.String("bias")}, mock.Anything).
+		Return(&sagemaker.DescribeMonitoringScheduleOutput{
+			MonitoringScheduleStatus: types.ScheduleStatusScheduled,
+			CreationTime:             aws.Time(created),
+			EndpointName:             aws.String("churn"),
+			MonitoringType:           types.MonitoringTypeModelBias,
+			MonitoringScheduleConfig: &types.MonitoringScheduleConfig{
+				ScheduleConfig:              &types.ScheduleConfig{ScheduleExpression: aws.String("cron(0 0 ? * * *)")},
+				MonitoringJobDefinitionName: aws.String("bias-definition"),
+			},
+		}, nil)
+	mockClient.On("DescribeModelBiasJobDefinition", mock.Anything, &sagemaker.DescribeModelBiasJobDefinitionInput{JobDefinitionName: aws.String("bias-definition")}, mock.Anything).
+		Return(&sagemaker.DescribeModelBiasJobDefinitionOutput{
+			JobResources: &types.MonitoringResources{
+				ClusterConfig: &types.MonitoringClusterConfig{
+					InstanceType:  types.ProcessingInstanceTypeMlC5Xlarge,
+					InstanceCount: aws.Int32(2),
+				},
+			},
+		}, nil)
+
+	client := &clientImpl{client: mockClient}
+	resources, err := client.ListMonitoringSchedules(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []ResourceInfo{
+		{
+			Name:          "inline",
+			Status:        "Scheduled",
+			InstanceType:  "ml.m5.xlarge",
+			InstanceCount: 1,
+			CreationTime:  created,
+			EndpointName:  "churn",
+			Details:       "type DataQuality; endpoint churn; cron cron(0 * ? * * *); last run CompletedWithViolations",
+		},
+		{
+			Name:          "bias",
+			Status:        "Scheduled",
+			InstanceType:  "ml.c5.xlarge",
+			InstanceCount: 2,
+			CreationTime:  created,
+			EndpointName:  "churn",
+			Details:       "type ModelBias; endpoint churn; cron cron(0 0 ? * * *); no runs yet",
+		},
+	}, resources)
+	mockClient.AssertExpectations(t)
+}
+
+func TestListEndpoints_Details(t *testing.T) {
+	ctx := context.Background()
+	created := time.Now().Add(-time.Hour)
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListEndpoints", ctx, &sagemaker.ListEndpointsInput{}, mock.Anything).
+		Return(&sagemaker.ListEndpointsOutput{
+			Endpoints: []types.EndpointSummary{
+				{EndpointName: aws.String("captured"), EndpointStatus: types.EndpointStatusInService, CreationTime: aws.Time(created)},
+				{EndpointName: aws.String("plain"), EndpointStatus: types.EndpointStatusInService, CreationTime: aws.Time(created)},
+			},
+		}, nil)
+	mockClient.On("DescribeEndpoint", mock.Anything, &sagemaker.DescribeEndpointInput{EndpointName: aws.String("captured")}, mock.Anything).
+		Return(&sagemaker.DescribeEndpointOutput{
+			EndpointConfigName: aws.String("captured-config"),
+			ProductionVariants: []types.ProductionVariantSummary{
+				{VariantName: aws.String("a"), CurrentInstanceCount: aws.Int32(2)},
+				{VariantName: aws.String("b"), CurrentInstanceCount: aws.Int32(1)},
+			},
+			DataCaptureConfig: &types.DataCaptureConfigSummary{
+				EnableCapture:             aws.Bool(true),
+				CurrentSamplingPercentage: aws.Int32(20),
+				DestinationS3Uri:          aws.String("s3://bucket/capture"),
+			},
+		}, nil)
+	mockClient.On("DescribeEndpointConfig", mock.Anything, &sagemaker.DescribeEndpointConfigInput{EndpointConfigName: aws.String("captured-config")}, mock.Anything).
+		Return(&sagemaker.DescribeEndpointConfigOutput{
+			ProductionVariants: []types.ProductionVariant{
+				{VariantName: aws.String("a"), InstanceType: types.ProductionVariantInstanceTypeMlG5Xlarge},
+				{VariantName: aws.String("b"), InstanceType: types.ProductionVariantInstanceTypeMlG5Xlarge},
+			},
+		}, nil)
+	mockClient.On("DescribeEndpoint", mock.Anything, &sagemaker.DescribeEndpointInput{EndpointName: aws.String("plain")}, mock.Anything).
+		Return(&sagemaker.DescribeEndpointOutput{
+			DataCaptureConfig: &types.DataCaptureConfigSummary{EnableCapture: aws.Bool(false)},
+		}, nil)
+
+	client := &clientImpl{client: mockClient}
+	resources, err := client.ListEndpoints(ctx)
+
+	assert.NoError(t, err)
+	if assert.Len(t, resources, 2) {
+		assert.Equal(t, &DataCapture{SamplingPercentage: 20, Destination: "s3://bucket/capture"}, resources[0].DataCapture)
+		assert.Equal(t, "capture 20% to s3://bucket/capture", resources[0].Details)
+		assert.Equal(t, "ml.g5.xlarge", resources[0].InstanceType)
+		assert.Equal(t, 3, resources[0].InstanceCount)
+		assert.Equal(t, "unknown", resources[1].InstanceType)
+		assert.Nil(t, resources[1].DataCapture)
+		assert.Empty(t, resources[1].Details)
+	}
+}
+
+func TestListEndpoints_MixedVariants(t *testing.T) {
+	ctx := context.Background()
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListEndpoints", ctx, &sagemaker.ListEndpointsInput{}, mock.Anything).
+		Return(&sagemaker.ListEndpointsOutput{
+			Endpoints: []types.EndpointSummary{
+				{EndpointName: aws.String("ab-test"), EndpointStatus: types.EndpointStatusInService, CreationTime: aws.Time(time.Now())},
+			},
+		}, nil)
+	mockClient.On("DescribeEndpoint", mock.Anything, mock.Anything, mock.Anything).
+		Return(&sagemaker.DescribeEndpointOutput{
+			EndpointConfigName: aws.String("ab-config"),
+			ProductionVariants: []types.ProductionVariantSummary{
+				{VariantName: aws.String("cpu"), CurrentInstanceCount: aws.Int32(4)},
+				{VariantName: aws.String("gpu"), CurrentInstanceCount: aws.Int32(1)},
+			},
+		}, nil)
+	mockClient.On("DescribeEndpointConfig", mock.Anything, mock.Anything, mock.Anything).
+		Return(&sagemaker.DescribeEndpointConfigOutput{
+			ProductionVariants: []types.ProductionVariant{
+				{VariantName: aws.String("cpu"), InstanceType: types.ProductionVariantInstanceTypeMlM5Xlarge},
+				{VariantName: aws.String("gpu"), InstanceType: types.ProductionVariantInstanceTypeMlG5Xlarge},
+			},
+		}, nil)
+
+	client := &clientImpl{client: mockClient}
+	resources, err := client.ListEndpoints(ctx)
+
+	assert.NoError(t, err)
+	if assert.Len(t, resources, 1) {
+		assert.Equal(t, "multiple", resources[0].InstanceType)
+		assert.Equal(t, 5, resources[0].InstanceCount)
+		assert.Equal(t, map[string]int{"ml.m5.xlarge": 4, "ml.g5.xlarge": 1}, resources[0].Instances())
+	}
+}
@@ -0,0 +1,439 @@
+package sagemaker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker/types"
+	"mohua/internal/retry"
+)
+
+// Orphaned resource types
+const (
+	OrphanEndpointConfig    = "EndpointConfig"
+	OrphanModel             = "Model"
+	OrphanModelPackageGroup = "ModelPackageGroup"
+)
+
+// OrphanInfo describes a resource that no live endpoint or inference component references
+type OrphanInfo struct {
+	ResourceType string
+	Name         string
+	CreationTime time.Time
+	// ModelPackages are the version ARNs of a model package group that
+	// DeleteOrphan deletes before the group itself
+	ModelPackages []string
+}
+
+// namedResource is a name with its creation time as returned by List* calls
+type namedResource struct {
+	name         string
+	creationTime time.Time
+}
+
+// FindOrphans returns endpoint configs and models that are not referenced by any
+// endpoint or inference component. Model package groups are only checked when
+// includeModelPackages is set, and are orphaned when no referenced model uses them.
+func (c *clientImpl) FindOrphans(ctx context.Context, includeModelPackages bool) ([]OrphanInfo, error) {
+	var (
+		endpoints, configs, models, components, groups []namedResource
+		wg                                             sync.WaitGroup
+		mu                                             sync.Mutex
+		firstErr                                       error
+	)
+
+	type lister struct {
+		target *[]namedResource
+		list   func(context.Context) ([]namedResource, error)
+	}
+	listers := []lister{
+		{&endpoints, c.listEndpointNames},
+		{&configs, c.listEndpointConfigNames},
+		{&models, c.listModelNames},
+		{&components, c.listInferenceComponentNames},
+	}
+	if includeModelPackages {
+		listers = append(listers, lister{&groups, c.listModelPackageGroupNames})
+	}
+
+	wg.Add(len(listers))
+	for _, lister := range listers {
+		go func(target *[]namedResource, list func(context.Context) ([]namedResource, error)) {
+			defer wg.Done()
+			resources, err := list(ctx)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil && firstErr == nil {
+				firstErr = err
+			}
+			*target = resources
+		}(lister.target, lister.list)
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	retrier := retry.NewRetrier(retry.DefaultConfig)
+	referencedConfigs := make(map[string]bool)
+	referencedModels := make(map[string]bool)
+	referencedGroups := make(map[string]bool)
+
+	// Endpoint configs used by endpoints, including pending deployments
+	err := forEachConcurrently(ctx, names(endpoints), func(ctx context.Context, name string) error {
+		var output *sagemaker.DescribeEndpointOutput
+		err := retrier.Do(ctx, func() error {
+			var err error
+			output, err = c.client.DescribeEndpoint(ctx, &sagemaker.DescribeEndpointInput{EndpointName: aws.String(name)})
+			return WrapError(err)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to describe endpoint %s: %w", name, err)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if output.EndpointConfigName != nil {
+			referencedConfigs[*output.EndpointConfigName] = true
+		}
+		if output.PendingDeploymentSummary != nil && output.PendingDeploymentSummary.EndpointConfigName != nil {
+			referencedConfigs[*output.PendingDeploymentSummary.EndpointConfigName] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Models deployed through inference components
+	err = forEachConcurrently(ctx, names(components), func(ctx context.Context, name string) error {
+		var output *sagemaker.DescribeInferenceComponentOutput
+		err := retrier.Do(ctx, func() error {
+			var err error
+			output, err = c.client.DescribeInferenceComponent(ctx, &sagemaker.DescribeInferenceComponentInput{InferenceComponentName: aws.String(name)})
+			return WrapError(err)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to describe inference component %s: %w", name, err)
+		}
+
+		if output.Specification != nil && output.Specification.ModelName != nil {
+			mu.Lock()
+			referencedModels[*output.Specification.ModelName] = true
+			mu.Unlock()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Models used by the variants of referenced endpoint configs
+	err = forEachConcurrently(ctx, keys(referencedConfigs), func(ctx context.Context, name string) error {
+		var output *sagemaker.DescribeEndpointConfigOutput
+		err := retrier.Do(ctx, func() error {
+			var err error
+			output, err = c.client.DescribeEndpointConfig(ctx, &sagemaker.DescribeEndpointConfigInput{EndpointConfigName: aws.String(name)})
+			return WrapError(err)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to describe endpoint config %s: %w", name, err)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		for _, variant := range append(output.ProductionVariants, output.ShadowProductionVariants...) {
+			if variant.ModelName != nil {
+				referencedModels[*variant.ModelName] = true
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Model package groups used by the containers of referenced models
+	if includeModelPackages {
+		err = forEachConcurrently(ctx, keys(referencedModels), func(ctx context.Context, name string) error {
+			var output *sagemaker.DescribeModelOutput
+			err := retrier.Do(ctx, func() error {
+				var err error
+				output, err = c.client.DescribeModel(ctx, &sagemaker.DescribeModelInput{ModelName: aws.String(name)})
+				return WrapError(err)
+			})
+			if err != nil {
+				return fmt.Errorf("failed to describe model %s: %w", name, err)
+			}
+
+			containers := output.Containers
+			if output.PrimaryContainer != nil {
+				containers = append(containers, *output.PrimaryContainer)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			for _, container := range containers {
+				if container.ModelPackageName == nil {
+					continue
+				}
+				if group := modelPackageGroupName(*container.ModelPackageName); group != "" {
+					referencedGroups[group] = true
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var orphans []OrphanInfo
+	orphans = appendUnreferenced(orphans, OrphanEndpointConfig, configs, referencedConfigs)
+	orphans = appendUnreferenced(orphans, OrphanModel, models, referencedModels)
+	orphans = appendUnreferenced(orphans, OrphanModelPackageGroup, groups, referencedGroups)
+	return orphans, nil
+}
+
+// DeleteOrphan deletes an unreferenced resource returned by FindOrphans. A
+// model package group can only be deleted once it is empty, so the versions in
+// orphan.ModelPackages are deleted first. Versions not listed there are never
+// deleted and make the group deletion fail.
+func (c *clientImpl) DeleteOrphan(ctx context.Context, orphan OrphanInfo) error {
+	retrier := retry.NewRetrier(retry.DefaultConfig)
+	if orphan.ResourceType == OrphanModelPackageGroup {
+		for _, arn := range orphan.ModelPackages {
+			err := retrier.Do(ctx, func() error {
+				_, err := c.client.DeleteModelPackage(ctx, &sagemaker.DeleteModelPackageInput{ModelPackageName: aws.String(arn)})
+				return WrapError(err)
+			})
+			if err != nil {
+				return fmt.Errorf("failed to delete model package %s: %w", arn, err)
+			}
+		}
+	}
+	return retrier.Do(ctx, func() error {
+		var err error
+		switch orphan.ResourceType {
+		case OrphanEndpointConfig:
+			_, err = c.client.DeleteEndpointConfig(ctx, &sagemaker.DeleteEndpointConfigInput{EndpointConfigName: aws.String(orphan.Name)})
+		case OrphanModel:
+			_, err = c.client.DeleteModel(ctx, &sagemaker.DeleteModelInput{ModelName: aws.String(orphan.Name)})
+		case OrphanModelPackageGroup:
+			_, err = c.client.DeleteModelPackageGroup(ctx, &sagemaker.DeleteModelPackageGroupInput{ModelPackageGroupName: aws.String(orphan.Name)})
+		default:
+			return &NonRetryableError{Err: fmt.Errorf("unsupported resource type %q", orphan.ResourceType)}
+		}
+		return WrapError(err)
+	})
+}
+
+// ListModelPackageVersions returns the ARNs of every model package version registered in a group
+func (c *clientImpl) ListModelPackageVersions(ctx context.Context, group string) ([]string, error) {
+	retrier := retry.NewRetrier(retry.DefaultConfig)
+	var arns []string
+	err := retrier.Do(ctx, func() error {
+		arns = nil
+		input := &sagemaker.ListModelPackagesInput{ModelPackageGroupName: aws.String(group)}
+		for {
+			output, err := c.client.ListModelPackages(ctx, input)
+			if err != nil {
+				return WrapError(err)
+			}
+			for _, modelPackage := range output.ModelPackageSummaryList {
+				if modelPackage.ModelPackageArn != nil {
+					arns = append(arns, *modelPackage.ModelPackageArn)
+				}
+			}
+			if output.NextToken == nil {
+				return nil
+			}
+			input.NextToken = output.NextToken
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list model packages of %s: %w", group, err)
+	}
+	return arns, nil
+}
+
+// modelPackageGroupName extracts the group name from a versioned model package ARN
+func modelPackageGroupName(modelPackage string) string {
+	const marker = ":model-package/"
+	idx := strings.Index(modelPackage, marker)
+	if idx < 0 {
+		return ""
+	}
+	parts := strings.Split(modelPackage[idx+len(marker):], "/")
+	if len(parts) != 2 {
+		// Unversioned model packages do not belong to a group
+		return ""
+	}
+	return parts[0]
+}
+
+// appendUnreferenced appends every resource whose name is not in referenced
+func appendUnreferenced(orphans []OrphanInfo, resourceType string, resources []namedResource, referenced map[string]bool) []OrphanInfo {
+	for _, resource := range resources {
+		if !referenced[resource.name] {
+			orphans = append(orphans, OrphanInfo{
+				ResourceType: resourceType,
+				Name:         resource.name,
+				CreationTime: resource.creationTime,
+			})
+		}
+	}
+	return orphans
+}
+
+func names(resources []namedResource) []string {
+	result := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		result = append(result, resource.name)
+	}
+	return result
+}
+
+func keys(set map[string]bool) []string {
+	result := make([]string, 0, len(set))
+	for key := range set {
+		result = append(result, key)
+	}
+	return result
+}
+
+// listEndpointNames returns all endpoints regardless of their status
+func (c *clientImpl) listEndpointNames(ctx context.Context) ([]namedResource, error) {
+	var resources []namedResource
+	retrier := retry.NewRetrier(retry.DefaultConfig)
+	err := retrier.Do(ctx, func() error {
+		resources = nil
+		input := &sagemaker.ListEndpointsInput{}
+		for {
+			output, err := c.client.ListEndpoints(ctx, input)
+			if err != nil {
+				return WrapError(err)
+			}
+			for _, endpoint := range output.Endpoints {
+				resources = appendNamed(resources, endpoint.EndpointName, endpoint.CreationTime)
+			}
+			if output.NextToken == nil {
+				return nil
+			}
+			input.NextToken = output.NextToken
+		}
+	})
+	return resources, err
+}
+
+func (c *clientImpl) listEndpointConfigNames(ctx context.Context) ([]namedResource, error) {
+	var resources []namedResource
+	retrier := retry.NewRetrier(retry.DefaultConfig)
+	err := retrier.Do(ctx, func() error {
+		resources = nil
+		input := &sagemaker.ListEndpointConfigsInput{}
+		for {
+			output, err := c.client.ListEndpointConfigs(ctx, input)
+			if err != nil {
+				return WrapError(err)
+			}
+			for _, config := range output.EndpointConfigs {
+				resources = appendNamed(resources, config.EndpointConfigName, config.CreationTime)
+			}
+			if output.NextToken == nil {
+				return nil
+			}
+			input.NextToken = output.NextToken
+		}
+	})
+	return resources, err
+}
+
+func (c *clientImpl) listModelNames(ctx context.Context) ([]namedResource, error) {
+	var resources []namedResource
+	retrier := retry.NewRetrier(retry.DefaultConfig)
+	err := retrier.Do(ctx, func() error {
+		resources = nil
+		input := &sagemaker.ListModelsInput{}
+		for {
+			output, err := c.client.ListModels(ctx, input)
+			if err != nil {
+				return WrapError(err)
+			}
+			for _, model := range output.Models {
+				resources = appendNamed(resources, model.ModelName, model.CreationTime)
+			}
+			if output.NextToken == nil {
+				return nil
+			}
+			input.NextToken = output.NextToken
+		}
+	})
+	return resources, err
+}
+
+func (c *clientImpl) listInferenceComponentNames(ctx context.Context) ([]namedResource, error) {
+	var resources []namedResource
+	retrier := retry.NewRetrier(retry.DefaultConfig)
+	err := retrier.Do(ctx, func() error {
+		resources = nil
+		input := &sagemaker.ListInferenceComponentsInput{}
+		for {
+			output, err := c.client.ListInferenceComponents(ctx, input)
+			if err != nil {
+				return WrapError(err)
+			}
+			for _, component := range output.InferenceComponents {
+				resources = appendNamed(resources, component.InferenceComponentName, component.CreationTime)
+			}
+			if output.NextToken == nil {
+				return nil
+			}
+			input.NextToken = output.NextToken
+		}
+	})
+	return resources, err
+}
+
+func (c *clientImpl) listModelPackageGroupNames(ctx context.Context) ([]namedResource, error) {
+	var resources []namedResource
+	retrier := retry.NewRetrier(retry.DefaultConfig)
+	err := retrier.Do(ctx, func() error {
+		resources = nil
+		input := &sagemaker.ListModelPackageGroupsInput{}
+		for {
+			output, err := c.client.ListModelPackageGroups(ctx, input)
+			if err != nil {
+				return WrapError(err)
+			}
+			for _, group := range output.ModelPackageGroupSummaryList {
+				if group.ModelPackageGroupStatus == types.ModelPackageGroupStatusDeleting {
+					continue
+				}
+				resources = appendNamed(resources, group.ModelPackageGroupName, group.CreationTime)
+			}
+			if output.NextToken == nil {
+				return nil
+			}
+			input.NextToken = output.NextToken
+		}
+	})
+	return resources, err
+}
+
+// appendNamed appends a resource when its name is set
+func appendNamed(resources []namedResource, name *string, creationTime *time.Time) []namedResource {
+	if name == nil {
+		return resources
+	}
+	resource := namedResource{name: *name}
+	if creationTime != nil {
+		resource.creationTime = *creationTime
+	}
+	return append(resources, resource)
+}
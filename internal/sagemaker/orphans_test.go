@@ -0,0 +1,169 @@
+package sagemaker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestModelPackageGroupName(t *testing.T) {
+	assert.Equal(t, "churn", modelPackageGroupName("arn:aws:sagemaker:us-east-1:123456789012:model-package/churn/3"))
+	assert.Equal(t, "", modelPackageGroupName("arn:aws:sagemaker:us-east-1:123456789012:model-package/unversioned"))
+	assert.Equal(t, "", modelPackageGroupName("churn"))
+}
+
+func TestFindOrphans(t *testing.T) {
+	ctx := context.Background()
+	created := time.Now().Add(-30 * 24 * time.Hour)
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListEndpoints", mock.Anything, &sagemaker.ListEndpointsInput{}, mock.Anything).
+		Return(&sagemaker.ListEndpointsOutput{
+			Endpoints: []types.EndpointSummary{{EndpointName: aws.String("live"), CreationTime: aws.Time(created)}},
+		}, nil)
+	mockClient.On("ListEndpointConfigs", mock.Anything, &sagemaker.ListEndpointConfigsInput{}, mock.Anything).
+		Return(&sagemaker.ListEndpointConfigsOutput{
+			EndpointConfigs: []types.EndpointConfigSummary{
+				{EndpointConfigName: aws.String("live-config"), CreationTime: aws.Time(created)},
+				{EndpointConfigName: aws.String("old-config"), CreationTime: aws.Time(created)},
+			},
+		}, nil)
+	mockClient.On("ListModels", mock.Anything, &sagemaker.ListModelsInput{}, mock.Anything).
+		Return(&sagemaker.ListModelsOutput{
+			Models: []types.ModelSummary{
+				{ModelName: aws.String("live-model"), CreationTime: aws.Time(created)},
+				{ModelName: aws.String("component-model"), CreationTime: aws.Time(created)},
+				{ModelName: aws.String("old-model"), CreationTime: aws.Time(created)},
+			},
+		}, nil)
+	mockClient.On("ListInferenceComponents", mock.Anything, &sagemaker.ListInferenceComponentsInput{}, mock.Anything).
+		Return(&sagemaker.ListInferenceComponentsOutput{
+			InferenceComponents: []types.InferenceComponentSummary{{InferenceComponentName: aws.String("ic")}},
+		}, nil)
+	mockClient.On("ListModelPackageGroups", mock.Anything, &sagemaker.ListModelPackageGroupsInput{}, mock.Anything).
+		Return(&sagemaker.ListModelPackageGroupsOutput{
+			ModelPackageGroupSummaryList: []types.ModelPackageGroupSummary{
+				{ModelPackageGroupName: aws.String("used-group"), CreationTime: aws.Time(created)},
+				{ModelPackageGroupName: aws.String("unused-group"), CreationTime: aws.Time(created)},
+			},
+		}, nil)
+	mockClient.On("DescribeEndpoint", mock.Anything, &sagemaker.DescribeEndpointInput{EndpointName: aws.String("live")}, mock.Anything).
+		Return(&sagemaker.DescribeEndpointOutput{EndpointConfigName: aws.String("live-config")}, nil)
+	mockClient.On("DescribeInferenceComponent", mock.Anything, &sagemaker.DescribeInferenceComponentInput{InferenceComponentName: aws.String("ic")}, mock.Anything).
+		Return(&sagemaker.DescribeInferenceComponentOutput{
+			Specification: &types.InferenceComponentSpecificationSummary{ModelName: aws.String("component-model")},
+		}, nil)
+	mockClient.On("DescribeEndpointConfig", mock.Anything, &sagemaker.DescribeEndpointConfigInput{EndpointConfigName: aws.String("live-config")}, mock.Anything).
+		Return(&sagemaker.DescribeEndpointConfigOutput{
+			ProductionVariants: []types.ProductionVariant{{ModelName: aws.String("live-model")}},
+		}, nil)
+	mockClient.On("DescribeModel", mock.Anything, &sagemaker.DescribeModelInput{ModelName: aws.String("live-model")}, mock.Anything).
+		Return(&sagemaker.DescribeModelOutput{
+			PrimaryContainer: &types.ContainerDefinition{
+				ModelPackageName: aws.String("arn:aws:sagemaker:us-east-1:123456789012:model-package/used-group/1"),
+			},
+		}, nil)
+	mockClient.On("DescribeModel", mock.Anything, &sagemaker.DescribeModelInput{ModelName: aws.String("component-model")}, mock.Anything).
+		Return(&sagemaker.DescribeModelOutput{}, nil)
+
+	client := &clientImpl{client: mockClient}
+
+	t.Run("without model packages", func(t *testing.T) {
+		orphans, err := client.FindOrphans(ctx, false)
+		assert.NoError(t, err)
+		assert.Equal(t, []OrphanInfo{
+			{ResourceType: OrphanEndpointConfig, Name: "old-config", CreationTime: created},
+			{ResourceType: OrphanModel, Name: "old-model", CreationTime: created},
+		}, orphans)
+	})
+
+	t.Run("with model packages", func(t *testing.T) {
+		orphans, err := client.FindOrphans(ctx, true)
+		assert.NoError(t, err)
+		assert.Len(t, orphans, 3)
+		assert.Equal(t, OrphanInfo{ResourceType: OrphanModelPackageGroup, Name: "unused-group", CreationTime: created}, orphans[2])
+	})
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestDeleteOrphan(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("DeleteEndpointConfig", ctx, &sagemaker.DeleteEndpointConfigInput{EndpointConfigName: aws.String("old-config")}, mock.Anything).
+		Return(&sagemaker.DeleteEndpointConfigOutput{}, nil)
+	mockClient.On("DeleteModel", ctx, &sagemaker.DeleteModelInput{ModelName: aws.String("old-model")}, mock.Anything).
+		Return(&sagemaker.DeleteModelOutput{}, nil)
+
+	client := &clientImpl{client: mockClient}
+	assert.NoError(t, client.DeleteOrphan(ctx, OrphanInfo{ResourceType: OrphanEndpointConfig, Name: "old-config"}))
+	assert.NoError(t, client.DeleteOrphan(ctx, OrphanInfo{ResourceType: OrphanModel, Name: "old-model"}))
+	assert.Error(t, client.DeleteOrphan(ctx, OrphanInfo{ResourceType: "Endpoint", Name: "live"}))
+	mockClient.AssertExpectations(t)
+}
+
+func TestListModelPackageVersions(t *testing.T) {
+	ctx := context.Background()
+	v1 := "arn:aws:sagemaker:us-east-1:123456789012:model-package/unused-group/1"
+	v2 := "arn:aws:sagemaker:us-east-1:123456789012:model-package/unused-group/2"
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListModelPackages", ctx, &sagemaker.ListModelPackagesInput{ModelPackageGroupName: aws.String("unused-group")}, mock.Anything).
+		Return(&sagemaker.ListModelPackagesOutput{
+			ModelPackageSummaryList: []types.ModelPackageSummary{{ModelPackageArn: aws.String(v1)}},
+			NextToken:               aws.String("page2"),
+		}, nil).Once()
+	mockClient.On("ListModelPackages", ctx, &sagemaker.ListModelPackagesInput{ModelPackageGroupName: aws.String("unused-group"), NextToken: aws.String("page2")}, mock.Anything).
+		Return(&sagemaker.ListModelPackagesOutput{
+			ModelPackageSummaryList: []types.ModelPackageSummary{{ModelPackageArn: aws.String(v2)}},
+		}, nil).Once()
+
+	client := &clientImpl{client: mockClient}
+	versions, err := client.ListModelPackageVersions(ctx, "unused-group")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{v1, v2}, versions)
+	mockClient.AssertExpectations(t)
+}
+
+func TestDeleteOrphan_ModelPackageGroup(t *testing.T) {
+	ctx := context.Background()
+	v1 := "arn:aws:sagemaker:us-east-1:123456789012:model-package/unused-group/1"
+	v2 := "arn:aws:sagemaker:us-east-1:123456789012:model-package/unused-group/2"
+
+	mockClient := new(MockSageMakerClient)
+	// The group can only be deleted once its versions are gone
+	var deleted []string
+	mockClient.On("DeleteModelPackage", ctx, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		deleted = append(deleted, aws.ToString(args.Get(1).(*sagemaker.DeleteModelPackageInput).ModelPackageName))
+	}).Return(&sagemaker.DeleteModelPackageOutput{}, nil)
+	mockClient.On("DeleteModelPackageGroup", ctx, &sagemaker.DeleteModelPackageGroupInput{ModelPackageGroupName: aws.String("unused-group")}, mock.Anything).
+		Run(func(args mock.Arguments) {
+			assert.Equal(t, []string{v1, v2}, deleted)
+		}).Return(&sagemaker.DeleteModelPackageGroupOutput{}, nil)
+
+	client := &clientImpl{client: mockClient}
+	orphan := OrphanInfo{ResourceType: OrphanModelPackageGroup, Name: "unused-group", ModelPackages: []string{v1, v2}}
+	assert.NoError(t, client.DeleteOrphan(ctx, orphan))
+	// Only the listed versions are deleted
+	mockClient.AssertNotCalled(t, "ListModelPackages", mock.Anything, mock.Anything, mock.Anything)
+	mockClient.AssertExpectations(t)
+}
+
+func TestDeleteOrphan_ModelPackageError(t *testing.T) {
+	ctx := context.Background()
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("DeleteModelPackage", ctx, mock.Anything, mock.Anything).Return(nil, errors.New("access denied"))
+
+	client := &clientImpl{client: mockClient}
+	err := client.DeleteOrphan(ctx, OrphanInfo{ResourceType: OrphanModelPackageGroup, Name: "unused-group", ModelPackages: []string{"arn:v1"}})
+
+	assert.ErrorContains(t, err, "failed to delete model package arn:v1")
+	mockClient.AssertNotCalled(t, "DeleteModelPackageGroup", mock.Anything, mock.Anything, mock.Anything)
+}
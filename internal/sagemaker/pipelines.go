@@ -0,0 +1,289 @@
+package sagemaker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker/types"
+	"mohua/internal/retry"
+)
+
+// pipelineLookback bounds how far back executions are listed; SageMaker stops
+// pipeline executions that run longer than 30 days
+const pipelineLookback = 30 * 24 * time.Hour
+
+// PipelineRun describes an executing SageMaker Pipelines run
+type PipelineRun struct {
+	PipelineName   string
+	ExecutionArn   string
+	LastStepChange time.Time
+	Stuck          bool // No step started or finished within the stuck threshold
+	Steps          []PipelineStep
+}
+
+// PipelineStep describes a single step of a pipeline execution
+type PipelineStep struct {
+	Name         string
+	Status       string
+	JobType      string // TrainingJob or ProcessingJob when the step launched one
+	JobName      string
+	InstanceType string
+	StartTime    time.Time
+	EndTime      time.Time
+}
+
+// ListPipelineExecutions returns the executing runs of every pipeline. Runs with no
+// step change for longer than stuckAfter are flagged as stuck.
+func (c *clientImpl) ListPipelineExecutions(ctx context.Context, stuckAfter time.Duration) ([]ResourceInfo, error) {
+	var pipelineNames []string
+
+	retrier := retry.NewRetrier(retry.DefaultConfig)
+	err := retrier.Do(ctx, func() error {
+		pipelineNames = nil
+		input := &sagemaker.ListPipelinesInput{}
+		for {
+			output, err := c.client.ListPipelines(ctx, input)
+			if err != nil {
+				return WrapError(err)
+			}
+			for _, pipeline := range output.PipelineSummaries {
+				if pipeline.PipelineName != nil {
+					pipelineNames = append(pipelineNames, *pipeline.PipelineName)
+				}
+			}
+			if output.NextToken == nil {
+				return nil
+			}
+			input.NextToken = output.NextToken
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var mu sync.Mutex
+	runsByPipeline := make(map[string][]ResourceInfo)
+
+	err = forEachConcurrently(ctx, pipelineNames, func(ctx context.Context, pipelineName string) error {
+		executions, err := c.listExecutingRuns(ctx, retrier, pipelineName, now)
+		if err != nil {
+			return err
+		}
+
+		var runs []ResourceInfo
+		for _, execution := range executions {
+			run, err := c.describePipelineRun(ctx, retrier, pipelineName, execution, now, stuckAfter)
+			if err != nil {
+				return err
+			}
+			runs = append(runs, run)
+		}
+
+		mu.Lock()
+		runsByPipeline[pipelineName] = runs
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var resources []ResourceInfo
+	for _, name := range pipelineNames {
+		resources = append(resources, runsByPipeline[name]...)
+	}
+	return resources, nil
+}
+
+// listExecutingRuns returns the executions of a pipeline that are still running
+func (c *clientImpl) listExecutingRuns(ctx context.Context, retrier *retry.Retrier, pipelineName string, now time.Time) ([]types.PipelineExecutionSummary, error) {
+	var executions []types.PipelineExecutionSummary
+	err := retrier.Do(ctx, func() error {
+		executions = nil
+		input := &sagemaker.ListPipelineExecutionsInput{
+			PipelineName: aws.String(pipelineName),
+			CreatedAfter: aws.Time(now.Add(-pipelineLookback)),
+		}
+		for {
+			output, err := c.client.ListPipelineExecutions(ctx, input)
+			if err != nil {
+				return WrapError(err)
+			}
+			for _, execution := range output.PipelineExecutionSummaries {
+				if execution.PipelineExecutionStatus == types.PipelineExecutionStatusExecuting && execution.PipelineExecutionArn != nil {
+					executions = append(executions, execution)
+				}
+			}
+			if output.NextToken == nil {
+				return nil
+			}
+			input.NextToken = output.NextToken
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list executions of pipeline %s: %w", pipelineName, err)
+	}
+	return executions, nil
+}
+
+// describePipelineRun collects the steps of an execution and the instance types of their jobs
+func (c *clientImpl) describePipelineRun(ctx context.Context, retrier *retry.Retrier, pipelineName string, execution types.PipelineExecutionSummary, now time.Time, stuckAfter time.Duration) (ResourceInfo, error) {
+	executionArn := *execution.PipelineExecutionArn
+
+	var steps []types.PipelineExecutionStep
+	err := retrier.Do(ctx, func() error {
+		steps = nil
+		input := &sagemaker.ListPipelineExecutionStepsInput{PipelineExecutionArn: aws.String(executionArn)}
+		for {
+			output, err := c.client.ListPipelineExecutionSteps(ctx, input)
+			if err != nil {
+				return WrapError(err)
+			}
+			steps = append(steps, output.PipelineExecutionSteps...)
+			if output.NextToken == nil {
+				return nil
+			}
+			input.NextToken = output.NextToken
+		}
+	})
+	if err != nil {
+		return ResourceInfo{}, fmt.Errorf("failed to list steps of pipeline execution %s: %w", executionArn, err)
+	}
+
+	run := PipelineRun{
+		PipelineName: pipelineName,
+		ExecutionArn: executionArn,
+	}
+	if execution.StartTime != nil {
+		run.LastStepChange = *execution.StartTime
+	}
+
+	for _, step := range steps {
+		info := PipelineStep{Status: string(step.StepStatus)}
+		if step.StepName != nil {
+			info.Name = *step.StepName
+		}
+		if step.StartTime != nil {
+			info.StartTime = *step.StartTime
+			if info.StartTime.After(run.LastStepChange) {
+				run.LastStepChange = info.StartTime
+			}
+		}
+		if step.EndTime != nil {
+			info.EndTime = *step.EndTime
+			if info.EndTime.After(run.LastStepChange) {
+				run.LastStepChange = info.EndTime
+			}
+		}
+
+		if metadata := step.Metadata; metadata != nil {
+			switch {
+			case metadata.TrainingJob != nil && metadata.TrainingJob.Arn != nil:
+				info.JobType = "TrainingJob"
+				info.JobName = resourceNameFromArn(*metadata.TrainingJob.Arn)
+				info.InstanceType, err = c.trainingJobInstanceType(ctx, retrier, info.JobName)
+			case metadata.ProcessingJob != nil && metadata.ProcessingJob.Arn != nil:
+				info.JobType = "ProcessingJob"
+				info.JobName = resourceNameFromArn(*metadata.ProcessingJob.Arn)
+				info.InstanceType, err = c.processingJobInstanceType(ctx, retrier, info.JobName)
+			}
+			if err != nil {
+				return ResourceInfo{}, err
+			}
+		}
+
+		run.Steps = append(run.Steps, info)
+	}
+
+	run.Stuck = !run.LastStepChange.IsZero() && now.Sub(run.LastStepChange) > stuckAfter
+
+	resource := ResourceInfo{
+		Name:     fmt.Sprintf("%s/%s", pipelineName, resourceNameFromArn(executionArn)),
+		Status:   string(execution.PipelineExecutionStatus),
+		Details:  run.summary(now),
+		Pipeline: &run,
+	}
+	if execution.StartTime != nil {
+		resource.CreationTime = *execution.StartTime
+	}
+	for _, step := range run.Steps {
+		if step.Status == string(types.StepStatusExecuting) && step.InstanceType != "" {
+			resource.InstanceType = step.InstanceType
+			break
+		}
+	}
+	return resource, nil
+}
+
+func (c *clientImpl) trainingJobInstanceType(ctx context.Context, retrier *retry.Retrier, jobName string) (string, error) {
+	var output *sagemaker.DescribeTrainingJobOutput
+	err := retrier.Do(ctx, func() error {
+		var err error
+		output, err = c.client.DescribeTrainingJob(ctx, &sagemaker.DescribeTrainingJobInput{TrainingJobName: aws.String(jobName)})
+		return WrapError(err)
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to describe training job %s: %w", jobName, err)
+	}
+	if output.ResourceConfig == nil {
+		return "", nil
+	}
+	return string(output.ResourceConfig.InstanceType), nil
+}
+
+func (c *clientImpl) processingJobInstanceType(ctx context.Context, retrier *retry.Retrier, jobName string) (string, error) {
+	var output *sagemaker.DescribeProcessingJobOutput
+	err := retrier.Do(ctx, func() error {
+		var err error
+		output, err = c.client.DescribeProcessingJob(ctx, &sagemaker.DescribeProcessingJobInput{ProcessingJobName: aws.String(jobName)})
+		return WrapError(err)
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to describe processing job %s: %w", jobName, err)
+	}
+	if output.ProcessingResources == nil || output.ProcessingResources.ClusterConfig == nil {
+		return "", nil
+	}
+	return string(output.ProcessingResources.ClusterConfig.InstanceType), nil
+}
+
+// summary describes the executing steps and progress for the Details column
+func (r PipelineRun) summary(now time.Time) string {
+	var executing []string
+	succeeded := 0
+	for _, step := range r.Steps {
+		switch step.Status {
+		case string(types.StepStatusSucceeded):
+			succeeded++
+		case string(types.StepStatusExecuting), string(types.StepStatusStarting):
+			if step.JobName != "" {
+				executing = append(executing, fmt.Sprintf("%s (%s)", step.Name, step.JobName))
+			} else {
+				executing = append(executing, step.Name)
+			}
+		}
+	}
+
+	summary := fmt.Sprintf("steps %d/%d succeeded", succeeded, len(r.Steps))
+	if len(executing) > 0 {
+		summary += "; running " + strings.Join(executing, ", ")
+	}
+	if r.Stuck {
+		summary = fmt.Sprintf("STUCK: no step change for %s; %s", now.Sub(r.LastStepChange).Truncate(time.Minute), summary)
+	}
+	return summary
+}
+
+// resourceNameFromArn returns the last path segment of an ARN
+func resourceNameFromArn(arn string) string {
+	if idx := strings.LastIndex(arn, "/"); idx >= 0 {
+		return arn[idx+1:]
+	}
+	return arn
+}
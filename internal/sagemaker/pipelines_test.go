@@ -0,0 +1,105 @@
+package sagemaker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestResourceNameFromArn(t *testing.T) {
+	assert.Equal(t, "train-abc", resourceNameFromArn("arn:aws:sagemaker:us-east-1:123456789012:training-job/train-abc"))
+	assert.Equal(t, "plain", resourceNameFromArn("plain"))
+}
+
+func TestListPipelineExecutions(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	executionArn := "arn:aws:sagemaker:us-east-1:123456789012:pipeline/nightly/execution/abc123"
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListPipelines", mock.Anything, &sagemaker.ListPipelinesInput{}, mock.Anything).
+		Return(&sagemaker.ListPipelinesOutput{
+			PipelineSummaries: []types.PipelineSummary{{PipelineName: aws.String("nightly")}},
+		}, nil)
+	mockClient.On("ListPipelineExecutions", mock.Anything, mock.MatchedBy(func(in *sagemaker.ListPipelineExecutionsInput) bool {
+		return *in.PipelineName == "nightly" && in.CreatedAfter != nil
+	}), mock.Anything).
+		Return(&sagemaker.ListPipelineExecutionsOutput{
+			PipelineExecutionSummaries: []types.PipelineExecutionSummary{
+				{
+					PipelineExecutionArn:    aws.String(executionArn),
+					PipelineExecutionStatus: types.PipelineExecutionStatusExecuting,
+					StartTime:               aws.Time(now.Add(-10 * time.Hour)),
+				},
+				{
+					PipelineExecutionArn:    aws.String("arn:aws:sagemaker:us-east-1:123456789012:pipeline/nightly/execution/done"),
+					PipelineExecutionStatus: types.PipelineExecutionStatusSucceeded,
+				},
+			},
+		}, nil)
+	mockClient.On("ListPipelineExecutionSteps", mock.Anything, &sagemaker.ListPipelineExecutionStepsInput{PipelineExecutionArn: aws.String(executionArn)}, mock.Anything).
+		Return(&sagemaker.ListPipelineExecutionStepsOutput{
+			PipelineExecutionSteps: []types.PipelineExecutionStep{
+				{
+					StepName:   aws.String("Preprocess"),
+					StepStatus: types.StepStatusSucceeded,
+					StartTime:  aws.Time(now.Add(-10 * time.Hour)),
+					EndTime:    aws.Time(now.Add(-9 * time.Hour)),
+					Metadata: &types.PipelineExecutionStepMetadata{
+						ProcessingJob: &types.ProcessingJobStepMetadata{Arn: aws.String("arn:aws:sagemaker:us-east-1:123456789012:processing-job/pre")},
+					},
+				},
+				{
+					StepName:   aws.String("Train"),
+					StepStatus: types.StepStatusExecuting,
+					StartTime:  aws.Time(now.Add(-9 * time.Hour)),
+					Metadata: &types.PipelineExecutionStepMetadata{
+						TrainingJob: &types.TrainingJobStepMetadata{Arn: aws.String("arn:aws:sagemaker:us-east-1:123456789012:training-job/train")},
+					},
+				},
+			},
+		}, nil)
+	mockClient.On("DescribeProcessingJob", mock.Anything, &sagemaker.DescribeProcessingJobInput{ProcessingJobName: aws.String("pre")}, mock.Anything).
+		Return(&sagemaker.DescribeProcessingJobOutput{
+			ProcessingResources: &types.ProcessingResources{
+				ClusterConfig: &types.ProcessingClusterConfig{InstanceType: types.ProcessingInstanceTypeMlM5Xlarge},
+			},
+		}, nil)
+	mockClient.On("DescribeTrainingJob", mock.Anything, &sagemaker.DescribeTrainingJobInput{TrainingJobName: aws.String("train")}, mock.Anything).
+		Return(&sagemaker.DescribeTrainingJobOutput{
+			ResourceConfig: &types.ResourceConfig{InstanceType: types.TrainingInstanceTypeMlG5Xlarge},
+		}, nil)
+
+	client := &clientImpl{client: mockClient}
+
+	t.Run("stuck", func(t *testing.T) {
+		resources, err := client.ListPipelineExecutions(ctx, 6*time.Hour)
+		assert.NoError(t, err)
+		assert.Len(t, resources, 1)
+
+		run := resources[0]
+		assert.Equal(t, "nightly/abc123", run.Name)
+		assert.Equal(t, "Executing", run.Status)
+		assert.Equal(t, "ml.g5.xlarge", run.InstanceType)
+		assert.True(t, run.Pipeline.Stuck)
+		assert.Equal(t, []PipelineStep{
+			{Name: "Preprocess", Status: "Succeeded", JobType: "ProcessingJob", JobName: "pre", InstanceType: "ml.m5.xlarge", StartTime: now.Add(-10 * time.Hour), EndTime: now.Add(-9 * time.Hour)},
+			{Name: "Train", Status: "Executing", JobType: "TrainingJob", JobName: "train", InstanceType: "ml.g5.xlarge", StartTime: now.Add(-9 * time.Hour)},
+		}, run.Pipeline.Steps)
+		assert.Contains(t, run.Details, "STUCK: no step change for 9h")
+		assert.Contains(t, run.Details, "steps 1/2 succeeded; running Train (train)")
+	})
+
+	t.Run("progressing", func(t *testing.T) {
+		resources, err := client.ListPipelineExecutions(ctx, 12*time.Hour)
+		assert.NoError(t, err)
+		assert.False(t, resources[0].Pipeline.Stuck)
+		assert.Equal(t, "steps 1/2 succeeded; running Train (train)", resources[0].Details)
+	})
+}
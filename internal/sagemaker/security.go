@@ -0,0 +1,352 @@
+package sagemaker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker/types"
+	"mohua/internal/retry"
+)
+
+// SecurityInfo is the security relevant configuration of a notebook instance,
+// endpoint, Studio domain or Studio user profile
+type SecurityInfo struct {
+	ResourceType         string // Notebook, Endpoint, Domain or UserProfile
+	Name                 string
+	KmsKeyID             string   // Empty when the resource uses an AWS managed key
+	RootAccess           bool     // Notebooks
+	DirectInternetAccess bool     // Notebooks
+	SubnetID             string   // Notebooks; empty when not attached to a VPC
+	UnisolatedModels     []string // Endpoints; models served without network isolation
+	AppNetworkAccessType string   // Domains
+	ExecutionRole        string   // Domains and user profiles
+}
+
+// ListNotebookSecurity returns the security configuration of every notebook instance
+func (c *clientImpl) ListNotebookSecurity(ctx context.Context) ([]SecurityInfo, error) {
+	var names []string
+
+	retrier := retry.NewRetrier(retry.DefaultConfig)
+	err := retrier.Do(ctx, func() error {
+		names = nil
+		input := &sagemaker.ListNotebookInstancesInput{}
+		for {
+			output, err := c.client.ListNotebookInstances(ctx, input)
+			if err != nil {
+				return WrapError(err)
+			}
+			for _, notebook := range output.NotebookInstances {
+				if notebook.NotebookInstanceName != nil {
+					names = append(names, *notebook.NotebookInstanceName)
+				}
+			}
+			if output.NextToken == nil {
+				return nil
+			}
+			input.NextToken = output.NextToken
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return describeSecurity(ctx, names, func(ctx context.Context, name string) (SecurityInfo, error) {
+		var output *sagemaker.DescribeNotebookInstanceOutput
+		err := retrier.Do(ctx, func() error {
+			var err error
+			output, err = c.client.DescribeNotebookInstance(ctx, &sagemaker.DescribeNotebookInstanceInput{
+				NotebookInstanceName: aws.String(name),
+			})
+			return WrapError(err)
+		})
+		if err != nil {
+			return SecurityInfo{}, fmt.Errorf("failed to describe notebook instance %s: %w", name, err)
+		}
+
+		return SecurityInfo{
+			ResourceType:         "Notebook",
+			Name:                 name,
+			KmsKeyID:             aws.ToString(output.KmsKeyId),
+			RootAccess:           output.RootAccess != types.RootAccessDisabled,
+			DirectInternetAccess: output.DirectInternetAccess != types.DirectInternetAccessDisabled,
+			SubnetID:             aws.ToString(output.SubnetId),
+		}, nil
+	})
+}
+
+// ListEndpointSecurity returns the security configuration of every in-service endpoint
+func (c *clientImpl) ListEndpointSecurity(ctx context.Context) ([]SecurityInfo, error) {
+	var names []string
+
+	retrier := retry.NewRetrier(retry.DefaultConfig)
+	err := retrier.Do(ctx, func() error {
+		names = nil
+		input := &sagemaker.ListEndpointsInput{}
+		for {
+			output, err := c.client.ListEndpoints(ctx, input)
+			if err != nil {
+				return WrapError(err)
+			}
+			for _, endpoint := range output.Endpoints {
+				if endpoint.EndpointStatus == types.EndpointStatusInService && endpoint.EndpointName != nil {
+					names = append(names, *endpoint.EndpointName)
+				}
+			}
+			if output.NextToken == nil {
+				return nil
+			}
+			input.NextToken = output.NextToken
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return describeSecurity(ctx, names, func(ctx context.Context, name string) (SecurityInfo, error) {
+		var endpoint *sagemaker.DescribeEndpointOutput
+		err := retrier.Do(ctx, func() error {
+			var err error
+			endpoint, err = c.client.DescribeEndpoint(ctx, &sagemaker.DescribeEndpointInput{
+				EndpointName: aws.String(name),
+			})
+			return WrapError(err)
+		})
+		if err != nil {
+			return SecurityInfo{}, fmt.Errorf("failed to describe endpoint %s: %w", name, err)
+		}
+
+		var config *sagemaker.DescribeEndpointConfigOutput
+		err = retrier.Do(ctx, func() error {
+			var err error
+			config, err = c.client.DescribeEndpointConfig(ctx, &sagemaker.DescribeEndpointConfigInput{
+				EndpointConfigName: endpoint.EndpointConfigName,
+			})
+			return WrapError(err)
+		})
+		if err != nil {
+			return SecurityInfo{}, fmt.Errorf("failed to describe endpoint config %s: %w", aws.ToString(endpoint.EndpointConfigName), err)
+		}
+
+		info := SecurityInfo{
+			ResourceType: "Endpoint",
+			Name:         name,
+			KmsKeyID:     aws.ToString(config.KmsKeyId),
+		}
+		// Variants backed by inference components have no model of their own
+		for _, variant := range config.ProductionVariants {
+			if variant.ModelName == nil {
+				continue
+			}
+			modelName := *variant.ModelName
+			var model *sagemaker.DescribeModelOutput
+			err := retrier.Do(ctx, func() error {
+				var err error
+				model, err = c.client.DescribeModel(ctx, &sagemaker.DescribeModelInput{
+					ModelName: aws.String(modelName),
+				})
+				return WrapError(err)
+			})
+			if err != nil {
+				return SecurityInfo{}, fmt.Errorf("failed to describe model %s: %w", modelName, err)
+			}
+			if !aws.ToBool(model.EnableNetworkIsolation) {
+				info.UnisolatedModels = append(info.UnisolatedModels, modelName)
+			}
+		}
+		return info, nil
+	})
+}
+
+// ListDomainSecurity returns the security configuration of every Studio domain
+func (c *clientImpl) ListDomainSecurity(ctx context.Context) ([]SecurityInfo, error) {
+	retrier := retry.NewRetrier(retry.DefaultConfig)
+	domainIDs, err := c.listDomainIDs(ctx, retrier)
+	if err != nil {
+		return nil, err
+	}
+
+	return describeSecurity(ctx, domainIDs, func(ctx context.Context, domainID string) (SecurityInfo, error) {
+		output, err := c.describeDomain(ctx, retrier, domainID)
+		if err != nil {
+			return SecurityInfo{}, err
+		}
+
+		info := SecurityInfo{
+			ResourceType:         "Domain",
+			Name:                 domainID,
+			KmsKeyID:             aws.ToString(output.KmsKeyId),
+			AppNetworkAccessType: string(output.AppNetworkAccessType),
+		}
+		if output.DomainName != nil {
+			info.Name = *output.DomainName
+		}
+		// The network access type defaults to PublicInternetOnly when it is not set
+		if info.AppNetworkAccessType == "" {
+			info.AppNetworkAccessType = string(types.AppNetworkAccessTypePublicInternetOnly)
+		}
+		if output.DefaultUserSettings != nil {
+			info.ExecutionRole = aws.ToString(output.DefaultUserSettings.ExecutionRole)
+		}
+		return info, nil
+	})
+}
+
+// ListUserProfileSecurity returns the execution role of every Studio user profile.
+// Profiles without their own role use the default role of their domain.
+func (c *clientImpl) ListUserProfileSecurity(ctx context.Context) ([]SecurityInfo, error) {
+	var profiles []types.UserProfileDetails
+
+	retrier := retry.NewRetrier(retry.DefaultConfig)
+	err := retrier.Do(ctx, func() error {
+		profiles = nil
+		input := &sagemaker.ListUserProfilesInput{}
+		for {
+			output, err := c.client.ListUserProfiles(ctx, input)
+			if err != nil {
+				return WrapError(err)
+			}
+			profiles = append(profiles, output.UserProfiles...)
+			if output.NextToken == nil {
+				return nil
+			}
+			input.NextToken = output.NextToken
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(profiles))
+	profilesByKey := make(map[string]types.UserProfileDetails, len(profiles))
+	for _, profile := range profiles {
+		if profile.DomainId == nil || profile.UserProfileName == nil {
+			continue
+		}
+		key := *profile.DomainId + "/" + *profile.UserProfileName
+		keys = append(keys, key)
+		profilesByKey[key] = profile
+	}
+
+	var (
+		mu          sync.Mutex
+		domainRoles = make(map[string]string)
+	)
+	domainRole := func(ctx context.Context, domainID string) (string, error) {
+		mu.Lock()
+		role, ok := domainRoles[domainID]
+		mu.Unlock()
+		if ok {
+			return role, nil
+		}
+		output, err := c.describeDomain(ctx, retrier, domainID)
+		if err != nil {
+			return "", err
+		}
+		if output.DefaultUserSettings != nil {
+			role = aws.ToString(output.DefaultUserSettings.ExecutionRole)
+		}
+		mu.Lock()
+		domainRoles[domainID] = role
+		mu.Unlock()
+		return role, nil
+	}
+
+	return describeSecurity(ctx, keys, func(ctx context.Context, key string) (SecurityInfo, error) {
+		profile := profilesByKey[key]
+		var output *sagemaker.DescribeUserProfileOutput
+		err := retrier.Do(ctx, func() error {
+			var err error
+			output, err = c.client.DescribeUserProfile(ctx, &sagemaker.DescribeUserProfileInput{
+				DomainId:        profile.DomainId,
+				UserProfileName: profile.UserProfileName,
+			})
+			return WrapError(err)
+		})
+		if err != nil {
+			return SecurityInfo{}, fmt.Errorf("failed to describe user profile %s: %w", key, err)
+		}
+
+		info := SecurityInfo{ResourceType: "UserProfile", Name: key}
+		if output.UserSettings != nil {
+			info.ExecutionRole = aws.ToString(output.UserSettings.ExecutionRole)
+		}
+		if info.ExecutionRole == "" {
+			info.ExecutionRole, err = domainRole(ctx, *profile.DomainId)
+			if err != nil {
+				return SecurityInfo{}, err
+			}
+		}
+		return info, nil
+	})
+}
+
+// listDomainIDs returns the IDs of every Studio domain
+func (c *clientImpl) listDomainIDs(ctx context.Context, retrier *retry.Retrier) ([]string, error) {
+	var domainIDs []string
+	err := retrier.Do(ctx, func() error {
+		domainIDs = nil
+		input := &sagemaker.ListDomainsInput{}
+		for {
+			output, err := c.client.ListDomains(ctx, input)
+			if err != nil {
+				return WrapError(err)
+			}
+			for _, domain := range output.Domains {
+				if domain.DomainId != nil {
+					domainIDs = append(domainIDs, *domain.DomainId)
+				}
+			}
+			if output.NextToken == nil {
+				return nil
+			}
+			input.NextToken = output.NextToken
+		}
+	})
+	return domainIDs, err
+}
+
+// describeDomain describes a single Studio domain
+func (c *clientImpl) describeDomain(ctx context.Context, retrier *retry.Retrier, domainID string) (*sagemaker.DescribeDomainOutput, error) {
+	var output *sagemaker.DescribeDomainOutput
+	err := retrier.Do(ctx, func() error {
+		var err error
+		output, err = c.client.DescribeDomain(ctx, &sagemaker.DescribeDomainInput{
+			DomainId: aws.String(domainID),
+		})
+		return WrapError(err)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe domain %s: %w", domainID, err)
+	}
+	return output, nil
+}
+
+// describeSecurity calls describe for every item concurrently and returns the
+// results in the order of items
+func describeSecurity(ctx context.Context, items []string, describe func(ctx context.Context, item string) (SecurityInfo, error)) ([]SecurityInfo, error) {
+	var mu sync.Mutex
+	infoByItem := make(map[string]SecurityInfo, len(items))
+
+	err := forEachConcurrently(ctx, items, func(ctx context.Context, item string) error {
+		info, err := describe(ctx, item)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		infoByItem[item] = info
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]SecurityInfo, 0, len(items))
+	for _, item := range items {
+		infos = append(infos, infoByItem[item])
+	}
+	return infos, nil
+}
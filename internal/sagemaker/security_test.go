@@ -0,0 +1,128 @@
+package sagemaker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListNotebookSecurity(t *testing.T) {
+	ctx := context.Background()
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListNotebookInstances", ctx, &sagemaker.ListNotebookInstancesInput{}, mock.Anything).
+		Return(&sagemaker.ListNotebookInstancesOutput{
+			NotebookInstances: []types.NotebookInstanceSummary{{NotebookInstanceName: aws.String("nb")}},
+		}, nil)
+	mockClient.On("DescribeNotebookInstance", mock.Anything, &sagemaker.DescribeNotebookInstanceInput{NotebookInstanceName: aws.String("nb")}, mock.Anything).
+		Return(&sagemaker.DescribeNotebookInstanceOutput{
+			RootAccess:           types.RootAccessEnabled,
+			DirectInternetAccess: types.DirectInternetAccessDisabled,
+			SubnetId:             aws.String("subnet-1"),
+		}, nil)
+
+	client := &clientImpl{client: mockClient}
+	infos, err := client.ListNotebookSecurity(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []SecurityInfo{
+		{ResourceType: "Notebook", Name: "nb", RootAccess: true, SubnetID: "subnet-1"},
+	}, infos)
+}
+
+func TestListEndpointSecurity(t *testing.T) {
+	ctx := context.Background()
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListEndpoints", ctx, &sagemaker.ListEndpointsInput{}, mock.Anything).
+		Return(&sagemaker.ListEndpointsOutput{
+			Endpoints: []types.EndpointSummary{{EndpointName: aws.String("churn"), EndpointStatus: types.EndpointStatusInService}},
+		}, nil)
+	mockClient.On("DescribeEndpoint", mock.Anything, &sagemaker.DescribeEndpointInput{EndpointName: aws.String("churn")}, mock.Anything).
+		Return(&sagemaker.DescribeEndpointOutput{EndpointConfigName: aws.String("churn-config")}, nil)
+	mockClient.On("DescribeEndpointConfig", mock.Anything, &sagemaker.DescribeEndpointConfigInput{EndpointConfigName: aws.String("churn-config")}, mock.Anything).
+		Return(&sagemaker.DescribeEndpointConfigOutput{
+			KmsKeyId: aws.String("key"),
+			ProductionVariants: []types.ProductionVariant{
+				{ModelName: aws.String("isolated")},
+				{ModelName: aws.String("open")},
+				{VariantName: aws.String("components")},
+			},
+		}, nil)
+	mockClient.On("DescribeModel", mock.Anything, &sagemaker.DescribeModelInput{ModelName: aws.String("isolated")}, mock.Anything).
+		Return(&sagemaker.DescribeModelOutput{EnableNetworkIsolation: aws.Bool(true)}, nil)
+	mockClient.On("DescribeModel", mock.Anything, &sagemaker.DescribeModelInput{ModelName: aws.String("open")}, mock.Anything).
+		Return(&sagemaker.DescribeModelOutput{}, nil)
+
+	client := &clientImpl{client: mockClient}
+	infos, err := client.ListEndpointSecurity(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []SecurityInfo{
+		{ResourceType: "Endpoint", Name: "churn", KmsKeyID: "key", UnisolatedModels: []string{"open"}},
+	}, infos)
+}
+
+func TestListDomainSecurity(t *testing.T) {
+	ctx := context.Background()
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListDomains", ctx, &sagemaker.ListDomainsInput{}, mock.Anything).
+		Return(&sagemaker.ListDomainsOutput{Domains: []types.DomainDetails{{DomainId: aws.String("d-1")}}}, nil)
+	mockClient.On("DescribeDomain", mock.Anything, &sagemaker.DescribeDomainInput{DomainId: aws.String("d-1")}, mock.Anything).
+		Return(&sagemaker.DescribeDomainOutput{
+			DomainName:          aws.String("research"),
+			DefaultUserSettings: &types.UserSettings{ExecutionRole: aws.String("arn:aws:iam::123456789012:role/Default")},
+		}, nil)
+
+	client := &clientImpl{client: mockClient}
+	infos, err := client.ListDomainSecurity(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []SecurityInfo{
+		{
+			ResourceType:         "Domain",
+			Name:                 "research",
+			AppNetworkAccessType: "PublicInternetOnly",
+			ExecutionRole:        "arn:aws:iam::123456789012:role/Default",
+		},
+	}, infos)
+}
+
+func TestListUserProfileSecurity(t *testing.T) {
+	ctx := context.Background()
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListUserProfiles", ctx, &sagemaker.ListUserProfilesInput{}, mock.Anything).
+		Return(&sagemaker.ListUserProfilesOutput{
+			UserProfiles: []types.UserProfileDetails{
+				{DomainId: aws.String("d-1"), UserProfileName: aws.String("alice")},
+				{DomainId: aws.String("d-1"), UserProfileName: aws.String("bob")},
+			},
+		}, nil)
+	mockClient.On("DescribeUserProfile", mock.Anything, &sagemaker.DescribeUserProfileInput{DomainId: aws.String("d-1"), UserProfileName: aws.String("alice")}, mock.Anything).
+		Return(&sagemaker.DescribeUserProfileOutput{
+			UserSettings: &types.UserSettings{ExecutionRole: aws.String("arn:aws:iam::123456789012:role/Admin")},
+		}, nil)
+	mockClient.On("DescribeUserProfile", mock.Anything, &sagemaker.DescribeUserProfileInput{DomainId: aws.String("d-1"), UserProfileName: aws.String("bob")}, mock.Anything).
+		Return(&sagemaker.DescribeUserProfileOutput{}, nil)
+	mockClient.On("DescribeDomain", mock.Anything, &sagemaker.DescribeDomainInput{DomainId: aws.String("d-1")}, mock.Anything).
+		Return(&sagemaker.DescribeDomainOutput{
+			DefaultUserSettings: &types.UserSettings{ExecutionRole: aws.String("arn:aws:iam::123456789012:role/Default")},
+		}, nil).Once()
+
+	client := &clientImpl{client: mockClient}
+	infos, err := client.ListUserProfileSecurity(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []SecurityInfo{
+		{ResourceType: "UserProfile", Name: "d-1/alice", ExecutionRole: "arn:aws:iam::123456789012:role/Admin"},
+		{ResourceType: "UserProfile", Name: "d-1/bob", ExecutionRole: "arn:aws:iam::123456789012:role/Default"},
+	}, infos)
+	mockClient.AssertExpectations(t)
+}
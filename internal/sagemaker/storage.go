@@ -0,0 +1,266 @@
+package sagemaker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker/types"
+	"mohua/internal/retry"
+)
+
+// StorageInfo describes a storage volume attached to a SageMaker resource
+type StorageInfo struct {
+	ResourceType string // Notebook, Space or Domain
+	Name         string
+	Status       string    // State of the resource the storage is attached to
+	VolumeSizeGB int       // Zero when the size is not reported by SageMaker
+	VolumeID     string    // EFS file system ID for domain home volumes
+	StoppedSince time.Time // Zero while the attached resource is running or when unknown
+}
+
+// ListNotebookVolumes returns the ML storage volume of every notebook instance, regardless of its state
+func (c *clientImpl) ListNotebookVolumes(ctx context.Context) ([]StorageInfo, error) {
+	var notebooks []types.NotebookInstanceSummary
+
+	retrier := retry.NewRetrier(retry.DefaultConfig)
+	err := retrier.Do(ctx, func() error {
+		notebooks = nil
+		input := &sagemaker.ListNotebookInstancesInput{}
+		for {
+			output, err := c.client.ListNotebookInstances(ctx, input)
+			if err != nil {
+				return WrapError(err)
+			}
+			notebooks = append(notebooks, output.NotebookInstances...)
+			if output.NextToken == nil {
+				return nil
+			}
+			input.NextToken = output.NextToken
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	volumes := make([]StorageInfo, 0, len(notebooks))
+	for _, notebook := range notebooks {
+		if notebook.NotebookInstanceName == nil {
+			continue
+		}
+		name := *notebook.NotebookInstanceName
+
+		var output *sagemaker.DescribeNotebookInstanceOutput
+		err := retrier.Do(ctx, func() error {
+			var err error
+			output, err = c.client.DescribeNotebookInstance(ctx, &sagemaker.DescribeNotebookInstanceInput{
+				NotebookInstanceName: aws.String(name),
+			})
+			return WrapError(err)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe notebook instance %s: %w", name, err)
+		}
+
+		volume := StorageInfo{
+			ResourceType: "Notebook",
+			Name:         name,
+			Status:       string(output.NotebookInstanceStatus),
+		}
+		if output.VolumeSizeInGB != nil {
+			volume.VolumeSizeGB = int(*output.VolumeSizeInGB)
+		}
+		if output.NotebookInstanceStatus == types.NotebookInstanceStatusStopped && output.LastModifiedTime != nil {
+			volume.StoppedSince = *output.LastModifiedTime
+		}
+		volumes = append(volumes, volume)
+	}
+
+	return volumes, nil
+}
+
+// ListSpaceVolumes returns the EBS volume of every Studio space. A space counts as
+// stopped when none of its apps are in service, and has been stopped since the last
+// user activity on its newest app. The space's own LastModifiedTime is not used as
+// any settings change resets it.
+func (c *clientImpl) ListSpaceVolumes(ctx context.Context) ([]StorageInfo, error) {
+	var spaces []types.SpaceDetails
+	runningSpaces := make(map[string]bool)
+	newestApps := make(map[string]types.AppDetails)
+
+	retrier := retry.NewRetrier(retry.DefaultConfig)
+	err := retrier.Do(ctx, func() error {
+		spaces = nil
+		input := &sagemaker.ListSpacesInput{}
+		for {
+			output, err := c.client.ListSpaces(ctx, input)
+			if err != nil {
+				return WrapError(err)
+			}
+			spaces = append(spaces, output.Spaces...)
+			if output.NextToken == nil {
+				return nil
+			}
+			input.NextToken = output.NextToken
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = retrier.Do(ctx, func() error {
+		input := &sagemaker.ListAppsInput{}
+		for {
+			output, err := c.client.ListApps(ctx, input)
+			if err != nil {
+				return WrapError(err)
+			}
+			for _, app := range output.Apps {
+				if app.SpaceName == nil || app.DomainId == nil {
+					continue
+				}
+				key := *app.DomainId + "/" + *app.SpaceName
+				if app.Status == types.AppStatusInService {
+					runningSpaces[key] = true
+				}
+				if newest, ok := newestApps[key]; !ok || aws.ToTime(app.CreationTime).After(aws.ToTime(newest.CreationTime)) {
+					newestApps[key] = app
+				}
+			}
+			if output.NextToken == nil {
+				return nil
+			}
+			input.NextToken = output.NextToken
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	volumes := make([]StorageInfo, 0, len(spaces))
+	for _, space := range spaces {
+		if space.SpaceName == nil {
+			continue
+		}
+
+		var domainID string
+		if space.DomainId != nil {
+			domainID = *space.DomainId
+		}
+
+		volume := StorageInfo{
+			ResourceType: "Space",
+			Name:         *space.SpaceName,
+			Status:       "Stopped",
+		}
+		if summary := space.SpaceSettingsSummary; summary != nil && summary.SpaceStorageSettings != nil &&
+			summary.SpaceStorageSettings.EbsStorageSettings != nil &&
+			summary.SpaceStorageSettings.EbsStorageSettings.EbsVolumeSizeInGb != nil {
+			volume.VolumeSizeGB = int(*summary.SpaceStorageSettings.EbsStorageSettings.EbsVolumeSizeInGb)
+		}
+		key := domainID + "/" + volume.Name
+		if runningSpaces[key] {
+			volume.Status = "Running"
+		} else if app, ok := newestApps[key]; ok {
+			stoppedSince, err := c.lastAppActivity(ctx, retrier, app)
+			if err != nil {
+				return nil, fmt.Errorf("failed to describe app %s of space %s: %w", aws.ToString(app.AppName), volume.Name, err)
+			}
+			volume.StoppedSince = stoppedSince
+		}
+		volumes = append(volumes, volume)
+	}
+
+	return volumes, nil
+}
+
+// lastAppActivity returns the last user activity on a space app, falling back to its
+// last health check and then its creation time when SageMaker has not recorded activity
+func (c *clientImpl) lastAppActivity(ctx context.Context, retrier *retry.Retrier, app types.AppDetails) (time.Time, error) {
+	var output *sagemaker.DescribeAppOutput
+	err := retrier.Do(ctx, func() error {
+		var err error
+		output, err = c.client.DescribeApp(ctx, &sagemaker.DescribeAppInput{
+			DomainId:  app.DomainId,
+			AppType:   app.AppType,
+			AppName:   app.AppName,
+			SpaceName: app.SpaceName,
+		})
+		return WrapError(err)
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	switch {
+	case output.LastUserActivityTimestamp != nil:
+		return *output.LastUserActivityTimestamp, nil
+	case output.LastHealthCheckTimestamp != nil:
+		return *output.LastHealthCheckTimestamp, nil
+	case output.CreationTime != nil:
+		return *output.CreationTime, nil
+	}
+	return aws.ToTime(app.CreationTime), nil
+}
+
+// ListDomainVolumes returns the EFS home volume of every Studio domain
+func (c *clientImpl) ListDomainVolumes(ctx context.Context) ([]StorageInfo, error) {
+	var domains []types.DomainDetails
+
+	retrier := retry.NewRetrier(retry.DefaultConfig)
+	err := retrier.Do(ctx, func() error {
+		domains = nil
+		input := &sagemaker.ListDomainsInput{}
+		for {
+			output, err := c.client.ListDomains(ctx, input)
+			if err != nil {
+				return WrapError(err)
+			}
+			domains = append(domains, output.Domains...)
+			if output.NextToken == nil {
+				return nil
+			}
+			input.NextToken = output.NextToken
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	volumes := make([]StorageInfo, 0, len(domains))
+	for _, domain := range domains {
+		if domain.DomainId == nil {
+			continue
+		}
+		domainID := *domain.DomainId
+
+		var output *sagemaker.DescribeDomainOutput
+		err := retrier.Do(ctx, func() error {
+			var err error
+			output, err = c.client.DescribeDomain(ctx, &sagemaker.DescribeDomainInput{
+				DomainId: aws.String(domainID),
+			})
+			return WrapError(err)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe domain %s: %w", domainID, err)
+		}
+
+		volume := StorageInfo{
+			ResourceType: "Domain",
+			Name:         domainID,
+			Status:       string(output.Status),
+		}
+		if output.DomainName != nil {
+			volume.Name = *output.DomainName
+		}
+		if output.HomeEfsFileSystemId != nil {
+			volume.VolumeID = *output.HomeEfsFileSystemId
+		}
+		volumes = append(volumes, volume)
+	}
+
+	return volumes, nil
+}
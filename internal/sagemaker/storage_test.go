@@ -0,0 +1,154 @@
+package sagemaker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker/types"
+	"github.com/aws/smithy-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListNotebookVolumes(t *testing.T) {
+	ctx := context.Background()
+	stoppedAt := time.Now().Add(-10 * 24 * time.Hour)
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListNotebookInstances", ctx, &sagemaker.ListNotebookInstancesInput{}, mock.Anything).
+		Return(&sagemaker.ListNotebookInstancesOutput{
+			NotebookInstances: []types.NotebookInstanceSummary{
+				{NotebookInstanceName: aws.String("running"), NotebookInstanceStatus: types.NotebookInstanceStatusInService},
+				{NotebookInstanceName: aws.String("stopped"), NotebookInstanceStatus: types.NotebookInstanceStatusStopped},
+			},
+		}, nil)
+	mockClient.On("DescribeNotebookInstance", ctx, &sagemaker.DescribeNotebookInstanceInput{NotebookInstanceName: aws.String("running")}, mock.Anything).
+		Return(&sagemaker.DescribeNotebookInstanceOutput{
+			NotebookInstanceStatus: types.NotebookInstanceStatusInService,
+			VolumeSizeInGB:         aws.Int32(5),
+			LastModifiedTime:       aws.Time(stoppedAt),
+		}, nil)
+	mockClient.On("DescribeNotebookInstance", ctx, &sagemaker.DescribeNotebookInstanceInput{NotebookInstanceName: aws.String("stopped")}, mock.Anything).
+		Return(&sagemaker.DescribeNotebookInstanceOutput{
+			NotebookInstanceStatus: types.NotebookInstanceStatusStopped,
+			VolumeSizeInGB:         aws.Int32(100),
+			LastModifiedTime:       aws.Time(stoppedAt),
+		}, nil)
+
+	client := &clientImpl{client: mockClient}
+	volumes, err := client.ListNotebookVolumes(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []StorageInfo{
+		{ResourceType: "Notebook", Name: "running", Status: "InService", VolumeSizeGB: 5},
+		{ResourceType: "Notebook", Name: "stopped", Status: "Stopped", VolumeSizeGB: 100, StoppedSince: stoppedAt},
+	}, volumes)
+	mockClient.AssertExpectations(t)
+}
+
+func TestListSpaceVolumes(t *testing.T) {
+	ctx := context.Background()
+	modifiedAt := time.Now().Add(-time.Hour)
+	lastActivity := time.Now().Add(-48 * time.Hour)
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListSpaces", ctx, &sagemaker.ListSpacesInput{}, mock.Anything).
+		Return(&sagemaker.ListSpacesOutput{
+			Spaces: []types.SpaceDetails{
+				{
+					DomainId:         aws.String("d-1"),
+					SpaceName:        aws.String("active"),
+					LastModifiedTime: aws.Time(modifiedAt),
+					SpaceSettingsSummary: &types.SpaceSettingsSummary{
+						SpaceStorageSettings: &types.SpaceStorageSettings{
+							EbsStorageSettings: &types.EbsStorageSettings{EbsVolumeSizeInGb: aws.Int32(50)},
+						},
+					},
+				},
+				{
+					DomainId:         aws.String("d-1"),
+					SpaceName:        aws.String("idle"),
+					LastModifiedTime: aws.Time(modifiedAt),
+				},
+				{
+					DomainId:         aws.String("d-1"),
+					SpaceName:        aws.String("unused"),
+					LastModifiedTime: aws.Time(modifiedAt),
+				},
+			},
+		}, nil)
+	mockClient.On("ListApps", ctx, &sagemaker.ListAppsInput{}, mock.Anything).
+		Return(&sagemaker.ListAppsOutput{
+			Apps: []types.AppDetails{
+				{DomainId: aws.String("d-1"), SpaceName: aws.String("active"), Status: types.AppStatusInService},
+				{DomainId: aws.String("d-1"), SpaceName: aws.String("idle"), AppName: aws.String("old"), AppType: types.AppTypeJupyterLab,
+					Status: types.AppStatusDeleted, CreationTime: aws.Time(time.Now().Add(-30 * 24 * time.Hour))},
+				{DomainId: aws.String("d-1"), SpaceName: aws.String("idle"), AppName: aws.String("default"), AppType: types.AppTypeJupyterLab,
+					Status: types.AppStatusDeleted, CreationTime: aws.Time(time.Now().Add(-72 * time.Hour))},
+			},
+		}, nil)
+	// Only the newest app of a stopped space is described
+	mockClient.On("DescribeApp", ctx, &sagemaker.DescribeAppInput{
+		DomainId:  aws.String("d-1"),
+		AppType:   types.AppTypeJupyterLab,
+		AppName:   aws.String("default"),
+		SpaceName: aws.String("idle"),
+	}, mock.Anything).
+		Return(&sagemaker.DescribeAppOutput{LastUserActivityTimestamp: aws.Time(lastActivity)}, nil).Once()
+
+	client := &clientImpl{client: mockClient}
+	volumes, err := client.ListSpaceVolumes(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []StorageInfo{
+		{ResourceType: "Space", Name: "active", Status: "Running", VolumeSizeGB: 50},
+		{ResourceType: "Space", Name: "idle", Status: "Stopped", StoppedSince: lastActivity},
+		{ResourceType: "Space", Name: "unused", Status: "Stopped"},
+	}, volumes)
+	mockClient.AssertExpectations(t)
+}
+
+func TestListDomainVolumes(t *testing.T) {
+	ctx := context.Background()
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListDomains", ctx, &sagemaker.ListDomainsInput{}, mock.Anything).
+		Return(&sagemaker.ListDomainsOutput{
+			Domains: []types.DomainDetails{{DomainId: aws.String("d-1")}},
+		}, nil)
+	mockClient.On("DescribeDomain", ctx, &sagemaker.DescribeDomainInput{DomainId: aws.String("d-1")}, mock.Anything).
+		Return(&sagemaker.DescribeDomainOutput{
+			DomainName:          aws.String("research"),
+			Status:              types.DomainStatusInService,
+			HomeEfsFileSystemId: aws.String("fs-0123"),
+		}, nil)
+
+	client := &clientImpl{client: mockClient}
+	volumes, err := client.ListDomainVolumes(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []StorageInfo{
+		{ResourceType: "Domain", Name: "research", Status: "InService", VolumeID: "fs-0123"},
+	}, volumes)
+}
+
+func TestListDomainVolumes_DescribeError(t *testing.T) {
+	ctx := context.Background()
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListDomains", ctx, &sagemaker.ListDomainsInput{}, mock.Anything).
+		Return(&sagemaker.ListDomainsOutput{
+			Domains: []types.DomainDetails{{DomainId: aws.String("d-1")}},
+		}, nil)
+	mockClient.On("DescribeDomain", ctx, mock.Anything, mock.Anything).
+		Return(nil, &smithy.GenericAPIError{Code: "ValidationError", Message: "bad domain"})
+
+	client := &clientImpl{client: mockClient}
+	volumes, err := client.ListDomainVolumes(ctx)
+
+	assert.ErrorContains(t, err, "failed to describe domain d-1")
+	assert.Nil(t, volumes)
+}
@@ -0,0 +1,102 @@
+package sagemaker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker/types"
+	"mohua/internal/retry"
+)
+
+// ListTrainingJobs returns in-progress training jobs, including those started by
+// tuning jobs, AutoML jobs and pipelines, with the instances they run on
+func (c *clientImpl) ListTrainingJobs(ctx context.Context) ([]ResourceInfo, error) {
+	var jobNames []string
+
+	retrier := retry.NewRetrier(retry.DefaultConfig)
+	err := retrier.Do(ctx, func() error {
+		jobNames = nil
+		input := &sagemaker.ListTrainingJobsInput{StatusEquals: types.TrainingJobStatusInProgress}
+		for {
+			output, err := c.client.ListTrainingJobs(ctx, input)
+			if err != nil {
+				return WrapError(err)
+			}
+			for _, job := range output.TrainingJobSummaries {
+				if job.TrainingJobName != nil {
+					jobNames = append(jobNames, *job.TrainingJobName)
+				}
+			}
+			if output.NextToken == nil {
+				return nil
+			}
+			input.NextToken = output.NextToken
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var mu sync.Mutex
+	resourcesByName := make(map[string]ResourceInfo, len(jobNames))
+	err = forEachConcurrently(ctx, jobNames, func(ctx context.Context, name string) error {
+		resource, err := c.describeTrainingJob(ctx, retrier, name)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		resourcesByName[name] = resource
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]ResourceInfo, 0, len(jobNames))
+	for _, name := range jobNames {
+		resources = append(resources, resourcesByName[name])
+	}
+	return resources, nil
+}
+
+// describeTrainingJob builds the resource entry of a single training job
+func (c *clientImpl) describeTrainingJob(ctx context.Context, retrier *retry.Retrier, name string) (ResourceInfo, error) {
+	var output *sagemaker.DescribeTrainingJobOutput
+	err := retrier.Do(ctx, func() error {
+		var err error
+		output, err = c.client.DescribeTrainingJob(ctx, &sagemaker.DescribeTrainingJobInput{TrainingJobName: aws.String(name)})
+		return WrapError(err)
+	})
+	if err != nil {
+		return ResourceInfo{}, fmt.Errorf("failed to describe training job %s: %w", name, err)
+	}
+
+	resource := ResourceInfo{
+		Name:   name,
+		Arn:    aws.ToString(output.TrainingJobArn),
+		Status: string(output.TrainingJobStatus),
+		Spot:   aws.ToBool(output.EnableManagedSpotTraining),
+	}
+	if output.CreationTime != nil {
+		resource.CreationTime = *output.CreationTime
+	}
+	if config := output.ResourceConfig; config != nil {
+		if config.InstanceType != "" {
+			resource.InstanceType = string(config.InstanceType)
+			resource.InstanceCount = int(aws.ToInt32(config.InstanceCount))
+		} else if len(config.InstanceGroups) > 0 {
+			// Heterogeneous clusters define one instance group per type
+			resource.InstanceType = "multiple"
+			resource.InstanceCounts = make(map[string]int)
+			for _, group := range config.InstanceGroups {
+				resource.InstanceCounts[string(group.InstanceType)] += int(aws.ToInt32(group.InstanceCount))
+				resource.InstanceCount += int(aws.ToInt32(group.InstanceCount))
+			}
+		}
+	}
+	return resource, nil
+}
@@ -0,0 +1,97 @@
+package sagemaker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListTrainingJobs(t *testing.T) {
+	ctx := context.Background()
+	created := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListTrainingJobs", ctx, &sagemaker.ListTrainingJobsInput{StatusEquals: types.TrainingJobStatusInProgress}, mock.Anything).
+		Return(&sagemaker.ListTrainingJobsOutput{
+			TrainingJobSummaries: []types.TrainingJobSummary{{TrainingJobName: aws.String("xgb")}},
+			NextToken:            aws.String("page2"),
+		}, nil).Once()
+	mockClient.On("ListTrainingJobs", ctx, &sagemaker.ListTrainingJobsInput{StatusEquals: types.TrainingJobStatusInProgress, NextToken: aws.String("page2")}, mock.Anything).
+		Return(&sagemaker.ListTrainingJobsOutput{
+			TrainingJobSummaries: []types.TrainingJobSummary{{TrainingJobName: aws.String("hetero")}},
+		}, nil).Once()
+	mockClient.On("DescribeTrainingJob", mock.Anything, &sagemaker.DescribeTrainingJobInput{TrainingJobName: aws.String("xgb")}, mock.Anything).
+		Return(&sagemaker.DescribeTrainingJobOutput{
+			TrainingJobArn:            aws.String("arn:aws:sagemaker:us-east-1:123456789012:training-job/xgb"),
+			TrainingJobStatus:         types.TrainingJobStatusInProgress,
+			CreationTime:              aws.Time(created),
+			EnableManagedSpotTraining: aws.Bool(true),
+			ResourceConfig:            &types.ResourceConfig{InstanceType: types.TrainingInstanceTypeMlM5Xlarge, InstanceCount: aws.Int32(2)},
+		}, nil)
+	mockClient.On("DescribeTrainingJob", mock.Anything, &sagemaker.DescribeTrainingJobInput{TrainingJobName: aws.String("hetero")}, mock.Anything).
+		Return(&sagemaker.DescribeTrainingJobOutput{
+			TrainingJobStatus: types.TrainingJobStatusInProgress,
+			ResourceConfig: &types.ResourceConfig{InstanceGroups: []types.InstanceGroup{
+				{InstanceType: types.TrainingInstanceTypeMlP4d24xlarge, InstanceCount: aws.Int32(2)},
+				{InstanceType: types.TrainingInstanceTypeMlC5Xlarge, InstanceCount: aws.Int32(4)},
+			}},
+		}, nil)
+
+	client := &clientImpl{client: mockClient}
+	resources, err := client.ListTrainingJobs(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []ResourceInfo{
+		{
+			Name:          "xgb",
+			Arn:           "arn:aws:sagemaker:us-east-1:123456789012:training-job/xgb",
+			Status:        "InProgress",
+			InstanceType:  "ml.m5.xlarge",
+			InstanceCount: 2,
+			CreationTime:  created,
+			Spot:          true,
+		},
+		{
+			Name:           "hetero",
+			Status:         "InProgress",
+			InstanceType:   "multiple",
+			InstanceCount:  6,
+			InstanceCounts: map[string]int{"ml.p4d.24xlarge": 2, "ml.c5.xlarge": 4},
+		},
+	}, resources)
+	mockClient.AssertExpectations(t)
+}
+
+func TestListTrainingJobs_DescribeError(t *testing.T) {
+	ctx := context.Background()
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListTrainingJobs", ctx, mock.Anything, mock.Anything).
+		Return(&sagemaker.ListTrainingJobsOutput{
+			TrainingJobSummaries: []types.TrainingJobSummary{{TrainingJobName: aws.String("xgb")}},
+		}, nil)
+	mockClient.On("DescribeTrainingJob", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("access denied"))
+
+	client := &clientImpl{client: mockClient}
+	_, err := client.ListTrainingJobs(ctx)
+
+	assert.ErrorContains(t, err, "failed to describe training job xgb")
+}
+
+func TestResourceInfoInstances(t *testing.T) {
+	assert.Equal(t, map[string]int{"ml.m5.xlarge": 1}, ResourceInfo{InstanceType: "ml.m5.xlarge"}.Instances())
+	assert.Equal(t, map[string]int{"ml.m5.xlarge": 3}, ResourceInfo{InstanceType: "ml.m5.xlarge", InstanceCount: 3}.Instances())
+	assert.Nil(t, ResourceInfo{InstanceType: "unknown"}.Instances())
+	assert.Nil(t, ResourceInfo{}.Instances())
+
+	mixed := ResourceInfo{InstanceType: "multiple", InstanceCounts: map[string]int{"ml.m5.xlarge": 2, "ml.t3.medium": 1}}
+	assert.InDelta(t, 2*0.23+0.05, mixed.EstimatedHourlyCost(), 1e-9)
+}
@@ -0,0 +1,256 @@
+package sagemaker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker/types"
+	"mohua/internal/pricing"
+	"mohua/internal/retry"
+)
+
+// TuningRollup aggregates the training jobs launched by a hyperparameter tuning job
+type TuningRollup struct {
+	Objective     string // Metric name and direction, e.g. "validation:auc (Maximize)"
+	BestMetric    *float64
+	Completed     int
+	InProgress    int
+	Failed        int
+	InstanceHours float64
+	EstimatedCost float64 // Zero when the instance type is not in the pricing table
+}
+
+// instanceSpec is the instance type and count used by a training job definition
+type instanceSpec struct {
+	instanceType string
+	count        int
+}
+
+// ListTuningJobs returns in-progress hyperparameter tuning jobs with a rollup of their training jobs
+func (c *clientImpl) ListTuningJobs(ctx context.Context) ([]ResourceInfo, error) {
+	var jobs []types.HyperParameterTuningJobSummary
+
+	retrier := retry.NewRetrier(retry.DefaultConfig)
+	err := retrier.Do(ctx, func() error {
+		jobs = nil
+		input := &sagemaker.ListHyperParameterTuningJobsInput{
+			StatusEquals: types.HyperParameterTuningJobStatusInProgress,
+		}
+		for {
+			output, err := c.client.ListHyperParameterTuningJobs(ctx, input)
+			if err != nil {
+				return WrapError(err)
+			}
+			jobs = append(jobs, output.HyperParameterTuningJobSummaries...)
+			if output.NextToken == nil {
+				return nil
+			}
+			input.NextToken = output.NextToken
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var jobNames []string
+	for _, job := range jobs {
+		if job.HyperParameterTuningJobName != nil {
+			jobNames = append(jobNames, *job.HyperParameterTuningJobName)
+		}
+	}
+
+	var mu sync.Mutex
+	resourcesByName := make(map[string]ResourceInfo, len(jobNames))
+	now := time.Now()
+
+	err = forEachConcurrently(ctx, jobNames, func(ctx context.Context, name string) error {
+		resource, err := c.describeTuningJob(ctx, retrier, name, now)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		resourcesByName[name] = resource
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]ResourceInfo, 0, len(jobNames))
+	for _, name := range jobNames {
+		resources = append(resources, resourcesByName[name])
+	}
+	return resources, nil
+}
+
+// describeTuningJob builds the resource entry of a single tuning job
+func (c *clientImpl) describeTuningJob(ctx context.Context, retrier *retry.Retrier, name string, now time.Time) (ResourceInfo, error) {
+	var output *sagemaker.DescribeHyperParameterTuningJobOutput
+	err := retrier.Do(ctx, func() error {
+		var err error
+		output, err = c.client.DescribeHyperParameterTuningJob(ctx, &sagemaker.DescribeHyperParameterTuningJobInput{
+			HyperParameterTuningJobName: aws.String(name),
+		})
+		return WrapError(err)
+	})
+	if err != nil {
+		return ResourceInfo{}, fmt.Errorf("failed to describe tuning job %s: %w", name, err)
+	}
+
+	var children []types.HyperParameterTrainingJobSummary
+	err = retrier.Do(ctx, func() error {
+		children = nil
+		input := &sagemaker.ListTrainingJobsForHyperParameterTuningJobInput{
+			HyperParameterTuningJobName: aws.String(name),
+		}
+		for {
+			page, err := c.client.ListTrainingJobsForHyperParameterTuningJob(ctx, input)
+			if err != nil {
+				return WrapError(err)
+			}
+			children = append(children, page.TrainingJobSummaries...)
+			if page.NextToken == nil {
+				return nil
+			}
+			input.NextToken = page.NextToken
+		}
+	})
+	if err != nil {
+		return ResourceInfo{}, fmt.Errorf("failed to list training jobs of tuning job %s: %w", name, err)
+	}
+
+	specs := tuningInstanceSpecs(output)
+	rollup := rollupTuningJob(output, children, specs, now)
+
+	resource := ResourceInfo{
+		Name:    name,
+		Arn:     aws.ToString(output.HyperParameterTuningJobArn),
+		Status:  string(output.HyperParameterTuningJobStatus),
+		Details: rollup.String(),
+		Tuning:  &rollup,
+	}
+	if output.CreationTime != nil {
+		resource.CreationTime = *output.CreationTime
+	}
+	if spec, ok := specs[""]; ok {
+		resource.InstanceType = spec.instanceType
+		resource.InstanceCount = spec.count
+	} else if len(specs) > 0 {
+		resource.InstanceType = "multiple"
+	}
+	return resource, nil
+}
+
+// tuningInstanceSpecs maps training job definition names to their instance spec.
+// Single-definition tuning jobs are stored under the empty name.
+func tuningInstanceSpecs(output *sagemaker.DescribeHyperParameterTuningJobOutput) map[string]instanceSpec {
+	specs := make(map[string]instanceSpec)
+	if output.TrainingJobDefinition != nil {
+		if spec, ok := definitionInstanceSpec(*output.TrainingJobDefinition); ok {
+			specs[""] = spec
+		}
+	}
+	for _, definition := range output.TrainingJobDefinitions {
+		if definition.DefinitionName == nil {
+			continue
+		}
+		if spec, ok := definitionInstanceSpec(definition); ok {
+			specs[*definition.DefinitionName] = spec
+		}
+	}
+	return specs
+}
+
+func definitionInstanceSpec(definition types.HyperParameterTrainingJobDefinition) (instanceSpec, bool) {
+	if config := definition.ResourceConfig; config != nil && config.InstanceType != "" {
+		spec := instanceSpec{instanceType: string(config.InstanceType), count: 1}
+		if config.InstanceCount != nil {
+			spec.count = int(*config.InstanceCount)
+		}
+		return spec, true
+	}
+	if config := definition.HyperParameterTuningResourceConfig; config != nil {
+		spec := instanceSpec{instanceType: string(config.InstanceType), count: 1}
+		if spec.instanceType == "" && len(config.InstanceConfigs) > 0 {
+			spec.instanceType = string(config.InstanceConfigs[0].InstanceType)
+			if config.InstanceConfigs[0].InstanceCount != nil {
+				spec.count = int(*config.InstanceConfigs[0].InstanceCount)
+			}
+		} else if config.InstanceCount != nil {
+			spec.count = int(*config.InstanceCount)
+		}
+		return spec, spec.instanceType != ""
+	}
+	return instanceSpec{}, false
+}
+
+// rollupTuningJob aggregates status counts, instance-hours and cost of the child training jobs
+func rollupTuningJob(output *sagemaker.DescribeHyperParameterTuningJobOutput, children []types.HyperParameterTrainingJobSummary, specs map[string]instanceSpec, now time.Time) TuningRollup {
+	var rollup TuningRollup
+
+	if config := output.HyperParameterTuningJobConfig; config != nil && config.HyperParameterTuningJobObjective != nil {
+		objective := config.HyperParameterTuningJobObjective
+		if objective.MetricName != nil {
+			rollup.Objective = fmt.Sprintf("%s (%s)", *objective.MetricName, objective.Type)
+		}
+	}
+	if best := output.BestTrainingJob; best != nil && best.FinalHyperParameterTuningJobObjectiveMetric != nil &&
+		best.FinalHyperParameterTuningJobObjectiveMetric.Value != nil {
+		value := float64(*best.FinalHyperParameterTuningJobObjectiveMetric.Value)
+		rollup.BestMetric = &value
+	}
+
+	for _, child := range children {
+		switch child.TrainingJobStatus {
+		case types.TrainingJobStatusCompleted:
+			rollup.Completed++
+		case types.TrainingJobStatusInProgress:
+			rollup.InProgress++
+		case types.TrainingJobStatusFailed:
+			rollup.Failed++
+		}
+
+		if child.TrainingStartTime == nil {
+			continue
+		}
+		end := now
+		if child.TrainingEndTime != nil {
+			end = *child.TrainingEndTime
+		}
+
+		definitionName := ""
+		if child.TrainingJobDefinitionName != nil {
+			definitionName = *child.TrainingJobDefinitionName
+		}
+		spec, ok := specs[definitionName]
+		if !ok {
+			spec, ok = specs[""]
+		}
+		if !ok {
+			continue
+		}
+
+		hours := end.Sub(*child.TrainingStartTime).Hours() * float64(spec.count)
+		rollup.InstanceHours += hours
+		if price, ok := pricing.HourlyPrice(spec.instanceType); ok {
+			rollup.EstimatedCost += hours * price
+		}
+	}
+
+	return rollup
+}
+
+// String summarizes the rollup for the Details column
+func (r TuningRollup) String() string {
+	best := "n/a"
+	if r.BestMetric != nil {
+		best = fmt.Sprintf("%g", *r.BestMetric)
+	}
+	return fmt.Sprintf("%s best=%s jobs=%d/%d/%d (done/running/failed) %.1f instance-hours $%.2f",
+		r.Objective, best, r.Completed, r.InProgress, r.Failed, r.InstanceHours, r.EstimatedCost)
+}
@@ -0,0 +1,122 @@
+package sagemaker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListTuningJobs(t *testing.T) {
+	ctx := context.Background()
+	created := time.Now().Add(-3 * time.Hour)
+	start := time.Date(2025, 1, 6, 10, 0, 0, 0, time.UTC)
+
+	mockClient := new(MockSageMakerClient)
+	mockClient.On("ListHyperParameterTuningJobs", mock.Anything, &sagemaker.ListHyperParameterTuningJobsInput{
+		StatusEquals: types.HyperParameterTuningJobStatusInProgress,
+	}, mock.Anything).
+		Return(&sagemaker.ListHyperParameterTuningJobsOutput{
+			HyperParameterTuningJobSummaries: []types.HyperParameterTuningJobSummary{
+				{HyperParameterTuningJobName: aws.String("xgb-tuning")},
+			},
+		}, nil)
+	mockClient.On("DescribeHyperParameterTuningJob", mock.Anything, &sagemaker.DescribeHyperParameterTuningJobInput{
+		HyperParameterTuningJobName: aws.String("xgb-tuning"),
+	}, mock.Anything).
+		Return(&sagemaker.DescribeHyperParameterTuningJobOutput{
+			CreationTime:                  aws.Time(created),
+			HyperParameterTuningJobStatus: types.HyperParameterTuningJobStatusInProgress,
+			HyperParameterTuningJobConfig: &types.HyperParameterTuningJobConfig{
+				HyperParameterTuningJobObjective: &types.HyperParameterTuningJobObjective{
+					MetricName: aws.String("validation:auc"),
+					Type:       types.HyperParameterTuningJobObjectiveTypeMaximize,
+				},
+			},
+			BestTrainingJob: &types.HyperParameterTrainingJobSummary{
+				FinalHyperParameterTuningJobObjectiveMetric: &types.FinalHyperParameterTuningJobObjectiveMetric{
+					Value: aws.Float32(0.5),
+				},
+			},
+			TrainingJobDefinition: &types.HyperParameterTrainingJobDefinition{
+				ResourceConfig: &types.ResourceConfig{
+					InstanceType:  types.TrainingInstanceTypeMlM5Xlarge,
+					InstanceCount: aws.Int32(2),
+				},
+			},
+		}, nil)
+	mockClient.On("ListTrainingJobsForHyperParameterTuningJob", mock.Anything, mock.Anything, mock.Anything).
+		Return(&sagemaker.ListTrainingJobsForHyperParameterTuningJobOutput{
+			TrainingJobSummaries: []types.HyperParameterTrainingJobSummary{
+				{
+					TrainingJobStatus: types.TrainingJobStatusCompleted,
+					TrainingStartTime: aws.Time(start),
+					TrainingEndTime:   aws.Time(start.Add(time.Hour)),
+				},
+				{
+					TrainingJobStatus: types.TrainingJobStatusFailed,
+					TrainingStartTime: aws.Time(start),
+					TrainingEndTime:   aws.Time(start.Add(30 * time.Minute)),
+				},
+				{
+					// Not started yet
+					TrainingJobStatus: types.TrainingJobStatusInProgress,
+				},
+			},
+		}, nil)
+
+	client := &clientImpl{client: mockClient}
+	resources, err := client.ListTuningJobs(ctx)
+
+	assert.NoError(t, err)
+	assert.Len(t, resources, 1)
+
+	job := resources[0]
+	assert.Equal(t, "xgb-tuning", job.Name)
+	assert.Equal(t, "InProgress", job.Status)
+	assert.Equal(t, "ml.m5.xlarge", job.InstanceType)
+	assert.Equal(t, 2, job.InstanceCount)
+	assert.Equal(t, created, job.CreationTime)
+
+	assert.NotNil(t, job.Tuning)
+	assert.Equal(t, "validation:auc (Maximize)", job.Tuning.Objective)
+	assert.InDelta(t, 0.5, *job.Tuning.BestMetric, 1e-6)
+	assert.Equal(t, 1, job.Tuning.Completed)
+	assert.Equal(t, 1, job.Tuning.InProgress)
+	assert.Equal(t, 1, job.Tuning.Failed)
+	assert.InDelta(t, 3.0, job.Tuning.InstanceHours, 1e-9)
+	assert.InDelta(t, 3.0*0.23, job.Tuning.EstimatedCost, 1e-9)
+	assert.Equal(t, "validation:auc (Maximize) best=0.5 jobs=1/1/1 (done/running/failed) 3.0 instance-hours $0.69", job.Details)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestTuningInstanceSpecs_MultipleDefinitions(t *testing.T) {
+	output := &sagemaker.DescribeHyperParameterTuningJobOutput{
+		TrainingJobDefinitions: []types.HyperParameterTrainingJobDefinition{
+			{
+				DefinitionName: aws.String("xgb"),
+				ResourceConfig: &types.ResourceConfig{InstanceType: types.TrainingInstanceTypeMlM5Xlarge},
+			},
+			{
+				DefinitionName: aws.String("gpu"),
+				HyperParameterTuningResourceConfig: &types.HyperParameterTuningResourceConfig{
+					InstanceConfigs: []types.HyperParameterTuningInstanceConfig{
+						{InstanceType: types.TrainingInstanceTypeMlG5Xlarge, InstanceCount: aws.Int32(4)},
+					},
+				},
+			},
+		},
+	}
+
+	specs := tuningInstanceSpecs(output)
+	assert.Equal(t, map[string]instanceSpec{
+		"xgb": {instanceType: "ml.m5.xlarge", count: 1},
+		"gpu": {instanceType: "ml.g5.xlarge", count: 4},
+	}, specs)
+}
@@ -0,0 +1,76 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNext(t *testing.T) {
+	// A Sunday
+	start := time.Date(2026, 10, 18, 12, 7, 30, 0, time.UTC)
+	tests := []struct {
+		spec string
+		next time.Time
+	}{
+		{"*/15 * * * *", time.Date(2026, 10, 18, 12, 15, 0, 0, time.UTC)},
+		{"* * * * *", time.Date(2026, 10, 18, 12, 8, 0, 0, time.UTC)},
+		{"5 * * * *", time.Date(2026, 10, 18, 13, 5, 0, 0, time.UTC)},
+		{"0 9-17/4 * * *", time.Date(2026, 10, 18, 13, 0, 0, 0, time.UTC)},
+		{"30 8 * * 1-5", time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC)},
+		{"0 0 1,15 * *", time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 6 * 2 *", time.Date(2027, 2, 1, 6, 0, 0, 0, time.UTC)},
+		{"0 0 13 * 5", time.Date(2026, 10, 23, 0, 0, 0, 0, time.UTC)}, // Day of month or Friday
+		{"0 12 * * 7", time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC).AddDate(0, 0, 7)},
+		{"0 13 * * 5-7", time.Date(2026, 10, 18, 13, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2026, 10, 18, 13, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)},
+		{"@every 10m", time.Date(2026, 10, 18, 12, 17, 30, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			s, err := Parse(tt.spec)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.next, s.Next(start))
+		})
+	}
+}
+
+func TestNext_Location(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("time zone data not available")
+	}
+	s, err := Parse("0 9 * * *")
+	assert.NoError(t, err)
+
+	// Summer time ends on October 25
+	next := s.Next(time.Date(2026, 10, 24, 10, 0, 0, 0, berlin))
+	assert.Equal(t, time.Date(2026, 10, 25, 9, 0, 0, 0, berlin), next)
+	assert.Equal(t, 8, next.UTC().Hour())
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		spec string
+		err  string
+	}{
+		{"* * * *", "expected 5 fields"},
+		{"60 * * * *", "minute field: value 60 out of range 0-59"},
+		{"* 5-2 * * *", `hour field: invalid range "5-2"`},
+		{"*/0 * * * *", `minute field: invalid step "0"`},
+		{"* * * jan *", `month field: invalid value "jan"`},
+		{"@every 30s", "interval must be at least 1m"},
+		{"@every soon", `invalid duration "soon"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			_, err := Parse(tt.spec)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package tagging
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"mohua/internal/jsonfile"
+)
+
+// cacheEntry holds the tags of one resource and when they were fetched
+type cacheEntry struct {
+	Tags      map[string]string `json:"tags"`
+	FetchedAt time.Time         `json:"fetchedAt"`
+}
+
+// cachedClient serves tags from a JSON file and only asks the wrapped client
+// for resources that are not cached or whose entry is older than ttl
+type cachedClient struct {
+	client Client
+	path   string
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+// NewCachedClient wraps client with a cache stored at path. The cache is best
+// effort: an unreadable cache file is ignored and a failed write only costs
+// the next run some API calls.
+func NewCachedClient(client Client, path string, ttl time.Duration) Client {
+	return &cachedClient{client: client, path: path, ttl: ttl, now: time.Now}
+}
+
+// DefaultCachePath returns the tag cache file of a region in the user cache directory
+func DefaultCachePath(region string) (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "mohua", "tags-"+region+".json"), nil
+}
+
+// ResourceTags returns cached tags where they are fresh and fetches the rest
+func (c *cachedClient) ResourceTags(ctx context.Context, arns []string) (map[string]map[string]string, error) {
+	now := c.now()
+	entries := c.load()
+
+	tagsByArn := make(map[string]map[string]string, len(arns))
+	var missing []string
+	for _, arn := range arns {
+		if entry, ok := entries[arn]; ok && now.Sub(entry.FetchedAt) < c.ttl {
+			tagsByArn[arn] = entry.Tags
+		} else {
+			missing = append(missing, arn)
+		}
+	}
+	if len(missing) == 0 {
+		return tagsByArn, nil
+	}
+
+	fetched, err := c.client.ResourceTags(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	for arn, tags := range fetched {
+		tagsByArn[arn] = tags
+		entries[arn] = cacheEntry{Tags: tags, FetchedAt: now}
+	}
+
+	// Drop expired entries of resources that no longer show up
+	for arn, entry := range entries {
+		if now.Sub(entry.FetchedAt) >= c.ttl {
+			delete(entries, arn)
+		}
+	}
+	// Failing to write the cache only costs the next run some lookups
+	jsonfile.Write(c.path, entries)
+	return tagsByArn, nil
+}
+
+// load reads the cache file, returning an empty cache when it is missing or invalid
+func (c *cachedClient) load() map[string]cacheEntry {
+	entries := make(map[string]cacheEntry)
+	if err := jsonfile.Read(c.path, &entries); err != nil {
+		return make(map[string]cacheEntry)
+	}
+	return entries
+}
@@ -0,0 +1,67 @@
+package tagging
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCachedClient(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "mohua", "tags-us-east-1.json")
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	mockClient := new(MockClient)
+	mockClient.On("ResourceTags", ctx, []string{"arn:a", "arn:b"}).
+		Return(map[string]map[string]string{"arn:a": {"owner": "alice"}, "arn:b": {}}, nil).Once()
+	mockClient.On("ResourceTags", ctx, []string{"arn:c"}).
+		Return(map[string]map[string]string{"arn:c": {"owner": "bob"}}, nil).Once()
+	mockClient.On("ResourceTags", ctx, []string{"arn:a"}).
+		Return(map[string]map[string]string{"arn:a": {"owner": "carol"}}, nil).Once()
+
+	cache := &cachedClient{client: mockClient, path: path, ttl: time.Hour, now: func() time.Time { return now }}
+
+	tags, err := cache.ResourceTags(ctx, []string{"arn:a", "arn:b"})
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", tags["arn:a"]["owner"])
+
+	// A new client reads the cache file and only fetches unknown resources
+	now = now.Add(30 * time.Minute)
+	cache = &cachedClient{client: mockClient, path: path, ttl: time.Hour, now: func() time.Time { return now }}
+	tags, err = cache.ResourceTags(ctx, []string{"arn:a", "arn:b", "arn:c"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]map[string]string{
+		"arn:a": {"owner": "alice"},
+		"arn:b": {},
+		"arn:c": {"owner": "bob"},
+	}, tags)
+
+	// Entries older than the TTL are fetched again
+	now = now.Add(45 * time.Minute)
+	tags, err = cache.ResourceTags(ctx, []string{"arn:a"})
+	assert.NoError(t, err)
+	assert.Equal(t, "carol", tags["arn:a"]["owner"])
+	mockClient.AssertExpectations(t)
+}
+
+func TestCachedClient_InvalidFile(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "tags.json")
+	assert.NoError(t, os.WriteFile(path, []byte("not json"), 0o600))
+
+	mockClient := new(MockClient)
+	mockClient.On("ResourceTags", ctx, mock.Anything).Return(map[string]map[string]string{"arn:a": {}}, nil)
+
+	tags, err := NewCachedClient(mockClient, path, time.Hour).ResourceTags(ctx, []string{"arn:a"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]map[string]string{"arn:a": {}}, tags)
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"arn:a"`)
+}
@@ -0,0 +1,96 @@
+package tagging
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+	"mohua/internal/awstrace"
+	"mohua/internal/retry"
+	"mohua/internal/sagemaker"
+)
+
+// maxBatchSize is the maximum number of ARNs GetResources accepts per request
+const maxBatchSize = 100
+
+// Client interface defines the methods that consumers of this package can use
+type Client interface {
+	ResourceTags(ctx context.Context, arns []string) (map[string]map[string]string, error)
+}
+
+// TaggingClientInterface defines the AWS SDK methods used by Client
+type TaggingClientInterface interface {
+	GetResources(ctx context.Context, params *resourcegroupstaggingapi.GetResourcesInput, optFns ...func(*resourcegroupstaggingapi.Options)) (*resourcegroupstaggingapi.GetResourcesOutput, error)
+}
+
+// clientImpl implements only the necessary Resource Groups Tagging API operations
+type clientImpl struct {
+	client TaggingClientInterface
+}
+
+// NewClientFunc is the type for the client creation function
+type NewClientFunc func(region string) (Client, error)
+
+// NewClient is the function used to create a new tagging client
+var NewClient NewClientFunc = newClient
+
+// newClient creates a new Resource Groups Tagging API client
+func newClient(region string) (Client, error) {
+	opts := []func(*config.LoadOptions) error{awstrace.WithTracing()}
+	if region != "" {
+		opts = append(opts, config.WithRegion(region))
+	}
+
+	cfg, err := config.LoadDefaultConfig(context.Background(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS SDK configuration: %w", err)
+	}
+
+	return &clientImpl{client: resourcegroupstaggingapi.NewFromConfig(cfg)}, nil
+}
+
+// ResourceTags returns the tags of every ARN, keyed by ARN. ARNs are looked up
+// in batches of up to 100 and resources without tags map to an empty map.
+func (c *clientImpl) ResourceTags(ctx context.Context, arns []string) (map[string]map[string]string, error) {
+	tagsByArn := make(map[string]map[string]string, len(arns))
+	var unique []string
+	for _, arn := range arns {
+		if _, ok := tagsByArn[arn]; !ok {
+			tagsByArn[arn] = map[string]string{}
+			unique = append(unique, arn)
+		}
+	}
+
+	retrier := retry.NewRetrier(retry.DefaultConfig)
+	for start := 0; start < len(unique); start += maxBatchSize {
+		batch := unique[start:min(start+maxBatchSize, len(unique))]
+		err := retrier.Do(ctx, func() error {
+			input := &resourcegroupstaggingapi.GetResourcesInput{ResourceARNList: batch}
+			for {
+				output, err := c.client.GetResources(ctx, input)
+				if err != nil {
+					return sagemaker.WrapError(err)
+				}
+				for _, mapping := range output.ResourceTagMappingList {
+					tags, ok := tagsByArn[aws.ToString(mapping.ResourceARN)]
+					if !ok {
+						continue
+					}
+					for _, tag := range mapping.Tags {
+						tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+					}
+				}
+				if aws.ToString(output.PaginationToken) == "" {
+					return nil
+				}
+				input.PaginationToken = output.PaginationToken
+			}
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tags of %d resources: %w", len(batch), err)
+		}
+	}
+	return tagsByArn, nil
+}
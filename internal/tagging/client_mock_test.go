@@ -0,0 +1,34 @@
+package tagging
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTaggingClient is a mock implementation of the TaggingClientInterface
+type MockTaggingClient struct {
+	mock.Mock
+}
+
+func (m *MockTaggingClient) GetResources(ctx context.Context, params *resourcegroupstaggingapi.GetResourcesInput, optFns ...func(*resourcegroupstaggingapi.Options)) (*resourcegroupstaggingapi.GetResourcesOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*resourcegroupstaggingapi.GetResourcesOutput), args.Error(1)
+}
+
+// MockClient is a mock implementation of the Client interface
+type MockClient struct {
+	mock.Mock
+}
+
+func (m *MockClient) ResourceTags(ctx context.Context, arns []string) (map[string]map[string]string, error) {
+	args := m.Called(ctx, arns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]map[string]string), args.Error(1)
+}
@@ -0,0 +1,75 @@
+package tagging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestResourceTags_Batches(t *testing.T) {
+	ctx := context.Background()
+	var arns []string
+	for i := 0; i < 150; i++ {
+		arns = append(arns, fmt.Sprintf("arn:aws:sagemaker:us-east-1:123456789012:endpoint/e%d", i))
+	}
+	// Duplicates are only looked up once
+	arns = append(arns, arns[0])
+
+	mockClient := new(MockTaggingClient)
+	mockClient.On("GetResources", ctx, mock.MatchedBy(func(in *resourcegroupstaggingapi.GetResourcesInput) bool {
+		return len(in.ResourceARNList) == 100 && in.PaginationToken == nil
+	}), mock.Anything).
+		Return(&resourcegroupstaggingapi.GetResourcesOutput{
+			ResourceTagMappingList: []types.ResourceTagMapping{
+				{ResourceARN: aws.String(arns[0]), Tags: []types.Tag{{Key: aws.String("owner"), Value: aws.String("ml-platform")}}},
+			},
+			PaginationToken: aws.String("page2"),
+		}, nil).Once()
+	mockClient.On("GetResources", ctx, mock.MatchedBy(func(in *resourcegroupstaggingapi.GetResourcesInput) bool {
+		return aws.ToString(in.PaginationToken) == "page2"
+	}), mock.Anything).
+		Return(&resourcegroupstaggingapi.GetResourcesOutput{
+			ResourceTagMappingList: []types.ResourceTagMapping{
+				{ResourceARN: aws.String(arns[0]), Tags: []types.Tag{{Key: aws.String("team"), Value: aws.String("search")}}},
+			},
+			PaginationToken: aws.String(""),
+		}, nil).Once()
+	mockClient.On("GetResources", ctx, mock.MatchedBy(func(in *resourcegroupstaggingapi.GetResourcesInput) bool {
+		return len(in.ResourceARNList) == 50
+	}), mock.Anything).
+		Return(&resourcegroupstaggingapi.GetResourcesOutput{
+			ResourceTagMappingList: []types.ResourceTagMapping{
+				{ResourceARN: aws.String(arns[149]), Tags: []types.Tag{{Key: aws.String("owner"), Value: aws.String("alice")}}},
+			},
+		}, nil).Once()
+
+	client := &clientImpl{client: mockClient}
+	tags, err := client.ResourceTags(ctx, arns)
+
+	assert.NoError(t, err)
+	assert.Len(t, tags, 150)
+	assert.Equal(t, map[string]string{"owner": "ml-platform", "team": "search"}, tags[arns[0]])
+	assert.Equal(t, map[string]string{"owner": "alice"}, tags[arns[149]])
+	assert.Equal(t, map[string]string{}, tags[arns[1]])
+	mockClient.AssertExpectations(t)
+}
+
+func TestResourceTags_Error(t *testing.T) {
+	ctx := context.Background()
+
+	mockClient := new(MockTaggingClient)
+	mockClient.On("GetResources", ctx, mock.Anything, mock.Anything).Return(nil, errors.New("access denied"))
+
+	client := &clientImpl{client: mockClient}
+	_, err := client.ResourceTags(ctx, []string{"arn:aws:sagemaker:us-east-1:123456789012:endpoint/churn"})
+
+	assert.ErrorContains(t, err, "failed to get tags of 1 resources")
+	assert.ErrorContains(t, err, "access denied")
+}
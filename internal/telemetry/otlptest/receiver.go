@@ -0,0 +1,177 @@
+// Package otlptest provides a local stand-in for an OTLP/HTTP receiver that
+// records the spans and metrics exported to it
+package otlptest
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricpb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// Receiver accepts OTLP/HTTP protobuf exports on /v1/traces and /v1/metrics
+type Receiver struct {
+	URL string // Base URL to export to
+
+	mu      sync.Mutex
+	spans   []*tracepb.Span
+	metrics []*metricpb.Metric // Of the latest metrics export
+	header  http.Header        // Of the latest export
+}
+
+// NewReceiver starts a receiver that is stopped when the test ends
+func NewReceiver(t testing.TB) *Receiver {
+	r := &Receiver{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/traces", func(w http.ResponseWriter, req *http.Request) {
+		var export coltracepb.ExportTraceServiceRequest
+		if !r.decode(w, req, &export) {
+			return
+		}
+		r.mu.Lock()
+		for _, rs := range export.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				r.spans = append(r.spans, ss.Spans...)
+			}
+		}
+		r.mu.Unlock()
+		respond(w, &coltracepb.ExportTraceServiceResponse{})
+	})
+	mux.HandleFunc("POST /v1/metrics", func(w http.ResponseWriter, req *http.Request) {
+		var export colmetricpb.ExportMetricsServiceRequest
+		if !r.decode(w, req, &export) {
+			return
+		}
+		var metrics []*metricpb.Metric
+		for _, rm := range export.ResourceMetrics {
+			for _, sm := range rm.ScopeMetrics {
+				metrics = append(metrics, sm.Metrics...)
+			}
+		}
+		r.mu.Lock()
+		r.metrics = metrics
+		r.mu.Unlock()
+		respond(w, &colmetricpb.ExportMetricsServiceResponse{})
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	r.URL = server.URL
+	return r
+}
+
+func (r *Receiver) decode(w http.ResponseWriter, req *http.Request, m proto.Message) bool {
+	body, err := io.ReadAll(req.Body)
+	if err == nil {
+		err = proto.Unmarshal(body, m)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return false
+	}
+	r.mu.Lock()
+	r.header = req.Header.Clone()
+	r.mu.Unlock()
+	return true
+}
+
+func respond(w http.ResponseWriter, m proto.Message) {
+	data, _ := proto.Marshal(m)
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	w.Write(data)
+}
+
+// Header returns the headers of the latest export
+func (r *Receiver) Header() http.Header {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.header
+}
+
+// Spans returns the spans received so far
+func (r *Receiver) Spans() []*tracepb.Span {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*tracepb.Span(nil), r.spans...)
+}
+
+// SpansNamed returns the spans received so far with a name
+func (r *Receiver) SpansNamed(name string) []*tracepb.Span {
+	var spans []*tracepb.Span
+	for _, span := range r.Spans() {
+		if span.Name == name {
+			spans = append(spans, span)
+		}
+	}
+	return spans
+}
+
+// Gauge returns the value of the gauge data point with exactly the given
+// attributes in the latest metrics export
+func (r *Receiver) Gauge(name string, attrs map[string]string) (float64, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, m := range r.metrics {
+		if m.Name != name || m.GetGauge() == nil {
+			continue
+		}
+		for _, p := range m.GetGauge().DataPoints {
+			if !sameAttributes(p.Attributes, attrs) {
+				continue
+			}
+			if v, ok := p.Value.(*metricpb.NumberDataPoint_AsInt); ok {
+				return float64(v.AsInt), true
+			}
+			return p.GetAsDouble(), true
+		}
+	}
+	return 0, false
+}
+
+// GaugePoints returns the number of data points of a gauge in the latest
+// metrics export
+func (r *Receiver) GaugePoints(name string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, m := range r.metrics {
+		if m.Name == name && m.GetGauge() != nil {
+			return len(m.GetGauge().DataPoints)
+		}
+	}
+	return 0
+}
+
+// Attribute returns the value of a string or integer attribute as a string
+func Attribute(attrs []*commonpb.KeyValue, key string) string {
+	for _, kv := range attrs {
+		if kv.Key == key {
+			if s, ok := kv.Value.Value.(*commonpb.AnyValue_StringValue); ok {
+				return s.StringValue
+			}
+			if i, ok := kv.Value.Value.(*commonpb.AnyValue_IntValue); ok {
+				return strconv.FormatInt(i.IntValue, 10)
+			}
+		}
+	}
+	return ""
+}
+
+func sameAttributes(attrs []*commonpb.KeyValue, want map[string]string) bool {
+	if len(attrs) != len(want) {
+		return false
+	}
+	for key, value := range want {
+		if Attribute(attrs, key) != value {
+			return false
+		}
+	}
+	return true
+}
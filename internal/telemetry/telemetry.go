@@ -0,0 +1,225 @@
+// Package telemetry exports the traces of the scans and the inventory gauges
+// to an OpenTelemetry collector over OTLP/HTTP
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"mohua/internal/sagemaker"
+)
+
+const meterName = "mohua/internal/telemetry"
+
+// Inventory is the outcome of a scan exported as gauges
+type Inventory struct {
+	Regions          []string                 // Scanned regions
+	Resources        []sagemaker.ResourceInfo // With the resource type and region set
+	FailedCollectors map[string]int           // Per region
+	Duration         time.Duration
+}
+
+// Exporter pushes the spans recorded with the global tracer provider and the
+// gauges of the latest inventory to an OTLP receiver
+type Exporter struct {
+	tracerProvider *sdktrace.TracerProvider
+	meterProvider  *sdkmetric.MeterProvider
+
+	mu        sync.Mutex
+	inventory *Inventory // Nil until the first scan is recorded
+}
+
+// New creates an exporter that sends to the OTLP/HTTP receiver at endpoint,
+// e.g. http://localhost:4318, and installs it as the global tracer provider
+func New(ctx context.Context, endpoint string, headers map[string]string) (*Exporter, error) {
+	base, err := url.Parse(endpoint)
+	if err != nil || (base.Scheme != "http" && base.Scheme != "https") || base.Host == "" {
+		return nil, fmt.Errorf("invalid OTLP endpoint %q: expected an http or https URL", endpoint)
+	}
+	base.Path = strings.TrimSuffix(base.Path, "/")
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(attribute.String("service.name", "mohua")))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create telemetry resource: %w", err)
+	}
+
+	traceExporter, err := otlptracehttp.New(ctx,
+		otlptracehttp.WithEndpointURL(base.String()+"/v1/traces"),
+		otlptracehttp.WithHeaders(headers))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+	metricExporter, err := otlpmetrichttp.New(ctx,
+		otlpmetrichttp.WithEndpointURL(base.String()+"/v1/metrics"),
+		otlpmetrichttp.WithHeaders(headers))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP metric exporter: %w", err)
+	}
+
+	e := &Exporter{
+		tracerProvider: sdktrace.NewTracerProvider(sdktrace.WithBatcher(traceExporter), sdktrace.WithResource(res)),
+		meterProvider:  sdkmetric.NewMeterProvider(sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExporter)), sdkmetric.WithResource(res)),
+	}
+	if err := e.registerGauges(); err != nil {
+		return nil, err
+	}
+	otel.SetTracerProvider(e.tracerProvider)
+	return e, nil
+}
+
+// registerGauges creates the inventory gauges, observed from the latest
+// inventory so that resources that went away are no longer reported
+func (e *Exporter) registerGauges() error {
+	meter := e.meterProvider.Meter(meterName)
+	resources, err := meter.Int64ObservableGauge("mohua.resources",
+		metric.WithDescription("Running resources per region and resource type"), metric.WithUnit("{resource}"))
+	if err != nil {
+		return err
+	}
+	instances, err := meter.Int64ObservableGauge("mohua.instances",
+		metric.WithDescription("Running instances per region, resource type and instance type"), metric.WithUnit("{instance}"))
+	if err != nil {
+		return err
+	}
+	cost, err := meter.Float64ObservableGauge("mohua.cost.hourly",
+		metric.WithDescription("Estimated hourly cost per region and resource type"), metric.WithUnit("USD/h"))
+	if err != nil {
+		return err
+	}
+	failed, err := meter.Int64ObservableGauge("mohua.collectors.failed",
+		metric.WithDescription("Collectors that failed in the latest scan per region"), metric.WithUnit("{collector}"))
+	if err != nil {
+		return err
+	}
+	duration, err := meter.Float64ObservableGauge("mohua.scan.duration",
+		metric.WithDescription("Duration of the latest scan"), metric.WithUnit("s"))
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		e.mu.Lock()
+		inv := e.inventory
+		e.mu.Unlock()
+		if inv == nil {
+			return nil
+		}
+		for _, p := range points(inv) {
+			attrs := metric.WithAttributes(p.attrs...)
+			switch p.kind {
+			case "resources":
+				o.ObserveInt64(resources, int64(p.value), attrs)
+			case "instances":
+				o.ObserveInt64(instances, int64(p.value), attrs)
+			case "cost":
+				o.ObserveFloat64(cost, p.value, attrs)
+			}
+		}
+		for _, region := range inv.Regions {
+			o.ObserveInt64(failed, int64(inv.FailedCollectors[region]), metric.WithAttributes(attribute.String("cloud.region", region)))
+		}
+		o.ObserveFloat64(duration, inv.Duration.Seconds())
+		return nil
+	}, resources, instances, cost, failed, duration)
+	return err
+}
+
+// point is an observation of an inventory gauge
+type point struct {
+	kind  string
+	attrs []attribute.KeyValue
+	value float64
+}
+
+// points aggregates the resources of an inventory per gauge and attribute set,
+// in a stable order
+func points(inv *Inventory) []point {
+	type key struct{ kind, region, resourceType, instanceType string }
+	values := make(map[key]float64)
+	for _, r := range inv.Resources {
+		values[key{"resources", r.Region, r.ResourceType, ""}]++
+		for instanceType, count := range r.Instances() {
+			values[key{"instances", r.Region, r.ResourceType, instanceType}] += float64(count)
+		}
+		if hourly := r.EstimatedHourlyCost(); hourly > 0 {
+			values[key{"cost", r.Region, r.ResourceType, ""}] += hourly
+		}
+	}
+
+	keys := make([]key, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.kind != b.kind {
+			return a.kind < b.kind
+		}
+		if a.region != b.region {
+			return a.region < b.region
+		}
+		if a.resourceType != b.resourceType {
+			return a.resourceType < b.resourceType
+		}
+		return a.instanceType < b.instanceType
+	})
+	result := make([]point, len(keys))
+	for i, k := range keys {
+		attrs := []attribute.KeyValue{attribute.String("cloud.region", k.region), attribute.String("resource.type", k.resourceType)}
+		if k.instanceType != "" {
+			attrs = append(attrs, attribute.String("instance.type", k.instanceType))
+		}
+		result[i] = point{kind: k.kind, attrs: attrs, value: values[k]}
+	}
+	return result
+}
+
+// Record replaces the inventory the gauges report and pushes the gauges and
+// the spans ended so far
+func (e *Exporter) Record(ctx context.Context, inv Inventory) error {
+	e.mu.Lock()
+	e.inventory = &inv
+	e.mu.Unlock()
+	return e.Flush(ctx)
+}
+
+// Flush pushes the spans ended so far and the gauges
+func (e *Exporter) Flush(ctx context.Context) error {
+	var errs []error
+	if err := e.tracerProvider.ForceFlush(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("failed to export traces: %w", err))
+	}
+	if err := e.meterProvider.ForceFlush(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("failed to export metrics: %w", err))
+	}
+	return errors.Join(errs...)
+}
+
+// Shutdown pushes the remaining spans and gauges, stops the exporter and
+// uninstalls its tracer provider
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	otel.SetTracerProvider(noop.NewTracerProvider())
+	var errs []error
+	if err := e.tracerProvider.Shutdown(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("failed to export traces: %w", err))
+	}
+	if err := e.meterProvider.Shutdown(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("failed to export metrics: %w", err))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,71 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"mohua/internal/sagemaker"
+	"mohua/internal/telemetry/otlptest"
+)
+
+func TestExporter_Record(t *testing.T) {
+	receiver := otlptest.NewReceiver(t)
+	exporter, err := New(context.Background(), receiver.URL+"/", map[string]string{"api-key": "secret"})
+	require.NoError(t, err)
+	defer exporter.Shutdown(context.Background())
+
+	_, span := otel.Tracer("test").Start(context.Background(), "scan")
+	span.End()
+
+	inv := Inventory{
+		Regions: []string{"us-east-1", "eu-west-1"},
+		Resources: []sagemaker.ResourceInfo{
+			{ResourceType: "Endpoint", Region: "us-east-1", Name: "search", InstanceType: "ml.g5.xlarge", InstanceCount: 2},
+			{ResourceType: "Endpoint", Region: "us-east-1", Name: "ranking", InstanceCounts: map[string]int{"ml.g5.xlarge": 1, "ml.m5.large": 1}},
+			{ResourceType: "Notebook", Region: "eu-west-1", Name: "nb", InstanceType: "ml.t3.medium"},
+			{ResourceType: "FeatureGroup", Region: "eu-west-1", Name: "features", HourlyCost: 0.25},
+		},
+		FailedCollectors: map[string]int{"eu-west-1": 2},
+		Duration:         1500 * time.Millisecond,
+	}
+	require.NoError(t, exporter.Record(context.Background(), inv))
+
+	spans := receiver.SpansNamed("scan")
+	require.Len(t, spans, 1)
+	assert.Equal(t, "secret", receiver.Header().Get("api-key"))
+
+	gauge := func(name string, attrs map[string]string) float64 {
+		t.Helper()
+		value, ok := receiver.Gauge(name, attrs)
+		require.True(t, ok, "no %s point with %v", name, attrs)
+		return value
+	}
+	endpoints := map[string]string{"cloud.region": "us-east-1", "resource.type": "Endpoint"}
+	assert.Equal(t, 2.0, gauge("mohua.resources", endpoints))
+	assert.Equal(t, 1.0, gauge("mohua.resources", map[string]string{"cloud.region": "eu-west-1", "resource.type": "FeatureGroup"}))
+	assert.Equal(t, 3.0, gauge("mohua.instances", map[string]string{"cloud.region": "us-east-1", "resource.type": "Endpoint", "instance.type": "ml.g5.xlarge"}))
+	assert.Equal(t, 1.0, gauge("mohua.instances", map[string]string{"cloud.region": "us-east-1", "resource.type": "Endpoint", "instance.type": "ml.m5.large"}))
+	assert.InDelta(t, 3*1.408+0.115, gauge("mohua.cost.hourly", endpoints), 1e-9)
+	assert.InDelta(t, 0.25, gauge("mohua.cost.hourly", map[string]string{"cloud.region": "eu-west-1", "resource.type": "FeatureGroup"}), 1e-9)
+	assert.Equal(t, 0.0, gauge("mohua.collectors.failed", map[string]string{"cloud.region": "us-east-1"}))
+	assert.Equal(t, 2.0, gauge("mohua.collectors.failed", map[string]string{"cloud.region": "eu-west-1"}))
+	assert.Equal(t, 1.5, gauge("mohua.scan.duration", map[string]string{}))
+
+	// Resources that went away are no longer reported
+	inv.Resources = inv.Resources[2:3]
+	require.NoError(t, exporter.Record(context.Background(), inv))
+	assert.Equal(t, 1, receiver.GaugePoints("mohua.resources"))
+	_, ok := receiver.Gauge("mohua.resources", endpoints)
+	assert.False(t, ok)
+}
+
+func TestNew_InvalidEndpoint(t *testing.T) {
+	for _, endpoint := range []string{"localhost:4318", "grpc://localhost:4317", "http://"} {
+		_, err := New(context.Background(), endpoint, nil)
+		assert.ErrorContains(t, err, "invalid OTLP endpoint", endpoint)
+	}
+}
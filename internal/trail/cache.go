@@ -0,0 +1,110 @@
+package trail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"mohua/internal/jsonfile"
+	"mohua/internal/sagemaker"
+)
+
+const (
+	// CloudTrail delivers events up to 15 minutes after the API call, so a
+	// missing event of a new resource is looked up again on the next run
+	deliveryDelay = time.Hour
+
+	// Entries of resources not seen for this long are dropped from the cache
+	cacheExpiry = 30 * 24 * time.Hour
+)
+
+// cacheEntry holds the creator of one resource. The creator never changes, so
+// entries are kept for as long as the resource keeps showing up.
+type cacheEntry struct {
+	CreatedBy string    `json:"createdBy"`
+	LastSeen  time.Time `json:"lastSeen"`
+}
+
+// cachedClient serves creators from a JSON file and only asks the wrapped
+// client about resources it has not seen before
+type cachedClient struct {
+	client Client
+	path   string
+	now    func() time.Time
+}
+
+// NewCachedClient wraps client with a creator cache stored at path
+func NewCachedClient(client Client, path string) Client {
+	return &cachedClient{client: client, path: path, now: time.Now}
+}
+
+// DefaultCachePath returns the creator cache file of a region in the user cache directory
+func DefaultCachePath(region string) (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "mohua", "creators-"+region+".json"), nil
+}
+
+// cacheKey identifies a resource across runs. The creation time tells apart
+// resources that were deleted and created again under the same name.
+func cacheKey(resource sagemaker.ResourceInfo) string {
+	return fmt.Sprintf("%s/%s@%d", resource.ResourceType, resource.Name, resource.CreationTime.Unix())
+}
+
+// CreatedBy returns cached creators and looks up the rest
+func (c *cachedClient) CreatedBy(ctx context.Context, resources []sagemaker.ResourceInfo) ([]string, error) {
+	now := c.now()
+	entries := c.load()
+
+	creators := make([]string, len(resources))
+	var missing []sagemaker.ResourceInfo
+	var missingIndexes []int
+	for i, resource := range resources {
+		entry, ok := entries[cacheKey(resource)]
+		if !ok {
+			missing = append(missing, resource)
+			missingIndexes = append(missingIndexes, i)
+			continue
+		}
+		creators[i] = entry.CreatedBy
+		entry.LastSeen = now
+		entries[cacheKey(resource)] = entry
+	}
+
+	if len(missing) > 0 {
+		found, err := c.client.CreatedBy(ctx, missing)
+		if err != nil {
+			return nil, err
+		}
+		for j, creator := range found {
+			resource := missing[j]
+			creators[missingIndexes[j]] = creator
+			if creator == "" && now.Sub(resource.CreationTime) < deliveryDelay {
+				continue
+			}
+			entries[cacheKey(resource)] = cacheEntry{CreatedBy: creator, LastSeen: now}
+		}
+	}
+
+	for key, entry := range entries {
+		if now.Sub(entry.LastSeen) > cacheExpiry {
+			delete(entries, key)
+		}
+	}
+	// An unwritten cache only means looking the new resources up again
+	jsonfile.Write(c.path, entries)
+	return creators, nil
+}
+
+// load reads the cache file, returning an empty cache when it is missing or invalid
+func (c *cachedClient) load() map[string]cacheEntry {
+	entries := make(map[string]cacheEntry)
+	if err := jsonfile.Read(c.path, &entries); err != nil {
+		return make(map[string]cacheEntry)
+	}
+	return entries
+}
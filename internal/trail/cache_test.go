@@ -0,0 +1,44 @@
+package trail
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"mohua/internal/sagemaker"
+)
+
+func TestCachedClient(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "creators-us-east-1.json")
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	old := sagemaker.ResourceInfo{ResourceType: "Endpoint", Name: "churn", CreationTime: now.Add(-48 * time.Hour)}
+	fresh := sagemaker.ResourceInfo{ResourceType: "Endpoint", Name: "new", CreationTime: now.Add(-5 * time.Minute)}
+	unknown := sagemaker.ResourceInfo{ResourceType: "Notebook", Name: "scratch", CreationTime: now.Add(-48 * time.Hour)}
+
+	mockClient := new(MockClient)
+	mockClient.On("CreatedBy", ctx, []sagemaker.ResourceInfo{old, fresh, unknown}).
+		Return([]string{"alice", "", ""}, nil).Once()
+	// The event of the new resource may not have been delivered yet, so it is looked up again
+	mockClient.On("CreatedBy", ctx, []sagemaker.ResourceInfo{fresh}).
+		Return([]string{"bob"}, nil).Once()
+
+	cache := &cachedClient{client: mockClient, path: path, now: func() time.Time { return now }}
+	creators, err := cache.CreatedBy(ctx, []sagemaker.ResourceInfo{old, fresh, unknown})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"alice", "", ""}, creators)
+
+	cache = &cachedClient{client: mockClient, path: path, now: func() time.Time { return now.Add(10 * time.Minute) }}
+	creators, err = cache.CreatedBy(ctx, []sagemaker.ResourceInfo{unknown, fresh, old})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"", "bob", "alice"}, creators)
+	mockClient.AssertExpectations(t)
+
+	// A resource deleted and created again under the same name is a new resource
+	recreated := old
+	recreated.CreationTime = now
+	assert.NotEqual(t, cacheKey(old), cacheKey(recreated))
+}
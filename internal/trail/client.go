@@ -0,0 +1,245 @@
+package trail
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/cloudtrail"
+	"github.com/aws/aws-sdk-go-v2/service/cloudtrail/types"
+	"mohua/internal/awstrace"
+	"mohua/internal/retry"
+	"mohua/internal/sagemaker"
+)
+
+const (
+	// Retention is how far back LookupEvents returns management events
+	Retention = 90 * 24 * time.Hour
+
+	// lookupInterval keeps lookups below the LookupEvents limit of two requests per second
+	lookupInterval = 500 * time.Millisecond
+
+	// Events are searched in a window around the creation time of a resource,
+	// which can be later than the API call for jobs that start after a delay
+	windowBefore = 15 * time.Minute
+	windowAfter  = 5 * time.Minute
+)
+
+// createEvents maps resource types to the CloudTrail events that create them
+var createEvents = map[string][]string{
+	"Endpoint":     {"CreateEndpoint"},
+	"Notebook":     {"CreateNotebookInstance"},
+	"Studio":       {"CreateApp"},
+	"Tuning":       {"CreateHyperParameterTuningJob"},
+	"Pipeline":     {"StartPipelineExecution"},
+	"AutoML":       {"CreateAutoMLJob", "CreateAutoMLJobV2"},
+	"Compilation":  {"CreateCompilationJob"},
+	"Recommender":  {"CreateInferenceRecommendationsJob"},
+	"Labeling":     {"CreateLabelingJob"},
+	"MLflow":       {"CreateMlflowTrackingServer"},
+	"FeatureGroup": {"CreateFeatureGroup"},
+	"Monitor":      {"CreateMonitoringSchedule"},
+	"Experiment":   {"CreateInferenceExperiment"},
+}
+
+// Client interface defines the methods that consumers of this package can use
+type Client interface {
+	// CreatedBy returns the identity that created each resource, in the order of
+	// resources. The identity is empty when no create event was found.
+	CreatedBy(ctx context.Context, resources []sagemaker.ResourceInfo) ([]string, error)
+}
+
+// CloudTrailClientInterface defines the AWS SDK methods used by Client
+type CloudTrailClientInterface interface {
+	LookupEvents(ctx context.Context, params *cloudtrail.LookupEventsInput, optFns ...func(*cloudtrail.Options)) (*cloudtrail.LookupEventsOutput, error)
+}
+
+// clientImpl implements only the necessary CloudTrail API operations
+type clientImpl struct {
+	client   CloudTrailClientInterface
+	now      func() time.Time
+	interval time.Duration // Minimum time between two LookupEvents requests
+}
+
+// NewClientFunc is the type for the client creation function
+type NewClientFunc func(region string) (Client, error)
+
+// NewClient is the function used to create a new CloudTrail client
+var NewClient NewClientFunc = newClient
+
+// newClient creates a new CloudTrail client
+func newClient(region string) (Client, error) {
+	opts := []func(*config.LoadOptions) error{awstrace.WithTracing()}
+	if region != "" {
+		opts = append(opts, config.WithRegion(region))
+	}
+
+	cfg, err := config.LoadDefaultConfig(context.Background(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS SDK configuration: %w", err)
+	}
+
+	return &clientImpl{
+		client:   cloudtrail.NewFromConfig(cfg),
+		now:      time.Now,
+		interval: lookupInterval,
+	}, nil
+}
+
+// CreatedBy looks up the create event of each resource one at a time, since
+// LookupEvents is rate limited per account and region
+func (c *clientImpl) CreatedBy(ctx context.Context, resources []sagemaker.ResourceInfo) ([]string, error) {
+	creators := make([]string, len(resources))
+	retrier := retry.NewRetrier(retry.DefaultConfig)
+	var lastLookup time.Time
+
+	for i, resource := range resources {
+		eventNames := createEvents[resource.ResourceType]
+		if len(eventNames) == 0 || resource.CreationTime.IsZero() || c.now().Sub(resource.CreationTime) > Retention {
+			continue
+		}
+
+		for _, eventName := range eventNames {
+			if wait := c.interval - time.Since(lastLookup); wait > 0 {
+				select {
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				case <-time.After(wait):
+				}
+			}
+			lastLookup = time.Now()
+
+			creator, err := c.lookupCreator(ctx, retrier, eventName, resource)
+			if err != nil {
+				return nil, fmt.Errorf("failed to look up creator of %s %s: %w", resource.ResourceType, resource.Name, err)
+			}
+			if creator != "" {
+				creators[i] = creator
+				break
+			}
+		}
+	}
+	return creators, nil
+}
+
+// lookupCreator searches the events named eventName around the creation time
+// of resource for the one that created it
+func (c *clientImpl) lookupCreator(ctx context.Context, retrier *retry.Retrier, eventName string, resource sagemaker.ResourceInfo) (string, error) {
+	var creator string
+	err := retrier.Do(ctx, func() error {
+		creator = ""
+		input := &cloudtrail.LookupEventsInput{
+			LookupAttributes: []types.LookupAttribute{
+				{AttributeKey: types.LookupAttributeKeyEventName, AttributeValue: aws.String(eventName)},
+			},
+			StartTime: aws.Time(resource.CreationTime.Add(-windowBefore)),
+			EndTime:   aws.Time(resource.CreationTime.Add(windowAfter)),
+		}
+		for {
+			output, err := c.client.LookupEvents(ctx, input)
+			if err != nil {
+				return sagemaker.WrapError(err)
+			}
+			for _, event := range output.Events {
+				if identity, ok := matchEvent(event, resource); ok {
+					creator = identity
+					return nil
+				}
+			}
+			if output.NextToken == nil {
+				return nil
+			}
+			input.NextToken = output.NextToken
+		}
+	})
+	return creator, err
+}
+
+// eventRecord holds the parts of a CloudTrail event record used for matching
+type eventRecord struct {
+	UserIdentity struct {
+		Type      string `json:"type"`
+		Arn       string `json:"arn"`
+		UserName  string `json:"userName"`
+		InvokedBy string `json:"invokedBy"`
+	} `json:"userIdentity"`
+	RequestParameters map[string]interface{} `json:"requestParameters"`
+	ResponseElements  map[string]interface{} `json:"responseElements"`
+	ErrorCode         string                 `json:"errorCode"`
+}
+
+// matchEvent returns the identity of a successful event that names resource
+// in its request parameters or response elements
+func matchEvent(event types.Event, resource sagemaker.ResourceInfo) (string, bool) {
+	var record eventRecord
+	if err := json.Unmarshal([]byte(aws.ToString(event.CloudTrailEvent)), &record); err != nil || record.ErrorCode != "" {
+		return "", false
+	}
+
+	candidates := []string{resource.Name, resource.Arn}
+	// Pipeline executions are named pipeline/execution-id, the event only has the ARN
+	if i := strings.LastIndex(resource.Name, "/"); i >= 0 {
+		candidates = append(candidates, resource.Name[i+1:])
+	}
+
+	matched := false
+	for _, values := range []map[string]interface{}{record.RequestParameters, record.ResponseElements} {
+		for _, value := range values {
+			s, ok := value.(string)
+			if ok && matchesAny(s, candidates) {
+				matched = true
+			}
+		}
+	}
+	if !matched {
+		return "", false
+	}
+
+	identity := formatIdentity(record)
+	if identity == "" {
+		identity = aws.ToString(event.Username)
+	}
+	return identity, identity != ""
+}
+
+// matchesAny reports whether value is one of the candidates or an ARN ending in one
+func matchesAny(value string, candidates []string) bool {
+	for _, candidate := range candidates {
+		if candidate == "" {
+			continue
+		}
+		if strings.EqualFold(value, candidate) ||
+			(strings.HasPrefix(value, "arn:") && strings.HasSuffix(strings.ToLower(value), "/"+strings.ToLower(candidate))) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatIdentity names the principal of an event. IAM Identity Center users
+// appear as the session name of an AWSReservedSSO_ role.
+func formatIdentity(record eventRecord) string {
+	identity := record.UserIdentity
+	switch identity.Type {
+	case "AssumedRole":
+		// arn:aws:sts::123456789012:assumed-role/<role>/<session>
+		parts := strings.Split(identity.Arn, "/")
+		if len(parts) == 3 && strings.HasPrefix(parts[1], "AWSReservedSSO_") {
+			return parts[2] + " (SSO)"
+		}
+		return identity.Arn
+	case "Root":
+		return "root"
+	case "AWSService":
+		return identity.InvokedBy
+	default:
+		if identity.Arn != "" {
+			return identity.Arn
+		}
+		return identity.UserName
+	}
+}
@@ -0,0 +1,35 @@
+package trail
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudtrail"
+	"github.com/stretchr/testify/mock"
+	"mohua/internal/sagemaker"
+)
+
+// MockCloudTrailClient is a mock implementation of the CloudTrailClientInterface
+type MockCloudTrailClient struct {
+	mock.Mock
+}
+
+func (m *MockCloudTrailClient) LookupEvents(ctx context.Context, params *cloudtrail.LookupEventsInput, optFns ...func(*cloudtrail.Options)) (*cloudtrail.LookupEventsOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*cloudtrail.LookupEventsOutput), args.Error(1)
+}
+
+// MockClient is a mock implementation of the Client interface
+type MockClient struct {
+	mock.Mock
+}
+
+func (m *MockClient) CreatedBy(ctx context.Context, resources []sagemaker.ResourceInfo) ([]string, error) {
+	args := m.Called(ctx, resources)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
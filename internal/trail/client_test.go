@@ -0,0 +1,112 @@
+package trail
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudtrail"
+	"github.com/aws/aws-sdk-go-v2/service/cloudtrail/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"mohua/internal/sagemaker"
+)
+
+// lookingUp matches LookupEvents requests for eventName
+func lookingUp(eventName string) interface{} {
+	return mock.MatchedBy(func(in *cloudtrail.LookupEventsInput) bool {
+		return len(in.LookupAttributes) == 1 && aws.ToString(in.LookupAttributes[0].AttributeValue) == eventName
+	})
+}
+
+func event(record string) types.Event {
+	return types.Event{CloudTrailEvent: aws.String(record)}
+}
+
+func TestCreatedBy(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	created := now.Add(-48 * time.Hour)
+
+	resources := []sagemaker.ResourceInfo{
+		{ResourceType: "Endpoint", Name: "churn", CreationTime: created},
+		{ResourceType: "Pipeline", Name: "train/abc123", CreationTime: created},
+		{ResourceType: "AutoML", Name: "autopilot", CreationTime: created},
+		{ResourceType: "Notebook", Name: "ancient", CreationTime: now.Add(-100 * 24 * time.Hour)},
+		{ResourceType: "Unknown", Name: "other", CreationTime: created},
+	}
+
+	mockClient := new(MockCloudTrailClient)
+	mockClient.On("LookupEvents", ctx, mock.MatchedBy(func(in *cloudtrail.LookupEventsInput) bool {
+		return aws.ToString(in.LookupAttributes[0].AttributeValue) == "CreateEndpoint" &&
+			in.StartTime.Equal(created.Add(-15*time.Minute)) && in.EndTime.Equal(created.Add(5*time.Minute))
+	}), mock.Anything).
+		Return(&cloudtrail.LookupEventsOutput{
+			Events: []types.Event{
+				event(`{"userIdentity":{"type":"IAMUser","arn":"arn:aws:iam::123456789012:user/bob"},"requestParameters":{"endpointName":"other"}}`),
+				event(`{"userIdentity":{"type":"IAMUser","arn":"arn:aws:iam::123456789012:user/bob"},"requestParameters":{"endpointName":"churn"},"errorCode":"ValidationException"}`),
+			},
+			NextToken: aws.String("page2"),
+		}, nil).Once()
+	mockClient.On("LookupEvents", ctx, mock.MatchedBy(func(in *cloudtrail.LookupEventsInput) bool {
+		return aws.ToString(in.NextToken) == "page2"
+	}), mock.Anything).
+		Return(&cloudtrail.LookupEventsOutput{
+			Events: []types.Event{
+				event(`{"userIdentity":{"type":"AssumedRole","arn":"arn:aws:sts::123456789012:assumed-role/AWSReservedSSO_DataScientist_0123abcd/alice@example.com"},"requestParameters":{"endpointName":"churn","endpointConfigName":"churn-v2"}}`),
+			},
+		}, nil).Once()
+	mockClient.On("LookupEvents", ctx, lookingUp("StartPipelineExecution"), mock.Anything).
+		Return(&cloudtrail.LookupEventsOutput{
+			Events: []types.Event{
+				event(`{"userIdentity":{"type":"AssumedRole","arn":"arn:aws:sts::123456789012:assumed-role/ci-deployer/build-42"},"requestParameters":{"pipelineName":"train"},"responseElements":{"pipelineExecutionArn":"arn:aws:sagemaker:us-east-1:123456789012:pipeline/train/execution/abc123"}}`),
+			},
+		}, nil).Once()
+	mockClient.On("LookupEvents", ctx, lookingUp("CreateAutoMLJob"), mock.Anything).
+		Return(&cloudtrail.LookupEventsOutput{}, nil).Once()
+	mockClient.On("LookupEvents", ctx, lookingUp("CreateAutoMLJobV2"), mock.Anything).
+		Return(&cloudtrail.LookupEventsOutput{
+			Events: []types.Event{
+				{Username: aws.String("carol"), CloudTrailEvent: aws.String(`{"userIdentity":{},"requestParameters":{"autoMLJobName":"autopilot"}}`)},
+			},
+		}, nil).Once()
+
+	client := &clientImpl{client: mockClient, now: func() time.Time { return now }}
+	creators, err := client.CreatedBy(ctx, resources)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"alice@example.com (SSO)",
+		"arn:aws:sts::123456789012:assumed-role/ci-deployer/build-42",
+		"carol",
+		"",
+		"",
+	}, creators)
+	mockClient.AssertExpectations(t)
+}
+
+func TestCreatedBy_Error(t *testing.T) {
+	ctx := context.Background()
+
+	mockClient := new(MockCloudTrailClient)
+	mockClient.On("LookupEvents", ctx, mock.Anything, mock.Anything).Return(nil, errors.New("access denied"))
+
+	client := &clientImpl{client: mockClient, now: time.Now}
+	_, err := client.CreatedBy(ctx, []sagemaker.ResourceInfo{
+		{ResourceType: "Endpoint", Name: "churn", CreationTime: time.Now().Add(-time.Hour)},
+	})
+
+	assert.ErrorContains(t, err, "failed to look up creator of Endpoint churn")
+}
+
+func TestFormatIdentity(t *testing.T) {
+	var record eventRecord
+	record.UserIdentity.Type = "Root"
+	assert.Equal(t, "root", formatIdentity(record))
+
+	record.UserIdentity.Type = "AWSService"
+	record.UserIdentity.InvokedBy = "sagemaker.amazonaws.com"
+	assert.Equal(t, "sagemaker.amazonaws.com", formatIdentity(record))
+}
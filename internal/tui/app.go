@@ -0,0 +1,511 @@
+// Package tui implements mohua tui, an interactive terminal view of the
+// collected resources with detail panes and stop and delete actions.
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"mohua/internal/sagemaker"
+)
+
+// Item is a collected resource as listed in the terminal UI
+type Item struct {
+	Resource sagemaker.ResourceInfo // ResourceType and Region are set
+	Name     string                 // Name shown in the list, e.g. user/app of Studio apps
+}
+
+// key identifies the item across refreshes
+func (i Item) key() string {
+	r := i.Resource
+	return strings.Join([]string{r.Region, r.ResourceType, r.UserProfile, r.SpaceName, r.Name}, "/")
+}
+
+// CollectorError is the error of a collector that failed during a refresh
+type CollectorError struct {
+	Collector string
+	Err       error
+}
+
+// Inventory is the outcome of a refresh
+type Inventory struct {
+	Items  []Item // In collector order
+	Errors []CollectorError
+}
+
+// Config connects the terminal UI to the collectors and the AWS actions
+type Config struct {
+	Region   string
+	Interval time.Duration // Between background refreshes
+	Load     func(ctx context.Context) Inventory
+	Describe func(ctx context.Context, item Item) (string, error)
+	Tags     func(ctx context.Context, item Item) (map[string]string, error) // Optional
+	Metrics  func(ctx context.Context, item Item) (string, error)            // Optional
+	Stop     func(ctx context.Context, item Item) error
+	Delete   func(ctx context.Context, item Item) error
+	Now      func() time.Time
+}
+
+// Panes of the detail view
+const (
+	paneDescribe = iota
+	paneTags
+	paneMetrics
+	paneCost
+	paneCount
+)
+
+var paneNames = [paneCount]string{"Describe", "Tags", "Metrics", "Cost"}
+
+// content is the text of a detail pane loaded in the background
+type content struct {
+	loaded bool
+	text   string
+	err    error
+}
+
+// detail is the state of the detail view of an item
+type detail struct {
+	item   Item
+	pane   int
+	scroll int
+	panes  [paneCount]content
+}
+
+// confirmation is an action waiting for the user to confirm it
+type confirmation struct {
+	action sagemaker.Action
+	item   Item
+}
+
+// App is the terminal UI. Its state is only accessed by the event loop;
+// background work reports back through the updates channel.
+type App struct {
+	screen tcell.Screen
+	cfg    Config
+
+	inventory  Inventory
+	refreshed  time.Time
+	refreshing bool
+	stale      bool // Refresh again when the running refresh completes
+	filter     string
+	filtering  bool // Keys edit the filter
+	selected   int  // Index into the filtered items
+	offset     int  // First filtered item shown
+	detail     *detail
+	confirm    *confirmation
+	message    string // Outcome of the last action, shown in the status bar
+	messageErr bool
+
+	updates chan interface{} // From background work
+}
+
+// Updates sent by background work to the event loop
+type (
+	inventoryUpdate struct{ inventory Inventory }
+	paneUpdate      struct {
+		key     string
+		pane    int
+		content content
+	}
+	actionUpdate struct {
+		action sagemaker.Action
+		item   Item
+		err    error
+	}
+)
+
+// New creates the terminal UI on a screen that is not initialized yet
+func New(screen tcell.Screen, cfg Config) *App {
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+	return &App{screen: screen, cfg: cfg, updates: make(chan interface{})}
+}
+
+// send hands an update to the event loop, unless the UI has quit
+func (a *App) send(ctx context.Context, update interface{}) {
+	select {
+	case a.updates <- update:
+	case <-ctx.Done():
+	}
+}
+
+// Run shows the UI until the user quits or ctx is cancelled
+func (a *App) Run(ctx context.Context) error {
+	if err := a.screen.Init(); err != nil {
+		return fmt.Errorf("failed to initialize terminal: %w", err)
+	}
+	defer a.screen.Fini()
+
+	// Cancelling stops the background work when the user quits
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events := make(chan tcell.Event)
+	go a.screen.ChannelEvents(events, ctx.Done())
+	ticker := time.NewTicker(a.cfg.Interval)
+	defer ticker.Stop()
+
+	a.refresh(ctx)
+	for {
+		a.draw()
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			a.refresh(ctx)
+		case ev := <-events:
+			switch ev := ev.(type) {
+			case *tcell.EventResize:
+				a.screen.Sync()
+			case *tcell.EventKey:
+				if a.handleKey(ctx, ev) {
+					return nil
+				}
+			}
+		case update := <-a.updates:
+			switch update := update.(type) {
+			case inventoryUpdate:
+				a.setInventory(update.inventory)
+				if a.stale {
+					a.stale = false
+					a.refresh(ctx)
+				}
+			case paneUpdate:
+				if a.detail != nil && a.detail.item.key() == update.key {
+					a.detail.panes[update.pane] = update.content
+				}
+			case actionUpdate:
+				a.finishAction(ctx, update)
+			}
+		}
+	}
+}
+
+// refresh runs the collectors in the background. While they run, another
+// refresh follows, so the outcome of an action shows up.
+func (a *App) refresh(ctx context.Context) {
+	if a.refreshing {
+		a.stale = true
+		return
+	}
+	a.refreshing = true
+	go func() {
+		a.send(ctx, inventoryUpdate{inventory: a.cfg.Load(ctx)})
+	}()
+}
+
+// setInventory replaces the items and keeps the selected item selected
+func (a *App) setInventory(inventory Inventory) {
+	var selectedKey string
+	if items := a.filtered(); a.selected < len(items) {
+		selectedKey = items[a.selected].key()
+	}
+	a.inventory = inventory
+	a.refreshed = a.cfg.Now()
+	a.refreshing = false
+
+	items := a.filtered()
+	a.selected = 0
+	for i, item := range items {
+		if item.key() == selectedKey {
+			a.selected = i
+			break
+		}
+	}
+	a.clampSelection()
+
+	// The detail view keeps showing a resource that disappeared, with the
+	// panes already loaded, so the user sees what was there
+	if a.detail != nil {
+		for _, item := range inventory.Items {
+			if item.key() == a.detail.item.key() {
+				a.detail.item = item
+				break
+			}
+		}
+	}
+}
+
+// filtered returns the items matching the filter
+func (a *App) filtered() []Item {
+	if a.filter == "" {
+		return a.inventory.Items
+	}
+	filter := strings.ToLower(a.filter)
+	var items []Item
+	for _, item := range a.inventory.Items {
+		if strings.Contains(searchText(item), filter) {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// searchText is what the filter matches against
+func searchText(item Item) string {
+	r := item.Resource
+	fields := []string{r.ResourceType, item.Name, r.Status, r.InstanceType, r.Region}
+	for key, value := range r.Tags {
+		fields = append(fields, key+"="+value)
+	}
+	return strings.ToLower(strings.Join(fields, " "))
+}
+
+// selectedItem returns the item the actions apply to
+func (a *App) selectedItem() (Item, bool) {
+	if a.detail != nil {
+		return a.detail.item, true
+	}
+	items := a.filtered()
+	if a.selected < len(items) {
+		return items[a.selected], true
+	}
+	return Item{}, false
+}
+
+func (a *App) clampSelection() {
+	if n := len(a.filtered()); a.selected >= n {
+		a.selected = n - 1
+	}
+	if a.selected < 0 {
+		a.selected = 0
+	}
+}
+
+// handleKey applies a key press and reports whether the user quits
+func (a *App) handleKey(ctx context.Context, ev *tcell.EventKey) bool {
+	if ev.Key() == tcell.KeyCtrlC {
+		return true
+	}
+	a.message = ""
+	if a.confirm != nil {
+		if ev.Key() == tcell.KeyRune && (ev.Rune() == 'y' || ev.Rune() == 'Y') {
+			a.startAction(ctx, *a.confirm)
+		} else {
+			a.setMessage("Cancelled", false)
+		}
+		a.confirm = nil
+		return false
+	}
+	if a.filtering {
+		a.handleFilterKey(ev)
+		return false
+	}
+
+	switch ev.Key() {
+	case tcell.KeyEscape:
+		if a.detail != nil {
+			a.detail = nil
+		} else if a.filter != "" {
+			a.filter = ""
+			a.clampSelection()
+		}
+		return false
+	case tcell.KeyCtrlD:
+		a.askConfirmation(sagemaker.ActionDelete)
+		return false
+	case tcell.KeyEnter:
+		if item, ok := a.selectedItem(); ok && a.detail == nil {
+			a.openDetail(ctx, item)
+		}
+		return false
+	case tcell.KeyTab:
+		if a.detail != nil {
+			a.switchPane(ctx, (a.detail.pane+1)%paneCount)
+		}
+		return false
+	case tcell.KeyBacktab:
+		if a.detail != nil {
+			a.switchPane(ctx, (a.detail.pane+paneCount-1)%paneCount)
+		}
+		return false
+	case tcell.KeyUp:
+		a.move(-1)
+		return false
+	case tcell.KeyDown:
+		a.move(1)
+		return false
+	case tcell.KeyPgUp:
+		a.move(-a.pageSize())
+		return false
+	case tcell.KeyPgDn:
+		a.move(a.pageSize())
+		return false
+	case tcell.KeyHome:
+		a.move(-1 << 30)
+		return false
+	case tcell.KeyEnd:
+		a.move(1 << 30)
+		return false
+	case tcell.KeyRune:
+	default:
+		return false
+	}
+
+	switch r := ev.Rune(); r {
+	case 'q':
+		return true
+	case 'j':
+		a.move(1)
+	case 'k':
+		a.move(-1)
+	case 'g':
+		a.move(-1 << 30)
+	case 'G':
+		a.move(1 << 30)
+	case '/':
+		if a.detail == nil {
+			a.filtering = true
+		}
+	case 'r':
+		a.refresh(ctx)
+		if a.detail != nil {
+			a.loadPane(ctx, a.detail.pane)
+		}
+	case 's':
+		a.askConfirmation(sagemaker.ActionStop)
+	case '1', '2', '3', '4':
+		if a.detail != nil {
+			a.switchPane(ctx, int(r-'1'))
+		}
+	}
+	return false
+}
+
+// handleFilterKey edits the filter, which applies as it is typed
+func (a *App) handleFilterKey(ev *tcell.EventKey) {
+	switch ev.Key() {
+	case tcell.KeyEnter:
+		a.filtering = false
+	case tcell.KeyEscape:
+		a.filtering = false
+		a.filter = ""
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if runes := []rune(a.filter); len(runes) > 0 {
+			a.filter = string(runes[:len(runes)-1])
+		}
+	case tcell.KeyRune:
+		a.filter += string(ev.Rune())
+	}
+	a.selected, a.offset = 0, 0
+}
+
+// move moves the selection in the list, or scrolls the detail pane
+func (a *App) move(delta int) {
+	if a.detail != nil {
+		a.detail.scroll += delta
+		if lines := len(a.paneLines()); a.detail.scroll > lines-1 {
+			a.detail.scroll = lines - 1
+		}
+		if a.detail.scroll < 0 {
+			a.detail.scroll = 0
+		}
+		return
+	}
+	a.selected += delta
+	a.clampSelection()
+}
+
+func (a *App) openDetail(ctx context.Context, item Item) {
+	a.detail = &detail{item: item}
+	a.loadPane(ctx, paneDescribe)
+}
+
+func (a *App) switchPane(ctx context.Context, pane int) {
+	a.detail.pane = pane
+	a.detail.scroll = 0
+	if !a.detail.panes[pane].loaded {
+		a.loadPane(ctx, pane)
+	}
+}
+
+// loadPane loads the content of a detail pane in the background
+func (a *App) loadPane(ctx context.Context, pane int) {
+	item := a.detail.item
+	a.detail.panes[pane] = content{}
+	switch pane {
+	case paneCost:
+		a.detail.panes[pane] = content{loaded: true, text: costText(item, a.cfg.Now())}
+		return
+	case paneTags:
+		if a.cfg.Tags == nil {
+			a.detail.panes[pane] = content{loaded: true, text: "Tags are not available"}
+			return
+		}
+	case paneMetrics:
+		if a.cfg.Metrics == nil {
+			a.detail.panes[pane] = content{loaded: true, text: "Metrics are not available"}
+			return
+		}
+	}
+
+	go func() {
+		var c content
+		switch pane {
+		case paneDescribe:
+			c.text, c.err = a.cfg.Describe(ctx, item)
+		case paneTags:
+			var tags map[string]string
+			if tags, c.err = a.cfg.Tags(ctx, item); c.err == nil {
+				c.text = tagsText(tags)
+			}
+		case paneMetrics:
+			c.text, c.err = a.cfg.Metrics(ctx, item)
+		}
+		c.loaded = true
+		a.send(ctx, paneUpdate{key: item.key(), pane: pane, content: c})
+	}()
+}
+
+// askConfirmation asks before an action on the selected item
+func (a *App) askConfirmation(action sagemaker.Action) {
+	item, ok := a.selectedItem()
+	if !ok {
+		return
+	}
+	if !sagemaker.Supports(item.Resource.ResourceType, action) {
+		a.setMessage(fmt.Sprintf("%s resources cannot be %s", item.Resource.ResourceType, pastTense(action)), true)
+		return
+	}
+	a.confirm = &confirmation{action: action, item: item}
+}
+
+// startAction runs a confirmed action in the background
+func (a *App) startAction(ctx context.Context, c confirmation) {
+	run := a.cfg.Stop
+	verb := "Stopping"
+	if c.action == sagemaker.ActionDelete {
+		run, verb = a.cfg.Delete, "Deleting"
+	}
+	a.setMessage(fmt.Sprintf("%s %s %s...", verb, c.item.Resource.ResourceType, c.item.Name), false)
+	go func() {
+		err := run(ctx, c.item)
+		a.send(ctx, actionUpdate{action: c.action, item: c.item, err: err})
+	}()
+}
+
+// finishAction reports the outcome of an action and refreshes the list
+func (a *App) finishAction(ctx context.Context, update actionUpdate) {
+	if update.err != nil {
+		a.setMessage(update.err.Error(), true)
+		return
+	}
+	done := pastTense(update.action)
+	a.setMessage(fmt.Sprintf("%s%s %s %s", strings.ToUpper(done[:1]), done[1:], update.item.Resource.ResourceType, update.item.Name), false)
+	a.refresh(ctx)
+}
+
+func (a *App) setMessage(message string, isErr bool) {
+	a.message, a.messageErr = message, isErr
+}
+
+func pastTense(action sagemaker.Action) string {
+	if action == sagemaker.ActionStop {
+		return "stopped"
+	}
+	return "deleted"
+}
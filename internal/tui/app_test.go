@@ -0,0 +1,258 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"mohua/internal/sagemaker"
+)
+
+var now = time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+var testItems = []Item{
+	{Name: "search", Resource: sagemaker.ResourceInfo{ResourceType: "Endpoint", Region: "us-east-1", Name: "search", Status: "InService", InstanceType: "ml.g5.xlarge", InstanceCount: 2, CreationTime: now.Add(-48 * time.Hour)}},
+	{Name: "nb", Resource: sagemaker.ResourceInfo{ResourceType: "Notebook", Region: "us-east-1", Name: "nb", Status: "InService", InstanceType: "ml.t3.medium", CreationTime: now.Add(-90 * time.Minute), Tags: map[string]string{"team": "search"}}},
+	{Name: "alice/JupyterLab", Resource: sagemaker.ResourceInfo{ResourceType: "Studio", Region: "us-east-1", Name: "default", Status: "InService", UserProfile: "alice", AppType: "JupyterLab", CreationTime: now.Add(-time.Hour)}},
+}
+
+// testScreen is a 120x20 simulated terminal that copies its content when it is
+// shown, since the simulation hands out the cells that Show writes to
+type testScreen struct {
+	tcell.SimulationScreen
+	mu   sync.Mutex
+	text string
+}
+
+func (s *testScreen) Init() error {
+	if err := s.SimulationScreen.Init(); err != nil {
+		return err
+	}
+	s.SetSize(120, 20)
+	return nil
+}
+
+func (s *testScreen) Show() {
+	s.SimulationScreen.Show()
+	cells, width, height := s.GetContents()
+	var b strings.Builder
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if runes := cells[y*width+x].Runes; len(runes) > 0 {
+				b.WriteRune(runes[0])
+			} else {
+				b.WriteRune(' ')
+			}
+		}
+		b.WriteRune('\n')
+	}
+	s.mu.Lock()
+	s.text = b.String()
+	s.mu.Unlock()
+}
+
+// harness runs the UI on a test screen
+type harness struct {
+	t      *testing.T
+	screen *testScreen
+	done   chan error
+}
+
+func start(t *testing.T, cfg Config) *harness {
+	t.Helper()
+	screen := &testScreen{SimulationScreen: tcell.NewSimulationScreen("UTF-8")}
+	if cfg.Interval == 0 {
+		cfg.Interval = time.Hour
+	}
+	cfg.Region = "us-east-1"
+	cfg.Now = func() time.Time { return now }
+	h := &harness{t: t, screen: screen, done: make(chan error, 1)}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	app := New(screen, cfg)
+	go func() {
+		h.done <- app.Run(ctx)
+	}()
+	h.waitFor("mohua")
+	return h
+}
+
+// text returns the screen content, one line per row
+func (h *harness) text() string {
+	h.screen.mu.Lock()
+	defer h.screen.mu.Unlock()
+	return h.screen.text
+}
+
+// waitFor waits until the screen shows text
+func (h *harness) waitFor(text string) {
+	h.t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if strings.Contains(h.text(), text) {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	h.t.Fatalf("screen does not show %q:\n%s", text, h.text())
+}
+
+func (h *harness) press(key tcell.Key) {
+	h.screen.InjectKey(key, 0, tcell.ModNone)
+}
+
+func (h *harness) typeText(text string) {
+	for _, r := range text {
+		h.screen.InjectKey(tcell.KeyRune, r, tcell.ModNone)
+	}
+}
+
+func TestApp_BrowseAndFilter(t *testing.T) {
+	h := start(t, Config{
+		Load: func(ctx context.Context) Inventory {
+			return Inventory{Items: testItems, Errors: []CollectorError{{Collector: "tuning jobs", Err: errors.New("throttled")}}}
+		},
+		Describe: func(ctx context.Context, item Item) (string, error) {
+			return "{\n  \"EndpointName\": \"" + item.Resource.Name + "\"\n}", nil
+		},
+		Tags: func(ctx context.Context, item Item) (map[string]string, error) {
+			return map[string]string{"owner": "alice", "team": "search"}, nil
+		},
+	})
+
+	h.waitFor("3/3 resources  $2.87/h")
+	h.waitFor("1 collectors failed: tuning jobs: throttled")
+	assert.Contains(t, h.text(), "ml.g5.xlarge x2")
+	assert.Contains(t, h.text(), "2d0h")
+
+	// The filter applies as it is typed and matches tags
+	h.typeText("/sea")
+	h.waitFor("2/3 resources")
+	h.waitFor("Filter: sea_")
+	h.typeText("rch")
+	h.press(tcell.KeyBackspace2)
+	h.typeText("h")
+	h.press(tcell.KeyEnter)
+	h.waitFor("Filter: search  (<esc> clears)")
+
+	h.press(tcell.KeyEnter)
+	h.waitFor(`"EndpointName": "search"`)
+	h.typeText("2")
+	h.waitFor("team   search")
+	h.typeText("3")
+	h.waitFor("Metrics are not available")
+	h.typeText("4")
+	h.waitFor("Hourly cost    $2.82")
+	h.waitFor("Cost so far    $135.17 since creation")
+
+	h.press(tcell.KeyEscape)
+	h.waitFor("2/3 resources")
+	h.press(tcell.KeyEscape)
+	h.waitFor("3/3 resources")
+
+	h.typeText("q")
+	select {
+	case err := <-h.done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the UI did not quit")
+	}
+}
+
+func TestApp_Actions(t *testing.T) {
+	var mu sync.Mutex
+	var loads int
+	var stopped []string
+	h := start(t, Config{
+		Load: func(ctx context.Context) Inventory {
+			mu.Lock()
+			defer mu.Unlock()
+			loads++
+			return Inventory{Items: testItems}
+		},
+		Describe: func(ctx context.Context, item Item) (string, error) { return "{}", nil },
+		Stop: func(ctx context.Context, item Item) error {
+			mu.Lock()
+			defer mu.Unlock()
+			stopped = append(stopped, item.Name)
+			return nil
+		},
+		Delete: func(ctx context.Context, item Item) error {
+			return errors.New("failed to delete Endpoint search: access denied")
+		},
+	})
+	h.waitFor("All collectors succeeded")
+
+	// Endpoints can only be deleted
+	h.typeText("s")
+	h.waitFor("Endpoint resources cannot be stopped")
+
+	h.press(tcell.KeyCtrlD)
+	h.waitFor("Delete Endpoint search? This cannot be undone. [y/N]")
+	h.typeText("y")
+	h.waitFor("failed to delete Endpoint search: access denied")
+
+	h.typeText("j")
+	h.typeText("s")
+	h.waitFor("Stop Notebook nb? [y/N]")
+	h.typeText("n")
+	h.waitFor("Cancelled")
+
+	h.typeText("s")
+	h.waitFor("Stop Notebook nb? [y/N]")
+	h.typeText("y")
+	h.waitFor("Stopped Notebook nb")
+
+	// The list is refreshed after the action
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		mu.Lock()
+		n := loads
+		mu.Unlock()
+		if n >= 2 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"nb"}, stopped)
+	assert.GreaterOrEqual(t, loads, 2)
+}
+
+func TestApp_BackgroundRefresh(t *testing.T) {
+	var mu sync.Mutex
+	items := testItems[:1]
+	h := start(t, Config{
+		Interval: 20 * time.Millisecond,
+		Load: func(ctx context.Context) Inventory {
+			mu.Lock()
+			defer mu.Unlock()
+			return Inventory{Items: items}
+		},
+	})
+	h.waitFor("1/1 resources")
+
+	mu.Lock()
+	items = testItems
+	mu.Unlock()
+	h.waitFor("3/3 resources")
+}
+
+func TestCostText(t *testing.T) {
+	assert.Equal(t, "Instances      ml.m5.large\nRunning for    1h30m\nHourly cost    $0.12\nMonthly cost   $83.95 at 730 hours\nCost so far    $0.17 since creation\n\nEstimates use on-demand instance prices.",
+		costText(Item{Resource: sagemaker.ResourceInfo{InstanceType: "ml.m5.large", CreationTime: now.Add(-90 * time.Minute)}}, now))
+	assert.Equal(t, "Instances      -\nRunning for    5m\n\nNo cost estimate: the instance type is not in the price list",
+		costText(Item{Resource: sagemaker.ResourceInfo{CreationTime: now.Add(-5 * time.Minute)}}, now))
+}
+
+func TestTagsText(t *testing.T) {
+	require.Equal(t, "No tags", tagsText(nil))
+	assert.Equal(t, "owner  alice\nteam   search", tagsText(map[string]string{"team": "search", "owner": "alice"}))
+}
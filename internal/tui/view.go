@@ -0,0 +1,326 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"mohua/internal/sagemaker"
+)
+
+// hoursPerMonth matches the monthly estimates of the CLI
+const hoursPerMonth = 730
+
+var (
+	styleDefault  = tcell.StyleDefault
+	styleTitle    = tcell.StyleDefault.Background(tcell.ColorNavy).Foreground(tcell.ColorWhite).Bold(true)
+	styleHints    = tcell.StyleDefault.Foreground(tcell.ColorTeal)
+	styleHeader   = tcell.StyleDefault.Bold(true).Underline(true)
+	styleSelected = tcell.StyleDefault.Reverse(true)
+	styleFailed   = tcell.StyleDefault.Foreground(tcell.ColorRed)
+	styleStatus   = tcell.StyleDefault.Background(tcell.ColorDarkSlateGray).Foreground(tcell.ColorWhite)
+	styleError    = tcell.StyleDefault.Background(tcell.ColorDarkRed).Foreground(tcell.ColorWhite)
+	styleConfirm  = tcell.StyleDefault.Background(tcell.ColorYellow).Foreground(tcell.ColorBlack).Bold(true)
+)
+
+// column is a column of the resource list
+type column struct {
+	title string
+	width int // Zero for the column that takes the remaining width
+	value func(item Item, now time.Time) string
+}
+
+var columns = []column{
+	{"TYPE", 12, func(item Item, _ time.Time) string { return item.Resource.ResourceType }},
+	{"NAME", 0, func(item Item, _ time.Time) string { return item.Name }},
+	{"STATUS", 12, func(item Item, _ time.Time) string { return item.Resource.Status }},
+	{"INSTANCE", 20, func(item Item, _ time.Time) string { return instances(item.Resource) }},
+	{"AGE", 8, func(item Item, now time.Time) string { return formatAge(now.Sub(item.Resource.CreationTime)) }},
+	{"COST/H", 9, func(item Item, _ time.Time) string { return formatCost(item.Resource.EstimatedHourlyCost()) }},
+}
+
+// draw renders the list or the detail view
+func (a *App) draw() {
+	a.screen.Clear()
+	if a.detail != nil {
+		a.drawDetail()
+	} else {
+		a.drawList()
+	}
+	a.drawStatus()
+	a.screen.Show()
+}
+
+// pageSize is the number of list rows or detail lines on screen
+func (a *App) pageSize() int {
+	_, height := a.screen.Size()
+	if height < 6 {
+		return 1
+	}
+	return height - 5
+}
+
+func (a *App) drawList() {
+	width, _ := a.screen.Size()
+	items := a.filtered()
+	var cost float64
+	for _, item := range items {
+		cost += item.Resource.EstimatedHourlyCost()
+	}
+	updated := "Refreshing..."
+	if !a.refreshing && !a.refreshed.IsZero() {
+		updated = "Updated " + a.refreshed.Format("15:04:05")
+	}
+	a.drawBar(0, styleTitle, fmt.Sprintf(" mohua  %s  %d/%d resources  %s/h", a.cfg.Region, len(items), len(a.inventory.Items), formatCost(cost)), updated+" ")
+	drawText(a.screen, 0, 1, width, styleHints, " <enter> details  </> filter  <s> stop  <ctrl-d> delete  <r> refresh  <q> quit")
+
+	switch {
+	case a.filtering:
+		drawText(a.screen, 0, 2, width, styleDefault, " Filter: "+a.filter+"_")
+	case a.filter != "":
+		drawText(a.screen, 0, 2, width, styleDefault, " Filter: "+a.filter+"  (<esc> clears)")
+	}
+
+	widths := columnWidths(width)
+	x := 1
+	for i, c := range columns {
+		drawText(a.screen, x, 3, widths[i], styleHeader, c.title)
+		x += widths[i] + 1
+	}
+
+	rows := a.pageSize()
+	if a.selected < a.offset {
+		a.offset = a.selected
+	}
+	if a.selected >= a.offset+rows {
+		a.offset = a.selected - rows + 1
+	}
+	if len(items) == 0 {
+		message := "No running resources"
+		if a.refreshed.IsZero() {
+			message = "Collecting resources..."
+		} else if a.filter != "" {
+			message = "No resources match the filter"
+		}
+		drawText(a.screen, 1, 4, width-1, styleDefault, message)
+	}
+	now := a.cfg.Now()
+	for row := 0; row < rows && a.offset+row < len(items); row++ {
+		i := a.offset + row
+		style := styleDefault
+		if strings.Contains(strings.ToLower(items[i].Resource.Status), "fail") {
+			style = styleFailed
+		}
+		if i == a.selected {
+			style = styleSelected
+			fill(a.screen, 0, 4+row, width, style)
+		}
+		x := 1
+		for j, c := range columns {
+			drawText(a.screen, x, 4+row, widths[j], style, c.value(items[i], now))
+			x += widths[j] + 1
+		}
+	}
+}
+
+// columnWidths gives the name column the width the others leave
+func columnWidths(width int) []int {
+	widths := make([]int, len(columns))
+	rest := width - 1
+	for i, c := range columns {
+		widths[i] = c.width
+		rest -= c.width + 1
+	}
+	for i, c := range columns {
+		if c.width == 0 {
+			widths[i] = rest
+			if widths[i] < 10 {
+				widths[i] = 10
+			}
+		}
+	}
+	return widths
+}
+
+func (a *App) drawDetail() {
+	width, _ := a.screen.Size()
+	r := a.detail.item.Resource
+	a.drawBar(0, styleTitle, fmt.Sprintf(" %s %s  %s", r.ResourceType, a.detail.item.Name, r.Region), r.Status+" ")
+	drawText(a.screen, 0, 1, width, styleHints, " <esc> back  <tab>/<1-4> panes  <j/k> scroll  <r> reload  <s> stop  <ctrl-d> delete  <q> quit")
+
+	x := 1
+	for i, name := range paneNames {
+		label := fmt.Sprintf(" %d %s ", i+1, name)
+		style := styleDefault
+		if i == a.detail.pane {
+			style = styleSelected
+		}
+		x += drawText(a.screen, x, 2, width-x, style, label) + 1
+	}
+
+	lines := a.paneLines()
+	for row := 0; row < a.pageSize() && a.detail.scroll+row < len(lines); row++ {
+		drawText(a.screen, 1, 3+row, width-1, styleDefault, lines[a.detail.scroll+row])
+	}
+}
+
+// paneLines returns the lines of the current detail pane
+func (a *App) paneLines() []string {
+	c := a.detail.panes[a.detail.pane]
+	switch {
+	case !c.loaded:
+		return []string{"Loading..."}
+	case c.err != nil:
+		return []string{"Error: " + c.err.Error()}
+	}
+	return strings.Split(strings.TrimRight(c.text, "\n"), "\n")
+}
+
+// drawStatus renders the confirmation prompt, the outcome of the last action
+// or the collector errors of the last refresh
+func (a *App) drawStatus() {
+	_, height := a.screen.Size()
+	y := height - 1
+	switch {
+	case a.confirm != nil:
+		r := a.confirm.item.Resource
+		prompt := fmt.Sprintf(" Stop %s %s? [y/N]", r.ResourceType, a.confirm.item.Name)
+		if a.confirm.action == sagemaker.ActionDelete {
+			prompt = fmt.Sprintf(" Delete %s %s? This cannot be undone. [y/N]", r.ResourceType, a.confirm.item.Name)
+		}
+		a.drawBar(y, styleConfirm, prompt, "")
+	case a.message != "":
+		style := styleStatus
+		if a.messageErr {
+			style = styleError
+		}
+		a.drawBar(y, style, " "+a.message, "")
+	case len(a.inventory.Errors) > 0:
+		failures := make([]string, len(a.inventory.Errors))
+		for i, e := range a.inventory.Errors {
+			failures[i] = e.Collector + ": " + e.Err.Error()
+		}
+		a.drawBar(y, styleError, fmt.Sprintf(" %d collectors failed: %s", len(failures), strings.Join(failures, "; ")), "")
+	case !a.refreshed.IsZero():
+		a.drawBar(y, styleStatus, " All collectors succeeded", "")
+	default:
+		a.drawBar(y, styleStatus, "", "")
+	}
+}
+
+// drawBar fills a row with left and right aligned text
+func (a *App) drawBar(y int, style tcell.Style, left, right string) {
+	width, _ := a.screen.Size()
+	fill(a.screen, 0, y, width, style)
+	rightWidth := len([]rune(right))
+	drawText(a.screen, 0, y, width-rightWidth, style, left)
+	drawText(a.screen, width-rightWidth, y, rightWidth, style, right)
+}
+
+// drawText draws text cut to width and returns the width drawn
+func drawText(screen tcell.Screen, x, y, width int, style tcell.Style, text string) int {
+	runes := []rune(text)
+	if len(runes) > width {
+		if width <= 0 {
+			return 0
+		}
+		runes = append(runes[:width-1], '…')
+	}
+	for i, r := range runes {
+		screen.SetContent(x+i, y, r, nil, style)
+	}
+	return len(runes)
+}
+
+func fill(screen tcell.Screen, x, y, width int, style tcell.Style) {
+	for i := 0; i < width; i++ {
+		screen.SetContent(x+i, y, ' ', nil, style)
+	}
+}
+
+// instances shows the instance type and count, or the counts per type of
+// resources running on several types
+func instances(r sagemaker.ResourceInfo) string {
+	counts := r.Instances()
+	if r.InstanceType == "" && len(counts) == 0 {
+		return "-"
+	}
+	types := make([]string, 0, len(counts))
+	for instanceType := range counts {
+		types = append(types, instanceType)
+	}
+	sort.Strings(types)
+	parts := make([]string, 0, len(types))
+	for _, instanceType := range types {
+		if counts[instanceType] > 1 {
+			parts = append(parts, fmt.Sprintf("%s x%d", instanceType, counts[instanceType]))
+		} else {
+			parts = append(parts, instanceType)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatCost(cost float64) string {
+	if cost == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("$%.2f", cost)
+}
+
+// formatAge shows a running time in its two largest units
+func formatAge(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd%dh", int(d.Hours())/24, int(d.Hours())%24)
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh%dm", int(d.Hours()), int(d.Minutes())%60)
+	default:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+}
+
+// costText is the content of the cost pane
+func costText(item Item, now time.Time) string {
+	r := item.Resource
+	running := now.Sub(r.CreationTime)
+	lines := []string{
+		fmt.Sprintf("Instances      %s", instances(r)),
+		fmt.Sprintf("Running for    %s", formatAge(running)),
+	}
+	hourly := r.EstimatedHourlyCost()
+	if hourly == 0 {
+		lines = append(lines, "", "No cost estimate: the instance type is not in the price list")
+		return strings.Join(lines, "\n")
+	}
+	return strings.Join(append(lines,
+		fmt.Sprintf("Hourly cost    $%.2f", hourly),
+		fmt.Sprintf("Monthly cost   $%.2f at %d hours", hourly*hoursPerMonth, hoursPerMonth),
+		fmt.Sprintf("Cost so far    $%.2f since creation", hourly*running.Hours()),
+		"",
+		"Estimates use on-demand instance prices.",
+	), "\n")
+}
+
+// tagsText is the content of the tags pane
+func tagsText(tags map[string]string) string {
+	if len(tags) == 0 {
+		return "No tags"
+	}
+	keys := make([]string, 0, len(tags))
+	width := 0
+	for key := range tags {
+		keys = append(keys, key)
+		if len(key) > width {
+			width = len(key)
+		}
+	}
+	sort.Strings(keys)
+	lines := make([]string, len(keys))
+	for i, key := range keys {
+		lines[i] = fmt.Sprintf("%-*s  %s", width, key, tags[key])
+	}
+	return strings.Join(lines, "\n")
+}